@@ -333,10 +333,14 @@ var Enable action.GTSAction = func(ctx context.Context) error {
 		return err
 	}
 
+	// Clear any dormant account warning too, so
+	// they aren't disabled again straight away.
 	user.Disabled = util.Ptr(false)
+	user.DormantWarnedAt = time.Time{}
 	return state.DB.UpdateUser(
 		ctx, user,
 		"disabled",
+		"dormant_warned_at",
 	)
 }
 
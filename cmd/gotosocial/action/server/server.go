@@ -51,6 +51,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -83,8 +84,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// depending on what services were
 		// managed to be started.
 
-		state = new(state.State)
-		route *router.Router
+		state      = new(state.State)
+		route      *router.Router
+		emailQueue *email.Queue
 	)
 
 	defer func() {
@@ -106,6 +108,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// tasks from being executed.
 		state.Workers.Stop()
 
+		if emailQueue != nil {
+			// Email queue was started, ensure it gets stopped.
+			emailQueue.Stop()
+		}
+
 		if state.Timelines.Home != nil {
 			// Home timeline mgr was setup, ensure it gets stopped.
 			if err := state.Timelines.Home.Stop(); err != nil {
@@ -132,6 +139,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		log.Info(ctx, "done! exiting...")
 	}()
 
+	// Load translations for web pages and emails.
+	if err := i18n.Init(config.GetWebLocaleBaseDir()); err != nil {
+		return fmt.Errorf("error initializing translations: %w", err)
+	}
+
 	// Initialize tracing (noop if not enabled).
 	if err := tracing.Initialize(); err != nil {
 		return fmt.Errorf("error initializing tracing: %w", err)
@@ -194,9 +206,15 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// Decide whether to create a noop email
 	// sender (won't send emails) or a real one.
 	var emailSender email.Sender
-	if smtpHost := config.GetSMTPHost(); smtpHost != "" {
-		// Host is defined; create a proper sender.
-		emailSender, err = email.NewSender()
+	if config.GetSMTPHost() != "" || config.GetSMTPMaildir() != "" {
+		// Host or maildir is defined; create a
+		// proper sender, backed by an email queue.
+		emailQueue, err = email.NewQueue(state)
+		if err != nil {
+			return fmt.Errorf("error creating email queue: %s", err)
+		}
+
+		emailSender, err = email.NewSender(emailQueue)
 		if err != nil {
 			return fmt.Errorf("error creating email sender: %s", err)
 		}
@@ -276,11 +294,41 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// Now start workers!
 	state.Workers.Start()
 
+	if emailQueue != nil {
+		// Start draining the email queue.
+		emailQueue.Start()
+	}
+
 	// Schedule notif tasks for all existing poll expiries.
 	if err := processor.Polls().ScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule reversal tasks for all timed admin actions.
+	if err := processor.Admin().ScheduleActionExpiries(ctx); err != nil {
+		return fmt.Errorf("error scheduling admin action expiries: %w", err)
+	}
+
+	// Schedule sending of notification email digests.
+	if err := processor.Workers().ScheduleEmailDigests(); err != nil {
+		return fmt.Errorf("error scheduling email digests: %w", err)
+	}
+
+	// Schedule rechecking of profile field links.
+	if err := processor.Workers().ScheduleFieldVerification(); err != nil {
+		return fmt.Errorf("error scheduling field verification: %w", err)
+	}
+
+	// Schedule checking for dormant accounts.
+	if err := processor.Workers().ScheduleDormantAccounts(); err != nil {
+		return fmt.Errorf("error scheduling dormant account checks: %w", err)
+	}
+
+	// Schedule recomputing of trends.
+	if err := processor.Trends().ScheduleTrends(); err != nil {
+		return fmt.Errorf("error scheduling trends: %w", err)
+	}
+
 	// Initialize metrics.
 	if err := metrics.Initialize(state.DB); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
@@ -77,6 +77,24 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// ActivityEmojiReact is not in the AS spec, but is used by
+	// Pleroma and its forks for emoji reactions. Since it's not
+	// supported by go-fed, these are resolved as Like activities.
+	//
+	// See https://docs.pleroma.social/backend/development/ap_extensions/#emojireacts
+	ActivityEmojiReact = "EmojiReact"
+
+	// ActivityStreamsProfile is the JSON-LD
+	// profile used in media types indicating
+	// an ActivityStreams object.
+	ActivityStreamsProfile = "https://www.w3.org/ns/activitystreams"
+
+	// ObjectLinkMediaType is the media type set on
+	// FEP-e232 object Links, eg., in a quote post.
+	//
+	// See https://codeberg.org/fediverse/fep/src/branch/main/fep/e232/fep-e232.md
+	ObjectLinkMediaType = `application/ld+json; profile="` + ActivityStreamsProfile + `"`
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
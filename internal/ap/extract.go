@@ -19,11 +19,13 @@ package ap
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"mime"
 	"net/url"
 	"strings"
 	"time"
@@ -32,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -251,6 +254,160 @@ func ExtractInReplyToURI(i WithInReplyTo) *url.URL {
 	return nil
 }
 
+// ExtractQuoteURI extracts the URI of the status
+// quoted by the given Statusable, if any. In order
+// of preference, the following are checked:
+//
+//   - FEP-e232 object Link in 'tag'
+//   - 'quoteUrl' (Pleroma / Akkoma)
+//   - '_misskey_quote' (Misskey and forks)
+//   - 'quoteUri' (Fedibird)
+//
+// Will return nil if no valid URI can be found.
+func ExtractQuoteURI(i Statusable) *url.URL {
+	if tagsProp := i.GetActivityStreamsTag(); tagsProp != nil {
+		for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+			if !iter.IsActivityStreamsLink() {
+				continue
+			}
+
+			link := iter.GetActivityStreamsLink()
+			if link == nil || !isObjectLink(link) {
+				continue
+			}
+
+			href := link.GetActivityStreamsHref()
+			if href == nil || href.Get() == nil {
+				continue
+			}
+
+			return href.Get()
+		}
+	}
+
+	unknown := i.GetUnknownProperties()
+	for _, key := range []string{
+		"quoteUrl",
+		"_misskey_quote",
+		"quoteUri",
+	} {
+		str, ok := unknown[key].(string)
+		if !ok || str == "" {
+			continue
+		}
+
+		uri, err := url.Parse(str)
+		if err != nil || !uri.IsAbs() {
+			continue
+		}
+
+		return uri
+	}
+
+	return nil
+}
+
+// ExtractBoostOfURI extracts the URI of the object boosted
+// by the given Announceable. Group actors (eg., Lemmy communities)
+// announce the Create activity that introduced a member's post,
+// rather than the post itself; in this case the URI of the
+// embedded Create's object is returned instead.
+//
+// Will return nil if no boostable URI can be found, for
+// example if the Announce relays some other activity
+// such as a Like, Update or Delete by a group member.
+func ExtractBoostOfURI(i Announceable) *url.URL {
+	objectProp := i.GetActivityStreamsObject()
+	if objectProp == nil {
+		return nil
+	}
+
+	for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
+		t := iter.GetType()
+		if t == nil {
+			// Plain IRI, assume
+			// this is the status.
+			if iri := iter.GetIRI(); iri != nil {
+				return iri
+			}
+			continue
+		}
+
+		switch typeName := t.GetTypeName(); {
+
+		// Group-wrapped Create,
+		// use the Create's object.
+		case typeName == ActivityCreate:
+			withObject, ok := t.(WithObject)
+			if !ok {
+				continue
+			}
+
+			if iris := GetObjectIRIs(withObject); len(iris) > 0 {
+				return iris[0]
+			}
+
+		// Some other relayed
+		// activity, not a boost.
+		case isActivity(typeName):
+			continue
+
+		// Embedded status.
+		default:
+			if id := GetJSONLDId(t); id != nil {
+				return id
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExtractReaction extracts the emoji reaction name, ie., a unicode
+// emoji or custom emoji :shortcode:, from the given Reactable. This
+// checks the '_misskey_reaction' property, then falls back to content.
+//
+// An empty string is returned for plain Likes, and for reactions
+// using a heart emoji, as these are semantically just a fave.
+func ExtractReaction(i Reactable) string {
+	name, _ := i.GetUnknownProperties()["_misskey_reaction"].(string)
+	if name == "" {
+		name = ExtractContent(i).Content
+	}
+
+	name = strings.TrimSpace(name)
+	switch name {
+	case "❤", "❤️", "♥", "♥️":
+		return ""
+	default:
+		return name
+	}
+}
+
+// isObjectLink returns whether the given Link
+// has a media type indicating that it links to
+// an ActivityPub object, as described in FEP-e232.
+func isObjectLink(link vocab.ActivityStreamsLink) bool {
+	mediaTypeProp := link.GetActivityStreamsMediaType()
+	if mediaTypeProp == nil {
+		return false
+	}
+
+	mediaType, params, err := mime.ParseMediaType(mediaTypeProp.Get())
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "application/activity+json":
+		return true
+	case "application/ld+json":
+		return params["profile"] == ActivityStreamsProfile
+	default:
+		return false
+	}
+}
+
 // ExtractItemsURIs extracts each URI it can
 // find for an item from the provided WithItems.
 func ExtractItemsURIs(i WithItems) []*url.URL {
@@ -609,6 +766,58 @@ func ExtractPubKeyFromKey(pkey vocab.W3IDSecurityV1PublicKey) (
 	return pubKey, pubKeyID, pubKeyOwner, nil
 }
 
+// ExtractAssertionMethod extracts the first ed25519 Multikey
+// (and its ID) from the FEP-521a 'assertionMethod' property
+// of the given actor, whose controller is the given owner.
+//
+// Returns nil, nil if no usable key is present.
+func ExtractAssertionMethod(i WithUnknownProperties, owner *url.URL) (
+	ed25519.PublicKey, // pubkey
+	*url.URL, // pubkey ID
+) {
+	var methods []interface{}
+	switch raw := i.GetUnknownProperties()["assertionMethod"].(type) {
+	case []interface{}:
+		methods = raw
+	case map[string]interface{}:
+		methods = []interface{}{raw}
+	}
+
+	for _, method := range methods {
+		// We only handle embedded methods,
+		// not references to other documents.
+		m, ok := method.(map[string]interface{})
+		if !ok || m["type"] != "Multikey" {
+			continue
+		}
+
+		// The key must belong to the owner
+		// (actor) of this assertion method.
+		controller, _ := m["controller"].(string)
+		if controller != owner.String() {
+			continue
+		}
+
+		keyIDStr, _ := m["id"].(string)
+		keyID, err := url.Parse(keyIDStr)
+		if err != nil || keyID.Host != owner.Host {
+			continue
+		}
+
+		multibase, _ := m["publicKeyMultibase"].(string)
+		pubKey, err := integrity.DecodeMultikey(multibase)
+		if err != nil {
+			// Probably
+			// not ed25519.
+			continue
+		}
+
+		return pubKey, keyID
+	}
+
+	return nil, nil
+}
+
 // ExtractContent returns an intermediary representation of
 // the given interface's Content and/or ContentMap property.
 func ExtractContent(i WithContent) gtsmodel.Content {
@@ -671,6 +880,33 @@ func ExtractAttachments(i WithAttachment) ([]*gtsmodel.MediaAttachment, error) {
 	return attachments, errs.Combine()
 }
 
+// ExtractLinkAttachments extracts the href URIs of any
+// Link type attachments on the given WithAttachment. These
+// are used by eg., Lemmy to attach the URL of a link post.
+func ExtractLinkAttachments(i WithAttachment) []*url.URL {
+	attachmentProp := i.GetActivityStreamsAttachment()
+	if attachmentProp == nil {
+		return nil
+	}
+
+	var hrefs []*url.URL
+	for iter := attachmentProp.Begin(); iter != attachmentProp.End(); iter = iter.Next() {
+		link := iter.GetActivityStreamsLink()
+		if link == nil {
+			continue
+		}
+
+		href := link.GetActivityStreamsHref()
+		if href == nil || href.Get() == nil {
+			continue
+		}
+
+		hrefs = append(hrefs, href.Get())
+	}
+
+	return hrefs
+}
+
 // ExtractAttachment extracts a minimal gtsmodel.Attachment
 // (just remote URL, description, and blurhash) from the given
 // Attachmentable interface, or an error if no remote URL is set.
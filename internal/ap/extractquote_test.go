@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) statusable(statusableJSON string) ap.Statusable {
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(statusableJSON), &raw); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), raw)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusable, ok := ap.ToStatusable(t)
+	if !ok {
+		suite.FailNow("type was not Statusable")
+	}
+
+	return statusable
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLink() {
+	statusable := suite.statusable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/notes/1",
+  "type": "Note",
+  "content": "quoting this!<br>RE: https://example.org/notes/0",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "href": "https://example.org/notes/0",
+      "name": "RE: https://example.org/notes/0"
+    }
+  ]
+}`)
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.Equal("https://example.org/notes/0", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteMisskey() {
+	statusable := suite.statusable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/notes/1",
+  "type": "Note",
+  "content": "quoting this!",
+  "_misskey_quote": "https://example.org/notes/0"
+}`)
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.Equal("https://example.org/notes/0", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	statusable := suite.statusable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/notes/1",
+  "type": "Note",
+  "content": "not quoting anything",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "text/html",
+      "href": "https://example.org/some/page"
+    }
+  ],
+  "quoteUrl": "not a url"
+}`)
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.Nil(quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestSetQuoteRoundTrip() {
+	note := streams.NewActivityStreamsNote()
+	ap.SetJSONLDId(note, testrig.URLMustParse("https://example.org/notes/1"))
+	ap.SetQuote(note, testrig.URLMustParse("https://example.org/notes/0"))
+
+	raw, err := ap.Serialize(note)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "_misskey_quote": "https://misskey-hub.net/ns#_misskey_quote",
+      "quoteUrl": "as:quoteUrl"
+    }
+  ],
+  "_misskey_quote": "https://example.org/notes/0",
+  "id": "https://example.org/notes/1",
+  "quoteUrl": "https://example.org/notes/0",
+  "tag": {
+    "href": "https://example.org/notes/0",
+    "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+    "name": "RE: https://example.org/notes/0",
+    "type": "Link"
+  },
+  "type": "Note"
+}`, string(b))
+
+	quoteURI := ap.ExtractQuoteURI(suite.statusable(string(b)))
+	suite.Equal("https://example.org/notes/0", quoteURI.String())
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
@@ -166,6 +166,7 @@ type Accountable interface {
 	WithEndpoints
 	WithTag
 	WithPublished
+	WithUnknownProperties
 }
 
 // Statusable represents the minimum activitypub interface for representing a 'status'.
@@ -182,12 +183,14 @@ type Statusable interface {
 	WithAttributedTo
 	WithTo
 	WithCc
+	WithAudience
 	WithSensitive
 	WithConversation
 	WithContent
 	WithAttachment
 	WithTag
 	WithReplies
+	WithUnknownProperties
 }
 
 // Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
@@ -268,6 +271,16 @@ type Likeable interface {
 	WithObject
 }
 
+// Reactable represents the minimum interface for an emoji reaction,
+// ie., a 'Like' activity carrying an emoji in its content. Incoming
+// Misskey / Pleroma 'EmojiReact' activities are resolved as these.
+type Reactable interface {
+	Likeable
+	WithContent
+	WithTag
+	WithUnknownProperties
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -381,6 +394,14 @@ type WithTypeName interface {
 	GetTypeName() string
 }
 
+// WithUnknownProperties represents an activity with a map of
+// properties not known to the go-fed vocabulary, eg., extension
+// properties like 'assertionMethod'. The returned map is the
+// live map used for serialization, so it may be modified.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithPreferredUsername represents an activity with ActivityStreamsPreferredUsernameProperty
 type WithPreferredUsername interface {
 	GetActivityStreamsPreferredUsername() vocab.ActivityStreamsPreferredUsernameProperty
@@ -506,6 +527,12 @@ type WithCc interface {
 	SetActivityStreamsCc(vocab.ActivityStreamsCcProperty)
 }
 
+// WithAudience represents an activity with ActivityStreamsAudienceProperty
+type WithAudience interface {
+	GetActivityStreamsAudience() vocab.ActivityStreamsAudienceProperty
+	SetActivityStreamsAudience(vocab.ActivityStreamsAudienceProperty)
+}
+
 // WithCC represents an activity with ActivityStreamsBccProperty
 type WithBcc interface {
 	GetActivityStreamsBcc() vocab.ActivityStreamsBccProperty
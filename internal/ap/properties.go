@@ -18,6 +18,7 @@
 package ap
 
 import (
+	"crypto/ed25519"
 	"fmt"
 	"net/url"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
 )
 
 // MustGet performs the given 'Get$Property(with) (T, error)' signature function, panicking on error.
@@ -117,6 +119,24 @@ func AppendCc(with WithCc, cc ...*url.URL) {
 	}, cc...)
 }
 
+// GetAudience returns the IRIs contained in the Audience property of 'with'.
+func GetAudience(with WithAudience) []*url.URL {
+	audienceProp := with.GetActivityStreamsAudience()
+	return extractIRIs[vocab.ActivityStreamsAudiencePropertyIterator](audienceProp)
+}
+
+// AppendAudience appends the given IRIs to the Audience property of 'with'.
+func AppendAudience(with WithAudience, audience ...*url.URL) {
+	appendIRIs(func() Property[vocab.ActivityStreamsAudiencePropertyIterator] {
+		audienceProp := with.GetActivityStreamsAudience()
+		if audienceProp == nil {
+			audienceProp = streams.NewActivityStreamsAudienceProperty()
+			with.SetActivityStreamsAudience(audienceProp)
+		}
+		return audienceProp
+	}, audience...)
+}
+
 // GetBcc returns the IRIs contained in the Bcc property of 'with'. Panics on entries with missing ID.
 func GetBcc(with WithBcc) []*url.URL {
 	bccProp := with.GetActivityStreamsBcc()
@@ -499,6 +519,36 @@ func SetDiscoverable(with WithDiscoverable, discoverable bool) {
 	discoverProp.Set(discoverable)
 }
 
+// GetIndexable returns the boolean contained in the (Mastodon)
+// 'indexable' property of 'with', which isn't (yet) part of
+// the go-fed vocabulary, so is read from unknown properties.
+//
+// Returns default 'false' if property unusable or not set.
+func GetIndexable(with WithUnknownProperties) bool {
+	indexable, _ := with.GetUnknownProperties()["indexable"].(bool)
+	return indexable
+}
+
+// SetIndexable sets the given boolean on the (Mastodon) 'indexable' property of 'with'.
+func SetIndexable(with WithUnknownProperties, indexable bool) {
+	with.GetUnknownProperties()["indexable"] = indexable
+}
+
+// GetMemorial returns the boolean contained in the (Mastodon)
+// 'memorial' property of 'with', which isn't part of the
+// go-fed vocabulary, so is read from unknown properties.
+//
+// Returns default 'false' if property unusable or not set.
+func GetMemorial(with WithUnknownProperties) bool {
+	memorial, _ := with.GetUnknownProperties()["memorial"].(bool)
+	return memorial
+}
+
+// SetMemorial sets the given boolean on the (Mastodon) 'memorial' property of 'with'.
+func SetMemorial(with WithUnknownProperties, memorial bool) {
+	with.GetUnknownProperties()["memorial"] = memorial
+}
+
 // GetManuallyApprovesFollowers returns the boolean contained in the ManuallyApprovesFollowers property of 'with'.
 //
 // Returns default 'true' if property unusable or not set.
@@ -520,6 +570,63 @@ func SetManuallyApprovesFollowers(with WithManuallyApprovesFollowers, manuallyAp
 	mafProp.Set(manuallyApprovesFollowers)
 }
 
+// SetAssertionMethod sets the given ed25519 public key as
+// a Multikey in the FEP-521a 'assertionMethod' property of
+// 'with', with the given key ID and controller (actor) ID.
+func SetAssertionMethod(with WithUnknownProperties, keyID string, controller string, key ed25519.PublicKey) {
+	with.GetUnknownProperties()["assertionMethod"] = []interface{}{
+		map[string]interface{}{
+			"id":                 keyID,
+			"type":               "Multikey",
+			"controller":         controller,
+			"publicKeyMultibase": integrity.EncodeMultikey(key),
+		},
+	}
+}
+
+// SetQuote sets the given quoted status URI on 'with', both as
+// a FEP-e232 object Link in the 'tag' property, and as the
+// 'quoteUrl' and '_misskey_quote' properties understood by
+// other implementations which don't (yet) support FEP-e232.
+func SetQuote(with Statusable, quoteURI *url.URL) {
+	link := streams.NewActivityStreamsLink()
+
+	href := streams.NewActivityStreamsHrefProperty()
+	href.Set(quoteURI)
+	link.SetActivityStreamsHref(href)
+
+	mediaType := streams.NewActivityStreamsMediaTypeProperty()
+	mediaType.Set(ObjectLinkMediaType)
+	link.SetActivityStreamsMediaType(mediaType)
+
+	name := streams.NewActivityStreamsNameProperty()
+	name.AppendXMLSchemaString("RE: " + quoteURI.String())
+	link.SetActivityStreamsName(name)
+
+	tagProp := with.GetActivityStreamsTag()
+	if tagProp == nil {
+		tagProp = streams.NewActivityStreamsTagProperty()
+		with.SetActivityStreamsTag(tagProp)
+	}
+	tagProp.AppendActivityStreamsLink(link)
+
+	unknown := with.GetUnknownProperties()
+	unknown["quoteUrl"] = quoteURI.String()
+	unknown["_misskey_quote"] = quoteURI.String()
+}
+
+// SetReaction sets the given emoji reaction name (either a unicode
+// emoji or a custom emoji :shortcode:) on 'with', both as 'content'
+// and as the '_misskey_reaction' property understood by Misskey.
+func SetReaction(with Reactable, name string) {
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString(name)
+	with.SetActivityStreamsContent(contentProp)
+
+	unknown := with.GetUnknownProperties()
+	unknown["_misskey_reaction"] = name
+}
+
 // extractIRIs extracts just the AP IRIs from an iterable
 // property that may contain types (with IRIs) or just IRIs.
 //
@@ -29,11 +29,16 @@ import (
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 // ResolveActivity is a util function for pulling a pub.Activity type out of an incoming request body,
 // returning the resolved activity type, error and whether to accept activity (false = transient i.e. ignore).
-func ResolveIncomingActivity(r *http.Request) (pub.Activity, bool, gtserror.WithCode) {
+//
+// If the activity carries a supported FEP-8b32 object integrity proof, this will also be returned, ready
+// for verification against the key of the activity's actor. Unsupported or malformed proofs are ignored.
+func ResolveIncomingActivity(r *http.Request) (pub.Activity, *integrity.Proof, bool, gtserror.WithCode) {
 	// Get "raw" map
 	// destination.
 	raw := getMap()
@@ -52,18 +57,18 @@ func ResolveIncomingActivity(r *http.Request) (pub.Activity, bool, gtserror.With
 
 		if !streams.IsUnmatchedErr(err) {
 			err := gtserror.Newf("error matching json to type: %w", err)
-			return nil, false, gtserror.NewErrorInternalError(err)
+			return nil, nil, false, gtserror.NewErrorInternalError(err)
 		}
 
 		const text = "body json not resolvable as ActivityStreams type"
-		return nil, false, gtserror.NewErrorBadRequest(errors.New(text), text)
+		return nil, nil, false, gtserror.NewErrorBadRequest(errors.New(text), text)
 	}
 
 	// Ensure this is an Activity type.
 	activity, ok := t.(pub.Activity)
 	if !ok {
 		text := fmt.Sprintf("cannot resolve vocab type %T as pub.Activity", t)
-		return nil, false, gtserror.NewErrorBadRequest(errors.New(text), text)
+		return nil, nil, false, gtserror.NewErrorBadRequest(errors.New(text), text)
 	}
 
 	if activity.GetJSONLDId() == nil {
@@ -72,7 +77,15 @@ func ResolveIncomingActivity(r *http.Request) (pub.Activity, bool, gtserror.With
 		// all objects distributed by the ActivityPub protocol MUST have unique global identifiers,
 		// unless they are intentionally transient (short lived activities that are not intended to
 		// be able to be looked up, such as some kinds of chat messages or game notifications).
-		return nil, false, nil
+		return nil, nil, false, nil
+	}
+
+	// Extract any integrity proof before normalization,
+	// as it must be checked against the raw JSON data.
+	proof, err := integrity.Extract(raw)
+	if err != nil {
+		log.Debugf(r.Context(), "ignoring integrity proof on %s: %v", GetJSONLDId(activity), err)
+		proof = nil
 	}
 
 	// Normalize any Statusable, Accountable, Pollable fields found.
@@ -82,7 +95,7 @@ func ResolveIncomingActivity(r *http.Request) (pub.Activity, bool, gtserror.With
 	// Release.
 	putMap(raw)
 
-	return activity, true, nil
+	return activity, proof, true, nil
 }
 
 // ResolveStatusable tries to resolve the response data as an ActivityPub
@@ -251,11 +264,42 @@ func decodeType(
 	// Done with body.
 	_ = body.Close()
 
-	// Resolve an ActivityStreams type.
+	// Resolve an ActivityStreams type, treating any
+	// EmojiReacts (unknown to go-fed) as Likes. Once
+	// resolved, the original 'raw' map is restored,
+	// since it may be needed to check integrity proofs.
+	restore := resolveEmojiReactAsLike(raw)
 	t, err := streams.ToType(ctx, raw)
+	restore()
 	if err != nil {
 		return nil, gtserror.NewfAt(3, "error resolving json into ap vocab type: %w", err)
 	}
 
 	return t, nil
 }
+
+// resolveEmojiReactAsLike changes the type of any EmojiReact in
+// the given raw JSON map, or in its 'object' (eg., for an Undo),
+// to Like, so that it may be resolved into a go-fed vocab.Type.
+// The returned function restores the original type name(s).
+func resolveEmojiReactAsLike(raw map[string]any) (restore func()) {
+	swapped := make([]map[string]any, 0, 1)
+
+	swap := func(m map[string]any) {
+		if m["type"] == ActivityEmojiReact {
+			m["type"] = ActivityLike
+			swapped = append(swapped, m)
+		}
+	}
+
+	swap(raw)
+	if object, ok := raw["object"].(map[string]any); ok {
+		swap(object)
+	}
+
+	return func() {
+		for _, m := range swapped {
+			m["type"] = ActivityEmojiReact
+		}
+	}
+}
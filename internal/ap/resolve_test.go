@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -80,6 +81,84 @@ func (suite *ResolveTestSuite) TestResolveNonAPJSONAsAccountable() {
 	suite.Nil(accountable)
 }
 
+func (suite *ResolveTestSuite) TestResolveEmojiReactAsLike() {
+	b := []byte(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "https://pleroma.example.org/users/someone",
+  "content": "🐈",
+  "id": "https://pleroma.example.org/activities/01J15C0WQ5Y1J7B4B0B1PF8E8T",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "type": "EmojiReact"
+}`)
+
+	r, err := http.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", bytes.NewReader(b))
+	suite.NoError(err)
+
+	activity, _, ok, errWithCode := ap.ResolveIncomingActivity(r)
+	suite.NoError(errWithCode)
+	suite.True(ok)
+	suite.Equal(ap.ActivityLike, activity.GetTypeName())
+
+	reactable, ok := activity.(ap.Reactable)
+	suite.True(ok)
+	suite.Equal("🐈", ap.ExtractReaction(reactable))
+}
+
+func (suite *ResolveTestSuite) TestResolveUndoEmojiReact() {
+	b := []byte(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "https://pleroma.example.org/users/someone",
+  "id": "https://pleroma.example.org/activities/01J15C2GJ2R8TFK0Y1TSQ1WTYE",
+  "object": {
+    "actor": "https://pleroma.example.org/users/someone",
+    "content": ":blobcat:",
+    "id": "https://pleroma.example.org/activities/01J15C0WQ5Y1J7B4B0B1PF8E8T",
+    "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+    "type": "EmojiReact"
+  },
+  "type": "Undo"
+}`)
+
+	r, err := http.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", bytes.NewReader(b))
+	suite.NoError(err)
+
+	activity, _, ok, errWithCode := ap.ResolveIncomingActivity(r)
+	suite.NoError(errWithCode)
+	suite.True(ok)
+	suite.Equal(ap.ActivityUndo, activity.GetTypeName())
+
+	objects := ap.ExtractObjects(activity)
+	suite.Len(objects, 1)
+
+	reactable, ok := objects[0].GetType().(ap.Reactable)
+	suite.True(ok)
+	suite.Equal(ap.ActivityLike, reactable.GetTypeName())
+	suite.Equal(":blobcat:", ap.ExtractReaction(reactable))
+}
+
+func (suite *ResolveTestSuite) TestExtractReactionHeartIsFave() {
+	b := []byte(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "_misskey_reaction": "❤",
+  "actor": "https://misskey.example.org/users/9pfdw0d04xxj0001",
+  "content": "❤",
+  "id": "https://misskey.example.org/likes/9ruvy8dq0cdr0001",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "type": "Like"
+}`)
+
+	r, err := http.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", bytes.NewReader(b))
+	suite.NoError(err)
+
+	activity, _, ok, errWithCode := ap.ResolveIncomingActivity(r)
+	suite.NoError(errWithCode)
+	suite.True(ok)
+
+	reactable, ok := activity.(ap.Reactable)
+	suite.True(ok)
+	suite.Empty(ap.ExtractReaction(reactable))
+}
+
 func TestResolveTestSuite(t *testing.T) {
 	suite.Run(t, &ResolveTestSuite{})
 }
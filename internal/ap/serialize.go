@@ -21,6 +21,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
 )
 
 // Serialize is a custom serializer for ActivityStreams types.
@@ -36,9 +37,9 @@ import (
 //
 //   - OrderedCollection:       'orderedItems' property will always be made into an array.
 //   - OrderedCollectionPage:   'orderedItems' property will always be made into an array.
-//   - Any Accountable type:    'attachment' property will always be made into an array.
-//   - Any Statusable type:     'attachment' property will always be made into an array; 'content' and 'contentMap' will be normalized.
-//   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
+//   - Any Accountable type:    'attachment' property will always be made into an array; Multikey context added if 'assertionMethod' set; indexable context added if 'indexable' set.
+//   - Any Statusable type:     'attachment' property will always be made into an array; 'content' and 'contentMap' will be normalized; quote context added if 'quoteUrl' set.
+//   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above; reaction context added if '_misskey_reaction' set.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
 	switch tn := t.GetTypeName(); {
 	case tn == ObjectOrderedCollection ||
@@ -127,6 +128,24 @@ func serializeAccountable(t vocab.Type, includeContext bool) (map[string]interfa
 	NormalizeOutgoingAttachmentProp(accountable, data)
 	NormalizeOutgoingAlsoKnownAsProp(accountable, data)
 
+	if _, ok := data["assertionMethod"]; ok && includeContext {
+		// Ensure the terms used by
+		// Multikey are defined.
+		integrity.AppendContext(data, integrity.ContextMultikey)
+	}
+
+	if _, ok := data["indexable"]; ok && includeContext {
+		// Ensure the non-standard
+		// indexable term is defined.
+		appendContext(data, indexableContext)
+	}
+
+	if _, ok := data["memorial"]; ok && includeContext {
+		// Ensure the non-standard
+		// memorial term is defined.
+		appendContext(data, memorialContext)
+	}
+
 	return data, nil
 }
 
@@ -154,6 +173,12 @@ func serializeStatusable(t vocab.Type, includeContext bool) (map[string]interfac
 	NormalizeOutgoingAttachmentProp(statusable, data)
 	NormalizeOutgoingContentProp(statusable, data)
 
+	if _, ok := data["quoteUrl"]; ok && includeContext {
+		// Ensure the non-standard
+		// quote terms are defined.
+		appendContext(data, quoteContext)
+	}
+
 	return data, nil
 }
 
@@ -182,5 +207,71 @@ func serializeActivityable(t vocab.Type, includeContext bool) (map[string]interf
 		return nil, err
 	}
 
+	if includeContext {
+		// If this is an emoji reaction, ensure
+		// the reaction term is defined in context.
+		if _, ok := data["_misskey_reaction"]; ok {
+			appendContext(data, reactionContext)
+		}
+
+		// If a wrapped status quotes another,
+		// ensure the quote terms are defined
+		// in the wrapping activity's context.
+		//
+		// Likewise for a wrapped emoji reaction,
+		// eg., when this is an Undo of a reaction.
+		if object, ok := data["object"].(map[string]interface{}); ok {
+			if _, ok := object["quoteUrl"]; ok {
+				appendContext(data, quoteContext)
+			}
+			if _, ok := object["_misskey_reaction"]; ok {
+				appendContext(data, reactionContext)
+			}
+		}
+	}
+
 	return data, nil
 }
+
+// quoteContext defines the non-standard terms used
+// by other implementations to indicate a quote post.
+var quoteContext = map[string]interface{}{
+	"quoteUrl":       "as:quoteUrl",
+	"_misskey_quote": "https://misskey-hub.net/ns#_misskey_quote",
+}
+
+// indexableContext defines the non-standard term used by
+// Mastodon to indicate that an actor's posts may be indexed.
+var indexableContext = map[string]interface{}{
+	"indexable": "http://joinmastodon.org/ns#indexable",
+}
+
+// memorialContext defines the non-standard term used by
+// Mastodon to indicate that an actor has been memorialized.
+var memorialContext = map[string]interface{}{
+	"memorial": "http://joinmastodon.org/ns#memorial",
+}
+
+// reactionContext defines the non-standard terms used
+// by other implementations to indicate an emoji reaction.
+var reactionContext = map[string]interface{}{
+	"_misskey_reaction": "https://misskey-hub.net/ns#_misskey_reaction",
+}
+
+// appendContext appends the
+// given extra context to the
+// '@context' of serialized data.
+func appendContext(data map[string]interface{}, extra map[string]interface{}) {
+	switch existing := data["@context"].(type) {
+	case nil:
+		data["@context"] = extra
+	case []interface{}:
+		// Copy to avoid touching
+		// the original's array.
+		ctxs := make([]interface{}, 0, len(existing)+1)
+		ctxs = append(ctxs, existing...)
+		data["@context"] = append(ctxs, extra)
+	default:
+		data["@context"] = []interface{}{existing, extra}
+	}
+}
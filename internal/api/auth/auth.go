@@ -73,6 +73,14 @@ const (
 	sessionClientState   = "client_state"
 	sessionClaims        = "claims"
 	sessionAppID         = "app_id"
+
+	/*
+		sign in form keys / assets
+	*/
+
+	challengeIDKey     = "challenge_id"
+	challengeAnswerKey = "challenge_answer"
+	jsChallenge        = "/assets/dist/challenge.js"
 )
 
 type Module struct {
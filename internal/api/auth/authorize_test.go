@@ -71,6 +71,19 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 			expectedStatusCode:     http.StatusSeeOther,
 			expectedLocationHeader: "/auth" + auth.AuthAccountDisabledPath,
 		},
+		{
+			description: "user has their email confirmed and is approved, but Account entity has been memorialized",
+			mutateUserAccount: func(user *gtsmodel.User, account *gtsmodel.Account) []string {
+				user.ConfirmedAt = time.Now()
+				user.Email = user.UnconfirmedEmail
+				user.Approved = util.Ptr(true)
+				user.Disabled = util.Ptr(false)
+				account.Memorial = util.Ptr(true)
+				return []string{"confirmed_at", "email", "approved", "disabled"}
+			},
+			expectedStatusCode:     http.StatusSeeOther,
+			expectedLocationHeader: "/auth" + auth.AuthAccountDisabledPath,
+		},
 	}
 
 	doTest := func(testCase authorizeHandlerTestCase) {
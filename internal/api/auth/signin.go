@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/challenges"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -55,9 +56,30 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 			return
 		}
 
+		// Get a challenge to solve,
+		// if sign-ins need one.
+		challenge, errWithCode := m.processor.Challenges().Issue(
+			c.Request.Context(),
+			challenges.FormSignIn,
+			c.ClientIP(),
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		var javascript []string
+		if challenge != nil {
+			javascript = []string{jsChallenge}
+		}
+
 		page := apiutil.WebPage{
-			Template: "sign-in.tmpl",
-			Instance: instance,
+			Template:   "sign-in.tmpl",
+			Instance:   instance,
+			Javascript: javascript,
+			Extra: map[string]any{
+				"challenge": challenge,
+			},
 		}
 
 		apiutil.TemplateWebPage(c, page)
@@ -92,8 +114,27 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Check the challenge (and cooldown)
+	// before even looking at the password.
+	clientIP := c.ClientIP()
+	if errWithCode := m.processor.Challenges().Check(
+		c.Request.Context(),
+		challenges.FormSignIn,
+		clientIP,
+		c.PostForm(challengeIDKey),
+		c.PostForm(challengeAnswerKey),
+	); errWithCode != nil {
+		// don't clear session here either, the user can go back and try again
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	userid, errWithCode := m.ValidatePassword(c.Request.Context(), form.Email, form.Password)
 	if errWithCode != nil {
+		// Wrong passwords count toward
+		// the cooldown for this IP too.
+		m.processor.Challenges().Fail(clientIP)
+
 		// don't clear session here, so the user can just press back and try again
 		// if they accidentally gave the wrong password or something
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/challenges"
+)
+
+type SignInTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *SignInTestSuite) signIn(form url.Values) int {
+	ctx, _ := suite.newContext(
+		http.MethodPost,
+		"auth"+auth.AuthSignInPath,
+		[]byte(form.Encode()),
+		"application/x-www-form-urlencoded",
+	)
+	suite.authModule.SignInPOSTHandler(ctx)
+
+	// Redirects from POST have no body, so gin
+	// won't have flushed the status to recorder.
+	return ctx.Writer.Status()
+}
+
+func (suite *SignInTestSuite) TestSignInGETChallenge() {
+	config.SetAccountsChallengeSignIn(config.AccountsChallengeCaptcha)
+
+	ctx, recorder := suite.newContext(http.MethodGet, "auth"+auth.AuthSignInPath, nil, "")
+	suite.authModule.SignInGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	suite.Contains(body, `name="challenge_id"`)
+	suite.Contains(body, `src="/challenge/captcha/`)
+}
+
+func (suite *SignInTestSuite) TestSignInPOSTChallenge() {
+	config.SetAccountsChallengeSignIn(config.AccountsChallengeCaptcha)
+
+	c, errWithCode := suite.processor.Challenges().Issue(context.Background(), challenges.FormSignIn, "127.0.0.1")
+	suite.NoError(errWithCode)
+
+	form := url.Values{
+		"username":         {"admin@example.org"},
+		"password":         {"password"},
+		"challenge_id":     {c.ID},
+		"challenge_answer": {"wrong"},
+	}
+
+	// Right password, wrong answer.
+	suite.Equal(http.StatusBadRequest, suite.signIn(form))
+
+	// Right password, right answer.
+	c, errWithCode = suite.processor.Challenges().Issue(context.Background(), challenges.FormSignIn, "127.0.0.1")
+	suite.NoError(errWithCode)
+	form.Set("challenge_id", c.ID)
+	form.Set("challenge_answer", strings.ToLower(c.Text))
+	suite.Equal(http.StatusFound, suite.signIn(form))
+}
+
+func (suite *SignInTestSuite) TestSignInPOSTCooldown() {
+	config.SetAccountsChallengeCooldownAttempts(2)
+
+	form := url.Values{
+		"username": {"admin@example.org"},
+		"password": {"wrong"},
+	}
+
+	// Two wrong passwords...
+	suite.Equal(http.StatusUnauthorized, suite.signIn(form))
+	suite.Equal(http.StatusUnauthorized, suite.signIn(form))
+
+	// ...and now even the right
+	// password is refused for a while.
+	form.Set("password", "password")
+	suite.Equal(http.StatusTooManyRequests, suite.signIn(form))
+}
+
+func TestSignInTestSuite(t *testing.T) {
+	suite.Run(t, &SignInTestSuite{})
+}
@@ -34,11 +34,13 @@ import (
 	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
@@ -46,6 +48,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
@@ -71,11 +74,13 @@ type Client struct {
 	filtersV2      *filtersV2.Module      // api/v2/filters
 	followRequests *followrequests.Module // api/v1/follow_requests
 	instance       *instance.Module       // api/v1/instance
+	invites        *invites.Module        // api/v1/invites
 	lists          *lists.Module          // api/v1/lists
 	markers        *markers.Module        // api/v1/markers
 	media          *media.Module          // api/v1/media, api/v2/media
 	mutes          *mutes.Module          // api/v1/mutes
 	notifications  *notifications.Module  // api/v1/notifications
+	oEmbed         *oembed.Module         // api/oembed
 	polls          *polls.Module          // api/v1/polls
 	preferences    *preferences.Module    // api/v1/preferences
 	reports        *reports.Module        // api/v1/reports
@@ -83,6 +88,7 @@ type Client struct {
 	statuses       *statuses.Module       // api/v1/statuses
 	streaming      *streaming.Module      // api/v1/streaming
 	timelines      *timelines.Module      // api/v1/timelines
+	trends         *trends.Module         // api/v1/trends
 	user           *user.Module           // api/v1/user
 }
 
@@ -116,11 +122,13 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.filtersV2.Route(h)
 	c.followRequests.Route(h)
 	c.instance.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
 	c.mutes.Route(h)
 	c.notifications.Route(h)
+	c.oEmbed.Route(h)
 	c.polls.Route(h)
 	c.preferences.Route(h)
 	c.reports.Route(h)
@@ -128,6 +136,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.statuses.Route(h)
 	c.streaming.Route(h)
 	c.timelines.Route(h)
+	c.trends.Route(h)
 	c.user.Route(h)
 }
 
@@ -149,11 +158,13 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		filtersV2:      filtersV2.New(p),
 		followRequests: followrequests.New(p),
 		instance:       instance.New(p),
+		invites:        invites.New(p),
 		lists:          lists.New(p),
 		markers:        markers.New(p),
 		media:          media.New(p),
 		mutes:          mutes.New(p),
 		notifications:  notifications.New(p),
+		oEmbed:         oembed.New(p),
 		polls:          polls.New(p),
 		preferences:    preferences.New(p),
 		reports:        reports.New(p),
@@ -161,6 +172,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		statuses:       statuses.New(p),
 		streaming:      streaming.New(p, time.Second*30, 4096),
 		timelines:      timelines.New(p),
+		trends:         trends.New(p),
 		user:           user.New(p),
 	}
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -62,6 +63,10 @@ import (
 //			description: bad request
 //		'401':
 //			description: unauthorized
+//		'403':
+//			description: >-
+//				Forbidden. Sign-ups via the API are disabled on this instance
+//				because it requires a challenge to be solved to sign up.
 //		'404':
 //			description: not found
 //		'406':
@@ -71,6 +76,10 @@ import (
 //				Unprocessable. Your account creation request cannot be processed
 //				because either too many accounts have been created on this instance
 //				in the last 24h, or the pending account backlog is full.
+//		'429':
+//			description: >-
+//				Too many requests. Too many failed attempts have been made
+//				from this IP address, and it must wait before trying again.
 //		'500':
 //			description: internal server error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
@@ -85,6 +94,16 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Challenges can only be solved via the
+	// web sign-up form, so if sign-ups need
+	// one, don't accept sign-ups via the API.
+	if config.GetAccountsChallengeSignUp() != "" {
+		const text = "sign-ups via the API are disabled on this instance, please sign up via the instance website instead"
+		errWithCode := gtserror.NewErrorForbidden(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	form := &apimodel.AccountCreateRequest{}
 	if err := c.ShouldBind(form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
@@ -105,6 +124,13 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 	}
 	form.IP = signUpIP
 
+	// Refuse IPs that have failed
+	// too many challenges or sign-ins.
+	if errWithCode := m.processor.Challenges().Cooldown(clientIP); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	// Create the new user+account.
 	ctx := c.Request.Context()
 	user, errWithCode := m.processor.User().Create(
@@ -17,3 +17,65 @@
 // */
 
 package accounts_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type AccountCreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountCreateTestSuite) createAccount() (int, string) {
+	form := url.Values{
+		"username":  {"new_person"},
+		"email":     {"new_person@example.org"},
+		"password":  {"very-good-password-123"},
+		"agreement": {"true"},
+		"locale":    {"en"},
+		"reason":    {"i would like to join this instance please, it seems very nice"},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), accounts.BasePath, "application/x-www-form-urlencoded")
+	ctx.Request.RemoteAddr = "127.0.0.1:6969"
+
+	suite.accountsModule.AccountCreatePOSTHandler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *AccountCreateTestSuite) TestAccountCreateChallenge() {
+	config.SetAccountsChallengeSignUp(config.AccountsChallengePoW)
+
+	code, body := suite.createAccount()
+	suite.Equal(http.StatusForbidden, code)
+	suite.Equal(`{"error":"Forbidden: sign-ups via the API are disabled on this instance, please sign up via the instance website instead"}`, body)
+}
+
+func (suite *AccountCreateTestSuite) TestAccountCreateCoolingDown() {
+	config.SetAccountsChallengeCooldownAttempts(1)
+	suite.processor.Challenges().Fail("127.0.0.1")
+
+	code, body := suite.createAccount()
+	suite.Equal(http.StatusTooManyRequests, code)
+	suite.True(strings.Contains(body, "too many failed attempts"), body)
+}
+
+func TestAccountCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountCreateTestSuite))
+}
@@ -133,6 +133,16 @@ import (
 //		description: Hide the account's following/followers collections.
 //		type: boolean
 //	-
+//		name: indexable
+//		in: formData
+//		description: Allow the account's public posts to be found by anyone via search.
+//		type: boolean
+//	-
+//		name: disable_embeds
+//		in: formData
+//		description: Prevent the account's posts from being embedded on other websites.
+//		type: boolean
+//	-
 //		name: fields_attributes[0][name]
 //		in: formData
 //		description: Name of 1st profile field to be added to this account's profile.
@@ -325,7 +335,9 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.Theme == nil &&
 			form.CustomCSS == nil &&
 			form.EnableRSS == nil &&
-			form.HideCollections == nil) {
+			form.HideCollections == nil &&
+			form.Indexable == nil &&
+			form.DisableEmbeds == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
@@ -360,6 +360,42 @@ func (suite *AccountUpdateTestSuite) TestUpdateAccountDiscoverableJSON() {
 	suite.False(*dbZork.Discoverable)
 }
 
+func (suite *AccountUpdateTestSuite) TestUpdateAccountIndexableForm() {
+	data := map[string][]string{
+		"indexable": {"true"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromForm(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(apimodelAccount.Indexable)
+
+	// Check the account settings in the database too.
+	dbSettings, err := suite.db.GetAccountSettings(context.Background(), apimodelAccount.ID)
+	suite.NoError(err)
+	suite.True(*dbSettings.Indexable)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountDisableEmbedsForm() {
+	data := map[string][]string{
+		"disable_embeds": {"true"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromForm(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(apimodelAccount.DisableEmbeds)
+
+	// Check the account settings in the database too.
+	dbSettings, err := suite.db.GetAccountSettings(context.Background(), apimodelAccount.ID)
+	suite.NoError(err)
+	suite.True(*dbSettings.DisableEmbeds)
+}
+
 func (suite *AccountUpdateTestSuite) TestUpdateAccountWithImageFormData() {
 	data := map[string][]string{
 		"display_name": {"updated zork display name!!!"},
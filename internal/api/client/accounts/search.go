@@ -51,8 +51,8 @@ import (
 //		type: integer
 //		description: >-
 //			Page number of results to return (starts at 0).
-//			This parameter is currently not used, offsets
-//			over 0 will always return 0 results.
+//			Results are ordered by relevance, so page
+//			through them using this parameter.
 //		default: 0
 //		maximum: 10
 //		minimum: 0
@@ -369,16 +369,16 @@ func (suite *AccountSearchTestSuite) TestSearchAFollowing() {
 		suite.FailNow(err.Error())
 	}
 
-	if l := len(accounts); l != 5 {
-		suite.FailNow("", "expected length %d got %d", 5, l)
+	if l := len(accounts); l != 1 {
+		suite.FailNow("", "expected length %d got %d", 1, l)
 	}
 
-	usernames := make([]string, 0, 5)
+	usernames := make([]string, 0, 1)
 	for _, account := range accounts {
 		usernames = append(usernames, account.Username)
 	}
 
-	suite.EqualValues([]string{"her_fuckin_maj", "foss_satan", "1happyturtle", "the_mighty_zork", "admin"}, usernames)
+	suite.EqualValues([]string{"admin"}, usernames)
 }
 
 func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
@@ -421,7 +421,45 @@ func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
 		usernames = append(usernames, account.Username)
 	}
 
-	suite.EqualValues([]string{"1happyturtle", "admin"}, usernames)
+	suite.EqualValues([]string{"admin", "1happyturtle"}, usernames)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchANotFollowingOffset() {
+	var (
+		requestingAccount        = suite.testAccounts["local_account_1"]
+		token                    = suite.testTokens["local_account_1"]
+		user                     = suite.testUsers["local_account_1"]
+		limit              *int  = nil
+		offset             *int  = func() *int { i := 1; return &i }()
+		resolve            *bool = nil
+		query                    = "a"
+		following          *bool = func() *bool { i := true; return &i }()
+		expectedHTTPStatus       = http.StatusOK
+		expectedBody             = ""
+	)
+
+	accounts, err := suite.getSearch(
+		requestingAccount,
+		token,
+		user,
+		limit,
+		offset,
+		query,
+		resolve,
+		following,
+		expectedHTTPStatus,
+		expectedBody,
+	)
+
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if l := len(accounts); l != 1 {
+		suite.FailNow("", "expected length %d got %d", 1, l)
+	}
+
+	suite.Equal("1happyturtle", accounts[0].Username)
 }
 
 func TestAccountSearchTestSuite(t *testing.T) {
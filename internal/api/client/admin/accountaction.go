@@ -53,14 +53,34 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken. One of `disable`, `reenable`, `silence`,
+//			`unsilence`, `sensitive`, `unsensitive`, `suspend`, `unsuspend`,
+//			`memorialize`, `unmemorialize`. `disable`, `reenable`, `memorialize`
+//			and `unmemorialize` are only supported for local accounts.
 //		type: string
 //		required: true
 //	-
 //		name: text
 //		in: formData
-//		description: Optional text describing why this action was taken.
+//		description: >-
+//			Optional text describing why this action was taken.
+//			For local accounts, this is included in the email sent to the user.
 //		type: string
+//	-
+//		name: send_email_notification
+//		in: formData
+//		description: Email the user of the target account about this action (local accounts only).
+//		type: boolean
+//		default: true
+//	-
+//		name: duration
+//		in: formData
+//		description: >-
+//			Number of seconds after which the action should be automatically reversed.
+//			If omitted or 0, the action does not expire. Only supported for `disable`,
+//			`silence` and `sensitive`.
+//		type: integer
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -77,6 +77,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
       "username": "1happyturtle",
@@ -85,6 +86,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "<p>i post about things that concern me</p>",
       "url": "http://localhost:8080/@1happyturtle",
@@ -110,6 +112,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
         }
       ],
       "hide_collections": true,
+      "indexable": true,
       "role": {
         "name": "user"
       }
@@ -134,6 +137,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
       "username": "admin",
@@ -142,6 +146,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -180,6 +185,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01AY6P665V14JJR0AFVRT7311Y",
       "username": "localhost:8080",
@@ -188,6 +194,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@localhost:8080",
@@ -221,6 +228,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH1H7YV1Z7D2C8K2730QBF",
       "username": "the_mighty_zork",
@@ -229,6 +237,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "<p>hey yo this is my profile!</p>",
       "url": "http://localhost:8080/@the_mighty_zork",
@@ -267,6 +276,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH0BBE4FHXPH513MBVFHB0",
       "username": "weed_lord420",
@@ -275,6 +285,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "",
       "url": "http://localhost:8080/@weed_lord420",
@@ -312,6 +323,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01FHMQX3GAABWSM0S2VZEC2SWC",
       "username": "Some_User",
@@ -320,6 +332,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": true,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "i'm a real son of a gun",
       "url": "http://example.org/@Some_User",
@@ -353,6 +366,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
       "username": "foss_satan",
@@ -361,6 +375,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -394,6 +409,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "062G5WYKY35KKD12EMSM3F8PJ8",
       "username": "her_fuckin_maj",
@@ -402,6 +418,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": true,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "if i die blame charles don't let that fuck become king",
       "url": "http://thequeenisstillalive.technology/@her_fuckin_maj",
@@ -435,6 +452,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "07GZRBAEMBNKGZ8Z9VSKSXKR98",
       "username": "üser",
@@ -443,6 +461,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "",
       "url": "https://xn--xample-ova.org/users/@%C3%BCser",
@@ -515,6 +534,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01AY6P665V14JJR0AFVRT7311Y",
       "username": "localhost:8080",
@@ -523,6 +543,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@localhost:8080",
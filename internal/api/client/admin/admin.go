@@ -28,42 +28,71 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + apiutil.IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + apiutil.IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                     = "/v1/admin"
+	EmojiPath                    = BasePath + "/custom_emojis"
+	EmojiPathWithID              = EmojiPath + "/:" + apiutil.IDKey
+	EmojiCategoriesPath          = EmojiPath + "/categories"
+	DomainBlocksPath             = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID       = DomainBlocksPath + "/:" + apiutil.IDKey
+	DomainAllowsPath             = BasePath + "/domain_allows"
+	DomainAllowsPathWithID       = DomainAllowsPath + "/:" + apiutil.IDKey
+	DomainKeysExpirePath         = BasePath + "/domain_keys_expire"
+	HeaderAllowsPath             = BasePath + "/header_allows"
+	HeaderAllowsPathWithID       = HeaderAllowsPath + "/:" + apiutil.IDKey
+	HeaderBlocksPath             = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID       = HeaderBlocksPath + "/:" + apiutil.IDKey
+	AccountsV1Path               = BasePath + "/accounts"
+	AccountsV2Path               = "/v2/admin/accounts"
+	AccountsPathWithID           = AccountsV1Path + "/:" + apiutil.IDKey
+	AccountsActionPath           = AccountsPathWithID + "/action"
+	AccountsApprovePath          = AccountsPathWithID + "/approve"
+	AccountsRejectPath           = AccountsPathWithID + "/reject"
+	MediaCleanupPath             = BasePath + "/media_cleanup"
+	MediaRefetchPath             = BasePath + "/media_refetch"
+	ReportsPath                  = BasePath + "/reports"
+	ReportsPathWithID            = ReportsPath + "/:" + apiutil.IDKey
+	ReportsResolvePath           = ReportsPathWithID + "/resolve"
+	EmailPath                    = BasePath + "/email"
+	EmailTestPath                = EmailPath + "/test"
+	EmailFailedPath              = EmailPath + "/failed"
+	EmailFailedPathWithID        = EmailFailedPath + "/:" + apiutil.IDKey
+	EmailFailedRetryPath         = EmailFailedPathWithID + "/retry"
+	TrendsPath                   = BasePath + "/trends"
+	TrendsTagsPath               = TrendsPath + "/tags"
+	TrendsTagApprovePath         = TrendsTagsPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsTagRejectPath          = TrendsTagsPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsStatusesPath           = TrendsPath + "/statuses"
+	TrendsStatusApprovePath      = TrendsStatusesPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsStatusRejectPath       = TrendsStatusesPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsLinksPath              = TrendsPath + "/links"
+	TrendsLinkApprovePath        = TrendsLinksPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsLinkRejectPath         = TrendsLinksPath + "/:" + apiutil.IDKey + "/reject"
+	FederationPoliciesPath       = BasePath + "/federation_policies"
+	FederationPoliciesPathWithID = FederationPoliciesPath + "/:" + apiutil.IDKey
+	SpamPath                     = BasePath + "/spam"
+	SpamQuarantinePath           = SpamPath + "/quarantine"
+	SpamQuarantinePathWithID     = SpamQuarantinePath + "/:" + apiutil.IDKey
+	SpamQuarantineReleasePath    = SpamQuarantinePathWithID + "/release"
+	SpamSignalsPath              = SpamPath + "/signals"
+	SpamSignalsPathWithID        = SpamSignalsPath + "/:" + apiutil.IDKey
+	WebhooksPath                 = BasePath + "/webhooks"
+	WebhooksPathWithID           = WebhooksPath + "/:" + apiutil.IDKey
+	InvitesPath                  = BasePath + "/invites"
+	InvitesPathWithID            = InvitesPath + "/:" + apiutil.IDKey
+	InstanceRulesPath            = BasePath + "/instance/rules"
+	InstanceRulesPathWithID      = InstanceRulesPath + "/:" + apiutil.IDKey
+	DebugPath                    = BasePath + "/debug"
+	DebugAPUrlPath               = DebugPath + "/apurl"
+	DebugClearCachesPath         = DebugPath + "/caches/clear"
 
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
 	DomainQueryKey        = "domain"
+
+	// trendsMaxOffset is the highest
+	// useful offset into trending items.
+	trendsMaxOffset = 50
 )
 
 type Module struct {
@@ -131,6 +160,48 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
+	attachHandler(http.MethodGet, EmailFailedPath, m.EmailFailedGETHandler)
+	attachHandler(http.MethodPost, EmailFailedRetryPath, m.EmailFailedRetryPOSTHandler)
+	attachHandler(http.MethodDelete, EmailFailedPathWithID, m.EmailFailedDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsTagsPath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodPost, TrendsTagApprovePath, m.TrendsTagApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsTagRejectPath, m.TrendsTagRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsStatusesPath, m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodPost, TrendsStatusApprovePath, m.TrendsStatusApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsStatusRejectPath, m.TrendsStatusRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsLinksPath, m.TrendsLinksGETHandler)
+	attachHandler(http.MethodPost, TrendsLinkApprovePath, m.TrendsLinkApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsLinkRejectPath, m.TrendsLinkRejectPOSTHandler)
+
+	// federation policy stuff
+	attachHandler(http.MethodGet, FederationPoliciesPath, m.FederationPoliciesGETHandler)
+	attachHandler(http.MethodGet, FederationPoliciesPathWithID, m.FederationPolicyGETHandler)
+	attachHandler(http.MethodPost, FederationPoliciesPath, m.FederationPolicyPOSTHandler)
+	attachHandler(http.MethodPatch, FederationPoliciesPathWithID, m.FederationPolicyPATCHHandler)
+	attachHandler(http.MethodDelete, FederationPoliciesPathWithID, m.FederationPolicyDELETEHandler)
+
+	// spam filter stuff
+	attachHandler(http.MethodGet, SpamQuarantinePath, m.QuarantinedStatusesGETHandler)
+	attachHandler(http.MethodGet, SpamQuarantinePathWithID, m.QuarantinedStatusGETHandler)
+	attachHandler(http.MethodPost, SpamQuarantineReleasePath, m.QuarantinedStatusReleasePOSTHandler)
+	attachHandler(http.MethodDelete, SpamQuarantinePathWithID, m.QuarantinedStatusDELETEHandler)
+	attachHandler(http.MethodGet, SpamSignalsPath, m.SpamSignalsGETHandler)
+	attachHandler(http.MethodPost, SpamSignalsPath, m.SpamSignalPOSTHandler)
+	attachHandler(http.MethodPatch, SpamSignalsPathWithID, m.SpamSignalPATCHHandler)
+	attachHandler(http.MethodDelete, SpamSignalsPathWithID, m.SpamSignalDELETEHandler)
+
+	// webhook stuff
+	attachHandler(http.MethodGet, WebhooksPath, m.WebhooksGETHandler)
+	attachHandler(http.MethodGet, WebhooksPathWithID, m.WebhookGETHandler)
+	attachHandler(http.MethodPost, WebhooksPath, m.WebhookPOSTHandler)
+	attachHandler(http.MethodPatch, WebhooksPathWithID, m.WebhookPATCHHandler)
+	attachHandler(http.MethodDelete, WebhooksPathWithID, m.WebhookDELETEHandler)
+
+	// invite stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodDelete, InvitesPathWithID, m.InviteDELETEHandler)
 
 	// instance rules stuff
 	attachHandler(http.MethodGet, InstanceRulesPath, m.RulesGETHandler)
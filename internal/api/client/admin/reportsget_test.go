@@ -164,6 +164,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
         "username": "foss_satan",
@@ -172,6 +173,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -205,6 +207,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
         "username": "1happyturtle",
@@ -213,6 +216,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -238,6 +242,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           }
         ],
         "hide_collections": true,
+        "indexable": true,
         "role": {
           "name": "user"
         }
@@ -262,6 +267,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH17FWEB39HZJ76B6VXSKF",
         "username": "admin",
@@ -270,6 +276,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2022-05-17T13:10:59.000Z",
         "note": "",
         "url": "http://localhost:8080/@admin",
@@ -308,6 +315,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH17FWEB39HZJ76B6VXSKF",
         "username": "admin",
@@ -316,6 +324,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2022-05-17T13:10:59.000Z",
         "note": "",
         "url": "http://localhost:8080/@admin",
@@ -367,6 +376,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
         "username": "1happyturtle",
@@ -375,6 +385,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -400,6 +411,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           }
         ],
         "hide_collections": true,
+        "indexable": true,
         "role": {
           "name": "user"
         }
@@ -424,6 +436,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
         "username": "foss_satan",
@@ -432,6 +445,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -479,6 +493,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "locked": false,
           "discoverable": true,
           "bot": false,
+          "group": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
           "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -589,6 +604,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
         "username": "1happyturtle",
@@ -597,6 +613,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -622,6 +639,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
           }
         ],
         "hide_collections": true,
+        "indexable": true,
         "role": {
           "name": "user"
         }
@@ -646,6 +664,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
         "username": "foss_satan",
@@ -654,6 +673,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -701,6 +721,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
           "locked": false,
           "discoverable": true,
           "bot": false,
+          "group": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
           "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -811,6 +832,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
         "username": "1happyturtle",
@@ -819,6 +841,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -844,6 +867,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
           }
         ],
         "hide_collections": true,
+        "indexable": true,
         "role": {
           "name": "user"
         }
@@ -868,6 +892,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "disabled": false,
       "silenced": false,
       "suspended": false,
+      "sensitized": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
         "username": "foss_satan",
@@ -876,6 +901,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -923,6 +949,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
           "locked": false,
           "discoverable": true,
           "bot": false,
+          "group": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
           "url": "http://fossbros-anonymous.io/@foss_satan",
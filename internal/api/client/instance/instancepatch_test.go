@@ -147,6 +147,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -268,6 +269,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -389,6 +391,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -561,6 +564,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -706,6 +710,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -862,6 +867,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oembed
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base path for serving oEmbed, minus the 'api' prefix.
+	// Unlike most client API paths, this one isn't versioned, as per Mastodon.
+	BasePath = "/oembed"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePath, m.OEmbedGETHandler)
+}
+
+// OEmbedGETHandler swagger:operation GET /api/oembed oEmbedGet
+//
+// Get an oEmbed response for embedding a status on another website.
+//
+// Only Public and Unlisted statuses from this instance can be embedded,
+// and only if their author hasn't opted out of having their posts embedded.
+//
+// See https://oembed.com/.
+//
+//	---
+//	tags:
+//	- oembed
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: url
+//		type: string
+//		description: Web URL or ActivityPub URI of the status to embed.
+//		in: query
+//		required: true
+//	-
+//		name: maxwidth
+//		type: integer
+//		description: Maximum width of the embed in pixels.
+//		in: query
+//	-
+//		name: maxheight
+//		type: integer
+//		description: Maximum height of the embed in pixels.
+//		in: query
+//	-
+//		name: format
+//		type: string
+//		description: Format of the response. Only `json` is supported.
+//		default: json
+//		in: query
+//
+//	responses:
+//		'200':
+//			description: oEmbed response for the status.
+//			schema:
+//				"$ref": "#/definitions/oEmbed"
+//		'400':
+//			description: bad request
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+//		'501':
+//			description: requested format not implemented
+func (m *Module) OEmbedGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if format := c.Query(apiutil.OEmbedFormatKey); format != "" && format != "json" {
+		const text = "only json format is supported"
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotImplemented(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	statusURL, errWithCode := apiutil.ParseOEmbedURL(c.Query(apiutil.OEmbedURLKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	maxWidth, errWithCode := apiutil.ParseOEmbedMaxWidth(c.Query(apiutil.OEmbedMaxWidthKey), 0, math.MaxInt32, 0)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	maxHeight, errWithCode := apiutil.ParseOEmbedMaxHeight(c.Query(apiutil.OEmbedMaxHeightKey), 0, math.MaxInt32, 0)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	oEmbed, errWithCode := m.processor.Status().OEmbedGet(c.Request.Context(), statusURL, maxWidth, maxHeight)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, oEmbed)
+}
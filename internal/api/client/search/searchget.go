@@ -82,8 +82,8 @@ import (
 //		type: integer
 //		description: >-
 //			Page number of results to return (starts at 0).
-//			This parameter is currently not used, page by selecting
-//			a specific query type and using maxID and minID instead.
+//			Arbitrary string queries return results ordered by relevance,
+//			so use this rather than max_id and min_id to page through them.
 //		default: 0
 //		maximum: 10
 //		minimum: 0
@@ -101,7 +101,11 @@ import (
 //			- any arbitrary string -- search for accounts or statuses containing the given string. Can return multiple results.
 //
 //			Arbitrary string queries may include the following operators:
+//			- `"some phrase"`: match the words in quotes only when they appear together, in that order.
+//			- `-word`, `-"some phrase"`: exclude results containing the given word or phrase.
 //			- `from:localuser`, `from:remoteuser@instance.tld`: restrict results to statuses created by the specified account.
+//			- `has:media`: restrict results to statuses with media attachments.
+//			- `before:YYYY-MM-DD`, `after:YYYY-MM-DD`, `during:YYYY-MM-DD`: restrict results to statuses created before, after, or on the given date (UTC).
 //		in: query
 //		required: true
 //	-
@@ -111,6 +111,14 @@ import (
 //		type: string
 //		in: formData
 //	-
+//		name: quoted_status_id
+//		x-go-name: QuotedStatusID
+//		description: |-
+//			ID of the status being quoted, if status is a quote.
+//			The quoted status must be public or unlisted.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
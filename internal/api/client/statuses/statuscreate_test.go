@@ -503,6 +503,62 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
 	})
 }
 
+func (suite *StatusCreateTestSuite) testPostNewStatusWithQuote(configure func(request *http.Request)) {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	quoted := suite.testStatuses["local_account_2_status_1"]
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	configure(ctx.Request)
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &apimodel.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+
+	suite.Equal("<p>this is a status with a quote!</p>", statusReply.Content)
+	if suite.NotNil(statusReply.Quote) {
+		suite.Equal(quoted.ID, statusReply.Quote.ID)
+		suite.Equal(quoted.URL, statusReply.Quote.URL)
+	}
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusWithQuoteForm() {
+	suite.testPostNewStatusWithQuote(func(request *http.Request) {
+		request.Form = url.Values{
+			"status":           {"this is a status with a quote!"},
+			"visibility":       {"public"},
+			"quoted_status_id": {suite.testStatuses["local_account_2_status_1"].ID},
+		}
+	})
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusWithQuoteJSON() {
+	suite.testPostNewStatusWithQuote(func(request *http.Request) {
+		request.Header.Set("content-type", "application/json")
+		request.Body = io.NopCloser(strings.NewReader(`{
+			"status": "this is a status with a quote!",
+			"visibility": "public",
+			"quoted_status_id": "` + suite.testStatuses["local_account_2_status_1"].ID + `"
+		}`))
+	})
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -121,6 +121,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
     "url": "http://localhost:8080/@the_mighty_zork",
@@ -184,6 +185,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
     "url": "http://localhost:8080/@the_mighty_zork",
@@ -0,0 +1,590 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package streaming
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oklog/ulid"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+const (
+	// stream types only
+	// used in this package.
+	streamTypeHashtag      = "hashtag"
+	streamTypeHashtagLocal = "hashtag:local"
+
+	// sseWriteTimeout is the deadline
+	// set for each server-sent event write.
+	sseWriteTimeout = 30 * time.Second
+)
+
+// StreamUserSSEGETHandler swagger:operation GET /api/v1/streaming/user streamUserSSEGet
+//
+// Stream updates to the account's home timeline and notifications as server-sent events.
+//
+// Each message is sent as an event with `id`, `event` and `data` fields, where `event`
+// is the event type (`update`, `notification`, `delete`, `status.update`, `filters_changed`),
+// and `data` is the payload, as described for the websocket streaming endpoint.
+//
+// GoToSocial will send a comment line into the stream every 30 seconds to keep the connection alive.
+//
+// A client reconnecting with the `Last-Event-ID` header set will first be sent any recent
+// events it missed since that ID, if they are still available.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamUserSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streampkg.TimelineHome)
+}
+
+// StreamUserNotificationSSEGETHandler swagger:operation GET /api/v1/streaming/user/notification streamUserNotificationSSEGet
+//
+// Stream notifications for the account as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamUserNotificationSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streampkg.TimelineNotifications)
+}
+
+// StreamPublicSSEGETHandler swagger:operation GET /api/v1/streaming/public streamPublicSSEGet
+//
+// Stream updates to the public timeline as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamPublicSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streampkg.TimelinePublic)
+}
+
+// StreamPublicLocalSSEGETHandler swagger:operation GET /api/v1/streaming/public/local streamPublicLocalSSEGet
+//
+// Stream updates to the local timeline as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamPublicLocalSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streampkg.TimelineLocal)
+}
+
+// StreamHashtagSSEGETHandler swagger:operation GET /api/v1/streaming/hashtag streamHashtagSSEGet
+//
+// Stream updates for a given hashtag as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: tag
+//		type: string
+//		description: Name of the tag to subscribe to.
+//		in: query
+//		required: true
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamHashtagSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streamTypeHashtag)
+}
+
+// StreamHashtagLocalSSEGETHandler swagger:operation GET /api/v1/streaming/hashtag/local streamHashtagLocalSSEGet
+//
+// Stream local updates for a given hashtag as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: tag
+//		type: string
+//		description: Name of the tag to subscribe to.
+//		in: query
+//		required: true
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamHashtagLocalSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streamTypeHashtagLocal)
+}
+
+// StreamListSSEGETHandler swagger:operation GET /api/v1/streaming/list streamListSSEGet
+//
+// Stream updates to a given list as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: list
+//		type: string
+//		description: ID of the list to subscribe to.
+//		in: query
+//		required: true
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamListSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streampkg.TimelineList)
+}
+
+// StreamDirectSSEGETHandler swagger:operation GET /api/v1/streaming/direct streamDirectSSEGet
+//
+// Stream updates to direct messages as server-sent events.
+//
+// See `/api/v1/streaming/user` for the event format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received, to replay events missed since.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) StreamDirectSSEGETHandler(c *gin.Context) {
+	m.streamSSE(c, streampkg.TimelineDirect)
+}
+
+// streamSSE authorizes the request, opens a stream of the given type,
+// and writes messages from it into the response as server-sent events,
+// until the client goes away. Any recent messages since the client's
+// Last-Event-ID header are replayed before any new messages.
+func (m *Module) streamSSE(c *gin.Context, streamType string) {
+	account, ok := m.authorize(c)
+	if !ok {
+		return
+	}
+
+	// Lists and hashtags must be specified.
+	switch streamType {
+	case streampkg.TimelineList:
+		if c.Query(StreamListKey) == "" {
+			const text = "list must be set"
+			errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+	case streamTypeHashtag, streamTypeHashtagLocal:
+		if c.Query(StreamTagKey) == "" {
+			const text = "tag must be set"
+			errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+	}
+
+	// Append list ID / tag name to stream type.
+	streamType = m.streamType(c, streamType)
+
+	ctx := c.Request.Context()
+
+	// Open a stream with the processor; this lets processor
+	// functions pass messages into a channel, which we can
+	// then read from and write into the response.
+	stream, errWithCode := m.processor.Stream().OpenReplay(ctx, account, streamType)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+	defer stream.Close()
+
+	// Fetch any recent messages the client missed. This
+	// is done *after* opening the stream so nothing gets
+	// lost in between, duplicates are dropped below.
+	lastID := parseLastEventID(c.GetHeader(LastEventIDHeader))
+	var history []streampkg.Message
+	if lastID != "" {
+		history = m.processor.Stream().History(ctx, account, streamType, lastID)
+	}
+
+	l := log.
+		WithContext(ctx).
+		WithField("streamID", id.NewULID()).
+		WithField("username", account.Username)
+
+	// This request will be held open for as long as the
+	// client is connected, so return its throttle token.
+	middleware.ReleaseThrottle(c)
+
+	// Write the event stream response headers.
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := &sseWriter{
+		rc: http.NewResponseController(c.Writer),
+		w:  c.Writer,
+	}
+
+	l.Info("opened server-sent events stream")
+	defer l.Info("closed server-sent events stream")
+
+	// Send an initial comment so
+	// the client knows we're live.
+	if err := w.comment(")"); err != nil {
+		l.Debugf("error writing server-sent event: %v", err)
+		return
+	}
+
+	for _, msg := range history {
+		if err := w.event(msg); err != nil {
+			l.Debugf("error writing server-sent event: %v", err)
+			return
+		}
+		lastID = msg.ID
+	}
+
+	for {
+		// Wrap context with timeout to send a heartbeat.
+		pingctx, cncl := context.WithTimeout(ctx, m.dTicker)
+
+		// Block on receipt of msg.
+		msg, ok := stream.Recv(pingctx)
+
+		// Check if cancel because ping.
+		pinged := (pingctx.Err() != nil)
+		cncl()
+
+		switch {
+		case ctx.Err() != nil:
+			// Client went away.
+			return
+
+		case !ok && pinged:
+			// The ping context timed out,
+			// send a keep-alive comment.
+			l.Trace("writing server-sent event heartbeat")
+			if err := w.comment("thump"); err != nil {
+				l.Debugf("error writing server-sent event heartbeat: %v", err)
+				return
+			}
+			continue
+
+		case !ok:
+			// Stream was
+			// closed.
+			return
+
+		case msg.ID <= lastID:
+			// Already sent
+			// from history.
+			continue
+		}
+
+		l.Tracef("writing server-sent event: %+v", msg)
+
+		if err := w.event(msg); err != nil {
+			l.Debugf("error writing server-sent event: %v", err)
+			return
+		}
+	}
+}
+
+// parseLastEventID returns the given Last-Event-ID header value
+// in canonical form, or an empty string if it's not a valid ULID
+// or is timestamped in the future. A future ID would otherwise
+// cause all messages up to that time to be skipped as sent.
+func parseLastEventID(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	lastID, err := ulid.ParseStrict(header)
+	if err != nil {
+		return ""
+	}
+
+	if ulid.Time(lastID.Time()).After(time.Now()) {
+		return ""
+	}
+
+	return lastID.String()
+}
+
+// sseWriter writes server-sent events into an http response.
+type sseWriter struct {
+	rc *http.ResponseController
+	w  gin.ResponseWriter
+}
+
+// event writes the given message as an event.
+func (w *sseWriter) event(msg streampkg.Message) error {
+	var b strings.Builder
+	b.WriteString("id: " + msg.ID + "\n")
+	b.WriteString("event: " + msg.Event + "\n")
+
+	// Payload may (in theory) contain
+	// newlines, each line needs a prefix.
+	for _, line := range strings.Split(msg.Payload, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+
+	b.WriteString("\n")
+	return w.write(b.String())
+}
+
+// comment writes the given text as a comment,
+// which clients ignore, used for keep-alives.
+func (w *sseWriter) comment(text string) error {
+	return w.write(":" + text + "\n\n")
+}
+
+// write writes the given string and flushes it to the client,
+// after extending the write deadline of the server connection.
+func (w *sseWriter) write(s string) error {
+	// Not all writers support deadlines
+	// (e.g. when testing), so ignore error.
+	_ = w.rc.SetWriteDeadline(time.Now().Add(sseWriteTimeout))
+
+	if _, err := w.w.WriteString(s); err != nil {
+		return err
+	}
+
+	return w.rc.Flush()
+}
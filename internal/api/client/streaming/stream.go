@@ -150,6 +150,57 @@ import (
 //		'400':
 //			description: bad request
 func (m *Module) StreamGETHandler(c *gin.Context) {
+	account, ok := m.authorize(c)
+	if !ok {
+		return
+	}
+
+	// Get the initial requested stream type, if there is one.
+	streamType := m.streamType(c, c.Query(StreamQueryKey))
+
+	// Open a stream with the processor; this lets processor
+	// functions pass messages into a channel, which we can
+	// then read from and put into a websockets connection.
+	stream, errWithCode := m.processor.Stream().Open(
+		c.Request.Context(), // this ctx is only used for logging
+		account,
+		streamType,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	l := log.
+		WithContext(c.Request.Context()).
+		WithField("streamID", id.NewULID()).
+		WithField("username", account.Username)
+
+	// Upgrade the incoming HTTP request. This hijacks the
+	// underlying connection and reuses it for the websocket
+	// (non-http) protocol.
+	//
+	// If the upgrade fails, then Upgrade replies to the client
+	// with an HTTP error response.
+	wsConn, err := m.wsUpgrade.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		l.Errorf("error upgrading websocket connection: %v", err)
+		stream.Close()
+		return
+	}
+
+	// We perform the main websocket rw loops in a separate
+	// goroutine in order to let the upgrade handler return.
+	// This prevents the upgrade handler from holding open any
+	// throttle / rate-limit request tokens which could become
+	// problematic on instances with multiple users.
+	go m.handleWSConn(&l, wsConn, stream)
+}
+
+// authorize authorizes the account for a streaming request, using
+// either an access token from query or header, or regular oauth.
+// If false is returned, an error has already been written to c.
+func (m *Module) authorize(c *gin.Context) (*gtsmodel.Account, bool) {
 	var (
 		account     *gtsmodel.Account
 		errWithCode gtserror.WithCode
@@ -168,7 +219,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		account, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
 		if errWithCode != nil {
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
+			return nil, false
 		}
 
 	} else {
@@ -179,7 +230,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		if err != nil {
 			errWithCode := gtserror.NewErrorUnauthorized(err, err.Error())
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
+			return nil, false
 		}
 
 		// Set the auth'ed account.
@@ -190,12 +241,15 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		// Moving accounts can't
 		// use streaming endpoints.
 		apiutil.NotFoundAfterMove(c)
-		return
+		return nil, false
 	}
 
-	// Get the initial requested stream type, if there is one.
-	streamType := c.Query(StreamQueryKey)
+	return account, true
+}
 
+// streamType returns the given stream type with
+// any list ID or tag name from query appended.
+func (m *Module) streamType(c *gin.Context, streamType string) string {
 	// By appending other query params to the streamType, we
 	// can allow streaming for specific list IDs or hashtags.
 	// The streamType in this case will end up looking like
@@ -205,44 +259,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	} else if tag := c.Query(StreamTagKey); tag != "" {
 		streamType += ":" + tag
 	}
-
-	// Open a stream with the processor; this lets processor
-	// functions pass messages into a channel, which we can
-	// then read from and put into a websockets connection.
-	stream, errWithCode := m.processor.Stream().Open(
-		c.Request.Context(), // this ctx is only used for logging
-		account,
-		streamType,
-	)
-	if errWithCode != nil {
-		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-		return
-	}
-
-	l := log.
-		WithContext(c.Request.Context()).
-		WithField("streamID", id.NewULID()).
-		WithField("username", account.Username)
-
-	// Upgrade the incoming HTTP request. This hijacks the
-	// underlying connection and reuses it for the websocket
-	// (non-http) protocol.
-	//
-	// If the upgrade fails, then Upgrade replies to the client
-	// with an HTTP error response.
-	wsConn, err := m.wsUpgrade.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		l.Errorf("error upgrading websocket connection: %v", err)
-		stream.Close()
-		return
-	}
-
-	// We perform the main websocket rw loops in a separate
-	// goroutine in order to let the upgrade handler return.
-	// This prevents the upgrade handler from holding open any
-	// throttle / rate-limit request tokens which could become
-	// problematic on instances with multiple users.
-	go m.handleWSConn(&l, wsConn, stream)
+	return streamType
 }
 
 // handleWSConn handles a two-way websocket streaming connection.
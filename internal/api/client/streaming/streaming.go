@@ -27,12 +27,21 @@ import (
 )
 
 const (
-	BasePath            = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
-	StreamQueryKey      = "stream"                 // type of stream being requested
-	StreamListKey       = "list"                   // id of list being requested
-	StreamTagKey        = "tag"                    // name of tag being requested
-	AccessTokenQueryKey = "access_token"           // oauth access token
-	AccessTokenHeader   = "Sec-Websocket-Protocol" //nolint:gosec
+	BasePath             = "/v1/streaming"                 // path for the streaming api, minus the 'api' prefix
+	UserPath             = BasePath + "/user"              // path for server-sent events user stream
+	UserNotificationPath = BasePath + "/user/notification" // path for server-sent events notification stream
+	PublicPath           = BasePath + "/public"            // path for server-sent events public stream
+	PublicLocalPath      = BasePath + "/public/local"      // path for server-sent events local stream
+	HashtagPath          = BasePath + "/hashtag"           // path for server-sent events hashtag stream
+	HashtagLocalPath     = BasePath + "/hashtag/local"     // path for server-sent events local hashtag stream
+	ListPath             = BasePath + "/list"              // path for server-sent events list stream
+	DirectPath           = BasePath + "/direct"            // path for server-sent events direct stream
+	StreamQueryKey       = "stream"                        // type of stream being requested
+	StreamListKey        = "list"                          // id of list being requested
+	StreamTagKey         = "tag"                           // name of tag being requested
+	AccessTokenQueryKey  = "access_token"                  // oauth access token
+	AccessTokenHeader    = "Sec-Websocket-Protocol"        //nolint:gosec
+	LastEventIDHeader    = "Last-Event-ID"                 // id of last server-sent event received by client
 )
 
 type Module struct {
@@ -60,4 +69,12 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.StreamGETHandler)
+	attachHandler(http.MethodGet, UserPath, m.StreamUserSSEGETHandler)
+	attachHandler(http.MethodGet, UserNotificationPath, m.StreamUserNotificationSSEGETHandler)
+	attachHandler(http.MethodGet, PublicPath, m.StreamPublicSSEGETHandler)
+	attachHandler(http.MethodGet, PublicLocalPath, m.StreamPublicLocalSSEGETHandler)
+	attachHandler(http.MethodGet, HashtagPath, m.StreamHashtagSSEGETHandler)
+	attachHandler(http.MethodGet, HashtagLocalPath, m.StreamHashtagLocalSSEGETHandler)
+	attachHandler(http.MethodGet, ListPath, m.StreamListSSEGETHandler)
+	attachHandler(http.MethodGet, DirectPath, m.StreamDirectSSEGETHandler)
 }
@@ -19,6 +19,7 @@ package streaming_test
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -245,6 +247,97 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	}
 }
 
+func (suite *StreamingTestSuite) TestSSEUserReplay() {
+	var (
+		account = suite.testAccounts["local_account_1"]
+		token   = oauth.DBTokenToToken(suite.testTokens["local_account_1"])
+	)
+
+	// Simulate the client having been connected
+	// before, then post a message before it reconnects.
+	prev, errWithCode := suite.processor.Stream().OpenReplay(context.Background(), account, "user")
+	suite.NoError(errWithCode)
+	prev.Close()
+	suite.processor.Stream().FiltersChanged(context.Background(), account)
+
+	// Use a long heartbeat interval so only the replay is written.
+	module := streaming.New(suite.processor, time.Minute, 4096)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+
+	// Request will be canceled shortly after connecting.
+	reqCtx, cncl := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cncl()
+
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api"+streaming.UserPath+"?access_token="+token.Access, nil).WithContext(reqCtx)
+	ctx.Request.Header.Set("accept", "text/event-stream")
+	ctx.Request.Header.Set(streaming.LastEventIDHeader, "00000000000000000000000000")
+
+	module.StreamUserSSEGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/event-stream", recorder.Header().Get("Content-Type"))
+
+	body := recorder.Body.String()
+	suite.True(strings.HasPrefix(body, ":)\n\n"), body)
+	suite.Regexp(`id: [0-9A-Z]{26}\nevent: filters_changed\ndata: \n\n$`, body)
+}
+
+func (suite *StreamingTestSuite) TestSSEUserReplayFutureID() {
+	var (
+		account = suite.testAccounts["local_account_1"]
+		token   = oauth.DBTokenToToken(suite.testTokens["local_account_1"])
+	)
+
+	prev, errWithCode := suite.processor.Stream().OpenReplay(context.Background(), account, "user")
+	suite.NoError(errWithCode)
+	prev.Close()
+	suite.processor.Stream().FiltersChanged(context.Background(), account)
+
+	module := streaming.New(suite.processor, time.Minute, 4096)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+
+	reqCtx, cncl := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cncl()
+
+	// A Last-Event-ID far in the future should be ignored
+	// rather than suppress everything up until that time.
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api"+streaming.UserPath+"?access_token="+token.Access, nil).WithContext(reqCtx)
+	ctx.Request.Header.Set("accept", "text/event-stream")
+	ctx.Request.Header.Set(streaming.LastEventIDHeader, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+
+	// Post a new message once the client is connected.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		suite.processor.Stream().FiltersChanged(context.Background(), account)
+	}()
+
+	module.StreamUserSSEGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// No history is replayed, but the new message is sent.
+	body := recorder.Body.String()
+	suite.Regexp(`^:\)\n\nid: [0-9A-Z]{26}\nevent: filters_changed\ndata: \n\n$`, body)
+}
+
+func (suite *StreamingTestSuite) TestSSEListMissing() {
+	token := oauth.DBTokenToToken(suite.testTokens["local_account_1"])
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api"+streaming.ListPath+"?access_token="+token.Access, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.streamingModule.StreamListSSEGETHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"Bad Request: list must be set"}`, recorder.Body.String())
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
@@ -86,6 +86,16 @@ import (
 //		default: false
 //		in: query
 //		required: false
+//	-
+//		name: languages[]
+//		type: array
+//		items:
+//			type: string
+//		description: >-
+//			Show only statuses in any of the given languages, as BCP47 language tags.
+//			Region and script subtags are ignored, so `en-GB` also matches `en` and `en-US`.
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -148,6 +158,12 @@ func (m *Module) PublicTimelineGETHandler(c *gin.Context) {
 		return
 	}
 
+	languages, errWithCode := apiutil.ParseLanguages(c.QueryArray(apiutil.LanguagesKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	resp, errWithCode := m.processor.Timeline().PublicTimelineGet(
 		c.Request.Context(),
 		authed.Account,
@@ -156,6 +172,7 @@ func (m *Module) PublicTimelineGETHandler(c *gin.Context) {
 		c.Query(apiutil.MinIDKey),
 		limit,
 		local,
+		languages,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
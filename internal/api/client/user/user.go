@@ -31,6 +31,10 @@ const (
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// EmailNotificationsPath is the path for getting and updating email notification preferences.
+	EmailNotificationsPath = BasePath + "/email_notifications"
+	// FeedTokenPath is the path for getting, rotating and revoking the private feed token.
+	FeedTokenPath = BasePath + "/feed_token"
 )
 
 type Module struct {
@@ -47,4 +51,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
 	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, EmailNotificationsPath, m.EmailNotificationsGETHandler)
+	attachHandler(http.MethodPatch, EmailNotificationsPath, m.EmailNotificationsPATCHHandler)
+	attachHandler(http.MethodGet, FeedTokenPath, m.FeedTokenGETHandler)
+	attachHandler(http.MethodPost, FeedTokenPath, m.FeedTokenPOSTHandler)
+	attachHandler(http.MethodDelete, FeedTokenPath, m.FeedTokenDELETEHandler)
 }
@@ -47,6 +47,9 @@ type Account struct {
 	Discoverable bool `json:"discoverable"`
 	// Account identifies as a bot.
 	Bot bool `json:"bot"`
+	// Account is a group actor, which
+	// announces posts addressed to it.
+	Group bool `json:"group"`
 	// When the account was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
@@ -86,6 +89,9 @@ type Account struct {
 	Fields []Field `json:"fields"`
 	// Account has been suspended by our instance.
 	Suspended bool `json:"suspended,omitempty"`
+	// Account has been memorialized, ie., the person who used it has passed away.
+	// Key/value omitted if false.
+	Memorial bool `json:"memorial,omitempty"`
 	// Extra profile information. Shown only if the requester owns the account being requested.
 	Source *Source `json:"source,omitempty"`
 	// Filename of user-selected CSS theme to include when rendering this account's profile or statuses. Eg., `blurple-light.css`.
@@ -98,6 +104,12 @@ type Account struct {
 	// Account has opted to hide their followers/following collections.
 	// Key/value omitted if false.
 	HideCollections bool `json:"hide_collections,omitempty"`
+	// Account has opted in to having its public posts found by anyone via search.
+	// Key/value omitted if false.
+	Indexable bool `json:"indexable,omitempty"`
+	// Account has opted out of having its posts embedded on other websites.
+	// Key/value omitted if false.
+	DisableEmbeds bool `json:"disable_embeds,omitempty"`
 	// Role of the account on this instance.
 	// Key/value omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
@@ -148,6 +160,11 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Code of an invite to sign up with, if any. Required if
+	// registration is not open, but invites are enabled.
+	// swagger:parameters
+	// example: 3b4e7dd4-5c1a-4d1c-9a8f-7e0b3a2ff8b1
+	Invite string `form:"invite" json:"invite" xml:"invite"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -188,6 +205,10 @@ type UpdateCredentialsRequest struct {
 	EnableRSS *bool `form:"enable_rss" json:"enable_rss"`
 	// Hide this account's following/followers collections.
 	HideCollections *bool `form:"hide_collections" json:"hide_collections"`
+	// Allow this account's public posts to be found by anyone via search.
+	Indexable *bool `form:"indexable" json:"indexable"`
+	// Prevent this account's posts from being embedded on other websites.
+	DisableEmbeds *bool `form:"disable_embeds" json:"disable_embeds"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
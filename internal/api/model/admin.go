@@ -66,6 +66,8 @@ type AdminAccountInfo struct {
 	Silenced bool `json:"silenced"`
 	// Whether the account is currently suspended.
 	Suspended bool `json:"suspended"`
+	// Whether the account's media is currently force-marked as sensitive.
+	Sensitized bool `json:"sensitized"`
 	// User-level information about the account.
 	Account *Account `json:"account"`
 	// The ID of the application that created this account.
@@ -170,10 +172,20 @@ type AdminEmoji struct {
 type AdminActionRequest struct {
 	// Category of the target entity.
 	Category string `form:"-" json:"-" xml:"-"`
-	// Type of admin action to take. One of disable, silence, suspend.
+	// Type of admin action to take. One of disable, reenable,
+	// silence, unsilence, sensitive, unsensitive, suspend, unsuspend,
+	// memorialize, unmemorialize.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Email the target account's user to inform them of the action
+	// (local accounts only). If omitted, defaults to true.
+	SendEmail *bool `form:"send_email_notification" json:"send_email_notification" xml:"send_email_notification"`
+	// Number of seconds from now after which the action
+	// should be automatically reversed. If omitted or 0,
+	// the action does not expire. Only supported for
+	// disable, silence and sensitive.
+	Duration *int `form:"duration" json:"duration" xml:"duration"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
@@ -206,6 +218,34 @@ type AdminSendTestEmailRequest struct {
 	Message string `form:"message" json:"message"`
 }
 
+// AdminQueuedEmail models the admin view of an
+// outgoing email whose delivery has failed.
+//
+// swagger:model adminQueuedEmail
+type AdminQueuedEmail struct {
+	// ID of the email.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Time when the email was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Addresses the email was being sent to.
+	// example: ["someone@example.org"]
+	To []string `json:"to"`
+	// Subject of the email.
+	// example: GoToSocial Email Confirmation
+	Subject string `json:"subject"`
+	// Number of delivery attempts made.
+	// example: 10
+	Attempts int `json:"attempts"`
+	// Error returned by the last delivery attempt.
+	// example: 421 Service not available
+	LastError string `json:"last_error"`
+	// Time when delivery of the email was given up on (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FailedAt string `json:"failed_at"`
+}
+
 type AdminInstanceRule struct {
 	ID        string `json:"id"`         // id of this item in the database
 	CreatedAt string `json:"created_at"` // when was item created
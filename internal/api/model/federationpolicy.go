@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// FederationPolicy represents one step in the pipeline
+// that incoming federated activities are run through.
+//
+// swagger:model federationPolicy
+type FederationPolicy struct {
+	// The ID of the federation policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// What the policy does. One of:
+	// `keyword_reject`, `keyword_cw`, `strip_media`, `force_unlisted`,
+	// `max_mentions`, `reject_announces`, `reject_new_accounts`.
+	// example: keyword_cw
+	Type string `json:"type"`
+
+	// Order in which this policy is run, lowest first.
+	// example: 0
+	Priority int `json:"priority"`
+
+	// Whether this policy is currently run.
+	// example: true
+	Enabled bool `json:"enabled"`
+
+	// Domains (and their subdomains) this policy applies to.
+	// If empty, the policy applies to activities from all domains.
+	// example: ["example.org"]
+	Domains []string `json:"domains"`
+
+	// Case-insensitive keywords to match against status text (keyword_reject, keyword_cw).
+	// example: ["spoilers"]
+	Keywords []string `json:"keywords"`
+
+	// Content warning to put on matching statuses (keyword_cw).
+	// example: Spoilers
+	ContentWarning string `json:"content_warning,omitempty"`
+
+	// Maximum number of mentions a status may have (max_mentions).
+	// example: 10
+	MaxMentions int `json:"max_mentions,omitempty"`
+
+	// Minimum age, in seconds, that an account must be to not be rejected (reject_new_accounts).
+	// example: 86400
+	MinAccountAge int64 `json:"min_account_age,omitempty"`
+
+	// Comment on why this policy exists.
+	// example: lots of spam coming from here lately
+	Comment string `json:"comment"`
+
+	// The ID of the admin account that created this policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the policy was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// FederationPolicyRequest is the form submitted to create
+// a new federation policy, or update an existing one.
+// Fields left unset are left unchanged on update.
+//
+// swagger:ignore
+type FederationPolicyRequest struct {
+	// What the policy does. Cannot be changed on update.
+	Type *string `form:"type" json:"type"`
+
+	// Order in which this policy is run, lowest first.
+	Priority *int `form:"priority" json:"priority"`
+
+	// Whether this policy is run.
+	Enabled *bool `form:"enabled" json:"enabled"`
+
+	// Domains (and their subdomains) this policy applies to.
+	Domains *[]string `form:"domains[]" json:"domains"`
+
+	// Keywords to match against status text.
+	Keywords *[]string `form:"keywords[]" json:"keywords"`
+
+	// Content warning to put on matching statuses.
+	ContentWarning *string `form:"content_warning" json:"content_warning"`
+
+	// Maximum number of mentions a status may have.
+	MaxMentions *int `form:"max_mentions" json:"max_mentions"`
+
+	// Minimum age, in seconds, that an account must be.
+	MinAccountAge *int64 `form:"min_account_age" json:"min_account_age"`
+
+	// Comment on why this policy exists.
+	Comment *string `form:"comment" json:"comment"`
+}
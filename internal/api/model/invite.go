@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite represents an invite code, which
+// lets someone sign up to this instance
+// even when registration is not open.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The invite code.
+	// example: 3b4e7dd4-5c1a-4d1c-9a8f-7e0b3a2ff8b1
+	// readonly: true
+	Code string `json:"code"`
+
+	// Link to the sign-up page with the invite code filled in.
+	// example: https://example.org/signup?invite=3b4e7dd4-5c1a-4d1c-9a8f-7e0b3a2ff8b1
+	// readonly: true
+	URL string `json:"url"`
+
+	// Time at which the invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time after which the invite can no longer be used (ISO 8601 Datetime).
+	// Null if the invite doesn't expire.
+	// example: 2021-07-31T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+
+	// Number of times the invite may be used.
+	// Null if the invite may be used any number of times.
+	// example: 5
+	MaxUses *int `json:"max_uses"`
+
+	// Number of accounts that have signed up with the invite.
+	// example: 2
+	Uses int `json:"uses"`
+
+	// Whether the invite may still be used to sign up;
+	// false if it has expired or reached its maximum uses.
+	// example: true
+	Valid bool `json:"valid"`
+
+	// The account that created the invite. Only
+	// set when invites are viewed by an admin.
+	Account *Account `json:"account,omitempty"`
+}
+
+// InviteCreateRequest is the form
+// submitted to create a new invite.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// Number of times the invite may
+	// be used. 0 for unlimited.
+	MaxUses int `form:"max_uses" json:"max_uses"`
+
+	// Number of seconds from now after which
+	// the invite expires. 0 for never.
+	ExpiresIn int `form:"expires_in" json:"expires_in"`
+}
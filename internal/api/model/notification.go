@@ -34,6 +34,7 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	pleroma:emoji_reaction = Someone reacted to one of your statuses with an emoji. `status` will be set. `account` will be set. `emoji` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -44,6 +45,18 @@ type Notification struct {
 
 	// Status that was the object of the notification, e.g. in mentions, reblogs, favourites, or polls.
 	Status *Status `json:"status,omitempty"`
+
+	// Emoji used to react to a status, in emoji reaction notifications.
+	// Either a unicode emoji, or a custom emoji's shortcode.
+	Emoji string `json:"emoji,omitempty"`
+
+	// Web link to the image of the custom emoji used to react
+	// to a status, in emoji reaction notifications.
+	EmojiURL string `json:"emoji_url,omitempty"`
+
+	// Account that created the invite used to sign
+	// up, in admin.sign_up notifications, if any.
+	InvitedBy *Account `json:"invited_by,omitempty"`
 }
 
 /*
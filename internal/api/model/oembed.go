@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// OEmbed models an oEmbed response for a status,
+// describing how to embed it on another website.
+//
+// See https://oembed.com/
+//
+// swagger:model oEmbed
+type OEmbed struct {
+	// Type of the oEmbed resource. Always "rich".
+	// example: rich
+	Type string `json:"type"`
+	// oEmbed version. Always "1.0".
+	// example: 1.0
+	Version string `json:"version"`
+	// Title of the status.
+	// example: Post by @some_user@example.org
+	Title string `json:"title"`
+	// Name of the author of the status.
+	// example: big jeff (he/him)
+	AuthorName string `json:"author_name"`
+	// Web URL of the author's profile.
+	// example: https://example.org/@some_user
+	AuthorURL string `json:"author_url"`
+	// Name of this instance.
+	// example: GoToSocial Example Instance
+	ProviderName string `json:"provider_name"`
+	// Web URL of this instance.
+	// example: https://example.org
+	ProviderURL string `json:"provider_url"`
+	// Suggested time in seconds to cache this response for.
+	// example: 86400
+	CacheAge int `json:"cache_age"`
+	// HTML snippet for embedding the status in a page.
+	// example: <iframe src="https://example.org/@some_user/statuses/01FBVD42CQ3ZEEVMW180SBX03B/embed" class="gotosocial-embed" style="max-width: 100%; border: 0" width="400" height="400" allowfullscreen="allowfullscreen" loading="lazy"></iframe>
+	HTML string `json:"html"`
+	// Width of the embed in pixels.
+	// example: 400
+	Width int `json:"width"`
+	// Height of the embed in pixels.
+	// example: 400
+	Height int `json:"height"`
+}
@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminQuarantinedStatus represents an incoming status that was held
+// back by the spam filter, pending review by an admin.
+//
+// swagger:model adminQuarantinedStatus
+type AdminQuarantinedStatus struct {
+	// The ID of the quarantined status.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// Time at which the status was quarantined (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// ActivityPub URI of the status.
+	// example: https://example.org/users/some_spammer/statuses/01FBW21XJA09XYX51KV5JVBW0F
+	URI string `json:"uri"`
+
+	// Web URL of the status, if known.
+	// example: https://example.org/@some_spammer/01FBW21XJA09XYX51KV5JVBW0F
+	URL string `json:"url,omitempty"`
+
+	// The account that authored the status.
+	Account *AdminAccountInfo `json:"account"`
+
+	// The local account that the status was delivered to.
+	ReceivingAccount *AdminAccountInfo `json:"receiving_account"`
+
+	// Content warning of the status, as plain text.
+	// example: buy now
+	SpoilerText string `json:"spoiler_text"`
+
+	// Content of the status, as sanitized HTML.
+	// example: <p>get more followers today!</p>
+	Content string `json:"content"`
+
+	// Total score given to the status by the spam filter.
+	// example: 70
+	Score int `json:"score"`
+
+	// Names of the spam filter rules that added to the score. Some of:
+	// `mention_fanout`, `mentions_strangers`, `attachments`, `errant_links`,
+	// `link_domains`, `repeated_content`, `new_account`.
+	// example: ["mentions_strangers","errant_links"]
+	Rules []string `json:"rules"`
+
+	// Fingerprint of the status text, if it was long enough to fingerprint.
+	// example: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Domains of links in the status (aside from mentions and hashtags).
+	// example: ["spammylink.org"]
+	LinkDomains []string `json:"link_domains"`
+}
+
+// SpamSignal represents a value that adds to (or subtracts
+// from) the spam score of incoming statuses that match it.
+//
+// swagger:model spamSignal
+type SpamSignal struct {
+	// The ID of the spam signal.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// What the value is matched against. One of:
+	// `link_domain` (domains, and their subdomains, of links in statuses),
+	// `fingerprint` (fingerprints of status text).
+	// example: link_domain
+	Type string `json:"type"`
+
+	// Value to match.
+	// example: spammylink.org
+	Value string `json:"value"`
+
+	// Score to add to matching statuses. May be negative.
+	// example: 50
+	Score int `json:"score"`
+
+	// Comment on why this signal exists.
+	// example: link spammed a lot lately
+	Comment string `json:"comment"`
+
+	// Time at which the signal was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the signal was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// SpamSignalRequest is the form submitted to create
+// a new spam signal, or update an existing one.
+// Fields left unset are left unchanged on update.
+//
+// swagger:ignore
+type SpamSignalRequest struct {
+	// What the value is matched against. Cannot be changed on update.
+	Type *string `form:"type" json:"type"`
+
+	// Value to match. Cannot be changed on update.
+	Value *string `form:"value" json:"value"`
+
+	// Score to add to matching statuses.
+	Score *int `form:"score" json:"score"`
+
+	// Comment on why this signal exists.
+	Comment *string `form:"comment" json:"comment"`
+}
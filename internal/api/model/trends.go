@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TrendsLink represents a link that is trending on this instance.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+	// Daily usage history of this link, most recent day first.
+	History []History `json:"history"`
+}
+
+// AdminTrendsTag models the admin view of a trending hashtag.
+//
+// swagger:model adminTrendsTag
+type AdminTrendsTag struct {
+	Tag
+	// The ID of the hashtag in the database.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	ID string `json:"id"`
+	// Whether the hashtag has been approved to show in trends.
+	Trendable bool `json:"trendable"`
+	// Whether the hashtag can be used in statuses on this instance.
+	Usable bool `json:"usable"`
+	// Whether the hashtag has not yet been approved or rejected by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTrendsStatus models the admin view of a trending status.
+//
+// swagger:model adminTrendsStatus
+type AdminTrendsStatus struct {
+	Status
+	// Whether the status has not yet been approved or rejected by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTrendsLink models the admin view of a trending link.
+//
+// swagger:model adminTrendsLink
+type AdminTrendsLink struct {
+	TrendsLink
+	// The ID of this trending link, for approving or rejecting it.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	ID string `json:"id"`
+	// Whether the link has been approved to show in trends.
+	Trendable bool `json:"trendable"`
+	// Whether the link has not yet been approved or rejected by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
@@ -95,3 +95,73 @@ type EmailChangeRequest struct {
 	// required: true
 	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
 }
+
+// EmailNotifications models a user's preferences
+// for being emailed about events on their account.
+//
+// swagger:model emailNotifications
+type EmailNotifications struct {
+	// Email user when they're mentioned in a non-direct status.
+	// example: true
+	Mention bool `json:"mention"`
+	// Email user when they're mentioned in a direct status.
+	// example: true
+	Direct bool `json:"direct"`
+	// Email user when they're followed.
+	// example: false
+	Follow bool `json:"follow"`
+	// Email user when they receive a follow request.
+	// example: true
+	FollowRequest bool `json:"follow_request"`
+	// Email user when a report they created is closed.
+	// example: true
+	Report bool `json:"report"`
+	// How often to send emails about the above.
+	//
+	// `immediate`: send an email as each event happens.
+	// `daily`: send a daily digest of events.
+	// `weekly`: send a weekly digest of events.
+	// example: daily
+	Frequency string `json:"frequency"`
+}
+
+// EmailNotificationsUpdateRequest models user email notification preference update parameters.
+//
+// swagger:parameters userEmailNotificationsUpdate
+type EmailNotificationsUpdateRequest struct {
+	// Email user when they're mentioned in a non-direct status.
+	//
+	// in: formData
+	Mention *bool `form:"mention" json:"mention" xml:"mention"`
+	// Email user when they're mentioned in a direct status.
+	//
+	// in: formData
+	Direct *bool `form:"direct" json:"direct" xml:"direct"`
+	// Email user when they're followed.
+	//
+	// in: formData
+	Follow *bool `form:"follow" json:"follow" xml:"follow"`
+	// Email user when they receive a follow request.
+	//
+	// in: formData
+	FollowRequest *bool `form:"follow_request" json:"follow_request" xml:"follow_request"`
+	// Email user when a report they created is closed.
+	//
+	// in: formData
+	Report *bool `form:"report" json:"report" xml:"report"`
+	// How often to send emails: `immediate`, `daily`, or `weekly`.
+	//
+	// in: formData
+	Frequency *string `form:"frequency" json:"frequency" xml:"frequency"`
+}
+
+// FeedToken models a user's private feed token, used
+// to access feeds that aren't public, such as of lists.
+//
+// swagger:model feedToken
+type FeedToken struct {
+	// Private feed token, or empty if none has been generated.
+	// Add this to private feed URLs as the `token` query parameter.
+	// example: 490e337c-0162-454f-ac48-4b22bb92a205
+	Token string `json:"token"`
+}
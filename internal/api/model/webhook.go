@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Webhook represents a URL that a signed JSON payload
+// is POSTed to whenever a subscribed admin event occurs.
+//
+// swagger:model webhook
+type Webhook struct {
+	// The ID of the webhook.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// URL that event payloads are POSTed to.
+	// example: https://moderation-bot.example.org/hooks/gts
+	URL string `json:"url"`
+
+	// Events this webhook is subscribed to. Some of:
+	// `account.created`, `account.approved`, `report.created`,
+	// `report.updated`, `status.created`, `status.updated`.
+	// example: ["report.created","report.updated"]
+	Events []string `json:"events"`
+
+	// Secret used to sign event payloads. The hex-encoded
+	// HMAC-SHA256 of the request body using this secret is
+	// sent in the `X-Hub-Signature` header as `sha256=<hmac>`.
+	// example: 8d7bd4d0e52e64f8bd6b77a6a2a05f3e1d2bbd5a
+	Secret string `json:"secret"`
+
+	// Whether event payloads are currently sent to this webhook.
+	// example: true
+	Enabled bool `json:"enabled"`
+
+	// Time at which the webhook was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the webhook was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// WebhookRequest is the form submitted to create
+// a new webhook, or update an existing one.
+// Fields left unset are left unchanged on update.
+//
+// swagger:ignore
+type WebhookRequest struct {
+	// URL to POST event payloads to.
+	URL *string `form:"url" json:"url"`
+
+	// Events to subscribe to.
+	Events *[]string `form:"events[]" json:"events"`
+
+	// Secret to sign payloads with. Generated if not set on create.
+	Secret *string `form:"secret" json:"secret"`
+
+	// Whether event payloads are sent to this webhook.
+	Enabled *bool `form:"enabled" json:"enabled"`
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks
+// when an event they're subscribed to occurs.
+//
+// swagger:ignore
+type WebhookPayload struct {
+	// The event that occurred, eg., `report.created`.
+	Event string `json:"event"`
+
+	// Time at which the event occurred (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+
+	// The object of the event: an admin account
+	// for account.* events, an admin report for
+	// report.* events, and a status for status.* events.
+	Object any `json:"object"`
+}
@@ -54,6 +54,10 @@ type OGMeta struct {
 
 	// profile tags
 	ProfileUsername string // profile:username
+
+	// oEmbed discovery, not an og tag
+	// but used for link previews too
+	OEmbed string // link rel="alternate" type="application/json+oembed"
 }
 
 // OGBase returns an *ogMeta suitable for serving at
@@ -19,10 +19,12 @@ package util
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
 )
 
 const (
@@ -37,6 +39,7 @@ const (
 	IDKey              = "id"
 	LimitKey           = "limit"
 	LocalKey           = "local"
+	LanguagesKey       = "languages[]"
 	MaxIDKey           = "max_id"
 	SinceIDKey         = "since_id"
 	MinIDKey           = "min_id"
@@ -44,6 +47,7 @@ const (
 	AccountIDKey       = "account_id"
 	TargetAccountIDKey = "target_account_id"
 	ResolvedKey        = "resolved"
+	OffsetKey          = "offset"
 
 	/* AP endpoint keys */
 
@@ -63,9 +67,17 @@ const (
 
 	TagNameKey = "tag_name"
 
+	/* oEmbed keys */
+
+	OEmbedURLKey       = "url"
+	OEmbedMaxWidthKey  = "maxwidth"
+	OEmbedMaxHeightKey = "maxheight"
+	OEmbedFormatKey    = "format"
+
 	/* Web endpoint keys */
 
 	WebStatusIDKey = "status"
+	FeedTokenKey   = "token"
 
 	/* Domain permission keys */
 
@@ -130,10 +142,35 @@ func ParseLimit(value string, defaultValue int, max, min int) (int, gtserror.Wit
 	return i, nil
 }
 
+func ParseOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OffsetKey)
+}
+
 func ParseLocal(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, LocalKey)
 }
 
+// ParseLanguages parses the given BCP47 language tags, returning
+// their deduplicated base languages, eg., "en" for "en-GB".
+func ParseLanguages(values []string) ([]string, gtserror.WithCode) {
+	key := LanguagesKey
+	languages := make([]string, 0, len(values))
+
+	for _, value := range values {
+		lang, err := language.Parse(value)
+		if err != nil {
+			return nil, parseError(key, value, languages, err)
+		}
+
+		base, _ := lang.Tag.Base()
+		if !slices.Contains(languages, base.String()) {
+			languages = append(languages, base.String())
+		}
+	}
+
+	return languages, nil
+}
+
 func ParseResolved(value string, defaultValue *bool) (*bool, gtserror.WithCode) {
 	return parseBoolPtr(value, defaultValue, ResolvedKey)
 }
@@ -166,6 +203,14 @@ func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.Wit
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
 
+func ParseOEmbedMaxWidth(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OEmbedMaxWidthKey)
+}
+
+func ParseOEmbedMaxHeight(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OEmbedMaxHeightKey)
+}
+
 func ParseAdminRemote(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, AdminRemoteKey)
 }
@@ -228,6 +273,16 @@ func ParseID(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseOEmbedURL(value string) (string, gtserror.WithCode) {
+	key := OEmbedURLKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 func ParseSearchLookup(value string) (string, gtserror.WithCode) {
 	key := SearchLookupKey
 
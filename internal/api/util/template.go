@@ -22,6 +22,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 )
 
 // WebPage encapsulates variables for
@@ -67,6 +68,24 @@ func TemplateWebPage(
 	c *gin.Context,
 	page WebPage,
 ) {
+	templatePage(c, page.Template, http.StatusOK, webPageObj(page))
+}
+
+// TemplateEmbedPage renders the given HTML template and
+// page params within the minimal GtS "embed" template,
+// which has no header or footer, for rendering pages
+// in frames on other websites.
+func TemplateEmbedPage(
+	c *gin.Context,
+	page WebPage,
+) {
+	const embedTmpl = "embed.tmpl"
+	renderPage(c, embedTmpl, page.Template, http.StatusOK, webPageObj(page))
+}
+
+// webPageObj returns the template
+// object for rendering the given page.
+func webPageObj(page WebPage) map[string]any {
 	obj := map[string]any{
 		"instance":    page.Instance,
 		"ogMeta":      page.OGMeta,
@@ -78,7 +97,7 @@ func TemplateWebPage(
 		obj[k] = v
 	}
 
-	templatePage(c, page.Template, http.StatusOK, obj)
+	return obj
 }
 
 // templateErrorPage renders the given
@@ -130,6 +149,25 @@ func templatePage(
 	obj map[string]any,
 ) {
 	const pageTmpl = "page.tmpl"
+	renderPage(c, pageTmpl, template, code, obj)
+}
+
+// render the given template inside the
+// given page template with the provided
+// code and template object.
+func renderPage(
+	c *gin.Context,
+	pageTmpl string,
+	template string,
+	code int,
+	obj map[string]any,
+) {
 	obj["pageContent"] = template
+
+	// Render in the reader's preferred language;
+	// caches must vary the page on their header.
+	obj["locale"] = i18n.MatchAcceptLanguage(c.GetHeader("Accept-Language"))
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
 	c.HTML(code, pageTmpl, obj)
 }
@@ -38,10 +38,31 @@ type Caches struct {
 	// the block []headerfilter.Filter cache.
 	BlockHeaderFilters headerfilter.Cache
 
+	// Challenges provides access to pending
+	// sign-up / sign-in challenges, and to
+	// failed attempts per IP address.
+	Challenges ChallengeCache
+
+	// FederationPolicies provides access to the
+	// ordered federation policies slice cache.
+	FederationPolicies FederationPolicyCache
+
+	// SpamSignals provides access to
+	// the spam signals slice cache.
+	SpamSignals SpamSignalCache
+
+	// Webhooks provides access to
+	// the admin webhooks slice cache.
+	Webhooks WebhookCache
+
 	// Visibility provides access to the item visibility
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// Trends provides access to the most recently
+	// computed trends. (used by the trends processor).
+	Trends TrendsCache
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -83,12 +104,15 @@ func (c *Caches) Init() {
 	c.initPoll()
 	c.initPollVote()
 	c.initPollVoteIDs()
+	c.initPreviewCard()
 	c.initReport()
 	c.initStatus()
 	c.initStatusBookmark()
 	c.initStatusBookmarkIDs()
 	c.initStatusFave()
 	c.initStatusFaveIDs()
+	c.initStatusReaction()
+	c.initStatusReactionIDs()
 	c.initTag()
 	c.initThreadMute()
 	c.initToken()
@@ -98,6 +122,13 @@ func (c *Caches) Init() {
 	c.initUserMuteIDs()
 	c.initWebfinger()
 	c.initVisibility()
+	c.initChallenges()
+
+	// Drop any loaded slices, these
+	// are lazily reloaded on next use.
+	c.FederationPolicies.Clear()
+	c.SpamSignals.Clear()
+	c.Webhooks.Clear()
 }
 
 // Start will start any caches that require a background
@@ -108,6 +139,14 @@ func (c *Caches) Start() {
 	tryUntil("starting webfinger cache", 5, func() bool {
 		return c.GTS.Webfinger.Start(5 * time.Minute)
 	})
+
+	tryUntil("starting challenge cache", 5, func() bool {
+		return c.Challenges.Pending.Start(time.Minute)
+	})
+
+	tryUntil("starting challenge failures cache", 5, func() bool {
+		return c.Challenges.failures.Start(time.Minute)
+	})
 }
 
 // Stop will stop any caches that require a background
@@ -116,6 +155,8 @@ func (c *Caches) Stop() {
 	log.Infof(nil, "stop: %p", c)
 
 	tryUntil("stopping webfinger cache", 5, c.GTS.Webfinger.Stop)
+	tryUntil("stopping challenge cache", 5, c.Challenges.Pending.Stop)
+	tryUntil("stopping challenge failures cache", 5, c.Challenges.failures.Stop)
 }
 
 // Sweep will sweep all the available caches to ensure none
@@ -155,12 +196,15 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.Poll.Trim(threshold)
 	c.GTS.PollVote.Trim(threshold)
 	c.GTS.PollVoteIDs.Trim(threshold)
+	c.GTS.PreviewCard.Trim(threshold)
 	c.GTS.Report.Trim(threshold)
 	c.GTS.Status.Trim(threshold)
 	c.GTS.StatusBookmark.Trim(threshold)
 	c.GTS.StatusBookmarkIDs.Trim(threshold)
 	c.GTS.StatusFave.Trim(threshold)
 	c.GTS.StatusFaveIDs.Trim(threshold)
+	c.GTS.StatusReaction.Trim(threshold)
+	c.GTS.StatusReactionIDs.Trim(threshold)
 	c.GTS.Tag.Trim(threshold)
 	c.GTS.ThreadMute.Trim(threshold)
 	c.GTS.Token.Trim(threshold)
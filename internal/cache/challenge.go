@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	// ChallengeTTL is how long issued
+	// challenges may be answered for.
+	ChallengeTTL = 10 * time.Minute
+
+	// ChallengeIssueLimit is how many challenges
+	// may be issued to one IP address per ChallengeTTL,
+	// so one client can't fill up the pending cache.
+	ChallengeIssueLimit = 30
+)
+
+// ChallengeCache keeps challenges issued to
+// clients until they're answered (or expire),
+// and counts issued challenges and failed
+// attempts per IP address, for limiting and
+// the accounts-challenge-cooldown.
+type ChallengeCache struct {
+	// Pending challenges, by ID.
+	Pending *ttl.Cache[string, *challenge.Challenge]
+
+	// Recently issued, by IP address.
+	issued   *ttl.Cache[string, challengeIssued]
+	issuedMu sync.Mutex
+
+	// Recent failures, by IP address.
+	failures *ttl.Cache[string, challengeFailures]
+	failMu   sync.Mutex
+}
+
+// challengeIssued counts challenges
+// issued to one IP since first.
+type challengeIssued struct {
+	count int
+	first time.Time
+}
+
+// challengeFailures counts
+// failures from one IP.
+type challengeFailures struct {
+	count int
+	last  time.Time
+}
+
+func (c *Caches) initChallenges() {
+	c.Challenges.Pending = new(ttl.Cache[string, *challenge.Challenge])
+	c.Challenges.Pending.Init(0, 10000, ChallengeTTL)
+
+	c.Challenges.issued = new(ttl.Cache[string, challengeIssued])
+	c.Challenges.issued.Init(0, 10000, ChallengeTTL)
+
+	// Keep failures around for at least
+	// the cooldown, so they're not dropped
+	// before the cooldown is over.
+	c.Challenges.failures = new(ttl.Cache[string, challengeFailures])
+	c.Challenges.failures.Init(0, 10000, max(
+		config.GetAccountsChallengeCooldown(),
+		ChallengeTTL,
+	))
+}
+
+// Issue records a challenge issued to ip, returning
+// false if ip has already been issued ChallengeIssueLimit
+// challenges within ChallengeTTL, in which case the
+// challenge should not be issued.
+func (c *ChallengeCache) Issue(ip string) bool {
+	c.issuedMu.Lock()
+	defer c.issuedMu.Unlock()
+
+	i, _ := c.issued.Get(ip)
+	if time.Since(i.first) > ChallengeTTL {
+		// Start a new window.
+		i.count = 0
+		i.first = time.Now()
+	}
+
+	if i.count >= ChallengeIssueLimit {
+		return false
+	}
+
+	i.count++
+	c.issued.Set(ip, i)
+	return true
+}
+
+// Take returns the pending challenge with given ID,
+// removing it, so that each challenge can only be
+// answered once. Expired challenges are not returned.
+func (c *ChallengeCache) Take(id string) (*challenge.Challenge, bool) {
+	ch, ok := c.Pending.Get(id)
+	if !ok {
+		return nil, false
+	}
+
+	c.Pending.Invalidate(id)
+	if ch.Expired() {
+		return nil, false
+	}
+
+	return ch, true
+}
+
+// Fail records a failed attempt from ip.
+func (c *ChallengeCache) Fail(ip string) {
+	c.failMu.Lock()
+	defer c.failMu.Unlock()
+
+	f, _ := c.failures.Get(ip)
+	if time.Since(f.last) > config.GetAccountsChallengeCooldown() {
+		// Old failures have
+		// been served, reset.
+		f.count = 0
+	}
+
+	f.count++
+	f.last = time.Now()
+	c.failures.Set(ip, f)
+}
+
+// CoolingDown returns true if ip has failed too
+// many attempts, and must wait to try again.
+func (c *ChallengeCache) CoolingDown(ip string) bool {
+	attempts := config.GetAccountsChallengeCooldownAttempts()
+	if attempts <= 0 {
+		// Cooldown disabled.
+		return false
+	}
+
+	c.failMu.Lock()
+	defer c.failMu.Unlock()
+
+	f, ok := c.failures.Get(ip)
+	if !ok {
+		return false
+	}
+
+	return f.count >= attempts &&
+		time.Since(f.last) <= config.GetAccountsChallengeCooldown()
+}
@@ -133,6 +133,9 @@ type GTSCaches struct {
 	// PollVoteIDs provides access to the poll vote IDs list database cache.
 	PollVoteIDs SliceCache[string]
 
+	// PreviewCard provides access to the gtsmodel PreviewCard database cache.
+	PreviewCard StructCache[*gtsmodel.PreviewCard]
+
 	// Report provides access to the gtsmodel Report database cache.
 	Report StructCache[*gtsmodel.Report]
 
@@ -151,6 +154,12 @@ type GTSCaches struct {
 	// StatusFaveIDs provides access to the status fave IDs list database cache.
 	StatusFaveIDs SliceCache[string]
 
+	// StatusReaction provides access to the gtsmodel StatusReaction database cache.
+	StatusReaction StructCache[*gtsmodel.StatusReaction]
+
+	// StatusReactionIDs provides access to the status reaction IDs list database cache.
+	StatusReactionIDs SliceCache[string]
+
 	// Tag provides access to the gtsmodel Tag database cache.
 	Tag StructCache[*gtsmodel.Tag]
 
@@ -1032,6 +1041,33 @@ func (c *Caches) initPollVoteIDs() {
 	c.GTS.PollVoteIDs.Init(0, cap)
 }
 
+func (c *Caches) initPreviewCard() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofPreviewCard(), // model in-mem size.
+		config.GetCachePreviewCardMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(c1 *gtsmodel.PreviewCard) *gtsmodel.PreviewCard {
+		c2 := new(gtsmodel.PreviewCard)
+		*c2 = *c1
+		return c2
+	}
+
+	c.GTS.PreviewCard.Init(structr.CacheConfig[*gtsmodel.PreviewCard]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URL"},
+			{Fields: "ImageURL"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initReport() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1088,7 +1124,9 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.QuoteOf = nil
 		s2.Poll = nil
+		s2.PreviewCard = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
@@ -1209,6 +1247,55 @@ func (c *Caches) initStatusFaveIDs() {
 	c.GTS.StatusFaveIDs.Init(0, cap)
 }
 
+func (c *Caches) initStatusReaction() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusReaction(), // model in-mem size.
+		config.GetCacheStatusReactionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(r1 *gtsmodel.StatusReaction) *gtsmodel.StatusReaction {
+		r2 := new(gtsmodel.StatusReaction)
+		*r2 = *r1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/statusreaction.go.
+		r2.Account = nil
+		r2.TargetAccount = nil
+		r2.Status = nil
+		r2.Emoji = nil
+
+		return r2
+	}
+
+	c.GTS.StatusReaction.Init(structr.CacheConfig[*gtsmodel.StatusReaction]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URI"},
+			{Fields: "AccountID,StatusID,Name"},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateStatusReaction,
+	})
+}
+
+func (c *Caches) initStatusReactionIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheStatusReactionIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.StatusReactionIDs.Init(0, cap)
+}
+
 func (c *Caches) initTag() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1345,6 +1432,8 @@ func (c *Caches) initUser() {
 			{Fields: "Email"},
 			{Fields: "ConfirmationToken"},
 			{Fields: "ExternalID"},
+			{Fields: "EmailUnsubscribeToken"},
+			{Fields: "FeedToken"},
 		},
 		MaxSize:    cap,
 		IgnoreErr:  ignoreErrors,
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// FederationPolicyCache caches the full ordered slice
+// of federation policies, which is checked on every
+// incoming activity, and so is worth keeping in memory.
+type FederationPolicyCache struct {
+	// current cached federation policies.
+	ptr atomic.Pointer[[]*gtsmodel.FederationPolicy]
+}
+
+// Load returns the currently cached federation policies, loading
+// using callback if necessary. The returned policies MUST NOT be modified.
+func (c *FederationPolicyCache) Load(load func() ([]*gtsmodel.FederationPolicy, error)) ([]*gtsmodel.FederationPolicy, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load policies from callback.
+		policies, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		// Store the new
+		// policies slice.
+		ptr = &policies
+		c.ptr.Store(ptr)
+	}
+
+	return *ptr, nil
+}
+
+// Clear will drop the currently loaded policies,
+// triggering a reload on next call to .Load().
+func (c *FederationPolicyCache) Clear() { c.ptr.Store(nil) }
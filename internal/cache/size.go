@@ -18,6 +18,7 @@
 package cache
 
 import (
+	"crypto/ed25519"
 	"crypto/rsa"
 	"time"
 	"unsafe"
@@ -199,12 +200,15 @@ func totalOfRatios() float64 {
 		config.GetCacheNotificationMemRatio() +
 		config.GetCachePollMemRatio() +
 		config.GetCachePollVoteMemRatio() +
+		config.GetCachePreviewCardMemRatio() +
 		config.GetCacheReportMemRatio() +
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusBookmarkMemRatio() +
 		config.GetCacheStatusBookmarkIDsMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
+		config.GetCacheStatusReactionMemRatio() +
+		config.GetCacheStatusReactionIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
 		config.GetCacheThreadMuteMemRatio() +
 		config.GetCacheTokenMemRatio() +
@@ -230,6 +234,7 @@ func sizeofAccount() uintptr {
 		Bot:                     func() *bool { ok := true; return &ok }(),
 		Locked:                  func() *bool { ok := true; return &ok }(),
 		Discoverable:            func() *bool { ok := false; return &ok }(),
+		Indexable:               func() *bool { ok := false; return &ok }(),
 		URI:                     exampleURI,
 		URL:                     exampleURI,
 		InboxURI:                exampleURI,
@@ -241,6 +246,9 @@ func sizeofAccount() uintptr {
 		PrivateKey:              &rsa.PrivateKey{},
 		PublicKey:               &rsa.PublicKey{},
 		PublicKeyURI:            exampleURI,
+		Ed25519PrivateKey:       make(ed25519.PrivateKey, ed25519.PrivateKeySize),
+		Ed25519PublicKey:        make(ed25519.PublicKey, ed25519.PublicKeySize),
+		Ed25519PublicKeyURI:     exampleURI,
 		SensitizedAt:            exampleTime,
 		SilencedAt:              exampleTime,
 		SuspendedAt:             exampleTime,
@@ -269,6 +277,8 @@ func sizeofAccountSettings() uintptr {
 		CustomCSS:         exampleText,
 		EnableRSS:         util.Ptr(true),
 		HideCollections:   util.Ptr(false),
+		Indexable:         util.Ptr(false),
+		DisableEmbeds:     util.Ptr(false),
 	}))
 }
 
@@ -536,6 +546,29 @@ func sizeofPoll() uintptr {
 	}))
 }
 
+func sizeofPreviewCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.PreviewCard{
+		ID:               exampleID,
+		CreatedAt:        exampleTime,
+		UpdatedAt:        exampleTime,
+		FetchedAt:        exampleTime,
+		URL:              exampleURI,
+		Title:            exampleTextSmall,
+		Description:      exampleText,
+		Type:             "link",
+		ProviderName:     exampleUsername,
+		ProviderURL:      exampleURI,
+		ImageRemoteURL:   exampleURI,
+		ImageURL:         exampleURI,
+		ImagePath:        exampleURI,
+		ImageContentType: "image/jpeg",
+		ImageFileSize:    69420,
+		ImageWidth:       400,
+		ImageHeight:      300,
+		Blurhash:         exampleID,
+	}))
+}
+
 func sizeofPollVote() uintptr {
 	return uintptr(size.Of(&gtsmodel.PollVote{
 		ID:        exampleID,
@@ -585,6 +618,8 @@ func sizeofStatus() uintptr {
 		InReplyToAccountID:       exampleID,
 		BoostOfID:                exampleID,
 		BoostOfAccountID:         exampleID,
+		QuoteOfID:                exampleID,
+		QuoteOfURI:               exampleURI,
 		ContentWarning:           exampleUsername, // similar length
 		Visibility:               gtsmodel.VisibilityPublic,
 		Sensitive:                func() *bool { ok := false; return &ok }(),
@@ -623,6 +658,20 @@ func sizeofStatusFave() uintptr {
 	}))
 }
 
+func sizeofStatusReaction() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusReaction{
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		AccountID:       exampleID,
+		TargetAccountID: exampleID,
+		StatusID:        exampleID,
+		Name:            ":" + exampleUsername + ":",
+		EmojiID:         exampleID,
+		URI:             exampleURI,
+	}))
+}
+
 func sizeofTag() uintptr {
 	return uintptr(size.Of(&gtsmodel.Tag{
 		ID:        exampleID,
@@ -679,37 +728,50 @@ func sizeofTombstone() uintptr {
 
 func sizeofVisibility() uintptr {
 	return uintptr(size.Of(&CachedVisibility{
-		ItemID:      exampleID,
-		RequesterID: exampleID,
-		Type:        VisibilityTypeAccount,
-		Value:       false,
+		ItemID:           exampleID,
+		RequesterID:      exampleID,
+		AccountID:        exampleID,
+		BoostOfAccountID: exampleID,
+		Type:             VisibilityTypeAccount,
+		Value:            false,
 	}))
 }
 
 func sizeofUser() uintptr {
 	return uintptr(size.Of(&gtsmodel.User{
-		ID:                     exampleID,
-		CreatedAt:              exampleTime,
-		UpdatedAt:              exampleTime,
-		Email:                  exampleURI,
-		AccountID:              exampleID,
-		EncryptedPassword:      exampleTextSmall,
-		InviteID:               exampleID,
-		Reason:                 exampleText,
-		Locale:                 "en",
-		CreatedByApplicationID: exampleID,
-		LastEmailedAt:          exampleTime,
-		ConfirmationToken:      exampleTextSmall,
-		ConfirmationSentAt:     exampleTime,
-		ConfirmedAt:            exampleTime,
-		UnconfirmedEmail:       exampleURI,
-		Moderator:              util.Ptr(false),
-		Admin:                  util.Ptr(false),
-		Disabled:               util.Ptr(false),
-		Approved:               util.Ptr(false),
-		ResetPasswordToken:     exampleTextSmall,
-		ResetPasswordSentAt:    exampleTime,
-		ExternalID:             exampleID,
+		ID:                       exampleID,
+		CreatedAt:                exampleTime,
+		UpdatedAt:                exampleTime,
+		Email:                    exampleURI,
+		AccountID:                exampleID,
+		EncryptedPassword:        exampleTextSmall,
+		InviteID:                 exampleID,
+		Reason:                   exampleText,
+		Locale:                   "en",
+		CreatedByApplicationID:   exampleID,
+		LastEmailedAt:            exampleTime,
+		ConfirmationToken:        exampleTextSmall,
+		ConfirmationSentAt:       exampleTime,
+		ConfirmedAt:              exampleTime,
+		UnconfirmedEmail:         exampleURI,
+		Moderator:                util.Ptr(false),
+		Admin:                    util.Ptr(false),
+		Disabled:                 util.Ptr(false),
+		Approved:                 util.Ptr(false),
+		ResetPasswordToken:       exampleTextSmall,
+		ResetPasswordSentAt:      exampleTime,
+		ExternalID:               exampleID,
+		EmailNotifyMention:       util.Ptr(false),
+		EmailNotifyDirect:        util.Ptr(false),
+		EmailNotifyFollow:        util.Ptr(false),
+		EmailNotifyFollowRequest: util.Ptr(false),
+		EmailNotifyReport:        util.Ptr(true),
+		EmailNotifyFrequency:     gtsmodel.EmailNotifyFrequencyImmediate,
+		EmailDigestSentAt:        exampleTime,
+		EmailUnsubscribeToken:    exampleTextSmall,
+		FeedToken:                exampleTextSmall,
+		LastActiveAt:             exampleTime,
+		DormantWarnedAt:          exampleTime,
 	}))
 }
 
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// SpamSignalCache caches the full slice of spam
+// signals, which is checked for every incoming
+// status that goes through the spam filter.
+type SpamSignalCache struct {
+	// current cached spam signals.
+	ptr atomic.Pointer[[]*gtsmodel.SpamSignal]
+}
+
+// Load returns the currently cached spam signals, loading using
+// callback if necessary. The returned signals MUST NOT be modified.
+func (c *SpamSignalCache) Load(load func() ([]*gtsmodel.SpamSignal, error)) ([]*gtsmodel.SpamSignal, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load signals from callback.
+		signals, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		// Store the new
+		// signals slice.
+		ptr = &signals
+		c.ptr.Store(ptr)
+	}
+
+	return *ptr, nil
+}
+
+// Clear will drop the currently loaded signals,
+// triggering a reload on next call to .Load().
+func (c *SpamSignalCache) Clear() { c.ptr.Store(nil) }
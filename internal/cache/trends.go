@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// TrendsCache caches the most recently computed snapshot of
+// trending items, which is replaced wholesale on each recompute.
+type TrendsCache struct {
+	// current cached trends snapshot.
+	ptr atomic.Pointer[gtsmodel.Trends]
+}
+
+// Load returns the currently cached trends
+// snapshot, or nil if none has been computed.
+// The returned snapshot MUST NOT be modified.
+func (c *TrendsCache) Load() *gtsmodel.Trends {
+	return c.ptr.Load()
+}
+
+// Store replaces the currently
+// cached trends snapshot.
+func (c *TrendsCache) Store(trends *gtsmodel.Trends) {
+	c.ptr.Store(trends)
+}
+
+// Clear will drop the currently
+// cached trends snapshot.
+func (c *TrendsCache) Clear() { c.ptr.Store(nil) }
+
+// SetReview updates the review of any matching trending
+// item in the currently cached snapshot to the given one,
+// so that a review takes effect without a full recompute.
+func (c *TrendsCache) SetReview(review *gtsmodel.TrendReview) {
+	for {
+		// Load current snapshot.
+		old := c.ptr.Load()
+		if old == nil {
+			return
+		}
+
+		// Take a copy of the snapshot to
+		// update, leaving the old untouched.
+		trends := &gtsmodel.Trends{
+			Tags:       slices.Clone(old.Tags),
+			Statuses:   slices.Clone(old.Statuses),
+			Links:      slices.Clone(old.Links),
+			ComputedAt: old.ComputedAt,
+		}
+
+		switch review.TargetType {
+		case gtsmodel.TrendTypeTag:
+			for i, tag := range trends.Tags {
+				if tag.Tag.ID == review.TargetID {
+					tag2 := *tag
+					tag2.Review = review
+					trends.Tags[i] = &tag2
+				}
+			}
+
+		case gtsmodel.TrendTypeStatus:
+			for i, status := range trends.Statuses {
+				if status.StatusID == review.TargetID {
+					status2 := *status
+					status2.Review = review
+					trends.Statuses[i] = &status2
+				}
+			}
+
+		case gtsmodel.TrendTypeLink:
+			for i, link := range trends.Links {
+				if link.URL == review.TargetID {
+					link2 := *link
+					link2.Review = review
+					trends.Links[i] = &link2
+				}
+			}
+		}
+
+		// Only swap in our updated snapshot if it hasn't
+		// been replaced in the meantime, else try again.
+		if c.ptr.CompareAndSwap(old, trends) {
+			return
+		}
+	}
+}
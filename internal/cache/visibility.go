@@ -46,6 +46,8 @@ func (c *Caches) initVisibility() {
 		Indices: []structr.IndexConfig{
 			{Fields: "ItemID", Multiple: true},
 			{Fields: "RequesterID", Multiple: true},
+			{Fields: "AccountID", Multiple: true},
+			{Fields: "BoostOfAccountID", Multiple: true},
 			{Fields: "Type,RequesterID,ItemID"},
 		},
 		MaxSize:   cap,
@@ -54,6 +56,14 @@ func (c *Caches) initVisibility() {
 	})
 }
 
+// InvalidateAccount invalidates all cached visibilities
+// of the account with given ID, and of statuses by it
+// or boosting it, eg., when it has been silenced.
+func (c *VisibilityCache) InvalidateAccount(accountID string) {
+	c.Invalidate("AccountID", accountID)
+	c.Invalidate("BoostOfAccountID", accountID)
+}
+
 // VisibilityType represents a visibility lookup type.
 // We use a byte type here to improve performance in the
 // result cache when generating the key.
@@ -75,6 +85,14 @@ type CachedVisibility struct {
 	// RequesterID is the ID of the requesting account for this visibility lookup.
 	RequesterID string
 
+	// AccountID is the ID of the account the item belongs to,
+	// ie., the status author, or the account itself.
+	AccountID string
+
+	// BoostOfAccountID is the ID of the author of the
+	// boosted status, if the item is a boost.
+	BoostOfAccountID string
+
 	// Type is the visibility lookup type.
 	Type VisibilityType
 
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebhookCache caches the full slice of webhooks,
+// which is checked for subscribers every time
+// a processed message may trigger a webhook event.
+type WebhookCache struct {
+	// current cached webhooks.
+	ptr atomic.Pointer[[]*gtsmodel.Webhook]
+}
+
+// Load returns the currently cached webhooks, loading using
+// callback if necessary. The returned webhooks MUST NOT be modified.
+func (c *WebhookCache) Load(load func() ([]*gtsmodel.Webhook, error)) ([]*gtsmodel.Webhook, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load webhooks from callback.
+		webhooks, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		// Store the new
+		// webhooks slice.
+		ptr = &webhooks
+		c.ptr.Store(ptr)
+	}
+
+	return *ptr, nil
+}
+
+// Clear will drop the currently loaded webhooks,
+// triggering a reload on next call to .Load().
+func (c *WebhookCache) Clear() { c.ptr.Store(nil) }
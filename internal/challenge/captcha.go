@@ -0,0 +1,200 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenge
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math/big"
+	mathrand "math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	captchaLength  = 6  // characters in a captcha
+	captchaScale   = 5  // pixels per glyph pixel
+	captchaAdvance = 32 // pixels between glyphs
+	captchaWidth   = captchaLength*captchaAdvance + 24
+	captchaHeight  = 70
+)
+
+// Captcha is an image captcha Challenger.
+//
+// Clients are shown a distorted, noisy image of some
+// random text, which they must type back in. The image
+// is drawn with a built-in bitmap font, so no fonts or
+// third-party services are needed.
+type Captcha struct{}
+
+// Issue implements Challenger.
+func (*Captcha) Issue(ttl time.Duration) (*Challenge, error) {
+	c, err := newChallenge(KindCaptcha, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	text := make([]byte, captchaLength)
+	alphabetLen := big.NewInt(int64(len(captchaAlphabet)))
+	for i := range text {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return nil, fmt.Errorf("error reading random int: %w", err)
+		}
+		text[i] = captchaAlphabet[n.Int64()]
+	}
+
+	c.Text = string(text)
+	return c, nil
+}
+
+// Check implements Challenger.
+func (*Captcha) Check(c *Challenge, answer string) bool {
+	// Be forgiving about case and
+	// any spaces people type in.
+	answer = strings.ToUpper(answer)
+	answer = strings.Join(strings.Fields(answer), "")
+	return subtle.ConstantTimeCompare([]byte(answer), []byte(c.Text)) == 1
+}
+
+// Image returns a PNG image of the text of captcha c.
+func (*Captcha) Image(c *Challenge) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, captchaWidth, captchaHeight))
+
+	// Light, slightly off-white background.
+	bg := color.RGBA{
+		R: uint8(225 + mathrand.Intn(30)),
+		G: uint8(225 + mathrand.Intn(30)),
+		B: uint8(225 + mathrand.Intn(30)),
+		A: 255,
+	}
+	for y := 0; y < captchaHeight; y++ {
+		for x := 0; x < captchaWidth; x++ {
+			img.Set(x, y, bg)
+		}
+	}
+
+	// Speckle the background
+	// to confuse simple OCR.
+	for i := 0; i < 400; i++ {
+		img.Set(
+			mathrand.Intn(captchaWidth),
+			mathrand.Intn(captchaHeight),
+			randomColor(100, 200),
+		)
+	}
+
+	// Draw each glyph with its own
+	// color, height and slant.
+	for i, r := range c.Text {
+		glyph, ok := captchaFont[r]
+		if !ok {
+			return nil, fmt.Errorf("no glyph for %q", r)
+		}
+
+		var (
+			col   = randomColor(0, 110)
+			x0    = 12 + i*captchaAdvance + mathrand.Intn(5) - 2
+			y0    = 5 + mathrand.Intn(captchaHeight-10-len(glyph)*captchaScale)
+			slant = mathrand.Float64()*0.6 - 0.3
+		)
+
+		for gy, row := range glyph {
+			// Shift rows sideways
+			// to slant the glyph.
+			shift := int(slant * float64((gy-len(glyph)/2)*captchaScale))
+
+			for gx, px := range row {
+				if px != '#' {
+					continue
+				}
+
+				fillRect(
+					img,
+					x0+gx*captchaScale+shift,
+					y0+gy*captchaScale,
+					captchaScale,
+					col,
+				)
+			}
+		}
+	}
+
+	// Finally, strike some lines
+	// through the whole thing.
+	for i := 0; i < 5; i++ {
+		drawLine(
+			img,
+			0, mathrand.Intn(captchaHeight),
+			captchaWidth, mathrand.Intn(captchaHeight),
+			randomColor(0, 160),
+		)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, fmt.Errorf("error encoding png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// randomColor returns an opaque color with
+// each channel in the range [lo, hi).
+func randomColor(lo, hi int) color.RGBA {
+	return color.RGBA{
+		R: uint8(lo + mathrand.Intn(hi-lo)),
+		G: uint8(lo + mathrand.Intn(hi-lo)),
+		B: uint8(lo + mathrand.Intn(hi-lo)),
+		A: 255,
+	}
+}
+
+// fillRect fills the size*size square
+// with top-left corner x,y with col.
+func fillRect(img *image.RGBA, x, y, size int, col color.RGBA) {
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			img.SetRGBA(x+dx, y+dy, col)
+		}
+	}
+}
+
+// drawLine draws a 2px thick line
+// from x0,y0 to x1,y1 with col.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, col color.RGBA) {
+	steps := max(abs(x1-x0), abs(y1-y0))
+	for i := 0; i <= steps; i++ {
+		x := x0 + (x1-x0)*i/steps
+		y := y0 + (y1-y0)*i/steps
+		img.SetRGBA(x, y, col)
+		img.SetRGBA(x, y+1, col)
+	}
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package challenge provides challenges that must be
+// solved before a form can be submitted, to keep bots
+// from signing up or brute-forcing sign-ins.
+package challenge
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// Kind is the kind of a challenge.
+// Kinds match the values used for
+// accounts-challenge-* config.
+type Kind string
+
+const (
+	KindPoW     Kind = config.AccountsChallengePoW
+	KindCaptcha Kind = config.AccountsChallengeCaptcha
+)
+
+// Challenge is a challenge issued to a client.
+// Challenges are stored server-side, and clients
+// only ever get back the ID, plus whatever they
+// need to solve the challenge (never the answer).
+type Challenge struct {
+	// Random ID of the challenge.
+	ID string
+
+	// Kind of the challenge.
+	Kind Kind
+
+	// Time after which the
+	// challenge can't be solved.
+	Expires time.Time
+
+	// Salt that, together with the answer, must
+	// hash to a value with Difficulty leading
+	// zero bits. Proof-of-work only.
+	Salt string
+
+	// Number of leading zero bits the
+	// hash must have. Proof-of-work only.
+	Difficulty int
+
+	// Text the client must read out of
+	// an image and type in. Captcha only.
+	Text string
+}
+
+// Expired returns true if the challenge
+// can no longer be solved.
+func (c *Challenge) Expired() bool {
+	return time.Now().After(c.Expires)
+}
+
+// Challenger issues and checks challenges of one kind.
+type Challenger interface {
+	// Issue returns a new challenge,
+	// which will expire after ttl.
+	Issue(ttl time.Duration) (*Challenge, error)
+
+	// Check returns true if answer solves c.
+	Check(c *Challenge, answer string) bool
+}
+
+// New returns the challenger for the given
+// kind, or an error if kind is not known.
+func New(kind Kind) (Challenger, error) {
+	switch kind {
+	case KindPoW:
+		return &PoW{Difficulty: config.GetAccountsChallengePoWDifficulty()}, nil
+	case KindCaptcha:
+		return &Captcha{}, nil
+	default:
+		return nil, fmt.Errorf("unknown challenge kind %q", kind)
+	}
+}
+
+// newChallenge returns a new challenge
+// of kind with a random ID, expiring
+// after ttl.
+func newChallenge(kind Kind, ttl time.Duration) (*Challenge, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Challenge{
+		ID:      id,
+		Kind:    kind,
+		Expires: time.Now().Add(ttl),
+	}, nil
+}
+
+// randomHex returns n random
+// bytes as a hex string.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error reading random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
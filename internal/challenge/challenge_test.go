@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenge_test
+
+import (
+	"bytes"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
+)
+
+type ChallengeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ChallengeTestSuite) TestPoW() {
+	pow := &challenge.PoW{Difficulty: 8}
+
+	c, err := pow.Issue(time.Minute)
+	suite.NoError(err)
+	suite.Equal(challenge.KindPoW, c.Kind)
+	suite.Equal(8, c.Difficulty)
+	suite.NotEmpty(c.ID)
+	suite.NotEmpty(c.Salt)
+	suite.False(c.Expired())
+
+	// Brute force an answer, like
+	// the browser would. At 8 bits
+	// this takes ~256 tries.
+	var answer string
+	for i := 0; i < 1<<20; i++ {
+		if pow.Check(c, strconv.Itoa(i)) {
+			answer = strconv.Itoa(i)
+			break
+		}
+	}
+	suite.NotEmpty(answer)
+
+	// Answer for one challenge should
+	// (almost certainly) not work for
+	// another challenge's salt.
+	other, err := pow.Issue(time.Minute)
+	suite.NoError(err)
+	other.Difficulty = 32
+	suite.False(pow.Check(other, answer))
+
+	// Garbage answers never check out.
+	suite.False(pow.Check(c, ""))
+	suite.False(pow.Check(c, "-1"))
+	suite.False(pow.Check(c, "0x1"))
+}
+
+func (suite *ChallengeTestSuite) TestCaptcha() {
+	captcha := &challenge.Captcha{}
+
+	c, err := captcha.Issue(time.Minute)
+	suite.NoError(err)
+	suite.Equal(challenge.KindCaptcha, c.Kind)
+	suite.Len(c.Text, 6)
+
+	// Case and whitespace
+	// shouldn't matter.
+	suite.True(captcha.Check(c, c.Text))
+	suite.True(captcha.Check(c, strings.ToLower(c.Text)))
+	suite.True(captcha.Check(c, " "+c.Text[:3]+" "+c.Text[3:]+"\n"))
+	suite.False(captcha.Check(c, ""))
+	suite.False(captcha.Check(c, c.Text[:5]))
+	suite.False(captcha.Check(c, c.Text+"A"))
+
+	// Image should be a valid PNG.
+	b, err := captcha.Image(c)
+	suite.NoError(err)
+	img, err := png.Decode(bytes.NewReader(b))
+	suite.NoError(err)
+	suite.Equal(216, img.Bounds().Dx())
+	suite.Equal(70, img.Bounds().Dy())
+}
+
+func (suite *ChallengeTestSuite) TestExpired() {
+	c, err := (&challenge.PoW{Difficulty: 1}).Issue(-time.Second)
+	suite.NoError(err)
+	suite.True(c.Expired())
+}
+
+func TestChallengeTestSuite(t *testing.T) {
+	suite.Run(t, new(ChallengeTestSuite))
+}
@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenge
+
+// captchaAlphabet contains the characters used in
+// captchas. Characters that are easily confused
+// with one another (0/O/Q, 1/I, 2/Z, 5/S, 8/B)
+// are left out, to be kinder to people.
+const captchaAlphabet = "ACDEFGHJKLMNPRTUVWXY34679"
+
+// captchaFont is a tiny 5x7 bitmap
+// font covering captchaAlphabet.
+var captchaFont = map[rune][7]string{
+	'A': {
+		".###.",
+		"#...#",
+		"#...#",
+		"#####",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'C': {
+		".###.",
+		"#...#",
+		"#....",
+		"#....",
+		"#....",
+		"#...#",
+		".###.",
+	},
+	'D': {
+		"####.",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"####.",
+	},
+	'E': {
+		"#####",
+		"#....",
+		"#....",
+		"####.",
+		"#....",
+		"#....",
+		"#####",
+	},
+	'F': {
+		"#####",
+		"#....",
+		"#....",
+		"####.",
+		"#....",
+		"#....",
+		"#....",
+	},
+	'G': {
+		".###.",
+		"#...#",
+		"#....",
+		"#.###",
+		"#...#",
+		"#...#",
+		".####",
+	},
+	'H': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#####",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'J': {
+		"..###",
+		"...#.",
+		"...#.",
+		"...#.",
+		"...#.",
+		"#..#.",
+		".##..",
+	},
+	'K': {
+		"#...#",
+		"#..#.",
+		"#.#..",
+		"##...",
+		"#.#..",
+		"#..#.",
+		"#...#",
+	},
+	'L': {
+		"#....",
+		"#....",
+		"#....",
+		"#....",
+		"#....",
+		"#....",
+		"#####",
+	},
+	'M': {
+		"#...#",
+		"##.##",
+		"#.#.#",
+		"#.#.#",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'N': {
+		"#...#",
+		"#...#",
+		"##..#",
+		"#.#.#",
+		"#..##",
+		"#...#",
+		"#...#",
+	},
+	'P': {
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+		"#....",
+		"#....",
+		"#....",
+	},
+	'R': {
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+		"#.#..",
+		"#..#.",
+		"#...#",
+	},
+	'T': {
+		"#####",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+	},
+	'U': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'V': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+	},
+	'W': {
+		"#...#",
+		"#...#",
+		"#...#",
+		"#.#.#",
+		"#.#.#",
+		"#.#.#",
+		".#.#.",
+	},
+	'X': {
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+		".#.#.",
+		"#...#",
+		"#...#",
+	},
+	'Y': {
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+	},
+	'3': {
+		"#####",
+		"...#.",
+		"..#..",
+		"...#.",
+		"....#",
+		"#...#",
+		".###.",
+	},
+	'4': {
+		"...#.",
+		"..##.",
+		".#.#.",
+		"#..#.",
+		"#####",
+		"...#.",
+		"...#.",
+	},
+	'6': {
+		"..##.",
+		".#...",
+		"#....",
+		"####.",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'7': {
+		"#####",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		".#...",
+		".#...",
+	},
+	'9': {
+		".###.",
+		"#...#",
+		"#...#",
+		".####",
+		"....#",
+		"...#.",
+		".##..",
+	},
+}
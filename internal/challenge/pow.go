@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenge
+
+import (
+	"crypto/sha256"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// PoW is a proof-of-work Challenger.
+//
+// Clients must find a number which, appended to
+// the challenge salt, gives a sha256 hash starting
+// with Difficulty zero bits. Finding that number
+// takes (on average) 2^Difficulty hashes, while
+// checking it takes just one; cheap for people
+// signing up once, but expensive for spam bots.
+type PoW struct {
+	// Number of leading zero bits
+	// the hash must have.
+	Difficulty int
+}
+
+// Issue implements Challenger.
+func (p *PoW) Issue(ttl time.Duration) (*Challenge, error) {
+	c, err := newChallenge(KindPoW, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Salt, err = randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Difficulty = p.Difficulty
+	return c, nil
+}
+
+// Check implements Challenger.
+func (p *PoW) Check(c *Challenge, answer string) bool {
+	// Answer must be a plain
+	// non-negative integer.
+	if _, err := strconv.ParseUint(answer, 10, 64); err != nil {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(c.Salt + answer))
+	return leadingZeroBits(sum[:]) >= c.Difficulty
+}
+
+// leadingZeroBits returns the number
+// of leading zero bits in b.
+func leadingZeroBits(b []byte) int {
+	var n int
+	for _, x := range b {
+		if x != 0 {
+			return n + bits.LeadingZeros8(x)
+		}
+		n += 8
+	}
+	return n
+}
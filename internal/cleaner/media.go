@@ -326,6 +326,32 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			l.Debug("missing db entry for emoji")
 			return true, nil
 		}
+
+	case media.TypeCard:
+		// Generate image URL for this card to lookup.
+		imageURL := uris.URIForAttachment(
+			pathParts[1], // instance account ID
+			string(media.TypeCard),
+			string(media.SizeSmall),
+			mediaID,
+			"jpg",
+		)
+
+		// Look for preview card in database stored by image URL.
+		// The media ID part of the storage key for cards changes
+		// on every recache of the image, so search by generated URL.
+		card, err := m.state.DB.GetPreviewCardByImageURL(
+			gtscontext.SetBarebones(ctx),
+			imageURL,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching preview card by url %s: %w", imageURL, err)
+		}
+
+		if card == nil {
+			l.Debug("missing db entry for preview card")
+			return true, nil
+		}
 	}
 
 	return false, nil
@@ -76,22 +76,41 @@ type Configuration struct {
 
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
+	WebLocaleBaseDir   string `name:"web-locale-base-dir" usage:"Directory to load message catalogues from, for translating web pages and emails."`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceFederationMode                string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter          bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceFederationSpamQuarantineScore int                `name:"instance-federation-spam-quarantine-score" usage:"Spam score at or above which incoming messages are held in a quarantine for admin review. 0 disables quarantine."`
+	InstanceFederationSpamRejectScore     int                `name:"instance-federation-spam-reject-score" usage:"Spam score at or above which incoming messages are dropped outright. 0 disables dropping."`
+	InstanceFederationBackfill            int                `name:"instance-federation-backfill" usage:"Number of recent statuses to fetch from a remote account's outbox when it is first discovered or followed. 0 disables backfill."`
+	InstanceExposePeers                   bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended               bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb            bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline          bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes        bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion         bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                     language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceTrendsEnabled                 bool               `name:"instance-trends-enabled" usage:"Compute trending hashtags, statuses and links from public statuses, and serve them at /api/v1/trends."`
+	InstanceTrendsReviewRequired          bool               `name:"instance-trends-review-required" usage:"Require trending items to be approved by an admin before they're shown publicly in trends."`
 
 	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsInvitesEnabled   bool `name:"accounts-invites-enabled" usage:"Allow accounts to create invites, which let people sign up even if registration is not open."`
+	AccountsInvitesModOnly   bool `name:"accounts-invites-mod-only" usage:"Only allow admins and moderators to create invites."`
+	AccountsInvitesApproved  bool `name:"accounts-invites-approved" usage:"Approve sign-ups made with a valid invite automatically, rather than waiting for an admin or moderator."`
 	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
 	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
+	AccountsChallengeSignUp           string        `name:"accounts-challenge-sign-up" usage:"Challenge that must be solved to submit the sign-up form: '' (none), 'pow' (proof-of-work), or 'captcha' (image captcha)."`
+	AccountsChallengeSignIn           string        `name:"accounts-challenge-sign-in" usage:"Challenge that must be solved to submit the sign-in form: '' (none), 'pow' (proof-of-work), or 'captcha' (image captcha)."`
+	AccountsChallengePoWDifficulty    int           `name:"accounts-challenge-pow-difficulty" usage:"Number of leading zero bits required of the hash in proof-of-work challenges. Each extra bit doubles the average work a browser must do."`
+	AccountsChallengeCooldownAttempts int           `name:"accounts-challenge-cooldown-attempts" usage:"Number of failed challenges or sign-ins allowed from one IP address before it must wait to try again. 0 to disable."`
+	AccountsChallengeCooldown         time.Duration `name:"accounts-challenge-cooldown" usage:"How long an IP address must wait to try again after too many failed challenges or sign-ins."`
+
+	AccountsDormantMonths      int           `name:"accounts-dormant-months" usage:"Number of months without signing in or posting after which a local account is considered dormant, and its user is emailed a warning. 0 to disable."`
+	AccountsDormantDisable     bool          `name:"accounts-dormant-disable" usage:"Disable dormant accounts if they're still not used after accounts-dormant-grace-period has passed since the warning email."`
+	AccountsDormantGracePeriod time.Duration `name:"accounts-dormant-grace-period" usage:"How long after being warned a dormant account is disabled, if accounts-dormant-disable is true."`
+
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
@@ -151,6 +170,10 @@ type Configuration struct {
 	SMTPPassword           string `name:"smtp-password" usage:"Password to pass to the smtp server."`
 	SMTPFrom               string `name:"smtp-from" usage:"Address to use as the 'from' field of the email. Eg., 'gotosocial@example.org'"`
 	SMTPDiscloseRecipients bool   `name:"smtp-disclose-recipients" usage:"If true, email notifications sent to multiple recipients will be To'd to every recipient at once. If false, recipients will not be disclosed"`
+	SMTPDKIMDomain         string `name:"smtp-dkim-domain" usage:"Domain to sign outgoing emails for with DKIM. Eg., 'example.org'. If not set, emails will not be DKIM signed"`
+	SMTPDKIMSelector       string `name:"smtp-dkim-selector" usage:"DKIM selector under which the public key is published in DNS. Eg., 'gotosocial'"`
+	SMTPDKIMPrivateKeyPath string `name:"smtp-dkim-private-key-path" usage:"Path to a PEM encoded RSA or Ed25519 private key to use for DKIM signing"`
+	SMTPMaildir            string `name:"smtp-maildir" usage:"Path to a maildir directory. If set, emails will be written to this directory instead of sent via smtp. Useful for testing"`
 
 	SyslogEnabled  bool   `name:"syslog-enabled" usage:"Enable the syslog logging hook. Logs will be mirrored to the configured destination."`
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
@@ -222,12 +245,15 @@ type CacheConfiguration struct {
 	PollMemRatio              float64       `name:"poll-mem-ratio"`
 	PollVoteMemRatio          float64       `name:"poll-vote-mem-ratio"`
 	PollVoteIDsMemRatio       float64       `name:"poll-vote-ids-mem-ratio"`
+	PreviewCardMemRatio       float64       `name:"preview-card-mem-ratio"`
 	ReportMemRatio            float64       `name:"report-mem-ratio"`
 	StatusMemRatio            float64       `name:"status-mem-ratio"`
 	StatusBookmarkMemRatio    float64       `name:"status-bookmark-mem-ratio"`
 	StatusBookmarkIDsMemRatio float64       `name:"status-bookmark-ids-mem-ratio"`
 	StatusFaveMemRatio        float64       `name:"status-fave-mem-ratio"`
 	StatusFaveIDsMemRatio     float64       `name:"status-fave-ids-mem-ratio"`
+	StatusReactionMemRatio    float64       `name:"status-reaction-mem-ratio"`
+	StatusReactionIDsMemRatio float64       `name:"status-reaction-ids-mem-ratio"`
 	TagMemRatio               float64       `name:"tag-mem-ratio"`
 	ThreadMuteMemRatio        float64       `name:"thread-mute-mem-ratio"`
 	TokenMemRatio             float64       `name:"token-mem-ratio"`
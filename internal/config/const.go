@@ -29,4 +29,10 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Accounts challenge determines which challenge,
+	// if any, must be solved to submit a form.
+	AccountsChallengeNone    = ""
+	AccountsChallengePoW     = "pow"
+	AccountsChallengeCaptcha = "captcha"
 )
@@ -57,20 +57,39 @@ var Defaults = Configuration{
 
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
-
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	WebLocaleBaseDir:   "./web/locale/",
+
+	InstanceFederationMode:                InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:          false,
+	InstanceFederationSpamQuarantineScore: 50,
+	InstanceFederationSpamRejectScore:     100,
+	InstanceFederationBackfill:            20,
+	InstanceExposePeers:                   false,
+	InstanceExposeSuspended:               false,
+	InstanceExposeSuspendedWeb:            false,
+	InstanceDeliverToSharedInboxes:        true,
+	InstanceLanguages:                     make(language.Languages, 0),
+	InstanceTrendsEnabled:                 true,
+	InstanceTrendsReviewRequired:          true,
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
+	AccountsInvitesEnabled:   false,
+	AccountsInvitesModOnly:   true,
+	AccountsInvitesApproved:  false,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
+	AccountsChallengeSignUp:           "",
+	AccountsChallengeSignIn:           "",
+	AccountsChallengePoWDifficulty:    17,
+	AccountsChallengeCooldownAttempts: 5,
+	AccountsChallengeCooldown:         15 * time.Minute,
+
+	AccountsDormantMonths:      0,
+	AccountsDormantDisable:     false,
+	AccountsDormantGracePeriod: 30 * 24 * time.Hour,
+
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
 	MediaDescriptionMinChars: 0,
@@ -114,6 +133,10 @@ var Defaults = Configuration{
 	SMTPPassword:           "",
 	SMTPFrom:               "",
 	SMTPDiscloseRecipients: false,
+	SMTPDKIMDomain:         "",
+	SMTPDKIMSelector:       "",
+	SMTPDKIMPrivateKeyPath: "",
+	SMTPMaildir:            "",
 
 	TracingEnabled:           false,
 	TracingTransport:         "grpc",
@@ -186,12 +209,15 @@ var Defaults = Configuration{
 		PollMemRatio:              1,
 		PollVoteMemRatio:          2,
 		PollVoteIDsMemRatio:       2,
+		PreviewCardMemRatio:       1,
 		ReportMemRatio:            1,
 		StatusMemRatio:            5,
 		StatusBookmarkMemRatio:    0.5,
 		StatusBookmarkIDsMemRatio: 2,
 		StatusFaveMemRatio:        2,
 		StatusFaveIDsMemRatio:     3,
+		StatusReactionMemRatio:    1,
+		StatusReactionIDsMemRatio: 2,
 		TagMemRatio:               2,
 		ThreadMuteMemRatio:        0.2,
 		TokenMemRatio:             0.75,
@@ -81,20 +81,37 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Template
 		cmd.Flags().String(WebTemplateBaseDirFlag(), cfg.WebTemplateBaseDir, fieldtag("WebTemplateBaseDir", "usage"))
 		cmd.Flags().String(WebAssetBaseDirFlag(), cfg.WebAssetBaseDir, fieldtag("WebAssetBaseDir", "usage"))
+		cmd.Flags().String(WebLocaleBaseDirFlag(), cfg.WebLocaleBaseDir, fieldtag("WebLocaleBaseDir", "usage"))
 
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamQuarantineScoreFlag(), cfg.InstanceFederationSpamQuarantineScore, fieldtag("InstanceFederationSpamQuarantineScore", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamRejectScoreFlag(), cfg.InstanceFederationSpamRejectScore, fieldtag("InstanceFederationSpamRejectScore", "usage"))
+		cmd.Flags().Int(InstanceFederationBackfillFlag(), cfg.InstanceFederationBackfill, fieldtag("InstanceFederationBackfill", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
+		cmd.Flags().Bool(InstanceTrendsEnabledFlag(), cfg.InstanceTrendsEnabled, fieldtag("InstanceTrendsEnabled", "usage"))
+		cmd.Flags().Bool(InstanceTrendsReviewRequiredFlag(), cfg.InstanceTrendsReviewRequired, fieldtag("InstanceTrendsReviewRequired", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
+		cmd.Flags().Bool(AccountsInvitesEnabledFlag(), cfg.AccountsInvitesEnabled, fieldtag("AccountsInvitesEnabled", "usage"))
+		cmd.Flags().Bool(AccountsInvitesModOnlyFlag(), cfg.AccountsInvitesModOnly, fieldtag("AccountsInvitesModOnly", "usage"))
+		cmd.Flags().Bool(AccountsInvitesApprovedFlag(), cfg.AccountsInvitesApproved, fieldtag("AccountsInvitesApproved", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().String(AccountsChallengeSignUpFlag(), cfg.AccountsChallengeSignUp, fieldtag("AccountsChallengeSignUp", "usage"))
+		cmd.Flags().String(AccountsChallengeSignInFlag(), cfg.AccountsChallengeSignIn, fieldtag("AccountsChallengeSignIn", "usage"))
+		cmd.Flags().Int(AccountsChallengePoWDifficultyFlag(), cfg.AccountsChallengePoWDifficulty, fieldtag("AccountsChallengePoWDifficulty", "usage"))
+		cmd.Flags().Int(AccountsChallengeCooldownAttemptsFlag(), cfg.AccountsChallengeCooldownAttempts, fieldtag("AccountsChallengeCooldownAttempts", "usage"))
+		cmd.Flags().Duration(AccountsChallengeCooldownFlag(), cfg.AccountsChallengeCooldown, fieldtag("AccountsChallengeCooldown", "usage"))
+		cmd.Flags().Int(AccountsDormantMonthsFlag(), cfg.AccountsDormantMonths, fieldtag("AccountsDormantMonths", "usage"))
+		cmd.Flags().Bool(AccountsDormantDisableFlag(), cfg.AccountsDormantDisable, fieldtag("AccountsDormantDisable", "usage"))
+		cmd.Flags().Duration(AccountsDormantGracePeriodFlag(), cfg.AccountsDormantGracePeriod, fieldtag("AccountsDormantGracePeriod", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
@@ -143,6 +160,10 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(SMTPPasswordFlag(), cfg.SMTPPassword, fieldtag("SMTPPassword", "usage"))
 		cmd.Flags().String(SMTPFromFlag(), cfg.SMTPFrom, fieldtag("SMTPFrom", "usage"))
 		cmd.Flags().Bool(SMTPDiscloseRecipientsFlag(), cfg.SMTPDiscloseRecipients, fieldtag("SMTPDiscloseRecipients", "usage"))
+		cmd.Flags().String(SMTPDKIMDomainFlag(), cfg.SMTPDKIMDomain, fieldtag("SMTPDKIMDomain", "usage"))
+		cmd.Flags().String(SMTPDKIMSelectorFlag(), cfg.SMTPDKIMSelector, fieldtag("SMTPDKIMSelector", "usage"))
+		cmd.Flags().String(SMTPDKIMPrivateKeyPathFlag(), cfg.SMTPDKIMPrivateKeyPath, fieldtag("SMTPDKIMPrivateKeyPath", "usage"))
+		cmd.Flags().String(SMTPMaildirFlag(), cfg.SMTPMaildir, fieldtag("SMTPMaildir", "usage"))
 
 		// Syslog
 		cmd.Flags().Bool(SyslogEnabledFlag(), cfg.SyslogEnabled, fieldtag("SyslogEnabled", "usage"))
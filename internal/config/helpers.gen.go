@@ -750,6 +750,31 @@ func GetWebAssetBaseDir() string { return global.GetWebAssetBaseDir() }
 // SetWebAssetBaseDir safely sets the value for global configuration 'WebAssetBaseDir' field
 func SetWebAssetBaseDir(v string) { global.SetWebAssetBaseDir(v) }
 
+// GetWebLocaleBaseDir safely fetches the Configuration value for state's 'WebLocaleBaseDir' field
+func (st *ConfigState) GetWebLocaleBaseDir() (v string) {
+	st.mutex.RLock()
+	v = st.config.WebLocaleBaseDir
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebLocaleBaseDir safely sets the Configuration value for state's 'WebLocaleBaseDir' field
+func (st *ConfigState) SetWebLocaleBaseDir(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebLocaleBaseDir = v
+	st.reloadToViper()
+}
+
+// WebLocaleBaseDirFlag returns the flag name for the 'WebLocaleBaseDir' field
+func WebLocaleBaseDirFlag() string { return "web-locale-base-dir" }
+
+// GetWebLocaleBaseDir safely fetches the value for global configuration 'WebLocaleBaseDir' field
+func GetWebLocaleBaseDir() string { return global.GetWebLocaleBaseDir() }
+
+// SetWebLocaleBaseDir safely sets the value for global configuration 'WebLocaleBaseDir' field
+func SetWebLocaleBaseDir(v string) { global.SetWebLocaleBaseDir(v) }
+
 // GetInstanceFederationMode safely fetches the Configuration value for state's 'InstanceFederationMode' field
 func (st *ConfigState) GetInstanceFederationMode() (v string) {
 	st.mutex.RLock()
@@ -800,6 +825,87 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationSpamQuarantineScore safely fetches the Configuration value for state's 'InstanceFederationSpamQuarantineScore' field
+func (st *ConfigState) GetInstanceFederationSpamQuarantineScore() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamQuarantineScore
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamQuarantineScore safely sets the Configuration value for state's 'InstanceFederationSpamQuarantineScore' field
+func (st *ConfigState) SetInstanceFederationSpamQuarantineScore(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamQuarantineScore = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamQuarantineScoreFlag returns the flag name for the 'InstanceFederationSpamQuarantineScore' field
+func InstanceFederationSpamQuarantineScoreFlag() string {
+	return "instance-federation-spam-quarantine-score"
+}
+
+// GetInstanceFederationSpamQuarantineScore safely fetches the value for global configuration 'InstanceFederationSpamQuarantineScore' field
+func GetInstanceFederationSpamQuarantineScore() int {
+	return global.GetInstanceFederationSpamQuarantineScore()
+}
+
+// SetInstanceFederationSpamQuarantineScore safely sets the value for global configuration 'InstanceFederationSpamQuarantineScore' field
+func SetInstanceFederationSpamQuarantineScore(v int) {
+	global.SetInstanceFederationSpamQuarantineScore(v)
+}
+
+// GetInstanceFederationSpamRejectScore safely fetches the Configuration value for state's 'InstanceFederationSpamRejectScore' field
+func (st *ConfigState) GetInstanceFederationSpamRejectScore() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamRejectScore
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamRejectScore safely sets the Configuration value for state's 'InstanceFederationSpamRejectScore' field
+func (st *ConfigState) SetInstanceFederationSpamRejectScore(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamRejectScore = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamRejectScoreFlag returns the flag name for the 'InstanceFederationSpamRejectScore' field
+func InstanceFederationSpamRejectScoreFlag() string { return "instance-federation-spam-reject-score" }
+
+// GetInstanceFederationSpamRejectScore safely fetches the value for global configuration 'InstanceFederationSpamRejectScore' field
+func GetInstanceFederationSpamRejectScore() int { return global.GetInstanceFederationSpamRejectScore() }
+
+// SetInstanceFederationSpamRejectScore safely sets the value for global configuration 'InstanceFederationSpamRejectScore' field
+func SetInstanceFederationSpamRejectScore(v int) { global.SetInstanceFederationSpamRejectScore(v) }
+
+// GetInstanceFederationBackfill safely fetches the Configuration value for state's 'InstanceFederationBackfill' field
+func (st *ConfigState) GetInstanceFederationBackfill() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationBackfill
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationBackfill safely sets the Configuration value for state's 'InstanceFederationBackfill' field
+func (st *ConfigState) SetInstanceFederationBackfill(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationBackfill = v
+	st.reloadToViper()
+}
+
+// InstanceFederationBackfillFlag returns the flag name for the 'InstanceFederationBackfill' field
+func InstanceFederationBackfillFlag() string { return "instance-federation-backfill" }
+
+// GetInstanceFederationBackfill safely fetches the value for global configuration 'InstanceFederationBackfill' field
+func GetInstanceFederationBackfill() int { return global.GetInstanceFederationBackfill() }
+
+// SetInstanceFederationBackfill safely sets the value for global configuration 'InstanceFederationBackfill' field
+func SetInstanceFederationBackfill(v int) { global.SetInstanceFederationBackfill(v) }
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -975,6 +1081,56 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceTrendsEnabled safely fetches the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) GetInstanceTrendsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceTrendsEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceTrendsEnabled safely sets the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) SetInstanceTrendsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceTrendsEnabled = v
+	st.reloadToViper()
+}
+
+// InstanceTrendsEnabledFlag returns the flag name for the 'InstanceTrendsEnabled' field
+func InstanceTrendsEnabledFlag() string { return "instance-trends-enabled" }
+
+// GetInstanceTrendsEnabled safely fetches the value for global configuration 'InstanceTrendsEnabled' field
+func GetInstanceTrendsEnabled() bool { return global.GetInstanceTrendsEnabled() }
+
+// SetInstanceTrendsEnabled safely sets the value for global configuration 'InstanceTrendsEnabled' field
+func SetInstanceTrendsEnabled(v bool) { global.SetInstanceTrendsEnabled(v) }
+
+// GetInstanceTrendsReviewRequired safely fetches the Configuration value for state's 'InstanceTrendsReviewRequired' field
+func (st *ConfigState) GetInstanceTrendsReviewRequired() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceTrendsReviewRequired
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceTrendsReviewRequired safely sets the Configuration value for state's 'InstanceTrendsReviewRequired' field
+func (st *ConfigState) SetInstanceTrendsReviewRequired(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceTrendsReviewRequired = v
+	st.reloadToViper()
+}
+
+// InstanceTrendsReviewRequiredFlag returns the flag name for the 'InstanceTrendsReviewRequired' field
+func InstanceTrendsReviewRequiredFlag() string { return "instance-trends-review-required" }
+
+// GetInstanceTrendsReviewRequired safely fetches the value for global configuration 'InstanceTrendsReviewRequired' field
+func GetInstanceTrendsReviewRequired() bool { return global.GetInstanceTrendsReviewRequired() }
+
+// SetInstanceTrendsReviewRequired safely sets the value for global configuration 'InstanceTrendsReviewRequired' field
+func SetInstanceTrendsReviewRequired(v bool) { global.SetInstanceTrendsReviewRequired(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1025,6 +1181,81 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
+// GetAccountsInvitesEnabled safely fetches the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) GetAccountsInvitesEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesEnabled safely sets the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) SetAccountsInvitesEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesEnabled = v
+	st.reloadToViper()
+}
+
+// AccountsInvitesEnabledFlag returns the flag name for the 'AccountsInvitesEnabled' field
+func AccountsInvitesEnabledFlag() string { return "accounts-invites-enabled" }
+
+// GetAccountsInvitesEnabled safely fetches the value for global configuration 'AccountsInvitesEnabled' field
+func GetAccountsInvitesEnabled() bool { return global.GetAccountsInvitesEnabled() }
+
+// SetAccountsInvitesEnabled safely sets the value for global configuration 'AccountsInvitesEnabled' field
+func SetAccountsInvitesEnabled(v bool) { global.SetAccountsInvitesEnabled(v) }
+
+// GetAccountsInvitesModOnly safely fetches the Configuration value for state's 'AccountsInvitesModOnly' field
+func (st *ConfigState) GetAccountsInvitesModOnly() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesModOnly
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesModOnly safely sets the Configuration value for state's 'AccountsInvitesModOnly' field
+func (st *ConfigState) SetAccountsInvitesModOnly(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesModOnly = v
+	st.reloadToViper()
+}
+
+// AccountsInvitesModOnlyFlag returns the flag name for the 'AccountsInvitesModOnly' field
+func AccountsInvitesModOnlyFlag() string { return "accounts-invites-mod-only" }
+
+// GetAccountsInvitesModOnly safely fetches the value for global configuration 'AccountsInvitesModOnly' field
+func GetAccountsInvitesModOnly() bool { return global.GetAccountsInvitesModOnly() }
+
+// SetAccountsInvitesModOnly safely sets the value for global configuration 'AccountsInvitesModOnly' field
+func SetAccountsInvitesModOnly(v bool) { global.SetAccountsInvitesModOnly(v) }
+
+// GetAccountsInvitesApproved safely fetches the Configuration value for state's 'AccountsInvitesApproved' field
+func (st *ConfigState) GetAccountsInvitesApproved() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesApproved
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesApproved safely sets the Configuration value for state's 'AccountsInvitesApproved' field
+func (st *ConfigState) SetAccountsInvitesApproved(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesApproved = v
+	st.reloadToViper()
+}
+
+// AccountsInvitesApprovedFlag returns the flag name for the 'AccountsInvitesApproved' field
+func AccountsInvitesApprovedFlag() string { return "accounts-invites-approved" }
+
+// GetAccountsInvitesApproved safely fetches the value for global configuration 'AccountsInvitesApproved' field
+func GetAccountsInvitesApproved() bool { return global.GetAccountsInvitesApproved() }
+
+// SetAccountsInvitesApproved safely sets the value for global configuration 'AccountsInvitesApproved' field
+func SetAccountsInvitesApproved(v bool) { global.SetAccountsInvitesApproved(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1075,6 +1306,206 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsChallengeSignUp safely fetches the Configuration value for state's 'AccountsChallengeSignUp' field
+func (st *ConfigState) GetAccountsChallengeSignUp() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsChallengeSignUp
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsChallengeSignUp safely sets the Configuration value for state's 'AccountsChallengeSignUp' field
+func (st *ConfigState) SetAccountsChallengeSignUp(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsChallengeSignUp = v
+	st.reloadToViper()
+}
+
+// AccountsChallengeSignUpFlag returns the flag name for the 'AccountsChallengeSignUp' field
+func AccountsChallengeSignUpFlag() string { return "accounts-challenge-sign-up" }
+
+// GetAccountsChallengeSignUp safely fetches the value for global configuration 'AccountsChallengeSignUp' field
+func GetAccountsChallengeSignUp() string { return global.GetAccountsChallengeSignUp() }
+
+// SetAccountsChallengeSignUp safely sets the value for global configuration 'AccountsChallengeSignUp' field
+func SetAccountsChallengeSignUp(v string) { global.SetAccountsChallengeSignUp(v) }
+
+// GetAccountsChallengeSignIn safely fetches the Configuration value for state's 'AccountsChallengeSignIn' field
+func (st *ConfigState) GetAccountsChallengeSignIn() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsChallengeSignIn
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsChallengeSignIn safely sets the Configuration value for state's 'AccountsChallengeSignIn' field
+func (st *ConfigState) SetAccountsChallengeSignIn(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsChallengeSignIn = v
+	st.reloadToViper()
+}
+
+// AccountsChallengeSignInFlag returns the flag name for the 'AccountsChallengeSignIn' field
+func AccountsChallengeSignInFlag() string { return "accounts-challenge-sign-in" }
+
+// GetAccountsChallengeSignIn safely fetches the value for global configuration 'AccountsChallengeSignIn' field
+func GetAccountsChallengeSignIn() string { return global.GetAccountsChallengeSignIn() }
+
+// SetAccountsChallengeSignIn safely sets the value for global configuration 'AccountsChallengeSignIn' field
+func SetAccountsChallengeSignIn(v string) { global.SetAccountsChallengeSignIn(v) }
+
+// GetAccountsChallengePoWDifficulty safely fetches the Configuration value for state's 'AccountsChallengePoWDifficulty' field
+func (st *ConfigState) GetAccountsChallengePoWDifficulty() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsChallengePoWDifficulty
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsChallengePoWDifficulty safely sets the Configuration value for state's 'AccountsChallengePoWDifficulty' field
+func (st *ConfigState) SetAccountsChallengePoWDifficulty(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsChallengePoWDifficulty = v
+	st.reloadToViper()
+}
+
+// AccountsChallengePoWDifficultyFlag returns the flag name for the 'AccountsChallengePoWDifficulty' field
+func AccountsChallengePoWDifficultyFlag() string { return "accounts-challenge-pow-difficulty" }
+
+// GetAccountsChallengePoWDifficulty safely fetches the value for global configuration 'AccountsChallengePoWDifficulty' field
+func GetAccountsChallengePoWDifficulty() int { return global.GetAccountsChallengePoWDifficulty() }
+
+// SetAccountsChallengePoWDifficulty safely sets the value for global configuration 'AccountsChallengePoWDifficulty' field
+func SetAccountsChallengePoWDifficulty(v int) { global.SetAccountsChallengePoWDifficulty(v) }
+
+// GetAccountsChallengeCooldownAttempts safely fetches the Configuration value for state's 'AccountsChallengeCooldownAttempts' field
+func (st *ConfigState) GetAccountsChallengeCooldownAttempts() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsChallengeCooldownAttempts
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsChallengeCooldownAttempts safely sets the Configuration value for state's 'AccountsChallengeCooldownAttempts' field
+func (st *ConfigState) SetAccountsChallengeCooldownAttempts(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsChallengeCooldownAttempts = v
+	st.reloadToViper()
+}
+
+// AccountsChallengeCooldownAttemptsFlag returns the flag name for the 'AccountsChallengeCooldownAttempts' field
+func AccountsChallengeCooldownAttemptsFlag() string { return "accounts-challenge-cooldown-attempts" }
+
+// GetAccountsChallengeCooldownAttempts safely fetches the value for global configuration 'AccountsChallengeCooldownAttempts' field
+func GetAccountsChallengeCooldownAttempts() int { return global.GetAccountsChallengeCooldownAttempts() }
+
+// SetAccountsChallengeCooldownAttempts safely sets the value for global configuration 'AccountsChallengeCooldownAttempts' field
+func SetAccountsChallengeCooldownAttempts(v int) { global.SetAccountsChallengeCooldownAttempts(v) }
+
+// GetAccountsChallengeCooldown safely fetches the Configuration value for state's 'AccountsChallengeCooldown' field
+func (st *ConfigState) GetAccountsChallengeCooldown() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AccountsChallengeCooldown
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsChallengeCooldown safely sets the Configuration value for state's 'AccountsChallengeCooldown' field
+func (st *ConfigState) SetAccountsChallengeCooldown(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsChallengeCooldown = v
+	st.reloadToViper()
+}
+
+// AccountsChallengeCooldownFlag returns the flag name for the 'AccountsChallengeCooldown' field
+func AccountsChallengeCooldownFlag() string { return "accounts-challenge-cooldown" }
+
+// GetAccountsChallengeCooldown safely fetches the value for global configuration 'AccountsChallengeCooldown' field
+func GetAccountsChallengeCooldown() time.Duration { return global.GetAccountsChallengeCooldown() }
+
+// SetAccountsChallengeCooldown safely sets the value for global configuration 'AccountsChallengeCooldown' field
+func SetAccountsChallengeCooldown(v time.Duration) { global.SetAccountsChallengeCooldown(v) }
+
+// GetAccountsDormantMonths safely fetches the Configuration value for state's 'AccountsDormantMonths' field
+func (st *ConfigState) GetAccountsDormantMonths() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsDormantMonths
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsDormantMonths safely sets the Configuration value for state's 'AccountsDormantMonths' field
+func (st *ConfigState) SetAccountsDormantMonths(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDormantMonths = v
+	st.reloadToViper()
+}
+
+// AccountsDormantMonthsFlag returns the flag name for the 'AccountsDormantMonths' field
+func AccountsDormantMonthsFlag() string { return "accounts-dormant-months" }
+
+// GetAccountsDormantMonths safely fetches the value for global configuration 'AccountsDormantMonths' field
+func GetAccountsDormantMonths() int { return global.GetAccountsDormantMonths() }
+
+// SetAccountsDormantMonths safely sets the value for global configuration 'AccountsDormantMonths' field
+func SetAccountsDormantMonths(v int) { global.SetAccountsDormantMonths(v) }
+
+// GetAccountsDormantDisable safely fetches the Configuration value for state's 'AccountsDormantDisable' field
+func (st *ConfigState) GetAccountsDormantDisable() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsDormantDisable
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsDormantDisable safely sets the Configuration value for state's 'AccountsDormantDisable' field
+func (st *ConfigState) SetAccountsDormantDisable(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDormantDisable = v
+	st.reloadToViper()
+}
+
+// AccountsDormantDisableFlag returns the flag name for the 'AccountsDormantDisable' field
+func AccountsDormantDisableFlag() string { return "accounts-dormant-disable" }
+
+// GetAccountsDormantDisable safely fetches the value for global configuration 'AccountsDormantDisable' field
+func GetAccountsDormantDisable() bool { return global.GetAccountsDormantDisable() }
+
+// SetAccountsDormantDisable safely sets the value for global configuration 'AccountsDormantDisable' field
+func SetAccountsDormantDisable(v bool) { global.SetAccountsDormantDisable(v) }
+
+// GetAccountsDormantGracePeriod safely fetches the Configuration value for state's 'AccountsDormantGracePeriod' field
+func (st *ConfigState) GetAccountsDormantGracePeriod() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AccountsDormantGracePeriod
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsDormantGracePeriod safely sets the Configuration value for state's 'AccountsDormantGracePeriod' field
+func (st *ConfigState) SetAccountsDormantGracePeriod(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDormantGracePeriod = v
+	st.reloadToViper()
+}
+
+// AccountsDormantGracePeriodFlag returns the flag name for the 'AccountsDormantGracePeriod' field
+func AccountsDormantGracePeriodFlag() string { return "accounts-dormant-grace-period" }
+
+// GetAccountsDormantGracePeriod safely fetches the value for global configuration 'AccountsDormantGracePeriod' field
+func GetAccountsDormantGracePeriod() time.Duration { return global.GetAccountsDormantGracePeriod() }
+
+// SetAccountsDormantGracePeriod safely sets the value for global configuration 'AccountsDormantGracePeriod' field
+func SetAccountsDormantGracePeriod(v time.Duration) { global.SetAccountsDormantGracePeriod(v) }
+
 // GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
 func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -2350,6 +2781,106 @@ func GetSMTPDiscloseRecipients() bool { return global.GetSMTPDiscloseRecipients(
 // SetSMTPDiscloseRecipients safely sets the value for global configuration 'SMTPDiscloseRecipients' field
 func SetSMTPDiscloseRecipients(v bool) { global.SetSMTPDiscloseRecipients(v) }
 
+// GetSMTPDKIMDomain safely fetches the Configuration value for state's 'SMTPDKIMDomain' field
+func (st *ConfigState) GetSMTPDKIMDomain() (v string) {
+	st.mutex.RLock()
+	v = st.config.SMTPDKIMDomain
+	st.mutex.RUnlock()
+	return
+}
+
+// SetSMTPDKIMDomain safely sets the Configuration value for state's 'SMTPDKIMDomain' field
+func (st *ConfigState) SetSMTPDKIMDomain(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SMTPDKIMDomain = v
+	st.reloadToViper()
+}
+
+// SMTPDKIMDomainFlag returns the flag name for the 'SMTPDKIMDomain' field
+func SMTPDKIMDomainFlag() string { return "smtp-dkim-domain" }
+
+// GetSMTPDKIMDomain safely fetches the value for global configuration 'SMTPDKIMDomain' field
+func GetSMTPDKIMDomain() string { return global.GetSMTPDKIMDomain() }
+
+// SetSMTPDKIMDomain safely sets the value for global configuration 'SMTPDKIMDomain' field
+func SetSMTPDKIMDomain(v string) { global.SetSMTPDKIMDomain(v) }
+
+// GetSMTPDKIMSelector safely fetches the Configuration value for state's 'SMTPDKIMSelector' field
+func (st *ConfigState) GetSMTPDKIMSelector() (v string) {
+	st.mutex.RLock()
+	v = st.config.SMTPDKIMSelector
+	st.mutex.RUnlock()
+	return
+}
+
+// SetSMTPDKIMSelector safely sets the Configuration value for state's 'SMTPDKIMSelector' field
+func (st *ConfigState) SetSMTPDKIMSelector(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SMTPDKIMSelector = v
+	st.reloadToViper()
+}
+
+// SMTPDKIMSelectorFlag returns the flag name for the 'SMTPDKIMSelector' field
+func SMTPDKIMSelectorFlag() string { return "smtp-dkim-selector" }
+
+// GetSMTPDKIMSelector safely fetches the value for global configuration 'SMTPDKIMSelector' field
+func GetSMTPDKIMSelector() string { return global.GetSMTPDKIMSelector() }
+
+// SetSMTPDKIMSelector safely sets the value for global configuration 'SMTPDKIMSelector' field
+func SetSMTPDKIMSelector(v string) { global.SetSMTPDKIMSelector(v) }
+
+// GetSMTPDKIMPrivateKeyPath safely fetches the Configuration value for state's 'SMTPDKIMPrivateKeyPath' field
+func (st *ConfigState) GetSMTPDKIMPrivateKeyPath() (v string) {
+	st.mutex.RLock()
+	v = st.config.SMTPDKIMPrivateKeyPath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetSMTPDKIMPrivateKeyPath safely sets the Configuration value for state's 'SMTPDKIMPrivateKeyPath' field
+func (st *ConfigState) SetSMTPDKIMPrivateKeyPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SMTPDKIMPrivateKeyPath = v
+	st.reloadToViper()
+}
+
+// SMTPDKIMPrivateKeyPathFlag returns the flag name for the 'SMTPDKIMPrivateKeyPath' field
+func SMTPDKIMPrivateKeyPathFlag() string { return "smtp-dkim-private-key-path" }
+
+// GetSMTPDKIMPrivateKeyPath safely fetches the value for global configuration 'SMTPDKIMPrivateKeyPath' field
+func GetSMTPDKIMPrivateKeyPath() string { return global.GetSMTPDKIMPrivateKeyPath() }
+
+// SetSMTPDKIMPrivateKeyPath safely sets the value for global configuration 'SMTPDKIMPrivateKeyPath' field
+func SetSMTPDKIMPrivateKeyPath(v string) { global.SetSMTPDKIMPrivateKeyPath(v) }
+
+// GetSMTPMaildir safely fetches the Configuration value for state's 'SMTPMaildir' field
+func (st *ConfigState) GetSMTPMaildir() (v string) {
+	st.mutex.RLock()
+	v = st.config.SMTPMaildir
+	st.mutex.RUnlock()
+	return
+}
+
+// SetSMTPMaildir safely sets the Configuration value for state's 'SMTPMaildir' field
+func (st *ConfigState) SetSMTPMaildir(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SMTPMaildir = v
+	st.reloadToViper()
+}
+
+// SMTPMaildirFlag returns the flag name for the 'SMTPMaildir' field
+func SMTPMaildirFlag() string { return "smtp-maildir" }
+
+// GetSMTPMaildir safely fetches the value for global configuration 'SMTPMaildir' field
+func GetSMTPMaildir() string { return global.GetSMTPMaildir() }
+
+// SetSMTPMaildir safely sets the value for global configuration 'SMTPMaildir' field
+func SetSMTPMaildir(v string) { global.SetSMTPMaildir(v) }
+
 // GetSyslogEnabled safely fetches the Configuration value for state's 'SyslogEnabled' field
 func (st *ConfigState) GetSyslogEnabled() (v bool) {
 	st.mutex.RLock()
@@ -3500,6 +4031,31 @@ func GetCachePollVoteIDsMemRatio() float64 { return global.GetCachePollVoteIDsMe
 // SetCachePollVoteIDsMemRatio safely sets the value for global configuration 'Cache.PollVoteIDsMemRatio' field
 func SetCachePollVoteIDsMemRatio(v float64) { global.SetCachePollVoteIDsMemRatio(v) }
 
+// GetCachePreviewCardMemRatio safely fetches the Configuration value for state's 'Cache.PreviewCardMemRatio' field
+func (st *ConfigState) GetCachePreviewCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.PreviewCardMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCachePreviewCardMemRatio safely sets the Configuration value for state's 'Cache.PreviewCardMemRatio' field
+func (st *ConfigState) SetCachePreviewCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.PreviewCardMemRatio = v
+	st.reloadToViper()
+}
+
+// CachePreviewCardMemRatioFlag returns the flag name for the 'Cache.PreviewCardMemRatio' field
+func CachePreviewCardMemRatioFlag() string { return "cache-preview-card-mem-ratio" }
+
+// GetCachePreviewCardMemRatio safely fetches the value for global configuration 'Cache.PreviewCardMemRatio' field
+func GetCachePreviewCardMemRatio() float64 { return global.GetCachePreviewCardMemRatio() }
+
+// SetCachePreviewCardMemRatio safely sets the value for global configuration 'Cache.PreviewCardMemRatio' field
+func SetCachePreviewCardMemRatio(v float64) { global.SetCachePreviewCardMemRatio(v) }
+
 // GetCacheReportMemRatio safely fetches the Configuration value for state's 'Cache.ReportMemRatio' field
 func (st *ConfigState) GetCacheReportMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3650,6 +4206,56 @@ func GetCacheStatusFaveIDsMemRatio() float64 { return global.GetCacheStatusFaveI
 // SetCacheStatusFaveIDsMemRatio safely sets the value for global configuration 'Cache.StatusFaveIDsMemRatio' field
 func SetCacheStatusFaveIDsMemRatio(v float64) { global.SetCacheStatusFaveIDsMemRatio(v) }
 
+// GetCacheStatusReactionMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionMemRatioFlag returns the flag name for the 'Cache.StatusReactionMemRatio' field
+func CacheStatusReactionMemRatioFlag() string { return "cache-status-reaction-mem-ratio" }
+
+// GetCacheStatusReactionMemRatio safely fetches the value for global configuration 'Cache.StatusReactionMemRatio' field
+func GetCacheStatusReactionMemRatio() float64 { return global.GetCacheStatusReactionMemRatio() }
+
+// SetCacheStatusReactionMemRatio safely sets the value for global configuration 'Cache.StatusReactionMemRatio' field
+func SetCacheStatusReactionMemRatio(v float64) { global.SetCacheStatusReactionMemRatio(v) }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionIDsMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionIDsMemRatioFlag returns the flag name for the 'Cache.StatusReactionIDsMemRatio' field
+func CacheStatusReactionIDsMemRatioFlag() string { return "cache-status-reaction-ids-mem-ratio" }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func GetCacheStatusReactionIDsMemRatio() float64 { return global.GetCacheStatusReactionIDsMemRatio() }
+
+// SetCacheStatusReactionIDsMemRatio safely sets the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func SetCacheStatusReactionIDsMemRatio(v float64) { global.SetCacheStatusReactionIDsMemRatio(v) }
+
 // GetCacheTagMemRatio safely fetches the Configuration value for state's 'Cache.TagMemRatio' field
 func (st *ConfigState) GetCacheTagMemRatio() (v float64) {
 	st.mutex.RLock()
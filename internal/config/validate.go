@@ -118,6 +118,49 @@ func Validate() error {
 		errf("%s must be set", WebAssetBaseDirFlag())
 	}
 
+	// Challenges should be "pow",
+	// "captcha", or not set at all.
+	for _, c := range []struct{ flag, kind string }{
+		{AccountsChallengeSignUpFlag(), GetAccountsChallengeSignUp()},
+		{AccountsChallengeSignInFlag(), GetAccountsChallengeSignIn()},
+	} {
+		switch c.kind {
+		case AccountsChallengeNone, AccountsChallengePoW, AccountsChallengeCaptcha:
+			// No problem.
+
+		default:
+			errf(
+				"%s must be set to either pow, captcha, or empty, provided value was %s",
+				c.flag, c.kind,
+			)
+		}
+	}
+
+	// PoW difficulty is a number of bits of a
+	// sha256 hash; anything over 32 would take
+	// browsers far too long to get through.
+	if d := GetAccountsChallengePoWDifficulty(); d < 1 || d > 32 {
+		errf(
+			"%s must be between 1 and 32, provided value was %d",
+			AccountsChallengePoWDifficultyFlag(), d,
+		)
+	}
+
+	// Dormant account settings.
+	if m := GetAccountsDormantMonths(); m < 0 {
+		errf(
+			"%s must not be negative, provided value was %d",
+			AccountsDormantMonthsFlag(), m,
+		)
+	}
+
+	if GetAccountsDormantDisable() && GetAccountsDormantGracePeriod() <= 0 {
+		errf(
+			"%s must be greater than 0 if %s is true",
+			AccountsDormantGracePeriodFlag(), AccountsDormantDisableFlag(),
+		)
+	}
+
 	// Custom / LE TLS settings.
 	//
 	// Only one of custom certs or LE can be set,
@@ -140,6 +140,27 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadChallenge() {
+	testrig.InitTestConfig()
+
+	config.SetAccountsChallengeSignIn("recaptcha")
+	config.SetAccountsChallengePoWDifficulty(64)
+
+	err := config.Validate()
+	suite.EqualError(err, "accounts-challenge-sign-in must be set to either pow, captcha, or empty, provided value was recaptcha\naccounts-challenge-pow-difficulty must be between 1 and 32, provided value was 64")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadDormant() {
+	testrig.InitTestConfig()
+
+	config.SetAccountsDormantMonths(-1)
+	config.SetAccountsDormantDisable(true)
+	config.SetAccountsDormantGracePeriod(0)
+
+	err := config.Validate()
+	suite.EqualError(err, "accounts-dormant-months must not be negative, provided value was -1\naccounts-dormant-grace-period must be greater than 0 if accounts-dormant-disable is true")
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
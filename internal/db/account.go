@@ -99,6 +99,10 @@ type Account interface {
 	// GetAccountFaves fetches faves/likes created by the target accountID.
 	GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, error)
 
+	// GetLocalAccountsWithFields fetches a page of local accounts of enabled,
+	// non-suspended users which have at least one profile field set.
+	GetLocalAccountsWithFields(ctx context.Context, page *paging.Page) ([]*gtsmodel.Account, error)
+
 	// GetAccountsUsingEmoji fetches all account models using emoji with given ID stored in their 'emojis' column.
 	GetAccountsUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Account, error)
 
@@ -118,11 +122,11 @@ type Account interface {
 	GetAccountPinnedStatuses(ctx context.Context, accountID string) ([]*gtsmodel.Status, error)
 
 	// GetAccountWebStatuses is similar to GetAccountStatuses, but it's specifically for returning statuses that
-	// should be visible via the web view of an account. So, only public, federated statuses that aren't boosts
-	// or replies.
+	// should be visible via the web view of an account. So, only public, federated statuses that aren't boosts,
+	// and that aren't replies unless withReplies is true. If mediaOnly is true, only statuses with attachments.
 	//
 	// In the case of no statuses, this function will return db.ErrNoEntries.
-	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error)
+	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, withReplies bool, mediaOnly bool, maxID string) ([]*gtsmodel.Status, error)
 
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
 	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) error
@@ -78,6 +78,11 @@ type Admin interface {
 	// GetAdminActions gets all admin actions from the database.
 	GetAdminActions(ctx context.Context) ([]*gtsmodel.AdminAction, error)
 
+	// GetAdminActionsPendingExpiry gets all admin actions
+	// from the database which have an expiry set, and which
+	// have not yet been reversed or superseded.
+	GetAdminActionsPendingExpiry(ctx context.Context) ([]*gtsmodel.AdminAction, error)
+
 	// PutAdminAction puts one admin action in the database.
 	PutAdminAction(ctx context.Context, action *gtsmodel.AdminAction) error
 
@@ -463,7 +463,30 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts that signed
+		// up with invites created by the
+		// given account.
+		var inviteIDs []string
+		if err := a.db.
+			NewSelect().
+			Table("invites").
+			Column("id").
+			Where("? = ?", bun.Ident("account_id"), invitedBy).
+			Scan(ctx, &inviteIDs); err != nil {
+			return nil, err
+		}
+
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if slices.Contains(inviteIDs, user.InviteID) {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -895,6 +918,47 @@ func (a *accountDB) GetAccountsUsingEmoji(ctx context.Context, emojiID string) (
 	return a.GetAccountsByIDs(ctx, accountIDs)
 }
 
+func (a *accountDB) GetLocalAccountsWithFields(ctx context.Context, page *paging.Page) ([]*gtsmodel.Account, error) {
+	var (
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+	)
+
+	accountIDs := make([]string, 0, limit)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		// Only accounts of
+		// enabled local users.
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("users"), bun.Ident("user"),
+			bun.Ident("user.account_id"), bun.Ident("account.id"),
+		).
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		// Fields are stored as JSON, so
+		// exclude any null or empty list.
+		Where("? IS NOT NULL", bun.Ident("account.fields_raw")).
+		Where("? NOT IN (?)", bun.Ident("account.fields_raw"), bun.In([]string{"null", "[]"})).
+		OrderExpr("? DESC", bun.Ident("account.id"))
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, error) {
 	faves := new([]*gtsmodel.StatusFave)
 
@@ -946,27 +1010,8 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	}
 
 	if mediaOnly {
-		// Attachments are stored as a json object; this
-		// implementation differs between SQLite and Postgres,
-		// so we have to be thorough to cover all eventualities
-		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			switch a.db.Dialect().Name() {
-			case dialect.PG:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments"))
-			case dialect.SQLite:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != ''", bun.Ident("status.attachments")).
-					Where("? != 'null'", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments")).
-					Where("? != '[]'", bun.Ident("status.attachments"))
-			default:
-				log.Panic(ctx, "db dialect was neither pg nor sqlite")
-				return q
-			}
-		})
+		// Select only statuses with attachments.
+		q = whereHasAttachments(q)
 	}
 
 	if publicOnly {
@@ -1042,7 +1087,7 @@ func (a *accountDB) GetAccountPinnedStatuses(ctx context.Context, accountID stri
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
-func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error) {
+func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string, limit int, withReplies bool, mediaOnly bool, maxID string) ([]*gtsmodel.Status, error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -1057,14 +1102,23 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 		// Select only IDs from table
 		Column("status.id").
 		Where("? = ?", bun.Ident("status.account_id"), accountID).
-		// Don't show replies or boosts.
-		Where("? IS NULL", bun.Ident("status.in_reply_to_uri")).
+		// Don't show boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
 		// Only Public statuses.
 		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
 		// Don't show local-only statuses on the web view.
 		Where("? = ?", bun.Ident("status.federated"), true)
 
+	if !withReplies {
+		// Don't show replies.
+		q = q.Where("? IS NULL", bun.Ident("status.in_reply_to_uri"))
+	}
+
+	if mediaOnly {
+		// Select only statuses with attachments.
+		q = whereHasAttachments(q)
+	}
+
 	// return only statuses LOWER (ie., older) than maxID
 	if maxID == "" {
 		maxID = id.Highest
@@ -89,6 +89,26 @@ func (suite *AccountTestSuite) TestGetAccountStatusesExcludeRepliesAndReblogsPub
 	suite.Len(statuses, 2)
 }
 
+func (suite *AccountTestSuite) TestGetAccountWebStatuses() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["admin_account"].ID
+
+	// Top-level posts only.
+	statuses, err := suite.db.GetAccountWebStatuses(ctx, accountID, 20, false, false, "")
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	// Posts and replies.
+	statuses, err = suite.db.GetAccountWebStatuses(ctx, accountID, 20, true, false, "")
+	suite.NoError(err)
+	suite.Len(statuses, 3)
+
+	// Posts and replies with media.
+	statuses, err = suite.db.GetAccountWebStatuses(ctx, accountID, 20, true, true, "")
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+}
+
 // populateTestStatus adds mandatory fields to a partially populated status.
 func (suite *AccountTestSuite) populateTestStatus(testAccountKey string, status *gtsmodel.Status, inReplyTo *gtsmodel.Status) *gtsmodel.Status {
 	testAccount := suite.testAccounts[testAccountKey]
@@ -773,6 +793,46 @@ func (suite *AccountTestSuite) TestAccountStatsAll() {
 	}
 }
 
+func (suite *AccountTestSuite) TestGetLocalAccountsWithFields() {
+	ctx := context.Background()
+
+	// Page through all accounts with fields.
+	var (
+		accounts []*gtsmodel.Account
+		page     = paging.Page{Limit: 1}
+	)
+
+	for {
+		got, err := suite.db.GetLocalAccountsWithFields(ctx, &page)
+		suite.NoError(err)
+		if len(got) == 0 {
+			break
+		}
+
+		accounts = append(accounts, got...)
+		page.Max = paging.MaxID(got[len(got)-1].ID)
+	}
+
+	// Only local_account_2 has fields set.
+	if suite.Len(accounts, 1) {
+		suite.Equal(suite.testAccounts["local_account_2"].ID, accounts[0].ID)
+	}
+
+	// Clearing the fields should
+	// exclude the account again.
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["local_account_2"]
+	account.Fields = []*gtsmodel.Field{}
+	account.FieldsRaw = []*gtsmodel.Field{}
+	if err := suite.db.UpdateAccount(ctx, account, "fields", "fields_raw"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetLocalAccountsWithFields(ctx, nil)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
@@ -120,6 +121,12 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			return nil, err
 		}
 
+		edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			err := gtserror.Newf("error creating new ed25519 private key: %w", err)
+			return nil, err
+		}
+
 		account = &gtsmodel.Account{
 			ID:                    accountID,
 			Username:              newSignup.Username,
@@ -135,6 +142,9 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			PrivateKey:            privKey,
 			PublicKey:             &privKey.PublicKey,
 			PublicKeyURI:          uris.PublicKeyURI,
+			Ed25519PrivateKey:     edPrivKey,
+			Ed25519PublicKey:      edPubKey,
+			Ed25519PublicKeyURI:   uris.Ed25519PublicKeyURI,
 		}
 
 		// Insert the new account!
@@ -205,6 +215,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		UnconfirmedEmail:       newSignup.Email,
 		CreatedByApplicationID: newSignup.AppID,
 		ExternalID:             newSignup.ExternalID,
+		InviteID:               newSignup.InviteID,
 	}
 
 	if newSignup.EmailVerified {
@@ -258,6 +269,12 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		return err
 	}
 
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Errorf(ctx, "error creating new ed25519 key: %s", err)
+		return err
+	}
+
 	aID, err := id.NewRandomULID()
 	if err != nil {
 		return err
@@ -272,6 +289,9 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		PrivateKey:            key,
 		PublicKey:             &key.PublicKey,
 		PublicKeyURI:          newAccountURIs.PublicKeyURI,
+		Ed25519PrivateKey:     edPrivKey,
+		Ed25519PublicKey:      edPubKey,
+		Ed25519PublicKeyURI:   newAccountURIs.Ed25519PublicKeyURI,
 		ActorType:             ap.ActorPerson,
 		URI:                   newAccountURIs.UserURI,
 		InboxURI:              newAccountURIs.InboxURI,
@@ -452,6 +472,7 @@ func (a *adminDB) GetAdminAction(ctx context.Context, id string) (*gtsmodel.Admi
 	if err := a.db.
 		NewSelect().
 		Model(action).
+		Where("? = ?", bun.Ident("admin_action.id"), id).
 		Scan(ctx); err != nil {
 		return nil, err
 	}
@@ -472,6 +493,21 @@ func (a *adminDB) GetAdminActions(ctx context.Context) ([]*gtsmodel.AdminAction,
 	return actions, nil
 }
 
+func (a *adminDB) GetAdminActionsPendingExpiry(ctx context.Context) ([]*gtsmodel.AdminAction, error) {
+	actions := make([]*gtsmodel.AdminAction, 0)
+
+	if err := a.db.
+		NewSelect().
+		Model(&actions).
+		Where("? IS NOT NULL", bun.Ident("admin_action.expires_at")).
+		Where("? IS NULL", bun.Ident("admin_action.reversed_at")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
 func (a *adminDB) PutAdminAction(ctx context.Context, action *gtsmodel.AdminAction) error {
 	_, err := a.db.
 		NewInsert().
@@ -58,8 +58,10 @@ type DBService struct {
 	db.Basic
 	db.Domain
 	db.Emoji
+	db.FederationPolicy
 	db.HeaderFilter
 	db.Instance
+	db.Invite
 	db.Filter
 	db.List
 	db.Marker
@@ -68,19 +70,25 @@ type DBService struct {
 	db.Move
 	db.Notification
 	db.Poll
+	db.PreviewCard
+	db.QueuedEmail
 	db.Relationship
 	db.Report
 	db.Rule
 	db.Search
 	db.Session
+	db.Spam
 	db.Status
 	db.StatusBookmark
 	db.StatusFave
+	db.StatusReaction
 	db.Tag
+	db.Trend
 	db.Thread
 	db.Timeline
 	db.User
 	db.Tombstone
+	db.Webhook
 	db *bun.DB
 }
 
@@ -195,6 +203,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		FederationPolicy: &federationPolicyDB{
+			db:    db,
+			state: state,
+		},
 		HeaderFilter: &headerFilterDB{
 			db:    db,
 			state: state,
@@ -203,6 +215,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
 		Filter: &filterDB{
 			db:    db,
 			state: state,
@@ -235,6 +251,13 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		PreviewCard: &previewCardDB{
+			db:    db,
+			state: state,
+		},
+		QueuedEmail: &queuedEmailDB{
+			db: db,
+		},
 		Relationship: &relationshipDB{
 			db:    db,
 			state: state,
@@ -254,6 +277,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Session: &sessionDB{
 			db: db,
 		},
+		Spam: &spamDB{
+			db:    db,
+			state: state,
+		},
 		Status: &statusDB{
 			db:    db,
 			state: state,
@@ -266,10 +293,17 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusReaction: &statusReactionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db: db,
+		},
 		Thread: &threadDB{
 			db:    db,
 			state: state,
@@ -286,6 +320,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Webhook: &webhookDB{
+			db:    db,
+			state: state,
+		},
 		db: db,
 	}
 
@@ -56,6 +56,7 @@ type BunDBStandardTestSuite struct {
 	testThreads      map[string]*gtsmodel.Thread
 	testPolls        map[string]*gtsmodel.Poll
 	testPollVotes    map[string]*gtsmodel.PollVote
+	testInvites      map[string]*gtsmodel.Invite
 }
 
 func (suite *BunDBStandardTestSuite) SetupSuite() {
@@ -81,6 +82,7 @@ func (suite *BunDBStandardTestSuite) SetupSuite() {
 	suite.testThreads = testrig.NewTestThreads()
 	suite.testPolls = testrig.NewTestPolls()
 	suite.testPollVotes = testrig.NewTestPollVotes()
+	suite.testInvites = testrig.NewTestInvites()
 }
 
 func (suite *BunDBStandardTestSuite) SetupTest() {
@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type federationPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (f *federationPolicyDB) GetFederationPolicyByID(ctx context.Context, id string) (*gtsmodel.FederationPolicy, error) {
+	var policy gtsmodel.FederationPolicy
+
+	if err := f.db.
+		NewSelect().
+		Model(&policy).
+		Where("? = ?", bun.Ident("federation_policy.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (f *federationPolicyDB) GetFederationPolicies(ctx context.Context) ([]*gtsmodel.FederationPolicy, error) {
+	return f.state.Caches.FederationPolicies.Load(func() ([]*gtsmodel.FederationPolicy, error) {
+		var policies []*gtsmodel.FederationPolicy
+
+		if err := f.db.
+			NewSelect().
+			Model(&policies).
+			Order("federation_policy.priority ASC", "federation_policy.id ASC").
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return policies, nil
+	})
+}
+
+func (f *federationPolicyDB) PutFederationPolicy(ctx context.Context, policy *gtsmodel.FederationPolicy) error {
+	// Policies changed, reload on next get.
+	defer f.state.Caches.FederationPolicies.Clear()
+
+	_, err := f.db.
+		NewInsert().
+		Model(policy).
+		Exec(ctx)
+	return err
+}
+
+func (f *federationPolicyDB) UpdateFederationPolicy(ctx context.Context, policy *gtsmodel.FederationPolicy, columns ...string) error {
+	policy.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Policies changed, reload on next get.
+	defer f.state.Caches.FederationPolicies.Clear()
+
+	_, err := f.db.
+		NewUpdate().
+		Model(policy).
+		Where("? = ?", bun.Ident("federation_policy.id"), policy.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (f *federationPolicyDB) DeleteFederationPolicyByID(ctx context.Context, id string) error {
+	// Policies changed, reload on next get.
+	defer f.state.Caches.FederationPolicies.Clear()
+
+	_, err := f.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("federation_policies"), bun.Ident("federation_policy")).
+		Where("? = ?", bun.Ident("federation_policy.id"), id).
+		Exec(ctx)
+	return err
+}
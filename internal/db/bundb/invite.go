@@ -0,0 +1,232 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "id", id)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "code", code)
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, column string, value string) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	if err := i.db.
+		NewSelect().
+		Model(&invite).
+		Where("? = ?", bun.Ident("invite."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := i.populateInvite(ctx, &invite); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) GetInvites(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Invite, error) {
+	var (
+		maxID = page.GetMax()
+		minID = page.GetMin()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		invites = make([]*gtsmodel.Invite, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		Model(&invites)
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("invite.account_id"), accountID)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("invite.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("invite.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		q = q.OrderExpr("? ASC", bun.Ident("invite.id"))
+	} else {
+		q = q.OrderExpr("? DESC", bun.Ident("invite.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want invites
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(invites)
+	}
+
+	for _, invite := range invites {
+		if err := i.populateInvite(ctx, invite); err != nil {
+			return nil, err
+		}
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) populateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	if invite.Account != nil || gtscontext.Barebones(ctx) {
+		return nil
+	}
+
+	var err error
+	invite.Account, err = i.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		invite.AccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating invite account: %w", err)
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.
+		NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(invite).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	invite.UpdatedAt = time.Now()
+
+	q := i.db.
+		NewUpdate().
+		Model((*gtsmodel.Invite)(nil)).
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), invite.UpdatedAt).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID)
+
+	// Only increment if there's a use left,
+	// checked in the same query to avoid races.
+	if invite.MaxUses > 0 {
+		q = q.Where("? < ?", bun.Ident("invite.uses"), invite.MaxUses)
+	}
+
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return db.ErrNoEntries
+	}
+
+	invite.Uses++
+	return nil
+}
+
+func (i *inviteDB) DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	invite.UpdatedAt = time.Now()
+
+	res, err := i.db.
+		NewUpdate().
+		Model((*gtsmodel.Invite)(nil)).
+		Set("? = ? - 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), invite.UpdatedAt).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Where("? > 0", bun.Ident("invite.uses")).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return db.ErrNoEntries
+	}
+
+	invite.Uses--
+	return nil
+}
+
+func (i *inviteDB) DeleteInviteByID(ctx context.Context, id string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+		Where("? = ?", bun.Ident("invite.id"), id).
+		Exec(ctx)
+	return err
+}
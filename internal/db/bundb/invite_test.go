@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestGetInviteByCode() {
+	testInvite := suite.testInvites["local_account_1_invite"]
+
+	invite, err := suite.db.GetInviteByCode(context.Background(), testInvite.Code)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(testInvite.ID, invite.ID)
+	suite.NotNil(invite.Account)
+	suite.Equal(testInvite.AccountID, invite.Account.ID)
+	suite.True(invite.Valid())
+}
+
+func (suite *InviteTestSuite) TestGetInvites() {
+	ctx := context.Background()
+
+	// All invites.
+	invites, err := suite.db.GetInvites(ctx, "", &paging.Page{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(invites, len(suite.testInvites))
+
+	// Newest first.
+	suite.Equal(suite.testInvites["admin_account_invite_expired"].ID, invites[0].ID)
+	suite.False(invites[0].Valid())
+
+	// Invites created by one account.
+	invites, err = suite.db.GetInvites(
+		ctx,
+		suite.testAccounts["local_account_1"].ID,
+		&paging.Page{},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(invites, 1)
+	suite.Equal(suite.testInvites["local_account_1_invite"].ID, invites[0].ID)
+}
+
+func (suite *InviteTestSuite) TestIncrementInviteUses() {
+	ctx := context.Background()
+
+	invite := &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		Code:      "onlytwice",
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		MaxUses:   2,
+	}
+
+	if err := suite.db.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Use the invite up.
+	for i := 0; i < invite.MaxUses; i++ {
+		if err := suite.db.IncrementInviteUses(ctx, invite); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	suite.True(invite.UsedUp())
+
+	// Further uses should be refused.
+	err := suite.db.IncrementInviteUses(ctx, invite)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, dbInvite.Uses)
+	suite.False(dbInvite.Valid())
+
+	// Giving back a use makes it valid again.
+	if err := suite.db.DecrementInviteUses(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(invite.UsedUp())
+
+	dbInvite, err = suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, dbInvite.Uses)
+	suite.True(dbInvite.Valid())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
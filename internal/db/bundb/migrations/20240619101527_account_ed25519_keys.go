@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			var keyType string
+			switch tx.Dialect().Name() {
+			case dialect.SQLite:
+				keyType = "BLOB"
+			case dialect.PG:
+				keyType = "BYTEA"
+			default:
+				panic("db conn was neither pg not sqlite")
+			}
+
+			// Add new key columns to accounts.
+			for _, column := range []struct {
+				name    string
+				colType string
+			}{
+				{"ed25519_private_key", keyType},
+				{"ed25519_public_key", keyType},
+				{"ed25519_public_key_uri", "VARCHAR"},
+			} {
+				if _, err := tx.
+					NewAddColumn().
+					Table("accounts").
+					ColumnExpr("? "+column.colType, bun.Ident(column.name)).
+					Exec(ctx); err != nil {
+					e := err.Error()
+					if !(strings.Contains(e, "already exists") ||
+						strings.Contains(e, "duplicate column name") ||
+						strings.Contains(e, "SQLSTATE 42701")) {
+						return err
+					}
+				}
+			}
+
+			// Select all local accounts,
+			// which need keys generating.
+			var accounts []struct {
+				ID  string
+				URI string
+			}
+
+			if err := tx.
+				NewSelect().
+				Table("accounts").
+				Column("id", "uri").
+				Where("? IS NULL", bun.Ident("domain")).
+				Scan(ctx, &accounts); err != nil {
+				return err
+			}
+
+			log.Infof(ctx, "generating ed25519 keys for %d local accounts", len(accounts))
+
+			for _, account := range accounts {
+				pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewUpdate().
+					Table("accounts").
+					Set("? = ?", bun.Ident("ed25519_private_key"), []byte(privKey)).
+					Set("? = ?", bun.Ident("ed25519_public_key"), []byte(pubKey)).
+					Set("? = ?", bun.Ident("ed25519_public_key_uri"), account.URI+"#"+uris.Ed25519KeyFragment).
+					Where("? = ?", bun.Ident("id"), account.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add email notification preference columns to users.
+			for column, def := range map[string]string{
+				"email_notify_mention":        "BOOLEAN NOT NULL DEFAULT false",
+				"email_notify_direct":         "BOOLEAN NOT NULL DEFAULT false",
+				"email_notify_follow":         "BOOLEAN NOT NULL DEFAULT false",
+				"email_notify_follow_request": "BOOLEAN NOT NULL DEFAULT false",
+				"email_notify_report":         "BOOLEAN NOT NULL DEFAULT true",
+				"email_notify_frequency":      "SMALLINT NOT NULL DEFAULT 1",
+				"email_digest_sent_at":        "TIMESTAMPTZ",
+				"email_unsubscribe_token":     "VARCHAR",
+			} {
+				if _, err := tx.
+					NewAddColumn().
+					Table("users").
+					ColumnExpr("? "+def, bun.Ident(column)).
+					Exec(ctx); err != nil {
+					e := err.Error()
+					if !(strings.Contains(e, "already exists") ||
+						strings.Contains(e, "duplicate column name") ||
+						strings.Contains(e, "SQLSTATE 42701")) {
+						return err
+					}
+				}
+			}
+
+			// Index unsubscribe tokens for lookup
+			// from one-click unsubscribe links.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("users").
+				Index("users_email_unsubscribe_token_idx").
+				Column("email_unsubscribe_token").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,196 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// searchConfigs maps ISO 639-1 language codes to the
+// Postgres text search configurations that handle them.
+// Only configurations actually present in the database
+// are used; anything else falls back to 'simple'.
+var searchConfigs = map[string]string{
+	"ar": "arabic",
+	"da": "danish",
+	"de": "german",
+	"el": "greek",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"ga": "irish",
+	"hu": "hungarian",
+	"id": "indonesian",
+	"it": "italian",
+	"lt": "lithuanian",
+	"nb": "norwegian",
+	"ne": "nepali",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"ta": "tamil",
+	"tr": "turkish",
+}
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			log.Info(ctx, "creating full-text search indexes for statuses and accounts; this may take a while, please don't interrupt this migration!")
+
+			switch tx.Dialect().Name() {
+			case dialect.PG:
+				return searchFTSPostgres(ctx, tx)
+			case dialect.SQLite:
+				return searchFTSSQLite(ctx, tx)
+			default:
+				panic("db conn was neither pg not sqlite")
+			}
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+func searchFTSPostgres(ctx context.Context, tx bun.Tx) error {
+	// See which text search configs
+	// this Postgres install provides.
+	var available []string
+	if err := tx.
+		NewSelect().
+		Table("pg_catalog.pg_ts_config").
+		Column("cfgname").
+		Scan(ctx, &available); err != nil {
+		return err
+	}
+
+	langs := make([]string, 0, len(searchConfigs))
+	for lang, cfg := range searchConfigs {
+		if slices.Contains(available, cfg) {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs)
+
+	// Build an immutable function mapping a status'
+	// language tag to a text search config, so that
+	// it can be used in an index expression.
+	var fn strings.Builder
+	fn.WriteString("CREATE OR REPLACE FUNCTION gts_search_config(lang TEXT) RETURNS regconfig AS $$ ")
+	fn.WriteString("SELECT CASE lower(split_part(COALESCE(lang, ''), '-', 1)) ")
+	for _, lang := range langs {
+		fn.WriteString("WHEN '" + lang + "' THEN '" + searchConfigs[lang] + "'::regconfig ")
+	}
+	fn.WriteString("ELSE 'simple'::regconfig END $$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE")
+
+	if _, err := tx.ExecContext(ctx, fn.String()); err != nil {
+		return err
+	}
+
+	// These expressions must match the ones
+	// used in bundb/search.go exactly, or
+	// the indexes won't be used by queries.
+	for _, stmt := range []string{
+		`CREATE INDEX IF NOT EXISTS "statuses_fts_idx" ON "statuses" ` +
+			`USING GIN (to_tsvector(gts_search_config("language"), COALESCE("content_warning", '') || ' ' || COALESCE("content", ''))) ` +
+			`WHERE "boost_of_id" IS NULL`,
+		`CREATE INDEX IF NOT EXISTS "accounts_names_fts_idx" ON "accounts" ` +
+			`USING GIN (to_tsvector('simple', "username" || ' ' || COALESCE("display_name", '')))`,
+		`CREATE INDEX IF NOT EXISTS "accounts_text_fts_idx" ON "accounts" ` +
+			`USING GIN (to_tsvector('simple', "username" || ' ' || COALESCE("display_name", '') || ' ' || COALESCE("note", '')))`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func searchFTSSQLite(ctx context.Context, tx bun.Tx) error {
+	// FTS5 tables are keyed by integer rowid, but the
+	// implicit rowids of the statuses and accounts tables
+	// may change on VACUUM, so we keep our own stable
+	// rowid <-> ID mapping tables alongside contentless
+	// FTS5 tables, and keep both in sync using triggers.
+	for _, stmt := range []string{
+		// Statuses.
+		`CREATE TABLE IF NOT EXISTS "statuses_fts_ids" ("rowid" INTEGER PRIMARY KEY, "status_id" CHAR(26) NOT NULL UNIQUE)`,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS "statuses_fts" USING fts5("content", "content_warning", content='', contentless_delete=1, tokenize='unicode61 remove_diacritics 2')`,
+		`CREATE TRIGGER IF NOT EXISTS "statuses_fts_insert" AFTER INSERT ON "statuses" WHEN new."boost_of_id" IS NULL BEGIN ` +
+			`INSERT OR IGNORE INTO "statuses_fts_ids" ("status_id") VALUES (new."id"); ` +
+			`INSERT INTO "statuses_fts" ("rowid", "content", "content_warning") SELECT "rowid", new."content", new."content_warning" FROM "statuses_fts_ids" WHERE "status_id" = new."id"; ` +
+			`END`,
+		`CREATE TRIGGER IF NOT EXISTS "statuses_fts_update" AFTER UPDATE OF "content", "content_warning" ON "statuses" WHEN new."boost_of_id" IS NULL BEGIN ` +
+			`DELETE FROM "statuses_fts" WHERE "rowid" = (SELECT "rowid" FROM "statuses_fts_ids" WHERE "status_id" = old."id"); ` +
+			`INSERT OR IGNORE INTO "statuses_fts_ids" ("status_id") VALUES (new."id"); ` +
+			`INSERT INTO "statuses_fts" ("rowid", "content", "content_warning") SELECT "rowid", new."content", new."content_warning" FROM "statuses_fts_ids" WHERE "status_id" = new."id"; ` +
+			`END`,
+		`CREATE TRIGGER IF NOT EXISTS "statuses_fts_delete" AFTER DELETE ON "statuses" BEGIN ` +
+			`DELETE FROM "statuses_fts" WHERE "rowid" = (SELECT "rowid" FROM "statuses_fts_ids" WHERE "status_id" = old."id"); ` +
+			`DELETE FROM "statuses_fts_ids" WHERE "status_id" = old."id"; ` +
+			`END`,
+		`INSERT OR IGNORE INTO "statuses_fts_ids" ("status_id") SELECT "id" FROM "statuses" WHERE "boost_of_id" IS NULL`,
+		`INSERT INTO "statuses_fts" ("rowid", "content", "content_warning") SELECT "ids"."rowid", "status"."content", "status"."content_warning" ` +
+			`FROM "statuses_fts_ids" AS "ids" JOIN "statuses" AS "status" ON "status"."id" = "ids"."status_id"`,
+
+		// Accounts.
+		`CREATE TABLE IF NOT EXISTS "accounts_fts_ids" ("rowid" INTEGER PRIMARY KEY, "account_id" CHAR(26) NOT NULL UNIQUE)`,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS "accounts_fts" USING fts5("username", "display_name", "note", content='', contentless_delete=1, tokenize='unicode61 remove_diacritics 2')`,
+		`CREATE TRIGGER IF NOT EXISTS "accounts_fts_insert" AFTER INSERT ON "accounts" BEGIN ` +
+			`INSERT OR IGNORE INTO "accounts_fts_ids" ("account_id") VALUES (new."id"); ` +
+			`INSERT INTO "accounts_fts" ("rowid", "username", "display_name", "note") SELECT "rowid", new."username", new."display_name", new."note" FROM "accounts_fts_ids" WHERE "account_id" = new."id"; ` +
+			`END`,
+		`CREATE TRIGGER IF NOT EXISTS "accounts_fts_update" AFTER UPDATE OF "username", "display_name", "note" ON "accounts" BEGIN ` +
+			`DELETE FROM "accounts_fts" WHERE "rowid" = (SELECT "rowid" FROM "accounts_fts_ids" WHERE "account_id" = old."id"); ` +
+			`INSERT OR IGNORE INTO "accounts_fts_ids" ("account_id") VALUES (new."id"); ` +
+			`INSERT INTO "accounts_fts" ("rowid", "username", "display_name", "note") SELECT "rowid", new."username", new."display_name", new."note" FROM "accounts_fts_ids" WHERE "account_id" = new."id"; ` +
+			`END`,
+		`CREATE TRIGGER IF NOT EXISTS "accounts_fts_delete" AFTER DELETE ON "accounts" BEGIN ` +
+			`DELETE FROM "accounts_fts" WHERE "rowid" = (SELECT "rowid" FROM "accounts_fts_ids" WHERE "account_id" = old."id"); ` +
+			`DELETE FROM "accounts_fts_ids" WHERE "account_id" = old."id"; ` +
+			`END`,
+		`INSERT OR IGNORE INTO "accounts_fts_ids" ("account_id") SELECT "id" FROM "accounts"`,
+		`INSERT INTO "accounts_fts" ("rowid", "username", "display_name", "note") SELECT "ids"."rowid", "account"."username", "account"."display_name", "account"."note" ` +
+			`FROM "accounts_fts_ids" AS "ids" JOIN "accounts" AS "account" ON "account"."id" = "ids"."account_id"`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
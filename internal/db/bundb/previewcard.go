@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type previewCardDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (p *previewCardDB) GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, error) {
+	return p.state.Caches.GTS.PreviewCard.LoadOne("ID", func() (*gtsmodel.PreviewCard, error) {
+		var card gtsmodel.PreviewCard
+
+		if err := p.db.
+			NewSelect().
+			Model(&card).
+			Where("? = ?", bun.Ident("preview_card.id"), id).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, id)
+}
+
+func (p *previewCardDB) GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, error) {
+	return p.state.Caches.GTS.PreviewCard.LoadOne("URL", func() (*gtsmodel.PreviewCard, error) {
+		var card gtsmodel.PreviewCard
+
+		if err := p.db.
+			NewSelect().
+			Model(&card).
+			Where("? = ?", bun.Ident("preview_card.url"), url).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, url)
+}
+
+func (p *previewCardDB) GetPreviewCardByImageURL(ctx context.Context, imageURL string) (*gtsmodel.PreviewCard, error) {
+	return p.state.Caches.GTS.PreviewCard.LoadOne("ImageURL", func() (*gtsmodel.PreviewCard, error) {
+		var card gtsmodel.PreviewCard
+
+		if err := p.db.
+			NewSelect().
+			Model(&card).
+			Where("? = ?", bun.Ident("preview_card.image_url"), imageURL).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, imageURL)
+}
+
+func (p *previewCardDB) PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	return p.state.Caches.GTS.PreviewCard.Store(card, func() error {
+		_, err := p.db.
+			NewInsert().
+			Model(card).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (p *previewCardDB) UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, columns ...string) error {
+	card.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return p.state.Caches.GTS.PreviewCard.Store(card, func() error {
+		_, err := p.db.
+			NewUpdate().
+			Model(card).
+			Where("? = ?", bun.Ident("preview_card.id"), card.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (p *previewCardDB) DeletePreviewCardByID(ctx context.Context, id string) error {
+	var statusIDs []string
+
+	defer func() {
+		// Invalidate cached card.
+		p.state.Caches.GTS.PreviewCard.Invalidate("ID", id)
+
+		// Invalidate any statuses that linked to it.
+		p.state.Caches.GTS.Status.InvalidateIDs("ID", statusIDs)
+	}()
+
+	return p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Unset the card on statuses
+		// that link to it, returning
+		// the IDs of those statuses.
+		if _, err := tx.
+			NewUpdate().
+			Table("statuses").
+			Set("? = NULL", bun.Ident("preview_card_id")).
+			Where("? = ?", bun.Ident("preview_card_id"), id).
+			Returning("id").
+			Exec(ctx, &statusIDs); err != nil {
+			return err
+		}
+
+		// Delete the card itself.
+		_, err := tx.
+			NewDelete().
+			Table("preview_cards").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+type queuedEmailDB struct {
+	db *bun.DB
+}
+
+func (q *queuedEmailDB) GetQueuedEmailByID(ctx context.Context, id string) (*gtsmodel.QueuedEmail, error) {
+	email := new(gtsmodel.QueuedEmail)
+
+	if err := q.db.
+		NewSelect().
+		Model(email).
+		Where("? = ?", bun.Ident("queued_email.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+func (q *queuedEmailDB) GetDueQueuedEmails(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.QueuedEmail, error) {
+	emails := []*gtsmodel.QueuedEmail{}
+
+	if err := q.db.
+		NewSelect().
+		Model(&emails).
+		Where("? IS NULL", bun.Ident("queued_email.failed_at")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("queued_email.next_attempt_at")).
+				WhereOr("? <= ?", bun.Ident("queued_email.next_attempt_at"), now)
+		}).
+		OrderExpr("? ASC", bun.Ident("queued_email.id")).
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+func (q *queuedEmailDB) GetFailedQueuedEmails(ctx context.Context, page *paging.Page) ([]*gtsmodel.QueuedEmail, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		emails = make([]*gtsmodel.QueuedEmail, 0, limit)
+	)
+
+	sq := q.db.
+		NewSelect().
+		Model(&emails).
+		Where("? IS NOT NULL", bun.Ident("queued_email.failed_at"))
+
+	// Return only emails with id
+	// lower than provided maxID.
+	if maxID != "" {
+		sq = sq.Where("? < ?", bun.Ident("queued_email.id"), maxID)
+	}
+
+	// Return only emails with id
+	// greater than provided minID.
+	if minID != "" {
+		sq = sq.Where("? > ?", bun.Ident("queued_email.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// emails returned.
+		sq = sq.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		sq = sq.OrderExpr("? ASC", bun.Ident("queued_email.id"))
+	} else {
+		// Page down.
+		sq = sq.OrderExpr("? DESC", bun.Ident("queued_email.id"))
+	}
+
+	if err := sq.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no emails early
+	if len(emails) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want emails
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(emails)
+	}
+
+	return emails, nil
+}
+
+func (q *queuedEmailDB) PutQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail) error {
+	_, err := q.db.
+		NewInsert().
+		Model(email).
+		Exec(ctx)
+	return err
+}
+
+func (q *queuedEmailDB) UpdateQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail, columns ...string) error {
+	email.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := q.db.
+		NewUpdate().
+		Model(email).
+		Where("? = ?", bun.Ident("queued_email.id"), email.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (q *queuedEmailDB) DeleteQueuedEmailByID(ctx context.Context, id string) error {
+	_, err := q.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("queued_emails"), bun.Ident("queued_email")).
+		Where("? = ?", bun.Ident("queued_email.id"), id).
+		Exec(ctx)
+	return err
+}
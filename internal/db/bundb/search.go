@@ -19,8 +19,11 @@ package bundb
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"unicode"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -29,46 +32,180 @@ import (
 	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// searchDB implements full-text search for accounts and
+// statuses using the text search facilities of whichever
+// database we're running on: GIN indexes over tsvector
+// expressions for Postgres, and FTS5 virtual tables kept
+// in sync by triggers for SQLite (see the search_fts
+// migration for details of both).
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
-//
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Text search results are ordered by relevance rather
+// than by ID, so maxID and minID only narrow the range
+// of results returned; callers should page through text
+// search results by providing an offset instead.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
 }
 
+// searchTerm is a single term or
+// quoted phrase parsed from query text.
+type searchTerm struct {
+	text   string // Term text, without quotes.
+	phrase bool   // Term was a "quoted phrase".
+	negate bool   // Term was -negated.
+}
+
+// parseSearchTerms splits the given query text into terms,
+// treating "double quoted" parts as phrases, and terms or
+// phrases prefixed with '-' as terms to exclude. Terms with
+// no letters or numbers in them are dropped, since they
+// can't match anything in the index anyway.
+func parseSearchTerms(query string) []searchTerm {
+	var terms []searchTerm
+
+	for query = strings.TrimSpace(query); query != ""; query = strings.TrimSpace(query) {
+		var term searchTerm
+
+		if len(query) > 1 && query[0] == '-' {
+			term.negate = true
+			query = query[1:]
+		}
+
+		if query[0] == '"' {
+			// Phrase runs until the closing
+			// quote, or the end of the query.
+			term.phrase = true
+			query = query[1:]
+			end := strings.IndexByte(query, '"')
+			if end == -1 {
+				term.text, query = query, ""
+			} else {
+				term.text, query = query[:end], query[end+1:]
+			}
+		} else {
+			// Term runs until the next space.
+			end := strings.IndexFunc(query, unicode.IsSpace)
+			if end == -1 {
+				end = len(query)
+			}
+			term.text, query = query[:end], query[end:]
+		}
+
+		term.text = strings.TrimSpace(strings.ReplaceAll(term.text, `"`, ""))
+		if strings.IndexFunc(term.text, isWordRune) == -1 {
+			continue
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// isWordRune returns true if r is a letter or number.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// hasPositiveTerm returns true if at least one
+// of the given terms is not negated. Queries made
+// only of negated terms would match almost everything.
+func hasPositiveTerm(terms []searchTerm) bool {
+	return slices.ContainsFunc(terms, func(t searchTerm) bool {
+		return !t.negate
+	})
+}
+
+// ftsMatch renders the given terms as an SQLite FTS5 MATCH
+// expression. Every term is quoted so that it's treated as
+// a string rather than as FTS5 syntax. If prefix is true,
+// single terms will also match tokens that start with them.
+func ftsMatch(terms []searchTerm, prefix bool) string {
+	var (
+		include = make([]string, 0, len(terms))
+		exclude = make([]string, 0, len(terms))
+	)
+
+	for _, term := range terms {
+		str := `"` + term.text + `"`
+		if prefix && !term.phrase {
+			str += "*"
+		}
+
+		if term.negate {
+			exclude = append(exclude, str)
+		} else {
+			include = append(include, str)
+		}
+	}
+
+	match := "(" + strings.Join(include, " AND ") + ")"
+	for _, str := range exclude {
+		match += " NOT " + str
+	}
+
+	return match
+}
+
+// pgWebsearch renders the given terms in the syntax
+// accepted by Postgres' websearch_to_tsquery function.
+func pgWebsearch(terms []searchTerm) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		str := term.text
+		if term.phrase {
+			str = `"` + str + `"`
+		}
+
+		if term.negate {
+			str = "-" + str
+		}
+
+		parts = append(parts, str)
+	}
+	return strings.Join(parts, " ")
+}
+
+// pgPrefixQuery renders the given terms in the syntax
+// accepted by Postgres' to_tsquery function, such that
+// single terms match lexemes starting with them.
+func pgPrefixQuery(terms []searchTerm) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		// Split into plain words so we don't
+		// need to worry about tsquery syntax.
+		words := strings.FieldsFunc(term.text, func(r rune) bool {
+			return !isWordRune(r)
+		})
+
+		var str string
+		if term.phrase {
+			str = "'" + strings.Join(words, "' <-> '") + "'"
+		} else {
+			str = "'" + strings.Join(words, "':* & '") + "':*"
+		}
+
+		str = "(" + str + ")"
+		if term.negate {
+			str = "!" + str
+		}
+
+		parts = append(parts, str)
+	}
+	return strings.Join(parts, " & ")
+}
+
 // Query example (SQLite):
 //
 //	SELECT "account"."id" FROM "accounts" AS "account"
+//	JOIN "accounts_fts_ids" AS "account_fts_id" ON "account_fts_id"."account_id" = "account"."id"
+//	JOIN "accounts_fts" ON "accounts_fts"."rowid" = "account_fts_id"."rowid"
 //	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
 //	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
-//	ORDER BY "account"."id" DESC LIMIT 10
+//	AND ("account"."id" IN (SELECT "follow"."target_account_id" FROM "follows" AS "follow" WHERE ("follow"."account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
+//	AND ("accounts_fts" MATCH '("turtle"*)')
+//	ORDER BY bm25("accounts_fts"), "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
 	accountID string,
@@ -114,9 +251,6 @@ func (s *searchDB) SearchForAccounts(
 	if minID != "" {
 		// Return only items with a HIGHER id than minID.
 		q = q.Where("? > ?", bun.Ident("account.id"), minID)
-
-		// page up
-		frontToBack = false
 	}
 
 	if following {
@@ -134,12 +268,29 @@ func (s *searchDB) SearchForAccounts(
 		// usernames that start with query.
 		query = query[1:]
 		q = whereStartsLike(q, bun.Ident("account.username"), query)
+
+		if minID != "" {
+			// page up
+			frontToBack = false
+		}
+
+		if frontToBack {
+			// Page down.
+			q = q.Order("account.id DESC")
+		} else {
+			// Page up.
+			q = q.Order("account.id ASC")
+		}
 	} else {
 		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
+		// Search the full-text index for it,
+		// ordering by relevance of results.
+		terms := parseSearchTerms(query)
+		if !hasPositiveTerm(terms) {
+			return nil, nil
+		}
+
+		q = s.accountText(q, terms, following)
 	}
 
 	if limit > 0 {
@@ -147,12 +298,9 @@ func (s *searchDB) SearchForAccounts(
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
-		// Page down.
-		q = q.Order("account.id DESC")
-	} else {
-		// Page up.
-		q = q.Order("account.id ASC")
+	if offset > 0 {
+		// Skip earlier pages of results.
+		q = q.Offset(offset)
 	}
 
 	if err := q.Scan(ctx, &accountIDs); err != nil {
@@ -198,77 +346,81 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
-// accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
-	)
+// accountText adds full-text search of account username
+// and display name for the given terms to the query, and
+// orders the query by relevance. If `following` is true,
+// then account note will also be searched.
+func (s *searchDB) accountText(
+	q *bun.SelectQuery,
+	terms []searchTerm,
+	following bool,
+) *bun.SelectQuery {
+	switch d := s.db.Dialect().Name(); d {
 
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
+	case dialect.SQLite:
+		match := ftsMatch(terms, true)
+		if !following {
+			// If querying for accounts we're not following,
+			// don't include note in text search params.
+			match = "{username display_name} : " + match
 		}
-	}
-
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
 
-	switch d := s.db.Dialect().Name(); {
+		return q.
+			Join(
+				"JOIN ? AS ? ON ? = ?",
+				bun.Ident("accounts_fts_ids"), bun.Ident("account_fts_id"),
+				bun.Ident("account_fts_id.account_id"), bun.Ident("account.id"),
+			).
+			Join(
+				"JOIN ? ON ? = ?",
+				bun.Ident("accounts_fts"),
+				bun.Ident("accounts_fts.rowid"), bun.Ident("account_fts_id.rowid"),
+			).
+			Where("? MATCH ?", bun.Ident("accounts_fts"), match).
+			OrderExpr("bm25(?)", bun.Ident("accounts_fts")).
+			Order("account.id DESC")
 
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
-
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
-
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
+	case dialect.PG:
+		// These expressions must match those
+		// of accounts_names_fts_idx and
+		// accounts_text_fts_idx respectively.
+		var vector string
+		if following {
+			// If querying for accounts we follow,
+			// include note in text search params.
+			vector = `to_tsvector('simple', "account"."username" || ' ' || COALESCE("account"."display_name", '') || ' ' || COALESCE("account"."note", ''))`
+		} else {
+			vector = `to_tsvector('simple', "account"."username" || ' ' || COALESCE("account"."display_name", ''))`
+		}
 
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
+		tsquery := pgPrefixQuery(terms)
+		return q.
+			Where("? @@ to_tsquery('simple', ?)", bun.Safe(vector), tsquery).
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC", bun.Safe(vector), tsquery).
+			Order("account.id DESC")
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return q
 	}
-
-	return accountText.ColumnExpr(query, args...)
 }
 
 // Query example (SQLite):
 //
-//	SELECT "status"."id"
-//	FROM "statuses" AS "status"
+//	SELECT "status"."id" FROM "statuses" AS "status"
+//	JOIN "statuses_fts_ids" AS "status_fts_id" ON "status_fts_id"."status_id" = "status"."id"
+//	JOIN "statuses_fts" ON "statuses_fts"."rowid" = "status_fts_id"."rowid"
 //	WHERE ("status"."boost_of_id" IS NULL)
-//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ...)
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
-//	ORDER BY "status"."id" DESC LIMIT 10
+//	AND ("statuses_fts" MATCH '("hello") NOT "world"')
+//	ORDER BY bm25("statuses_fts"), "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
 	query string,
 	fromAccountID string,
+	mediaOnly bool,
 	maxID string,
 	minID string,
 	limit int,
@@ -280,10 +432,7 @@ func (s *searchDB) SearchForStatuses(
 	}
 
 	// Make educated guess for slice size
-	var (
-		statusIDs   = make([]string, 0, limit)
-		frontToBack = true
-	)
+	statusIDs := make([]string, 0, limit)
 
 	q := s.db.
 		NewSelect().
@@ -292,17 +441,22 @@ func (s *searchDB) SearchForStatuses(
 		Column("status.id").
 		// Ignore boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
-		// Select only statuses created by
-		// accountID or replying to accountID.
+		// Select only statuses the requester has
+		// created or interacted with, or which
+		// are public and opted in to indexing.
 		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			return q.
-				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
-				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID)
+			return s.statusesSearchable(q, requestingAccountID)
 		})
+
 	if fromAccountID != "" {
 		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
 	}
 
+	if mediaOnly {
+		// Select only statuses with attachments.
+		q = whereHasAttachments(q)
+	}
+
 	// Return only items with a LOWER id than maxID.
 	if maxID == "" {
 		maxID = id.Highest
@@ -312,29 +466,30 @@ func (s *searchDB) SearchForStatuses(
 	if minID != "" {
 		// return only statuses HIGHER (ie., newer) than minID
 		q = q.Where("? > ?", bun.Ident("status.id"), minID)
-
-		// page up
-		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
+	if terms := parseSearchTerms(query); len(terms) != 0 {
+		if !hasPositiveTerm(terms) {
+			return nil, nil
+		}
 
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+		// Search the full-text index for
+		// the query, ordering by relevance.
+		q = s.statusText(q, terms)
+	} else {
+		// No text to search for, just the
+		// operators. Show newest first.
+		q = q.Order("status.id DESC")
+	}
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
-		// Page down.
-		q = q.Order("status.id DESC")
-	} else {
-		// Page up.
-		q = q.Order("status.id ASC")
+	if offset > 0 {
+		// Skip earlier pages of results.
+		q = q.Offset(offset)
 	}
 
 	if err := q.Scan(ctx, &statusIDs); err != nil {
@@ -345,15 +500,6 @@ func (s *searchDB) SearchForStatuses(
 		return nil, nil
 	}
 
-	// If we're paging up, we still want statuses
-	// to be sorted by ID desc, so reverse ids slice.
-	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
-		for l, r := 0, len(statusIDs)-1; l < r; l, r = l+1, r-1 {
-			statusIDs[l], statusIDs[r] = statusIDs[r], statusIDs[l]
-		}
-	}
-
 	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
 	for _, id := range statusIDs {
 		// Fetch status from db for ID
@@ -370,32 +516,117 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
+// statusesSearchable adds WHERE clauses to the given
+// query, selecting statuses that were created by accountID,
+// replied to accountID, mentioned accountID, or that accountID
+// has faved, bookmarked, or boosted, as well as public statuses
+// created by accounts that have opted in to being indexed.
+func (s *searchDB) statusesSearchable(q *bun.SelectQuery, accountID string) *bun.SelectQuery {
+	return q.
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID).
+		WhereOr("? IN (?)", bun.Ident("status.id"), s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("mentions"), bun.Ident("mention")).
+			Column("mention.status_id").
+			Where("? = ?", bun.Ident("mention.target_account_id"), accountID)).
+		WhereOr("? IN (?)", bun.Ident("status.id"), s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+			Column("status_fave.status_id").
+			Where("? = ?", bun.Ident("status_fave.account_id"), accountID)).
+		WhereOr("? IN (?)", bun.Ident("status.id"), s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+			Column("status_bookmark.status_id").
+			Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)).
+		WhereOr("? IN (?)", bun.Ident("status.id"), s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("boost")).
+			Column("boost.boost_of_id").
+			Where("? = ?", bun.Ident("boost.account_id"), accountID).
+			Where("? IS NOT NULL", bun.Ident("boost.boost_of_id"))).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+				WhereGroup(" AND ", s.indexableAccounts)
+		})
+}
 
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
+// indexableAccounts adds WHERE clauses to the given query,
+// selecting statuses created by local accounts which have
+// opted in to being indexed in their settings, or by remote
+// accounts which have advertised that they're indexable.
+func (s *searchDB) indexableAccounts(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.
+		Where("? IN (?)", bun.Ident("status.account_id"), s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("account_settings"), bun.Ident("account_settings")).
+			Column("account_settings.account_id").
+			Where("? = ?", bun.Ident("account_settings.indexable"), true)).
+		WhereOr("? IN (?)", bun.Ident("status.account_id"), s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+			Column("account.id").
+			Where("? IS NOT NULL", bun.Ident("account.domain")).
+			Where("? = ?", bun.Ident("account.indexable"), true))
+}
+
+// statusText adds full-text search of status content and
+// content warning for the given terms to the query, and
+// orders the query by relevance.
+func (s *searchDB) statusText(q *bun.SelectQuery, terms []searchTerm) *bun.SelectQuery {
 	switch d := s.db.Dialect().Name(); d {
 
 	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		return q.
+			Join(
+				"JOIN ? AS ? ON ? = ?",
+				bun.Ident("statuses_fts_ids"), bun.Ident("status_fts_id"),
+				bun.Ident("status_fts_id.status_id"), bun.Ident("status.id"),
+			).
+			Join(
+				"JOIN ? ON ? = ?",
+				bun.Ident("statuses_fts"),
+				bun.Ident("statuses_fts.rowid"), bun.Ident("status_fts_id.rowid"),
+			).
+			Where("? MATCH ?", bun.Ident("statuses_fts"), ftsMatch(terms, false)).
+			OrderExpr("bm25(?)", bun.Ident("statuses_fts")).
+			Order("status.id DESC")
 
 	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		// This expression must match
+		// that of statuses_fts_idx.
+		const vector = `to_tsvector(gts_search_config("status"."language"), COALESCE("status"."content_warning", '') || ' ' || COALESCE("status"."content", ''))`
+
+		// Statuses are indexed using the text search
+		// config for their own language, so match the
+		// query against each language we're likely to
+		// see, as well as the language-agnostic config.
+		websearch := pgWebsearch(terms)
+		langs := config.GetInstanceLanguages().TagStrs()
+		if len(langs) == 0 {
+			langs = []string{"en"}
+		}
+
+		var (
+			tsquery = "websearch_to_tsquery('simple', ?)"
+			args    = []any{websearch}
+		)
+		for _, lang := range langs {
+			tsquery += " || websearch_to_tsquery(gts_search_config(?), ?)"
+			args = append(args, lang, websearch)
+		}
+
+		return q.
+			Where("? @@ ("+tsquery+")", append([]any{bun.Safe(vector)}, args...)...).
+			OrderExpr("ts_rank(?, "+tsquery+") DESC", append([]any{bun.Safe(vector)}, args...)...).
+			Order("status.id DESC")
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return q
 	}
-
-	return statusText
 }
 
 // Query example (SQLite):
@@ -455,6 +686,11 @@ func (s *searchDB) SearchForTags(
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 {
+		// Skip earlier pages of results.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("tag.id DESC")
@@ -23,6 +23,8 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type SearchTestSuite struct {
@@ -107,20 +109,148 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", 10, 0)
+	// Should get our own status, plus the admin
+	// status we've faved, ranked by relevance.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", false, "", "", 10, 0)
 	suite.NoError(err)
-	suite.Len(statuses, 1)
+	if suite.Len(statuses, 2) {
+		suite.Equal("01F8MHAMCHF6Y650WCRSCP4WMY", statuses[0].ID)
+		suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", statuses[1].ID)
+	}
 }
 
 func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 	fromAccount := suite.testAccounts["local_account_2"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, false, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 3) {
+		for _, status := range statuses {
+			suite.Equal(fromAccount.ID, status.AccountID)
+		}
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesPhrase() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, `"hi everyone"`, "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Words are all there, but not as a phrase.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, `"everyone hi"`, "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesExclude() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi -zork", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	// Only excluded terms should match nothing.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "-zork", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesMediaOnly() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "cow", "", true, "", "", 10, 0)
 	suite.NoError(err)
 	if suite.Len(statuses, 1) {
-		suite.Equal(fromAccount.ID, statuses[0].AccountID)
+		suite.Equal("01F8MH82FYRXD2RC6108DAJ5HB", statuses[0].ID)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", "", true, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOffset() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	all, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(all, 5)
+
+	// Paging by offset should get
+	// the same results in the same order.
+	var paged []*gtsmodel.Status
+	for offset := 0; offset < 10; offset += 2 {
+		page, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", "", false, "", "", 2, offset)
+		suite.NoError(err)
+		paged = append(paged, page...)
 	}
+
+	suite.Equal(all, paged)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesIndexUpdated() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Edit the status content.
+	status := new(gtsmodel.Status)
+	*status = *testStatus
+	status.Content = "capybaras are the best"
+	err := suite.db.UpdateStatus(ctx, status, "content")
+	suite.NoError(err)
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "capybaras", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(status.ID, statuses[0].ID)
+	}
+
+	// Old content shouldn't match anymore.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "hello", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Deleted status shouldn't match at all.
+	err = suite.db.DeleteStatusByID(ctx, status.ID)
+	suite.NoError(err)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "capybaras", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesIndexable() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	indexableAccount := suite.testAccounts["local_account_2"]
+
+	// Public status from an account that's opted
+	// in to indexing, which we haven't interacted with.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "shed", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_2_status_8"].ID, statuses[0].ID)
+	}
+
+	// Non-public status from the same account.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "sharing", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Opt out of indexing.
+	settings, err := suite.db.GetAccountSettings(ctx, indexableAccount.ID)
+	suite.NoError(err)
+	settings.Indexable = util.Ptr(false)
+	err = suite.db.UpdateAccountSettings(ctx, settings, "indexable")
+	suite.NoError(err)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "shed", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
 }
 
 func (suite *SearchTestSuite) TestSearchTags() {
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type spamDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *spamDB) GetQuarantinedStatusByID(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, error) {
+	return s.getQuarantinedStatus(ctx, "id", id)
+}
+
+func (s *spamDB) GetQuarantinedStatusByURI(ctx context.Context, uri string) (*gtsmodel.QuarantinedStatus, error) {
+	return s.getQuarantinedStatus(ctx, "uri", uri)
+}
+
+func (s *spamDB) getQuarantinedStatus(ctx context.Context, column string, value any) (*gtsmodel.QuarantinedStatus, error) {
+	var status gtsmodel.QuarantinedStatus
+
+	if err := s.db.
+		NewSelect().
+		Model(&status).
+		Where("? = ?", bun.Ident("quarantined_status."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (s *spamDB) GetQuarantinedStatuses(ctx context.Context, page *paging.Page) ([]*gtsmodel.QuarantinedStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statuses = make([]*gtsmodel.QuarantinedStatus, 0, limit)
+	)
+
+	sq := s.db.
+		NewSelect().
+		Model(&statuses)
+
+	// Return only statuses with id
+	// lower than provided maxID.
+	if maxID != "" {
+		sq = sq.Where("? < ?", bun.Ident("quarantined_status.id"), maxID)
+	}
+
+	// Return only statuses with id
+	// greater than provided minID.
+	if minID != "" {
+		sq = sq.Where("? > ?", bun.Ident("quarantined_status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// statuses returned.
+		sq = sq.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		sq = sq.OrderExpr("? ASC", bun.Ident("quarantined_status.id"))
+	} else {
+		// Page down.
+		sq = sq.OrderExpr("? DESC", bun.Ident("quarantined_status.id"))
+	}
+
+	if err := sq.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no statuses early
+	if len(statuses) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statuses)
+	}
+
+	return statuses, nil
+}
+
+func (s *spamDB) PutQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) error {
+	_, err := s.db.
+		NewInsert().
+		Model(status).
+		Exec(ctx)
+	return err
+}
+
+func (s *spamDB) DeleteQuarantinedStatusByID(ctx context.Context, id string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Where("? = ?", bun.Ident("quarantined_status.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (s *spamDB) GetSpamSignalByID(ctx context.Context, id string) (*gtsmodel.SpamSignal, error) {
+	var signal gtsmodel.SpamSignal
+
+	if err := s.db.
+		NewSelect().
+		Model(&signal).
+		Where("? = ?", bun.Ident("spam_signal.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &signal, nil
+}
+
+func (s *spamDB) GetSpamSignal(ctx context.Context, signalType gtsmodel.SpamSignalType, value string) (*gtsmodel.SpamSignal, error) {
+	var signal gtsmodel.SpamSignal
+
+	if err := s.db.
+		NewSelect().
+		Model(&signal).
+		Where("? = ?", bun.Ident("spam_signal.type"), signalType).
+		Where("? = ?", bun.Ident("spam_signal.value"), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &signal, nil
+}
+
+func (s *spamDB) GetSpamSignals(ctx context.Context) ([]*gtsmodel.SpamSignal, error) {
+	return s.state.Caches.SpamSignals.Load(func() ([]*gtsmodel.SpamSignal, error) {
+		var signals []*gtsmodel.SpamSignal
+
+		if err := s.db.
+			NewSelect().
+			Model(&signals).
+			Order("spam_signal.type ASC", "spam_signal.value ASC").
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return signals, nil
+	})
+}
+
+func (s *spamDB) PutSpamSignal(ctx context.Context, signal *gtsmodel.SpamSignal) error {
+	// Signals changed, reload on next get.
+	defer s.state.Caches.SpamSignals.Clear()
+
+	_, err := s.db.
+		NewInsert().
+		Model(signal).
+		Exec(ctx)
+	return err
+}
+
+func (s *spamDB) UpdateSpamSignal(ctx context.Context, signal *gtsmodel.SpamSignal, columns ...string) error {
+	signal.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Signals changed, reload on next get.
+	defer s.state.Caches.SpamSignals.Clear()
+
+	_, err := s.db.
+		NewUpdate().
+		Model(signal).
+		Where("? = ?", bun.Ident("spam_signal.id"), signal.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (s *spamDB) DeleteSpamSignalByID(ctx context.Context, id string) error {
+	// Signals changed, reload on next get.
+	defer s.state.Caches.SpamSignals.Clear()
+
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("spam_signals"), bun.Ident("spam_signal")).
+		Where("? = ?", bun.Ident("spam_signal.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -181,7 +181,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -243,6 +243,19 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" && status.QuoteOf == nil {
+		// Status quote is not set, fetch from database.
+		status.QuoteOf, err = s.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.QuoteOfID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Quoted status may have since
+			// been deleted, which is fine.
+			errs.Appendf("error populating status quote: %w", err)
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -254,6 +267,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.PreviewCardID != "" && status.PreviewCard == nil {
+		// Status preview card is not set, fetch from database.
+		status.PreviewCard, err = s.state.DB.GetPreviewCardByID(
+			ctx,
+			status.PreviewCardID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status preview card: %w", err)
+		}
+	}
+
 	if !status.AttachmentsPopulated() {
 		// Status attachments are out-of-date with IDs, repopulate.
 		status.Attachments, err = s.state.DB.GetAttachmentsByIDs(
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusReactionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) TestPutGetDeleteStatusReaction() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		status  = suite.testStatuses["admin_account_status_1"]
+		emoji   = suite.testEmojis["rainbow"]
+	)
+
+	reactions := []*gtsmodel.StatusReaction{
+		{
+			ID:              "01J15B9A4R2CG8ZF3EBN7V0RXH",
+			AccountID:       account.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+			Name:            "👍",
+			URI:             "http://localhost:8080/users/the_mighty_zork/liked/01J15B9A4R2CG8ZF3EBN7V0RXH",
+		},
+		{
+			ID:              "01J15BCN2GPVHQ7EYRZ8Y4XWNS",
+			AccountID:       account.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+			Name:            ":" + emoji.Shortcode + ":",
+			EmojiID:         emoji.ID,
+			URI:             "http://localhost:8080/users/the_mighty_zork/liked/01J15BCN2GPVHQ7EYRZ8Y4XWNS",
+		},
+	}
+
+	for _, reaction := range reactions {
+		if err := suite.db.PutStatusReaction(ctx, reaction); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Same account + status + emoji should be rejected.
+	err := suite.db.PutStatusReaction(ctx, &gtsmodel.StatusReaction{
+		ID:              "01J15BFW0R6Q8X3Q6DVZB7NV1H",
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            "👍",
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01J15BFW0R6Q8X3Q6DVZB7NV1H",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	got, err := suite.db.GetStatusReactions(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(got, 2) {
+		suite.Equal("👍", got[0].Name)
+		suite.Nil(got[0].Emoji)
+		suite.Equal(":rainbow:", got[1].Name)
+		suite.NotNil(got[1].Emoji)
+		for _, reaction := range got {
+			suite.NotNil(reaction.Account)
+			suite.NotNil(reaction.TargetAccount)
+			suite.NotNil(reaction.Status)
+		}
+	}
+
+	reaction, err := suite.db.GetStatusReaction(ctx, account.ID, status.ID, ":rainbow:")
+	suite.NoError(err)
+	suite.Equal(reactions[1].ID, reaction.ID)
+
+	if err := suite.db.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetStatusReaction(ctx, account.ID, status.ID, ":rainbow:")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	got, err = suite.db.GetStatusReactions(ctx, status.ID)
+	suite.NoError(err)
+	suite.Len(got, 1)
+
+	if err := suite.db.DeleteStatusReactionsForStatus(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	got, err = suite.db.GetStatusReactions(ctx, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(got)
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
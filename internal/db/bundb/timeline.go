@@ -158,7 +158,7 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
-func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error) {
+func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool, languages []string) ([]*gtsmodel.Status, error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -213,6 +213,19 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 		q = q.Where("? = ?", bun.Ident("status.local"), local)
 	}
 
+	if len(languages) != 0 {
+		// return only statuses in one of the given
+		// base languages, or any region etc. of them
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, lang := range languages {
+				q = q.
+					WhereOr("? = ?", bun.Ident("status.language"), lang).
+					WhereOr("? LIKE ?", bun.Ident("status.language"), lang+"-%")
+			}
+			return q
+		})
+	}
+
 	if limit > 0 {
 		// limit amount of statuses returned
 		q = q.Limit(limit)
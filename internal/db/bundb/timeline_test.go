@@ -115,7 +115,7 @@ func (suite *TimelineTestSuite) checkStatuses(statuses []*gtsmodel.Status, maxID
 func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	ctx := context.Background()
 
-	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false)
+	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -137,7 +137,7 @@ func (suite *TimelineTestSuite) TestGetPublicTimelineWithFutureStatus() {
 		suite.FailNow(err.Error())
 	}
 
-	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false)
+	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -146,6 +146,41 @@ func (suite *TimelineTestSuite) TestGetPublicTimelineWithFutureStatus() {
 	suite.checkStatuses(s, id.Highest, id.Lowest, suite.publicCount())
 }
 
+func (suite *TimelineTestSuite) TestGetPublicTimelineLanguages() {
+	ctx := context.Background()
+
+	// Insert a Canadian French status,
+	// it should be found by base "fr".
+	frenchStatus := getFutureStatus()
+	frenchStatus.ID = id.NewULID()
+	frenchStatus.URI = "http://localhost:8080/users/admin/statuses/" + frenchStatus.ID
+	frenchStatus.URL = "http://localhost:8080/@admin/statuses/" + frenchStatus.ID
+	frenchStatus.CreatedAt = time.Now()
+	frenchStatus.UpdatedAt = time.Now()
+	frenchStatus.Language = "fr-CA"
+	if err := suite.db.PutStatus(ctx, frenchStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, []string{"fr"})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(s, 1)
+	suite.Equal(frenchStatus.ID, s[0].ID)
+
+	s, err = suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, []string{"en", "de"})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(s)
+	for _, status := range s {
+		suite.Equal("en", status.Language)
+	}
+}
+
 func (suite *TimelineTestSuite) TestGetHomeTimeline() {
 	var (
 		ctx            = context.Background()
@@ -0,0 +1,244 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/uptrace/bun"
+)
+
+type trendDB struct {
+	db *bun.DB
+}
+
+func (t *trendDB) GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error) {
+	review := new(gtsmodel.TrendReview)
+
+	if err := t.db.
+		NewSelect().
+		Model(review).
+		Where("? = ?", bun.Ident("trend_review.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (t *trendDB) GetTrendReview(ctx context.Context, targetType gtsmodel.TrendType, targetID string) (*gtsmodel.TrendReview, error) {
+	review := new(gtsmodel.TrendReview)
+
+	if err := t.db.
+		NewSelect().
+		Model(review).
+		Where("? = ?", bun.Ident("trend_review.target_type"), targetType).
+		Where("? = ?", bun.Ident("trend_review.target_id"), targetID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (t *trendDB) GetTrendReviews(ctx context.Context, targetType gtsmodel.TrendType) ([]*gtsmodel.TrendReview, error) {
+	reviews := []*gtsmodel.TrendReview{}
+
+	if err := t.db.
+		NewSelect().
+		Model(&reviews).
+		Where("? = ?", bun.Ident("trend_review.target_type"), targetType).
+		OrderExpr("? ASC", bun.Ident("trend_review.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (t *trendDB) PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error {
+	_, err := t.db.
+		NewInsert().
+		Model(review).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error {
+	review.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.
+		NewUpdate().
+		Model(review).
+		Where("? = ?", bun.Ident("trend_review.id"), review.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) DeleteTrendReviewByID(ctx context.Context, id string) error {
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("trend_reviews"), bun.Ident("trend_review")).
+		Where("? = ?", bun.Ident("trend_review.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) GetTrendableStatuses(ctx context.Context, since time.Time, page *paging.Page) ([]*gtsmodel.Status, error) {
+	// Status IDs are ULIDs, so we can use the
+	// lowest possible ID at the given time as a
+	// boundary, rather than the created_at column.
+	sinceID, err := id.NewLowestULIDFromTime(since)
+	if err != nil {
+		return nil, gtserror.Newf("error creating boundary id: %w", err)
+	}
+
+	var (
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+	)
+
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.db.
+		NewSelect().
+		Model(&statuses).
+		Column("id", "created_at", "account_id", "content").
+		Where("? >= ?", bun.Ident("status.id"), sinceID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		OrderExpr("? DESC", bun.Ident("status.id"))
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (t *trendDB) CountTagUsage(ctx context.Context, since time.Time, until time.Time) (map[string]gtsmodel.TrendUsage, error) {
+	sinceID, err := id.NewLowestULIDFromTime(since)
+	if err != nil {
+		return nil, gtserror.Newf("error creating boundary id: %w", err)
+	}
+
+	untilID, err := id.NewLowestULIDFromTime(until)
+	if err != nil {
+		return nil, gtserror.Newf("error creating boundary id: %w", err)
+	}
+
+	var rows []struct {
+		TagID    string
+		Uses     int
+		Accounts int
+	}
+
+	// Count the uses of each tag, and the distinct
+	// accounts using it, by statuses in the range.
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("statuses"), bun.Ident("status"), bun.Ident("status.id"), bun.Ident("status_to_tag.status_id")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("tag_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("uses")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("status.account_id"), bun.Ident("accounts")).
+		Where("? >= ?", bun.Ident("status.id"), sinceID).
+		Where("? < ?", bun.Ident("status.id"), untilID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		GroupExpr("?", bun.Ident("status_to_tag.tag_id")).
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]gtsmodel.TrendUsage, len(rows))
+	for _, row := range rows {
+		usages[row.TagID] = gtsmodel.TrendUsage{
+			Uses:     row.Uses,
+			Accounts: row.Accounts,
+		}
+	}
+
+	return usages, nil
+}
+
+func (t *trendDB) CountTrendableStatusesInteractions(ctx context.Context, since time.Time) (map[string]int, error) {
+	sinceID, err := id.NewLowestULIDFromTime(since)
+	if err != nil {
+		return nil, gtserror.Newf("error creating boundary id: %w", err)
+	}
+
+	// trendable selects the counts of the given interactions
+	// with public, non-boost, non-sensitive statuses created
+	// since the boundary, grouped by the interacted-with status.
+	trendable := func(table string, alias string, column string) *bun.SelectQuery {
+		return t.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+			Join("JOIN ? AS ? ON ? = ?", bun.Ident(table), bun.Ident(alias), bun.Ident(alias+"."+column), bun.Ident("status.id")).
+			ColumnExpr("? AS ?", bun.Ident("status.id"), bun.Ident("status_id")).
+			ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+			Where("? >= ?", bun.Ident("status.id"), sinceID).
+			Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+			Where("? IS NULL", bun.Ident("status.boost_of_id")).
+			Where("? = ?", bun.Ident("status.sensitive"), false).
+			GroupExpr("?", bun.Ident("status.id"))
+	}
+
+	counts := make(map[string]int)
+
+	// Gather the counts of boosts and faves.
+	for _, query := range []*bun.SelectQuery{
+		trendable("statuses", "boost", "boost_of_id"),
+		trendable("status_faves", "status_fave", "status_id"),
+	} {
+		var rows []struct {
+			StatusID string
+			Count    int
+		}
+
+		if err := query.Scan(ctx, &rows); err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			counts[row.StatusID] += row.Count
+		}
+	}
+
+	return counts, nil
+}
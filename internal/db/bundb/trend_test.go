@@ -0,0 +1,229 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type TrendTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TrendTestSuite) TestTrendReviews() {
+	ctx := context.Background()
+
+	review := &gtsmodel.TrendReview{
+		ID:         id.NewULID(),
+		TargetType: gtsmodel.TrendTypeTag,
+		TargetID:   suite.testTags["welcome"].ID,
+	}
+
+	if err := suite.db.PutTrendReview(ctx, review); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only one review per target.
+	err := suite.db.PutTrendReview(ctx, &gtsmodel.TrendReview{
+		ID:         id.NewULID(),
+		TargetType: review.TargetType,
+		TargetID:   review.TargetID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dbReview, err := suite.db.GetTrendReview(ctx, gtsmodel.TrendTypeTag, review.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(review.ID, dbReview.ID)
+	suite.True(dbReview.Pending())
+
+	dbReview.Approved = util.Ptr(true)
+	dbReview.ReviewedByAccountID = suite.testAccounts["admin_account"].ID
+	dbReview.ReviewedAt = time.Now()
+	if err := suite.db.UpdateTrendReview(ctx, dbReview,
+		"approved",
+		"reviewed_by_account_id",
+		"reviewed_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbReview, err = suite.db.GetTrendReviewByID(ctx, review.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbReview.Pending())
+	suite.True(*dbReview.Approved)
+
+	// Reviews are per target type.
+	reviews, err := suite.db.GetTrendReviews(ctx, gtsmodel.TrendTypeStatus)
+	suite.NoError(err)
+	suite.Empty(reviews)
+
+	reviews, err = suite.db.GetTrendReviews(ctx, gtsmodel.TrendTypeTag)
+	suite.NoError(err)
+	suite.Len(reviews, 1)
+
+	if err := suite.db.DeleteTrendReviewByID(ctx, review.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetTrendReviewByID(ctx, review.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func (suite *TrendTestSuite) TestGetTrendableStatuses() {
+	ctx := context.Background()
+
+	// All test statuses were
+	// created long before now.
+	statuses, err := suite.db.GetTrendableStatuses(ctx, time.Now().Add(-7*24*time.Hour), nil)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Only public non-boosts should be returned.
+	statuses, err = suite.db.GetTrendableStatuses(ctx, time.Unix(0, 0), nil)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	testStatuses := make(map[string]*gtsmodel.Status)
+	for _, status := range suite.testStatuses {
+		testStatuses[status.ID] = status
+	}
+
+	for _, status := range statuses {
+		testStatus := testStatuses[status.ID]
+		suite.Equal(gtsmodel.VisibilityPublic, testStatus.Visibility)
+		suite.Empty(testStatus.BoostOfID)
+		suite.Equal(testStatus.AccountID, status.AccountID)
+		suite.Equal(testStatus.Content, status.Content)
+	}
+
+	// Paging through should return
+	// the same statuses, newest first.
+	var (
+		paged []*gtsmodel.Status
+		page  = paging.Page{Limit: 2}
+	)
+
+	for {
+		statuses, err := suite.db.GetTrendableStatuses(ctx, time.Unix(0, 0), &page)
+		suite.NoError(err)
+		if len(statuses) == 0 {
+			break
+		}
+
+		suite.LessOrEqual(len(statuses), 2)
+		paged = append(paged, statuses...)
+		page.Max = paging.MaxID(statuses[len(statuses)-1].ID)
+	}
+
+	suite.Equal(len(statuses), len(paged))
+	for i := 1; i < len(paged); i++ {
+		suite.Greater(paged[i-1].ID, paged[i].ID)
+	}
+}
+
+func (suite *TrendTestSuite) TestCountTagUsage() {
+	ctx := context.Background()
+
+	// All test statuses were
+	// created long before now.
+	usages, err := suite.db.CountTagUsage(ctx, time.Now().Add(-24*time.Hour), time.Now())
+	suite.NoError(err)
+	suite.Empty(usages)
+
+	// Tally the expected usage from the
+	// public non-boost test statuses.
+	expected := make(map[string]gtsmodel.TrendUsage)
+	accounts := make(map[string]map[string]struct{})
+	for _, status := range suite.testStatuses {
+		if status.Visibility != gtsmodel.VisibilityPublic ||
+			status.BoostOfID != "" {
+			continue
+		}
+
+		for _, tagID := range util.Deduplicate(status.TagIDs) {
+			if accounts[tagID] == nil {
+				accounts[tagID] = make(map[string]struct{})
+			}
+			accounts[tagID][status.AccountID] = struct{}{}
+
+			usage := expected[tagID]
+			usage.Uses++
+			usage.Accounts = len(accounts[tagID])
+			expected[tagID] = usage
+		}
+	}
+	suite.NotEmpty(expected)
+
+	usages, err = suite.db.CountTagUsage(ctx, time.Unix(0, 0), time.Now())
+	suite.NoError(err)
+	suite.Equal(expected, usages)
+}
+
+func (suite *TrendTestSuite) TestCountTrendableStatusesInteractions() {
+	ctx := context.Background()
+
+	// All test statuses were
+	// created long before now.
+	counts, err := suite.db.CountTrendableStatusesInteractions(ctx, time.Now().Add(-48*time.Hour))
+	suite.NoError(err)
+	suite.Empty(counts)
+
+	counts, err = suite.db.CountTrendableStatusesInteractions(ctx, time.Unix(0, 0))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(counts)
+
+	// Counts should match boosts + faves of public,
+	// non-boost, non-sensitive statuses that have any.
+	for _, status := range suite.testStatuses {
+		boosts, err := suite.db.CountStatusBoosts(ctx, status.ID)
+		suite.NoError(err)
+
+		faves, err := suite.db.CountStatusFaves(ctx, status.ID)
+		suite.NoError(err)
+
+		count, ok := counts[status.ID]
+		if status.Visibility != gtsmodel.VisibilityPublic ||
+			status.BoostOfID != "" ||
+			*status.Sensitive ||
+			boosts+faves == 0 {
+			suite.False(ok, status.ID)
+			continue
+		}
+
+		suite.Equal(boosts+faves, count, status.ID)
+	}
+}
+
+func TestTrendTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendTestSuite))
+}
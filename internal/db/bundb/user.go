@@ -114,6 +114,28 @@ func (u *userDB) GetUserByConfirmationToken(ctx context.Context, token string) (
 	)
 }
 
+func (u *userDB) GetUserByEmailUnsubscribeToken(ctx context.Context, token string) (*gtsmodel.User, error) {
+	return u.getUser(
+		ctx,
+		"EmailUnsubscribeToken",
+		func(user *gtsmodel.User) error {
+			return u.db.NewSelect().Model(user).Where("? = ?", bun.Ident("email_unsubscribe_token"), token).Scan(ctx)
+		},
+		token,
+	)
+}
+
+func (u *userDB) GetUserByFeedToken(ctx context.Context, token string) (*gtsmodel.User, error) {
+	return u.getUser(
+		ctx,
+		"FeedToken",
+		func(user *gtsmodel.User) error {
+			return u.db.NewSelect().Model(user).Where("? = ?", bun.Ident("feed_token"), token).Scan(ctx)
+		},
+		token,
+	)
+}
+
 func (u *userDB) getUser(ctx context.Context, lookup string, dbQuery func(*gtsmodel.User) error, keyParts ...any) (*gtsmodel.User, error) {
 	// Fetch user from database cache with loader callback.
 	user, err := u.state.Caches.GTS.User.LoadOne(lookup, func() (*gtsmodel.User, error) {
@@ -178,6 +200,72 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error) {
 	return u.GetUsersByIDs(ctx, userIDs)
 }
 
+func (u *userDB) GetUsersDueEmailDigest(ctx context.Context, now time.Time) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Select IDs of users with a digest frequency
+	// whose last digest is at least one period ago.
+	q := u.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, freq := range []gtsmodel.EmailNotifyFrequency{
+				gtsmodel.EmailNotifyFrequencyDaily,
+				gtsmodel.EmailNotifyFrequencyWeekly,
+			} {
+				due := now.Add(-freq.Period())
+				q = q.WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? = ?", bun.Ident("user.email_notify_frequency"), freq).
+						WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+							return q.
+								Where("? IS NULL", bun.Ident("user.email_digest_sent_at")).
+								WhereOr("? <= ?", bun.Ident("user.email_digest_sent_at"), due)
+						})
+				})
+			}
+			return q
+		})
+
+	if err := q.Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	// Transform user IDs into user slice.
+	return u.GetUsersByIDs(ctx, userIDs)
+}
+
+func (u *userDB) GetUsersInactiveSince(ctx context.Context, since time.Time) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Select IDs of enabled users who've either been
+	// warned already, or not been active since time.
+	q := u.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NOT NULL", bun.Ident("user.dormant_warned_at")).
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? <= ?", bun.Ident("user.created_at"), since).
+						WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+							return q.
+								Where("? IS NULL", bun.Ident("user.last_active_at")).
+								WhereOr("? <= ?", bun.Ident("user.last_active_at"), since)
+						})
+				})
+		})
+
+	if err := q.Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	// Transform user IDs into user slice.
+	return u.GetUsersByIDs(ctx, userIDs)
+}
+
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) error {
 	return u.state.Caches.GTS.User.Store(user, func() error {
 		_, err := u.db.
@@ -197,6 +285,11 @@ func (u *userDB) UpdateUser(ctx context.Context, user *gtsmodel.User, columns ..
 		columns = append(columns, "updated_at")
 	}
 
+	// Drop any cached copy of this user first, so that
+	// lookups by since-changed keys (eg., feed tokens)
+	// don't keep returning the stale cached copy.
+	u.state.Caches.GTS.User.Invalidate("ID", user.ID)
+
 	return u.state.Caches.GTS.User.Store(user, func() error {
 		_, err := u.db.
 			NewUpdate().
@@ -20,6 +20,7 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -35,6 +36,105 @@ func (suite *UserTestSuite) TestGetAllUsers() {
 	suite.Len(users, len(suite.testUsers))
 }
 
+func (suite *UserTestSuite) TestGetUsersDueEmailDigest() {
+	var (
+		ctx = context.Background()
+		now = time.Now()
+	)
+
+	// No test users want digests.
+	users, err := suite.db.GetUsersDueEmailDigest(ctx, now)
+	suite.NoError(err)
+	suite.Empty(users)
+
+	// Daily digest never sent, due.
+	never := new(gtsmodel.User)
+	*never = *suite.testUsers["local_account_1"]
+	never.EmailNotifyFrequency = gtsmodel.EmailNotifyFrequencyDaily
+	never.EmailDigestSentAt = time.Time{}
+
+	// Daily digest sent over a day ago, due.
+	daily := new(gtsmodel.User)
+	*daily = *suite.testUsers["local_account_2"]
+	daily.EmailNotifyFrequency = gtsmodel.EmailNotifyFrequencyDaily
+	daily.EmailDigestSentAt = now.Add(-25 * time.Hour)
+
+	// Weekly digest sent a couple days ago, not due.
+	weekly := new(gtsmodel.User)
+	*weekly = *suite.testUsers["admin_account"]
+	weekly.EmailNotifyFrequency = gtsmodel.EmailNotifyFrequencyWeekly
+	weekly.EmailDigestSentAt = now.Add(-48 * time.Hour)
+
+	for _, user := range []*gtsmodel.User{never, daily, weekly} {
+		if err := suite.db.UpdateUser(ctx, user,
+			"email_notify_frequency",
+			"email_digest_sent_at",
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	users, err = suite.db.GetUsersDueEmailDigest(ctx, now)
+	suite.NoError(err)
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	suite.ElementsMatch([]string{never.ID, daily.ID}, ids)
+
+	// A week later, all are due.
+	users, err = suite.db.GetUsersDueEmailDigest(ctx, now.Add(7*24*time.Hour))
+	suite.NoError(err)
+	suite.Len(users, 3)
+}
+
+func (suite *UserTestSuite) TestGetUsersInactiveSince() {
+	var (
+		ctx   = context.Background()
+		since = time.Now().Add(-time.Hour)
+	)
+
+	// Test users were all created long ago,
+	// and have never signed in, except for
+	// zork, who has signed in just now.
+	zork := new(gtsmodel.User)
+	*zork = *suite.testUsers["local_account_1"]
+	zork.LastActiveAt = time.Now()
+
+	// Turtle has signed in recently,
+	// but was warned about it before.
+	turtle := new(gtsmodel.User)
+	*turtle = *suite.testUsers["local_account_2"]
+	turtle.LastActiveAt = time.Now()
+	turtle.DormantWarnedAt = time.Now().Add(-24 * time.Hour)
+
+	for _, user := range []*gtsmodel.User{zork, turtle} {
+		if err := suite.db.UpdateUser(ctx, user,
+			"last_active_at",
+			"dormant_warned_at",
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	users, err := suite.db.GetUsersInactiveSince(ctx, since)
+	suite.NoError(err)
+
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	suite.Contains(ids, turtle.ID)
+	suite.Contains(ids, suite.testUsers["admin_account"].ID)
+	suite.NotContains(ids, zork.ID)
+
+	// Disabled users are never included.
+	for _, user := range users {
+		suite.False(*user.Disabled)
+	}
+}
+
 func (suite *UserTestSuite) TestGetUser() {
 	user, err := suite.db.GetUserByID(context.Background(), suite.testUsers["local_account_1"].ID)
 	suite.NoError(err)
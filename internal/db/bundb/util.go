@@ -60,37 +60,11 @@ func likeOperator(query *bun.SelectQuery) string {
 	return ""
 }
 
-// whereLike appends a WHERE clause to the
-// given SelectQuery, which searches for
-// matches of `search` in the given subQuery
-// using LIKE (SQLite) or ILIKE (Postgres).
-func whereLike(
-	query *bun.SelectQuery,
-	subject interface{},
-	search string,
-) *bun.SelectQuery {
-	// Escape existing wildcard + escape
-	// chars in the search query string.
-	search = likeEscaper.Replace(search)
-
-	// Add our own wildcards back in; search
-	// zero or more chars around the query.
-	search = `%` + search + `%`
-
-	// Get appropriate operator.
-	like := likeOperator(query)
-
-	// Append resulting WHERE
-	// clause to the main query.
-	return query.Where(
-		"(?) ? ? ESCAPE ?",
-		subject, bun.Safe(like), search, `\`,
-	)
-}
-
-// whereStartsLike is like whereLike,
-// but only searches for strings that
-// START WITH `search`.
+// whereStartsLike appends a WHERE clause
+// to the given SelectQuery, which searches
+// for strings in the given subject that
+// START WITH `search`, using LIKE (SQLite)
+// or ILIKE (Postgres).
 func whereStartsLike(
 	query *bun.SelectQuery,
 	subject interface{},
@@ -115,6 +89,33 @@ func whereStartsLike(
 	)
 }
 
+// whereHasAttachments appends a WHERE clause to
+// the given SelectQuery, which selects only statuses
+// (aliased as "status") that have media attachments.
+func whereHasAttachments(query *bun.SelectQuery) *bun.SelectQuery {
+	// Attachments are stored as a json object; this
+	// implementation differs between SQLite and Postgres,
+	// so we have to be thorough to cover all eventualities
+	return query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		switch d := q.Dialect().Name(); d {
+		case dialect.PG:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments"))
+		case dialect.SQLite:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != ''", bun.Ident("status.attachments")).
+				Where("? != 'null'", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments")).
+				Where("? != '[]'", bun.Ident("status.attachments"))
+		default:
+			log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+			return q
+		}
+	})
+}
+
 // exists checks the results of a SelectQuery for the existence of the data in question, masking ErrNoEntries errors.
 func exists(ctx context.Context, query *bun.SelectQuery) (bool, error) {
 	exists, err := query.Exists(ctx)
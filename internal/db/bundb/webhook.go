@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type webhookDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (w *webhookDB) GetWebhookByID(ctx context.Context, id string) (*gtsmodel.Webhook, error) {
+	var webhook gtsmodel.Webhook
+
+	if err := w.db.
+		NewSelect().
+		Model(&webhook).
+		Where("? = ?", bun.Ident("webhook.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookDB) GetWebhooks(ctx context.Context) ([]*gtsmodel.Webhook, error) {
+	return w.state.Caches.Webhooks.Load(func() ([]*gtsmodel.Webhook, error) {
+		var webhooks []*gtsmodel.Webhook
+
+		if err := w.db.
+			NewSelect().
+			Model(&webhooks).
+			Order("webhook.id ASC").
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return webhooks, nil
+	})
+}
+
+func (w *webhookDB) PutWebhook(ctx context.Context, webhook *gtsmodel.Webhook) error {
+	// Webhooks changed, reload on next get.
+	defer w.state.Caches.Webhooks.Clear()
+
+	_, err := w.db.
+		NewInsert().
+		Model(webhook).
+		Exec(ctx)
+	return err
+}
+
+func (w *webhookDB) UpdateWebhook(ctx context.Context, webhook *gtsmodel.Webhook, columns ...string) error {
+	webhook.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Webhooks changed, reload on next get.
+	defer w.state.Caches.Webhooks.Clear()
+
+	_, err := w.db.
+		NewUpdate().
+		Model(webhook).
+		Where("? = ?", bun.Ident("webhook.id"), webhook.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (w *webhookDB) DeleteWebhookByID(ctx context.Context, id string) error {
+	// Webhooks changed, reload on next get.
+	defer w.state.Caches.Webhooks.Clear()
+
+	_, err := w.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("webhooks"), bun.Ident("webhook")).
+		Where("? = ?", bun.Ident("webhook.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -30,8 +30,10 @@ type DB interface {
 	Basic
 	Domain
 	Emoji
+	FederationPolicy
 	HeaderFilter
 	Instance
+	Invite
 	Filter
 	List
 	Marker
@@ -40,17 +42,23 @@ type DB interface {
 	Move
 	Notification
 	Poll
+	PreviewCard
+	QueuedEmail
 	Relationship
 	Report
 	Rule
 	Search
 	Session
+	Spam
 	Status
 	StatusBookmark
 	StatusFave
+	StatusReaction
 	Tag
+	Trend
 	Thread
 	Timeline
 	User
 	Tombstone
+	Webhook
 }
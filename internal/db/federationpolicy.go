@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FederationPolicy interface {
+	// GetFederationPolicyByID fetches the federation policy with given ID from the database.
+	GetFederationPolicyByID(ctx context.Context, id string) (*gtsmodel.FederationPolicy, error)
+
+	// GetFederationPolicies fetches all federation policies from the database (including
+	// disabled ones), in the order they should be run in. The result is cached, and so
+	// the returned policies MUST NOT be modified.
+	GetFederationPolicies(ctx context.Context) ([]*gtsmodel.FederationPolicy, error)
+
+	// PutFederationPolicy puts the given federation policy in the database.
+	PutFederationPolicy(ctx context.Context, policy *gtsmodel.FederationPolicy) error
+
+	// UpdateFederationPolicy updates the federation policy in the database, only on selected columns if provided (else, all).
+	UpdateFederationPolicy(ctx context.Context, policy *gtsmodel.FederationPolicy, columns ...string) error
+
+	// DeleteFederationPolicyByID deletes the federation policy with given ID from the database.
+	DeleteFederationPolicyByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type Invite interface {
+	// GetInviteByID fetches the invite with given ID from the database.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode fetches the invite with given code from the database.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvites fetches a page of invites from the database, created by the
+	// given account ID, or by any account if accountID is empty. Newest first.
+	GetInvites(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Invite, error)
+
+	// PutInvite puts the given invite in the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates the invite in the database, only on selected columns if provided (else, all).
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// IncrementInviteUses adds one to the number of uses of the given
+	// invite, unless it has reached its maximum number of uses already,
+	// in which case ErrNoEntries is returned. This is done atomically,
+	// so that concurrent sign-ups cannot use an invite too many times.
+	IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// DecrementInviteUses takes one from the number of uses of the given
+	// invite, to give back a use taken by IncrementInviteUses for a sign-up
+	// that subsequently failed. This is done atomically, as above.
+	DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// DeleteInviteByID deletes the invite with given ID from the database.
+	DeleteInviteByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type PreviewCard interface {
+	// GetPreviewCardByID fetches the preview card with given ID from the database.
+	GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, error)
+
+	// GetPreviewCardByURL fetches the preview card for the given linked page URL from the database.
+	GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, error)
+
+	// GetPreviewCardByImageURL fetches the preview card with the given local preview image URL from the database.
+	GetPreviewCardByImageURL(ctx context.Context, imageURL string) (*gtsmodel.PreviewCard, error)
+
+	// PutPreviewCard puts the given preview card in the database.
+	PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error
+
+	// UpdatePreviewCard updates the preview card in the database, only on selected columns if provided (else, all).
+	UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, columns ...string) error
+
+	// DeletePreviewCardByID deletes the preview card with given ID from the database,
+	// also unsetting it on any statuses that link to it.
+	DeletePreviewCardByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type QueuedEmail interface {
+	// GetQueuedEmailByID returns one queued email with the given id.
+	GetQueuedEmailByID(ctx context.Context, id string) (*gtsmodel.QueuedEmail, error)
+
+	// GetDueQueuedEmails returns up to limit queued emails that have not
+	// failed, and whose next delivery attempt is due at or before now,
+	// oldest first.
+	GetDueQueuedEmails(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.QueuedEmail, error)
+
+	// GetFailedQueuedEmails returns a page of queued emails
+	// whose delivery has been given up on, newest first.
+	GetFailedQueuedEmails(ctx context.Context, page *paging.Page) ([]*gtsmodel.QueuedEmail, error)
+
+	// PutQueuedEmail inserts the given queued email into the database.
+	PutQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail) error
+
+	// UpdateQueuedEmail updates the given queued email in the database, only updating given columns if provided.
+	UpdateQueuedEmail(ctx context.Context, email *gtsmodel.QueuedEmail, columns ...string) error
+
+	// DeleteQueuedEmailByID deletes one queued email with the given id.
+	DeleteQueuedEmailByID(ctx context.Context, id string) error
+}
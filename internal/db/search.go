@@ -24,12 +24,16 @@ import (
 )
 
 type Search interface {
-	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// SearchForAccounts uses the given query text to search for accounts, or only accounts that accountID follows if following is true.
+	// Results are ordered by relevance, except for queries starting with '@', which match usernames by prefix and are ordered by ID.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
-	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
-	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// SearchForStatuses uses the given query text to search for statuses created by, replying to, or mentioning
+	// requestingAccountID, faved, bookmarked, or boosted by requestingAccountID, or public and created by an account
+	// that has opted in to being indexed. Results are ordered by relevance.
+	// Query text may contain "quoted phrases" and -excluded terms. If fromAccountID is used, the results are restricted
+	// to statuses created by fromAccountID. If mediaOnly is true, only statuses with attachments are returned.
+	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, mediaOnly bool, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type Spam interface {
+	// GetQuarantinedStatusByID fetches the quarantined status with given ID from the database.
+	GetQuarantinedStatusByID(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, error)
+
+	// GetQuarantinedStatusByURI fetches the quarantined status with given status URI from the database.
+	GetQuarantinedStatusByURI(ctx context.Context, uri string) (*gtsmodel.QuarantinedStatus, error)
+
+	// GetQuarantinedStatuses fetches a page of quarantined statuses from the database, newest first.
+	GetQuarantinedStatuses(ctx context.Context, page *paging.Page) ([]*gtsmodel.QuarantinedStatus, error)
+
+	// PutQuarantinedStatus puts the given quarantined status in the database.
+	PutQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) error
+
+	// DeleteQuarantinedStatusByID deletes the quarantined status with given ID from the database.
+	DeleteQuarantinedStatusByID(ctx context.Context, id string) error
+
+	// GetSpamSignalByID fetches the spam signal with given ID from the database.
+	GetSpamSignalByID(ctx context.Context, id string) (*gtsmodel.SpamSignal, error)
+
+	// GetSpamSignal fetches the spam signal with given type and value from the database.
+	GetSpamSignal(ctx context.Context, signalType gtsmodel.SpamSignalType, value string) (*gtsmodel.SpamSignal, error)
+
+	// GetSpamSignals fetches all spam signals from the database. The
+	// result is cached, and so the returned signals MUST NOT be modified.
+	GetSpamSignals(ctx context.Context) ([]*gtsmodel.SpamSignal, error)
+
+	// PutSpamSignal puts the given spam signal in the database.
+	PutSpamSignal(ctx context.Context, signal *gtsmodel.SpamSignal) error
+
+	// UpdateSpamSignal updates the spam signal in the database, only on selected columns if provided (else, all).
+	UpdateSpamSignal(ctx context.Context, signal *gtsmodel.SpamSignal, columns ...string) error
+
+	// DeleteSpamSignalByID deletes the spam signal with given ID from the database.
+	DeleteSpamSignalByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type Trend interface {
+	// GetTrendReviewByID gets one trend review with the given id.
+	GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error)
+
+	// GetTrendReview gets the trend review for the given target type and
+	// target ID (tag ID, status ID, or link URL, depending on type).
+	GetTrendReview(ctx context.Context, targetType gtsmodel.TrendType, targetID string) (*gtsmodel.TrendReview, error)
+
+	// GetTrendReviews gets all trend reviews of the given target type.
+	GetTrendReviews(ctx context.Context, targetType gtsmodel.TrendType) ([]*gtsmodel.TrendReview, error)
+
+	// PutTrendReview inserts the given trend review into the database.
+	PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error
+
+	// UpdateTrendReview updates the given trend review in the database, only updating given columns if provided.
+	UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error
+
+	// DeleteTrendReviewByID deletes one trend review with the given id.
+	DeleteTrendReviewByID(ctx context.Context, id string) error
+
+	// GetTrendableStatuses returns a page of public, non-boost
+	// statuses created since the given time, newest first, for
+	// extracting the links they contain when computing trends.
+	//
+	// For efficiency these are fetched straight from the database,
+	// bypassing the cache, and are only partially populated: just
+	// the ID, CreatedAt, AccountID and Content fields are set.
+	// They MUST NOT be stored or put in the cache.
+	GetTrendableStatuses(ctx context.Context, since time.Time, page *paging.Page) ([]*gtsmodel.Status, error)
+
+	// CountTagUsage returns a map of tag IDs to their usage by public,
+	// non-boost statuses created between since (inclusive) and until
+	// (exclusive). Tags without any such uses are not included.
+	CountTagUsage(ctx context.Context, since time.Time, until time.Time) (map[string]gtsmodel.TrendUsage, error)
+
+	// CountTrendableStatusesInteractions returns a map of the IDs of
+	// public, non-boost, non-sensitive statuses created since the
+	// given time to the number of boosts and faves each has received.
+	// Statuses without any boosts or faves are not included.
+	CountTrendableStatusesInteractions(ctx context.Context, since time.Time) (map[string]int, error)
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -28,6 +29,14 @@ type User interface {
 	// GetAllUsers returns all local user accounts, or an error if something goes wrong.
 	GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error)
 
+	// GetUsersDueEmailDigest returns all users who want notification digest emails,
+	// and have either never been sent one, or are due one at the given time.
+	GetUsersDueEmailDigest(ctx context.Context, now time.Time) ([]*gtsmodel.User, error)
+
+	// GetUsersInactiveSince returns all enabled users who haven't signed up or signed in
+	// since the given time, plus all enabled users already warned about being dormant.
+	GetUsersInactiveSince(ctx context.Context, since time.Time) ([]*gtsmodel.User, error)
+
 	// GetUserByID returns one user with the given ID, or an error if something goes wrong.
 	GetUserByID(ctx context.Context, id string) (*gtsmodel.User, error)
 
@@ -43,6 +52,12 @@ type User interface {
 	// GetUserByConfirmationToken returns one user by its confirmation token, or an error if something goes wrong.
 	GetUserByConfirmationToken(ctx context.Context, confirmationToken string) (*gtsmodel.User, error)
 
+	// GetUserByEmailUnsubscribeToken returns one user by its email unsubscribe token, or an error if something goes wrong.
+	GetUserByEmailUnsubscribeToken(ctx context.Context, unsubscribeToken string) (*gtsmodel.User, error)
+
+	// GetUserByFeedToken returns one user by its private feed token, or an error if something goes wrong.
+	GetUserByFeedToken(ctx context.Context, feedToken string) (*gtsmodel.User, error)
+
 	// PopulateUser populates the struct pointers on the given user.
 	PopulateUser(ctx context.Context, user *gtsmodel.User) error
 
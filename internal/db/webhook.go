@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Webhook interface {
+	// GetWebhookByID fetches the webhook with given ID from the database.
+	GetWebhookByID(ctx context.Context, id string) (*gtsmodel.Webhook, error)
+
+	// GetWebhooks fetches all webhooks from the database (including disabled
+	// ones). The result is cached, and so the returned webhooks MUST NOT be modified.
+	GetWebhooks(ctx context.Context) ([]*gtsmodel.Webhook, error)
+
+	// PutWebhook puts the given webhook in the database.
+	PutWebhook(ctx context.Context, webhook *gtsmodel.Webhook) error
+
+	// UpdateWebhook updates the webhook in the database, only on selected columns if provided (else, all).
+	UpdateWebhook(ctx context.Context, webhook *gtsmodel.Webhook, columns ...string) error
+
+	// DeleteWebhookByID deletes the webhook with given ID from the database.
+	DeleteWebhookByID(ctx context.Context, id string) error
+}
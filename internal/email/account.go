@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
+const (
+	accountActionTemplate = "email_account_action.tmpl"
+	accountActionSubject  = "GoToSocial Moderation Notice"
+)
+
+type AccountActionData struct {
+	// Username to be addressed.
+	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Type of action taken on the account,
+	// eg., "silence", "unsilence", "disable".
+	ActionType string
+	// Message left by the moderator
+	// who took the action, if any.
+	Text string
+	// Time at which the action will be
+	// reversed automatically, if any.
+	ExpiresAt string
+}
+
+func (s *sender) SendAccountActionEmail(toAddress string, data AccountActionData) error {
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(accountActionTemplate, lang, i18n.Translate(lang, accountActionSubject), data, toAddress)
+}
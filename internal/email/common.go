@@ -19,9 +19,9 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"net/smtp"
 	"os"
 	"path/filepath"
 	"strings"
@@ -30,25 +30,70 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 )
 
-func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
-	buf := &bytes.Buffer{}
-	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+func (s *sender) sendTemplate(template string, lang string, subject string, data any, toAddresses ...string) error {
+	return s.sendTemplateWithHeaders(template, lang, subject, nil, data, toAddresses...)
+}
+
+// sendTemplateWithHeaders executes the given template in
+// the given language and assembles the resulting message,
+// then puts it on the queue to be delivered asynchronously.
+func (s *sender) sendTemplateWithHeaders(template string, lang string, subject string, headers []string, data any, toAddresses ...string) error {
+	msg, err := s.assembleTemplate(template, lang, subject, headers, data, toAddresses...)
+	if err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, s.msgIDHost, toAddresses...)
+	return s.queue.Enqueue(context.Background(), s.from, toAddresses, subject, msg)
+}
+
+// sendTemplateNow is like sendTemplate, but bypasses the queue
+// to deliver the message immediately, returning any error.
+func (s *sender) sendTemplateNow(template string, lang string, subject string, data any, toAddresses ...string) error {
+	msg, err := s.assembleTemplate(template, lang, subject, nil, data, toAddresses...)
 	if err != nil {
 		return err
 	}
 
-	if err := smtp.SendMail(s.hostAddress, s.auth, s.from, toAddresses, msg); err != nil {
-		return gtserror.SetSMTP(err)
+	return s.queue.send(s.from, toAddresses, msg)
+}
+
+func (s *sender) assembleTemplate(template string, lang string, subject string, headers []string, data any, toAddresses ...string) ([]byte, error) {
+	body, err := executeTemplate(s.template, template, lang, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleMessage(subject, body, headers, s.from, s.msgIDHost, toAddresses...)
+}
+
+// executeTemplate executes the named template with
+// its "t" function translating into the given language.
+func executeTemplate(tmpl *template.Template, name string, lang string, data any) (string, error) {
+	// Clone the templates so we can bind "t"
+	// to the language without racing other sends.
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{"t": i18n.Translator(lang)})
+
+	buf := &bytes.Buffer{}
+	if err := tmpl.ExecuteTemplate(buf, name, data); err != nil {
+		return "", err
 	}
 
-	return nil
+	return buf.String(), nil
+}
+
+// emailLanguage returns the language to write an email
+// in for a receiver with the given locale, falling back
+// to the instance languages and then to English.
+func emailLanguage(locale string) string {
+	locales := append([]string{locale}, config.GetInstanceLanguages().TagStrs()...)
+	return i18n.Match(locales...)
 }
 
 func loadTemplates(templateBaseDir string) (*template.Template, error) {
@@ -60,18 +105,43 @@ func loadTemplates(templateBaseDir string) (*template.Template, error) {
 		templateBaseDir = filepath.Join(cwd, templateBaseDir)
 	}
 
-	// look for all templates that start with 'email_'
-	return template.ParseGlob(filepath.Join(templateBaseDir, "email_*"))
+	// look for all templates that start with 'email_',
+	// with a placeholder "t" which is replaced per send
+	return template.New("").
+		Funcs(template.FuncMap{"t": i18n.Translator(i18n.DefaultLanguage)}).
+		ParseGlob(filepath.Join(templateBaseDir, "email_*"))
+}
+
+// unsubscribeHeaders returns the headers for one-click
+// unsubscription using the given URL, following:
+//   - https://datatracker.ietf.org/doc/html/rfc2369
+//   - https://datatracker.ietf.org/doc/html/rfc8058
+func unsubscribeHeaders(unsubscribeURL string) []string {
+	if unsubscribeURL == "" {
+		return nil
+	}
+	return []string{
+		"List-Unsubscribe: <" + unsubscribeURL + ">",
+		"List-Unsubscribe-Post: List-Unsubscribe=One-Click",
+	}
 }
 
 // assembleMessage assembles a valid email message following:
 //   - https://datatracker.ietf.org/doc/html/rfc2822
 //   - https://pkg.go.dev/net/smtp#SendMail
-func assembleMessage(mailSubject string, mailBody string, mailFrom string, msgIDHost string, mailTo ...string) ([]byte, error) {
+//
+// Headers, if given, should be complete "Name: value" header lines.
+func assembleMessage(mailSubject string, mailBody string, headers []string, mailFrom string, msgIDHost string, mailTo ...string) ([]byte, error) {
 	if strings.ContainsAny(mailSubject, "\r\n") {
 		return nil, errors.New("email subject must not contain newline characters")
 	}
 
+	for _, header := range headers {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, errors.New("email headers must not contain newline characters")
+		}
+	}
+
 	if strings.ContainsAny(mailFrom, "\r\n") {
 		return nil, errors.New("email from address must not contain newline characters")
 	}
@@ -109,6 +179,9 @@ func assembleMessage(mailSubject string, mailBody string, mailFrom string, msgID
 	msg.WriteString("From: " + mailFrom + CRLF)
 	msg.WriteString("Message-ID: <" + uuid.New().String() + "@" + msgIDHost + ">" + CRLF)
 	msg.WriteString("Subject: " + mailSubject + CRLF)
+	for _, header := range headers {
+		msg.WriteString(header + CRLF)
+	}
 	msg.WriteString("MIME-Version: 1.0" + CRLF)
 	msg.WriteString("Content-Transfer-Encoding: 8bit" + CRLF)
 	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"" + CRLF)
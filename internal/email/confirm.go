@@ -17,6 +17,8 @@
 
 package email
 
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
 const (
 	confirmTemplate = "email_confirm.tmpl"
 	confirmSubject  = "GoToSocial Email Confirmation"
@@ -26,6 +28,9 @@ const (
 type ConfirmData struct {
 	// Username to be addressed.
 	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
 	// URL of the instance to
 	// present to the receiver.
 	InstanceURL string
@@ -40,8 +45,13 @@ type ConfirmData struct {
 	// Is this confirm email being sent
 	// because this is a new sign-up?
 	NewSignup bool
+	// Handle of the account who invited the
+	// receiver, eg., "@someone", for new
+	// sign-ups made with an invite.
+	InvitedBy string
 }
 
 func (s *sender) SendConfirmEmail(toAddress string, data ConfirmData) error {
-	return s.sendTemplate(confirmTemplate, confirmSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(confirmTemplate, lang, i18n.Translate(lang, confirmSubject), data, toAddress)
 }
@@ -0,0 +1,287 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// dkimHeaders are the headers to include in DKIM
+// signatures, where present in the message.
+var dkimHeaders = []string{
+	"From",
+	"To",
+	"Subject",
+	"Date",
+	"Message-ID",
+	"MIME-Version",
+	"Content-Type",
+	"Content-Transfer-Encoding",
+	"List-Unsubscribe",
+	"List-Unsubscribe-Post",
+}
+
+// dkimSigner adds DKIM signatures to outgoing messages, using
+// relaxed/relaxed canonicalization, following:
+//   - https://datatracker.ietf.org/doc/html/rfc6376
+//   - https://datatracker.ietf.org/doc/html/rfc8463
+type dkimSigner struct {
+	domain    string
+	selector  string
+	algorithm string
+	key       crypto.Signer
+}
+
+// newDKIMSigner returns a new DKIM signer for the given domain
+// and selector, using the PEM encoded private key at keyPath.
+func newDKIMSigner(domain string, selector string, keyPath string) (*dkimSigner, error) {
+	if selector == "" {
+		return nil, errors.New("dkim selector must be set to use dkim signing")
+	}
+
+	if keyPath == "" {
+		return nil, errors.New("dkim private key path must be set to use dkim signing")
+	}
+
+	b, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, gtserror.Newf("error reading dkim private key: %w", err)
+	}
+
+	key, err := parseDKIMKey(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var algorithm string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		algorithm = "rsa-sha256"
+	case ed25519.PrivateKey:
+		algorithm = "ed25519-sha256"
+	}
+
+	return &dkimSigner{
+		domain:    domain,
+		selector:  selector,
+		algorithm: algorithm,
+		key:       key,
+	}, nil
+}
+
+// parseDKIMKey parses the given PEM encoded
+// bytes as either an RSA or Ed25519 private key.
+func parseDKIMKey(b []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("dkim private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing dkim private key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, gtserror.Newf("unsupported dkim private key type %T", key)
+	}
+}
+
+// sign returns a copy of the given message
+// with a DKIM-Signature header prepended.
+func (d *dkimSigner) sign(msg []byte) ([]byte, error) {
+	const CRLF = "\r\n"
+
+	header, body, ok := bytes.Cut(msg, []byte(CRLF+CRLF))
+	if !ok {
+		return nil, errors.New("message has no header / body separator")
+	}
+
+	// Hash the canonicalized body.
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+
+	// Collect the headers we're going to sign.
+	fields := dkimHeaderFields(string(header) + CRLF)
+	names := make([]string, 0, len(dkimHeaders))
+	hash := sha256.New()
+	for _, name := range dkimHeaders {
+		field, ok := fields[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		names = append(names, name)
+		hash.Write([]byte(dkimRelaxedHeader(field) + CRLF))
+	}
+
+	// Build the signature header with
+	// an empty b= tag, then hash that too.
+	tags := []string{
+		"v=1",
+		"a=" + d.algorithm,
+		"c=relaxed/relaxed",
+		"d=" + d.domain,
+		"s=" + d.selector,
+		"t=" + strconv.FormatInt(time.Now().Unix(), 10),
+		"h=" + strings.Join(names, ":"),
+		"bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]),
+		"b=",
+	}
+	sigHeader := "DKIM-Signature: " + strings.Join(tags, "; ")
+	hash.Write([]byte(dkimRelaxedHeader(sigHeader)))
+
+	// Sign the hash. For ed25519-sha256, the
+	// sha256 hash is signed as the message.
+	opts := crypto.Hash(0)
+	if d.algorithm == "rsa-sha256" {
+		opts = crypto.SHA256
+	}
+	sig, err := d.key.Sign(rand.Reader, hash.Sum(nil), opts)
+	if err != nil {
+		return nil, gtserror.Newf("error signing message: %w", err)
+	}
+
+	// Fold the header between tags to keep lines short,
+	// this doesn't affect the relaxed canonicalization.
+	sigHeader = strings.ReplaceAll(sigHeader, "; ", ";"+CRLF+" ")
+	sigHeader += base64.StdEncoding.EncodeToString(sig)
+
+	signed := make([]byte, 0, len(sigHeader)+len(CRLF)+len(msg))
+	signed = append(signed, sigHeader+CRLF...)
+	signed = append(signed, msg...)
+	return signed, nil
+}
+
+// dkimHeaderFields parses the given raw header block into
+// complete (possibly folded) fields, keyed by lowercase name.
+// Only the last instance of each header is kept, as per the
+// bottom-up selection of headers in RFC 6376 section 5.4.2.
+func dkimHeaderFields(header string) map[string]string {
+	fields := make(map[string]string)
+
+	var current string
+	flush := func() {
+		if current == "" {
+			return
+		}
+		name, _, _ := strings.Cut(current, ":")
+		fields[strings.ToLower(strings.TrimRight(name, " \t"))] = current
+		current = ""
+	}
+
+	for _, line := range strings.SplitAfter(header, "\r\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			// Continuation of
+			// a folded header.
+			current += line
+			continue
+		}
+		flush()
+		current = line
+	}
+	flush()
+
+	return fields
+}
+
+// dkimRelaxedHeader canonicalizes one header field using
+// the "relaxed" algorithm from RFC 6376 section 3.4.2,
+// without a trailing CRLF.
+func dkimRelaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	name = strings.ToLower(strings.TrimRight(name, " \t"))
+
+	// Unfold, then compress and trim whitespace.
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = dkimCompressWSP(value)
+	value = strings.Trim(value, " ")
+
+	return name + ":" + value
+}
+
+// dkimRelaxedBody canonicalizes a message body using
+// the "relaxed" algorithm from RFC 6376 section 3.4.4.
+func dkimRelaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(dkimCompressWSP(line), " ")
+	}
+
+	// Ignore all empty lines at the end of the body.
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// dkimCompressWSP reduces all sequences
+// of whitespace to a single space.
+func dkimCompressWSP(s string) string {
+	var (
+		b   strings.Builder
+		wsp bool
+	)
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == ' ' || c == '\t' {
+			wsp = true
+			continue
+		}
+		if wsp {
+			b.WriteByte(' ')
+			wsp = false
+		}
+		b.WriteByte(c)
+	}
+	if wsp {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDKIMRelaxedCanonicalization(t *testing.T) {
+	// Example from RFC 6376 section 3.4.5.
+	fields := dkimHeaderFields("A: X\r\nB : Y\t\r\n\tZ  \r\n")
+
+	if got := dkimRelaxedHeader(fields["a"]); got != "a:X" {
+		t.Errorf("unexpected header a: %q", got)
+	}
+
+	if got := dkimRelaxedHeader(fields["b"]); got != "b:Y Z" {
+		t.Errorf("unexpected header b: %q", got)
+	}
+
+	body := dkimRelaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))
+	if got := string(body); got != " C\r\nD E\r\n" {
+		t.Errorf("unexpected body: %q", got)
+	}
+
+	if body := dkimRelaxedBody([]byte("\r\n\r\n")); body != nil {
+		t.Errorf("expected empty body, got %q", body)
+	}
+}
+
+func TestDKIMSignEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := testDKIMSigner(t, priv)
+	if signer.algorithm != "ed25519-sha256" {
+		t.Fatalf("unexpected algorithm %s", signer.algorithm)
+	}
+
+	msg, signed := testDKIMSign(t, signer)
+	sig, hash := testDKIMHash(t, msg, signed)
+
+	if !ed25519.Verify(pub, hash, sig) {
+		t.Fatal("signature did not verify")
+	}
+}
+
+func TestDKIMSignRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := testDKIMSigner(t, priv)
+	if signer.algorithm != "rsa-sha256" {
+		t.Fatalf("unexpected algorithm %s", signer.algorithm)
+	}
+
+	msg, signed := testDKIMSign(t, signer)
+	sig, hash := testDKIMHash(t, msg, signed)
+
+	if err := rsa.VerifyPKCS1v15(&priv.PublicKey, crypto.SHA256, hash, sig); err != nil {
+		t.Fatalf("signature did not verify: %v", err)
+	}
+}
+
+// testDKIMSigner writes the given key to
+// a file and loads a dkimSigner from it.
+func testDKIMSigner(t *testing.T, key any) *dkimSigner {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "dkim.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := newDKIMSigner("example.org", "gts", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signer
+}
+
+// testDKIMSign assembles and signs a
+// message, returning both versions.
+func testDKIMSign(t *testing.T, signer *dkimSigner) ([]byte, []byte) {
+	msg, err := assembleMessage(
+		"Test  Subject",
+		"Hello there!  \n\nThis is a test.\n\n",
+		[]string{"List-Unsubscribe: <https://example.org/unsubscribe>"},
+		"sender@example.org",
+		"example.org",
+		"someone@example.org",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := signer.sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasSuffix(signed, msg) {
+		t.Fatal("signed message should end with original message")
+	}
+
+	return msg, signed
+}
+
+// testDKIMHash verifies the body hash of the given signed message
+// like a receiving server would, then returns the signature from
+// the DKIM-Signature header along with the hash it should sign.
+func testDKIMHash(t *testing.T, msg []byte, signed []byte) ([]byte, []byte) {
+	sigHeader := string(signed[:len(signed)-len(msg)])
+	sigHeader = strings.TrimSuffix(sigHeader, "\r\n")
+
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(strings.ReplaceAll(sigHeader[len("DKIM-Signature:"):], "\r\n", ""), ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		tags[k] = v
+	}
+
+	if tags["d"] != "example.org" || tags["s"] != "gts" || tags["c"] != "relaxed/relaxed" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+
+	if tags["h"] != "From:To:Subject:Date:Message-ID:MIME-Version:Content-Type:Content-Transfer-Encoding:List-Unsubscribe" {
+		t.Fatalf("unexpected signed headers: %s", tags["h"])
+	}
+
+	header, body, _ := bytes.Cut(msg, []byte("\r\n\r\n"))
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+	if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+		t.Fatal("body hash mismatch")
+	}
+
+	fields := dkimHeaderFields(string(header) + "\r\n")
+	hash := sha256.New()
+	for _, name := range strings.Split(tags["h"], ":") {
+		hash.Write([]byte(dkimRelaxedHeader(fields[strings.ToLower(name)]) + "\r\n"))
+	}
+
+	// Hash the signature header itself, with the b= value removed.
+	unsigned := regexp.MustCompile(`b=[A-Za-z0-9+/=]+$`).ReplaceAllString(sigHeader, "b=")
+	hash.Write([]byte(dkimRelaxedHeader(unsigned)))
+
+	sig, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sig, hash.Sum(nil)
+}
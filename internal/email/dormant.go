@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
+const (
+	dormantTemplate = "email_dormant.tmpl"
+	dormantSubject  = "GoToSocial Inactive Account Notice"
+)
+
+type DormantData struct {
+	// Username to be addressed.
+	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Time at which the account
+	// was last signed in or posted.
+	LastActive string
+	// Time at which the account will be
+	// disabled if not used, if at all.
+	DisableAt string
+}
+
+func (s *sender) SendDormantEmail(toAddress string, data DormantData) error {
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(dormantTemplate, lang, i18n.Translate(lang, dormantSubject), data, toAddress)
+}
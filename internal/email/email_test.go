@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -220,6 +221,145 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateReportClosedUnsubscribe() {
+	reportClosedData := email.ReportClosedData{
+		Username:             "user",
+		InstanceURL:          "https://example.org",
+		InstanceName:         "Test Instance",
+		ReportTargetUsername: "1happyturtle",
+		UnsubscribeURL:       "https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=report",
+	}
+
+	if err := suite.sender.SendReportClosedEmail("user@example.org", reportClosedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nList-Unsubscribe: <https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=report>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello user!\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\nTo stop receiving emails about your reports, visit: https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=report\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateNotificationMention() {
+	notificationData := email.NotificationData{
+		Username:     "user",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Notification: email.NotificationItem{
+			Type:    "mention",
+			Account: "@someone@fossbros-anonymous.io",
+			URL:     "https://fossbros-anonymous.io/@someone/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+			Text:    "hey @user what's up?",
+		},
+		UnsubscribeURL: "https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=mention",
+	}
+
+	if err := suite.sender.SendNotificationEmail("user@example.org", notificationData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial: New mention from @someone@fossbros-anonymous.io\r\nList-Unsubscribe: <https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=mention>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello user!\r\n\r\n@someone@fossbros-anonymous.io mentioned you:\r\n\r\nhey @user what's up?\r\n\r\nView it here: https://fossbros-anonymous.io/@someone/statuses/01FVW7JHQFSFK166WWKR8CBA6M\r\n\r\n---\r\n\r\nYou are receiving this email because you asked to be emailed about mentions on Test Instance (https://example.org).\r\n\r\nTo stop receiving these emails, visit: https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=mention\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateDigest() {
+	digestData := email.DigestData{
+		Username:     "user",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Period:       "day",
+		Notifications: []email.NotificationItem{
+			{
+				Type:    "follow",
+				Account: "@someone@fossbros-anonymous.io",
+				URL:     "https://fossbros-anonymous.io/@someone",
+			},
+			{
+				Type:    "direct",
+				Account: "@someone@fossbros-anonymous.io",
+				URL:     "https://fossbros-anonymous.io/@someone/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+				Text:    "thanks for the follow back!",
+			},
+			{
+				Type:    "report",
+				Account: "@1happyturtle",
+			},
+		},
+		UnsubscribeURL: "https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=all",
+	}
+
+	if err := suite.sender.SendDigestEmail("user@example.org", digestData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Notification Digest\r\nList-Unsubscribe: <https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=all>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello user!\r\n\r\nHere's what happened on Test Instance (https://example.org) in the last day:\r\n\r\n@someone@fossbros-anonymous.io followed you.\r\n\r\nView it here: https://fossbros-anonymous.io/@someone\r\n\r\n@someone@fossbros-anonymous.io sent you a direct message:\r\n\r\nthanks for the follow back!\r\n\r\nView it here: https://fossbros-anonymous.io/@someone/statuses/01FVW7JHQFSFK166WWKR8CBA6M\r\n\r\nYour report of @1happyturtle has been closed.\r\n\r\n---\r\n\r\nYou are receiving this email because you asked to be sent a digest of your notifications by email on Test Instance.\r\n\r\nTo stop receiving these emails, visit: https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=all\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateDormant() {
+	dormantData := email.DormantData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		LastActive:   "2024-01-02",
+		DisableAt:    "2024-08-02",
+	}
+
+	if err := suite.sender.SendDormantEmail("user@example.org", dormantData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Inactive Account Notice\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello test!\r\n\r\nYour account on Test Instance (https://example.org) hasn't been used since 2024-01-02.\r\n\r\nIf you'd like to keep your account, please sign in before 2024-08-02. Otherwise, your account will be disabled, and you'll need to contact the administrator to use it again. Your posts and other data will be kept either way.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateConfirmGerman() {
+	if err := i18n.Init("../../web/locale/"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer i18n.Init("")
+
+	confirmData := email.ConfirmData{
+		Username:     "test",
+		Locale:       "de-AT",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ConfirmLink:  "https://example.org/confirm_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+		NewSignup:    true,
+	}
+
+	suite.sender.SendConfirmEmail("user@example.org", confirmData)
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial: E-Mail-Bestätigung\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHallo test!\r\n\r\nDu erhältst diese E-Mail, weil du ein Konto auf https://example.org beantragt hast.\r\n\r\nUm dein Konto zu nutzen, musst du bestätigen, dass dies deine E-Mail-Adresse ist.\r\n\r\nUm deine E-Mail-Adresse zu bestätigen, füge Folgendes in die Adressleiste deines Browsers ein:\r\n\r\nhttps://example.org/confirm_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa\r\n\r\n---\r\n\r\nWenn du glaubst, dass du diese E-Mail irrtümlich erhalten hast, kannst du sie ignorieren oder dich an die Administration von https://example.org wenden.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateNotificationMentionGerman() {
+	if err := i18n.Init("../../web/locale/"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer i18n.Init("")
+
+	notificationData := email.NotificationData{
+		Username:     "user",
+		Locale:       "de",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Notification: email.NotificationItem{
+			Type:    "mention",
+			Account: "@someone@fossbros-anonymous.io",
+			URL:     "https://fossbros-anonymous.io/@someone/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+			Text:    "hey @user what's up?",
+		},
+		UnsubscribeURL: "https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=mention",
+	}
+
+	if err := suite.sender.SendNotificationEmail("user@example.org", notificationData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial: Neue Erwähnung von @someone@fossbros-anonymous.io\r\nList-Unsubscribe: <https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=mention>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHallo user!\r\n\r\n@someone@fossbros-anonymous.io hat dich erwähnt:\r\n\r\nhey @user what's up?\r\n\r\nHier ansehen: https://fossbros-anonymous.io/@someone/statuses/01FVW7JHQFSFK166WWKR8CBA6M\r\n\r\n---\r\n\r\nDu erhältst diese E-Mail, weil du auf Test Instance (https://example.org) per E-Mail über Erwähnungen informiert werden möchtest.\r\n\r\nUm diese E-Mails nicht mehr zu erhalten, besuche: https://example.org/unsubscribe?token=ee24f71d-e615-43f9-afae-385c0799b7fa&type=mention\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
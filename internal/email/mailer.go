@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// mailer delivers fully assembled
+// email messages to their recipients.
+type mailer interface {
+	mail(from string, to []string, msg []byte) error
+}
+
+// smtpMailer delivers messages via an smtp server.
+type smtpMailer struct {
+	hostAddress string
+	auth        smtp.Auth
+}
+
+func (m *smtpMailer) mail(from string, to []string, msg []byte) error {
+	if err := smtp.SendMail(m.hostAddress, m.auth, from, to, msg); err != nil {
+		return gtserror.SetSMTP(err)
+	}
+	return nil
+}
+
+// maildirMailer "delivers" messages by writing them
+// to a local maildir, for testing without a mail server.
+//
+// See: https://cr.yp.to/proto/maildir.html
+type maildirMailer struct {
+	dir      string
+	hostname string
+}
+
+func newMaildirMailer(dir string) (*maildirMailer, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, gtserror.Newf("error creating maildir: %w", err)
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return &maildirMailer{
+		dir:      dir,
+		hostname: hostname,
+	}, nil
+}
+
+func (m *maildirMailer) mail(from string, to []string, msg []byte) error {
+	// Messages are written to "tmp" then moved into "new"
+	// once complete, so that readers never see partial files.
+	name := strconv.FormatInt(time.Now().Unix(), 10) + "." + id.NewULID() + "." + m.hostname
+	tmp := filepath.Join(m.dir, "tmp", name)
+
+	// Prepend envelope details, as
+	// a local delivery agent would.
+	buf := fmt.Appendf(nil, "Return-Path: <%s>\r\n", from)
+	for _, addr := range to {
+		buf = fmt.Appendf(buf, "Delivered-To: %s\r\n", addr)
+	}
+	buf = append(buf, msg...)
+
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return gtserror.Newf("error writing message: %w", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(m.dir, "new", name)); err != nil {
+		return gtserror.Newf("error moving message: %w", err)
+	}
+
+	return nil
+}
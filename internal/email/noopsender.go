@@ -18,10 +18,10 @@
 package email
 
 import (
-	"bytes"
 	"text/template"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -52,44 +52,79 @@ type noopSender struct {
 }
 
 func (s *noopSender) SendConfirmEmail(toAddress string, data ConfirmData) error {
-	return s.sendTemplate(confirmTemplate, confirmSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(confirmTemplate, lang, i18n.Translate(lang, confirmSubject), data, toAddress)
 }
 
 func (s *noopSender) SendResetEmail(toAddress string, data ResetData) error {
-	return s.sendTemplate(resetTemplate, resetSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(resetTemplate, lang, i18n.Translate(lang, resetSubject), data, toAddress)
 }
 
 func (s *noopSender) SendTestEmail(toAddress string, data TestData) error {
-	return s.sendTemplate(testTemplate, testSubject, data, toAddress)
+	lang := emailLanguage("")
+	return s.sendTemplate(testTemplate, lang, i18n.Translate(lang, testSubject), data, toAddress)
 }
 
 func (s *noopSender) SendNewReportEmail(toAddresses []string, data NewReportData) error {
-	return s.sendTemplate(newReportTemplate, newReportSubject, data, toAddresses...)
+	lang := emailLanguage("")
+	return s.sendTemplate(newReportTemplate, lang, i18n.Translate(lang, newReportSubject), data, toAddresses...)
 }
 
 func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
-	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
+	headers := unsubscribeHeaders(data.UnsubscribeURL)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplateWithHeaders(reportClosedTemplate, lang, i18n.Translate(lang, reportClosedSubject), headers, data, toAddress)
 }
 
 func (s *noopSender) SendNewSignupEmail(toAddresses []string, data NewSignupData) error {
-	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddresses...)
+	lang := emailLanguage("")
+	return s.sendTemplate(newSignupTemplate, lang, i18n.Translate(lang, newSignupSubject), data, toAddresses...)
 }
 
 func (s *noopSender) SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error {
-	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(signupApprovedTemplate, lang, i18n.Translate(lang, signupApprovedSubject), data, toAddress)
 }
 
 func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error {
-	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(signupRejectedTemplate, lang, i18n.Translate(lang, signupRejectedSubject), data, toAddress)
 }
 
-func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
-	buf := &bytes.Buffer{}
-	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+func (s *noopSender) SendAccountActionEmail(toAddress string, data AccountActionData) error {
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(accountActionTemplate, lang, i18n.Translate(lang, accountActionSubject), data, toAddress)
+}
+
+func (s *noopSender) SendDormantEmail(toAddress string, data DormantData) error {
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(dormantTemplate, lang, i18n.Translate(lang, dormantSubject), data, toAddress)
+}
+
+func (s *noopSender) SendNotificationEmail(toAddress string, data NotificationData) error {
+	headers := unsubscribeHeaders(data.UnsubscribeURL)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplateWithHeaders(notificationTemplate, lang, notificationSubject(lang, data), headers, data, toAddress)
+}
+
+func (s *noopSender) SendDigestEmail(toAddress string, data DigestData) error {
+	headers := unsubscribeHeaders(data.UnsubscribeURL)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplateWithHeaders(digestTemplate, lang, i18n.Translate(lang, digestSubject), headers, data, toAddress)
+}
+
+func (s *noopSender) sendTemplate(template string, lang string, subject string, data any, toAddresses ...string) error {
+	return s.sendTemplateWithHeaders(template, lang, subject, nil, data, toAddresses...)
+}
+
+func (s *noopSender) sendTemplateWithHeaders(template string, lang string, subject string, headers []string, data any, toAddresses ...string) error {
+	body, err := executeTemplate(s.template, template, lang, data)
+	if err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), "test@example.org", s.msgIDHost, toAddresses...)
+	msg, err := assembleMessage(subject, body, headers, "test@example.org", s.msgIDHost, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
+const (
+	notificationTemplate = "email_notification.tmpl"
+	digestTemplate       = "email_digest.tmpl"
+	digestSubject        = "GoToSocial Notification Digest"
+)
+
+// NotificationItem models one notification
+// (or report update) to be included in an email.
+type NotificationItem struct {
+	// Type of this notification, one of:
+	// mention, direct, follow, follow_request, report.
+	Type string
+	// Account that caused the notification, eg.,
+	// @someone@example.org, or for reports the
+	// account that was reported.
+	Account string
+	// URL to view the notification subject
+	// (status, account etc) at, if any.
+	URL string
+	// Plaintext content of the status,
+	// or comment left by the moderator
+	// who closed the report, if any.
+	Text string
+}
+
+type NotificationData struct {
+	// Username to be addressed.
+	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// The notification itself.
+	Notification NotificationItem
+	// URL to unsubscribe from emails of this type.
+	UnsubscribeURL string
+}
+
+func (s *sender) SendNotificationEmail(toAddress string, data NotificationData) error {
+	headers := unsubscribeHeaders(data.UnsubscribeURL)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplateWithHeaders(notificationTemplate, lang, notificationSubject(lang, data), headers, data, toAddress)
+}
+
+// notificationSubject returns the email subject for
+// the given notification data, in the given language.
+func notificationSubject(lang string, data NotificationData) string {
+	account := data.Notification.Account
+	switch data.Notification.Type {
+	case "mention":
+		return i18n.Translate(lang, "GoToSocial: New mention from %s", account)
+	case "direct":
+		return i18n.Translate(lang, "GoToSocial: New direct message from %s", account)
+	case "follow":
+		return i18n.Translate(lang, "GoToSocial: New follower %s", account)
+	case "follow_request":
+		return i18n.Translate(lang, "GoToSocial: New follow request from %s", account)
+	case "report":
+		return i18n.Translate(lang, reportClosedSubject)
+	default:
+		return i18n.Translate(lang, "GoToSocial: New notification")
+	}
+}
+
+type DigestData struct {
+	// Username to be addressed.
+	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Period covered by this digest, eg., "day", "week".
+	Period string
+	// Notifications in this digest, oldest first.
+	Notifications []NotificationItem
+	// URL to unsubscribe from all notification emails.
+	UnsubscribeURL string
+}
+
+func (s *sender) SendDigestEmail(toAddress string, data DigestData) error {
+	headers := unsubscribeHeaders(data.UnsubscribeURL)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplateWithHeaders(digestTemplate, lang, i18n.Translate(lang, digestSubject), headers, data, toAddress)
+}
@@ -0,0 +1,322 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// queueMaxAttempts is the number of delivery attempts
+	// made for a queued email before it is marked as failed.
+	queueMaxAttempts = 10
+
+	// queueMinBackoff and queueMaxBackoff bound the
+	// exponential backoff between delivery attempts.
+	queueMinBackoff = time.Minute
+	queueMaxBackoff = 6 * time.Hour
+
+	// queueBatchSize is the maximum number of emails
+	// fetched from the database per delivery pass.
+	queueBatchSize = 50
+
+	// queueInterval is how often the queue is checked for
+	// due emails, when it isn't woken up by a new email.
+	queueInterval = time.Minute
+)
+
+// Queue is a persistent, database backed queue of outgoing
+// emails, which is drained by a worker that delivers them,
+// retrying failed deliveries with exponential backoff.
+//
+// Emails that still can't be delivered after the maximum
+// number of attempts are marked as failed, and kept for
+// instance admins to review, retry, or delete.
+type Queue struct {
+	state  *state.State
+	mailer mailer
+	dkim   *dkimSigner
+
+	// IDs of emails that were delivered,
+	// but couldn't be removed from the queue,
+	// so they're not delivered again. Only
+	// accessed by the queue worker.
+	delivered map[string]struct{}
+
+	wake   chan struct{}
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewQueue returns a new email Queue, delivering emails
+// either via smtp, or to a local maildir if configured.
+func NewQueue(state *state.State) (*Queue, error) {
+	q := &Queue{
+		state:     state,
+		delivered: make(map[string]struct{}),
+		wake:      make(chan struct{}, 1),
+	}
+
+	if dir := config.GetSMTPMaildir(); dir != "" {
+		// Write emails to a local maildir.
+		mailer, err := newMaildirMailer(dir)
+		if err != nil {
+			return nil, err
+		}
+		q.mailer = mailer
+	} else {
+		// Deliver emails via smtp server.
+		host := config.GetSMTPHost()
+		q.mailer = &smtpMailer{
+			hostAddress: fmt.Sprintf("%s:%d", host, config.GetSMTPPort()),
+			auth: smtp.PlainAuth("",
+				config.GetSMTPUsername(),
+				config.GetSMTPPassword(),
+				host,
+			),
+		}
+	}
+
+	if domain := config.GetSMTPDKIMDomain(); domain != "" {
+		// Sign emails with DKIM.
+		dkim, err := newDKIMSigner(
+			domain,
+			config.GetSMTPDKIMSelector(),
+			config.GetSMTPDKIMPrivateKeyPath(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		q.dkim = dkim
+	}
+
+	return q, nil
+}
+
+// Enqueue stores the given fully assembled message
+// in the database, and wakes the queue worker.
+func (q *Queue) Enqueue(ctx context.Context, from string, to []string, subject string, msg []byte) error {
+	email := &gtsmodel.QueuedEmail{
+		ID:      id.NewULID(),
+		From:    from,
+		To:      to,
+		Subject: subject,
+		Message: msg,
+	}
+
+	if err := q.state.DB.PutQueuedEmail(ctx, email); err != nil {
+		return err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+		// Worker is
+		// already awake.
+	}
+
+	return nil
+}
+
+// send delivers the given message immediately,
+// bypassing the queue, returning any error.
+func (q *Queue) send(from string, to []string, msg []byte) error {
+	if q.dkim != nil {
+		var err error
+		msg, err = q.dkim.sign(msg)
+		if err != nil {
+			return err
+		}
+	}
+	return q.mailer.mail(from, to, msg)
+}
+
+// Start starts the queue worker in the background.
+func (q *Queue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.cancel != nil {
+		// Already running.
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q.cancel = cancel
+	q.done = make(chan struct{})
+
+	go func() {
+		defer close(q.done)
+		q.run(ctx)
+	}()
+}
+
+// Stop stops the queue worker, waiting
+// for any in-progress delivery to finish.
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.cancel == nil {
+		// Not running.
+		return
+	}
+
+	q.cancel()
+	<-q.done
+	q.cancel = nil
+}
+
+func (q *Queue) run(ctx context.Context) {
+	ticker := time.NewTicker(queueInterval)
+	defer ticker.Stop()
+
+	for {
+		q.Process(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process attempts delivery of all queued emails that
+// are due at the given time. This is usually called by
+// the queue worker, but is exported for testing.
+//
+// On any database error, processing stops until the
+// next time the queue is checked, so that emails are
+// not repeatedly re-sent while the database is failing.
+func (q *Queue) Process(ctx context.Context, now time.Time) {
+	for ctx.Err() == nil {
+		emails, err := q.state.DB.GetDueQueuedEmails(ctx, now, queueBatchSize)
+		if err != nil {
+			log.Errorf(ctx, "db error getting queued emails: %v", err)
+			return
+		}
+
+		for _, email := range emails {
+			if err := q.deliver(ctx, email, now); err != nil {
+				log.Errorf(ctx, "stopping email delivery: %v", err)
+				return
+			}
+		}
+
+		if len(emails) < queueBatchSize {
+			// All due
+			// emails done.
+			return
+		}
+	}
+}
+
+// deliver makes one delivery attempt for the given queued
+// email, deleting it on success, or scheduling a retry or
+// marking it failed on error. Database errors are returned.
+func (q *Queue) deliver(ctx context.Context, email *gtsmodel.QueuedEmail, now time.Time) error {
+	l := log.WithContext(ctx).WithField("emailID", email.ID)
+
+	if _, ok := q.delivered[email.ID]; ok {
+		// Already delivered, just
+		// try removing it again.
+		return q.dequeue(ctx, email)
+	}
+
+	// Push back the next attempt before sending, so
+	// that if the outcome can't be recorded after,
+	// the email isn't immediately due again.
+	email.NextAttemptAt = now.Add(queueBackoff(email.Attempts + 1))
+	if err := q.state.DB.UpdateQueuedEmail(ctx, email, "next_attempt_at"); err != nil {
+		return fmt.Errorf("db error updating queued email %s: %w", email.ID, err)
+	}
+
+	err := q.send(email.From, email.To, email.Message)
+	if err == nil {
+		// Delivered! Remove from queue.
+		q.delivered[email.ID] = struct{}{}
+		return q.dequeue(ctx, email)
+	}
+
+	email.Attempts++
+	email.LastError = err.Error()
+
+	if email.Attempts >= queueMaxAttempts || permanentError(err) {
+		// Give up on this one, admins
+		// can retry it later if they like.
+		l.Warnf("giving up delivering email after %d attempt(s): %v", email.Attempts, err)
+		email.FailedAt = now
+	} else {
+		l.Infof("error delivering email (attempt %d), will retry: %v", email.Attempts, err)
+	}
+
+	if err := q.state.DB.UpdateQueuedEmail(ctx, email,
+		"attempts",
+		"last_error",
+		"failed_at",
+	); err != nil {
+		return fmt.Errorf("db error updating queued email %s: %w", email.ID, err)
+	}
+
+	return nil
+}
+
+// dequeue removes the given delivered email from the queue.
+func (q *Queue) dequeue(ctx context.Context, email *gtsmodel.QueuedEmail) error {
+	if err := q.state.DB.DeleteQueuedEmailByID(ctx, email.ID); err != nil {
+		return fmt.Errorf("db error deleting delivered email %s: %w", email.ID, err)
+	}
+	delete(q.delivered, email.ID)
+	return nil
+}
+
+// queueBackoff returns the backoff duration
+// after the given number of failed attempts.
+func queueBackoff(attempts int) time.Duration {
+	backoff := queueMinBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= queueMaxBackoff {
+			return queueMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// permanentError returns whether the given delivery
+// error is a permanent (5xx) smtp error, in which case
+// there's no point retrying delivery.
+func permanentError(err error) bool {
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code >= 500
+}
@@ -0,0 +1,253 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type QueueTestSuite struct {
+	suite.Suite
+
+	state state.State
+	db    db.DB
+}
+
+func (suite *QueueTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.state.Caches.Init()
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	testrig.StandardDBSetup(suite.db, nil)
+
+	config.SetWebTemplateBaseDir("../../web/template/")
+}
+
+func (suite *QueueTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *QueueTestSuite) newSender() (*email.Queue, email.Sender) {
+	queue, err := email.NewQueue(&suite.state)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	sender, err := email.NewSender(queue)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return queue, sender
+}
+
+func (suite *QueueTestSuite) queued() []*gtsmodel.QueuedEmail {
+	emails, err := suite.db.GetDueQueuedEmails(context.Background(), time.Now().Add(24*time.Hour), 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return emails
+}
+
+func (suite *QueueTestSuite) TestQueueMaildir() {
+	ctx := context.Background()
+	maildir := suite.T().TempDir()
+	config.SetSMTPMaildir(maildir)
+
+	queue, sender := suite.newSender()
+
+	if err := sender.SendResetEmail("user@example.org", email.ResetData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ResetLink:    "https://example.org/reset?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Email should be queued, not yet delivered.
+	queued := suite.queued()
+	suite.Len(queued, 1)
+	suite.Equal([]string{"user@example.org"}, queued[0].To)
+	suite.Equal("GoToSocial Password Reset", queued[0].Subject)
+
+	delivered, err := os.ReadDir(filepath.Join(maildir, "new"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(delivered)
+
+	// Drain the queue.
+	queue.Process(ctx, time.Now())
+
+	// Email should now be delivered + dequeued.
+	suite.Empty(suite.queued())
+
+	delivered, err = os.ReadDir(filepath.Join(maildir, "new"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(delivered, 1)
+
+	b, err := os.ReadFile(filepath.Join(maildir, "new", delivered[0].Name()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(string(b), "Delivered-To: user@example.org\r\n")
+	suite.Contains(string(b), "Subject: GoToSocial Password Reset\r\n")
+}
+
+func (suite *QueueTestSuite) TestQueueRetry() {
+	ctx := context.Background()
+
+	// Point at an smtp server that isn't there.
+	config.SetSMTPHost("127.0.0.1")
+	config.SetSMTPPort(1)
+
+	queue, sender := suite.newSender()
+
+	if err := sender.SendResetEmail("user@example.org", email.ResetData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ResetLink:    "https://example.org/reset?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	now := time.Now()
+	for attempt := 1; attempt <= 10; attempt++ {
+		queue.Process(ctx, now)
+
+		queued, err := suite.db.GetDueQueuedEmails(ctx, now, 100)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// Shouldn't be due again
+		// until the backoff passes.
+		suite.Empty(queued)
+
+		// Skip ahead to the next attempt.
+		now = now.Add(6 * time.Hour)
+	}
+
+	// After max attempts, email should be marked failed.
+	failed, err := suite.db.GetFailedQueuedEmails(ctx, &paging.Page{Limit: 20})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(failed, 1)
+	suite.Equal(10, failed[0].Attempts)
+	suite.NotEmpty(failed[0].LastError)
+	suite.True(failed[0].Failed())
+
+	// Failed emails are never due.
+	suite.Empty(suite.queued())
+}
+
+// failDeleteDB is a db.DB that
+// can't delete queued emails.
+type failDeleteDB struct {
+	db.DB
+}
+
+func (failDeleteDB) DeleteQueuedEmailByID(context.Context, string) error {
+	return errors.New("database on fire")
+}
+
+func (suite *QueueTestSuite) TestQueueDeleteFailed() {
+	ctx := context.Background()
+	maildir := suite.T().TempDir()
+	config.SetSMTPMaildir(maildir)
+
+	queue, sender := suite.newSender()
+
+	if err := sender.SendResetEmail("user@example.org", email.ResetData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ResetLink:    "https://example.org/reset?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Deliver while the email
+	// can't be removed from queue.
+	suite.state.DB = failDeleteDB{suite.db}
+	now := time.Now()
+	queue.Process(ctx, now)
+	queue.Process(ctx, now)
+
+	// Delivery should be pushed back.
+	queued, err := suite.db.GetDueQueuedEmails(ctx, now, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(queued)
+
+	// Once the database works again, the email
+	// should be removed without sending it again.
+	suite.state.DB = suite.db
+	queue.Process(ctx, now.Add(time.Hour))
+	suite.Empty(suite.queued())
+
+	delivered, err := os.ReadDir(filepath.Join(maildir, "new"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(delivered, 1)
+}
+
+func (suite *QueueTestSuite) TestQueueTestEmailNotQueued() {
+	// Point at an smtp server that isn't there.
+	config.SetSMTPHost("127.0.0.1")
+	config.SetSMTPPort(1)
+
+	_, sender := suite.newSender()
+
+	// Test emails should be sent
+	// directly, returning the error.
+	err := sender.SendTestEmail("user@example.org", email.TestData{
+		SendingUsername: "admin",
+		InstanceURL:     "https://example.org",
+		InstanceName:    "Test Instance",
+	})
+	suite.Error(err)
+	suite.Empty(suite.queued())
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
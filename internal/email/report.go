@@ -17,6 +17,8 @@
 
 package email
 
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
 const (
 	newReportTemplate    = "email_new_report.tmpl"
 	newReportSubject     = "GoToSocial New Report"
@@ -40,12 +42,16 @@ type NewReportData struct {
 }
 
 func (s *sender) SendNewReportEmail(toAddresses []string, data NewReportData) error {
-	return s.sendTemplate(newReportTemplate, newReportSubject, data, toAddresses...)
+	lang := emailLanguage("")
+	return s.sendTemplate(newReportTemplate, lang, i18n.Translate(lang, newReportSubject), data, toAddresses...)
 }
 
 type ReportClosedData struct {
 	// Username to be addressed.
 	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
 	// URL of the instance to present to the receiver.
 	InstanceURL string
 	// Name of the instance to present to the receiver.
@@ -57,8 +63,13 @@ type ReportClosedData struct {
 	ReportTargetDomain string
 	// Comment left by the admin who closed the report.
 	ActionTakenComment string
+	// URL to unsubscribe from report emails.
+	// Can be empty string to not include one.
+	UnsubscribeURL string
 }
 
 func (s *sender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
-	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
+	headers := unsubscribeHeaders(data.UnsubscribeURL)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplateWithHeaders(reportClosedTemplate, lang, i18n.Translate(lang, reportClosedSubject), headers, data, toAddress)
 }
@@ -17,6 +17,8 @@
 
 package email
 
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
 const (
 	resetTemplate = "email_reset.tmpl"
 	resetSubject  = "GoToSocial Password Reset"
@@ -26,6 +28,9 @@ const (
 type ResetData struct {
 	// Username to be addressed.
 	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
 	// URL of the instance to present to the receiver.
 	InstanceURL string
 	// Name of the instance to present to the receiver.
@@ -36,5 +41,6 @@ type ResetData struct {
 }
 
 func (s *sender) SendResetEmail(toAddress string, data ResetData) error {
-	return s.sendTemplate(resetTemplate, resetSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(resetTemplate, lang, i18n.Translate(lang, resetSubject), data, toAddress)
 }
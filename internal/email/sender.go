@@ -18,8 +18,6 @@
 package email
 
 import (
-	"fmt"
-	"net/smtp"
 	"text/template"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -34,6 +32,8 @@ type Sender interface {
 	SendResetEmail(toAddress string, data ResetData) error
 
 	// SendTestEmail sends a 'testing email sending' style email to the given toAddress, with the given data.
+	//
+	// Unlike other emails, this is delivered immediately rather than queued, so any delivery error is returned.
 	SendTestEmail(toAddress string, data TestData) error
 
 	// SendNewReportEmail sends an email notification to the given addresses, letting them
@@ -61,36 +61,45 @@ type Sender interface {
 	// SendSignupRejectedEmail sends an email to the given address
 	// that their sign-up request has been rejected by a moderator.
 	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// SendAccountActionEmail sends an email to the given address that
+	// a moderator has taken (or reversed) an action on their account.
+	SendAccountActionEmail(toAddress string, data AccountActionData) error
+
+	// SendDormantEmail sends an email to the given address that their
+	// account hasn't been used for a while, and may be disabled.
+	SendDormantEmail(toAddress string, data DormantData) error
+
+	// SendNotificationEmail sends an email to the given address
+	// about one new notification (mention, follow etc) they received.
+	SendNotificationEmail(toAddress string, data NotificationData) error
+
+	// SendDigestEmail sends an email to the given address with a
+	// digest of notifications they received over the last period.
+	SendDigestEmail(toAddress string, data DigestData) error
 }
 
-// NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
-func NewSender() (Sender, error) {
+// NewSender returns a new email Sender interface which
+// sends emails via the given queue, or an error if
+// something goes wrong.
+func NewSender(queue *Queue) (Sender, error) {
 	templateBaseDir := config.GetWebTemplateBaseDir()
 	t, err := loadTemplates(templateBaseDir)
 	if err != nil {
 		return nil, err
 	}
 
-	username := config.GetSMTPUsername()
-	password := config.GetSMTPPassword()
-	host := config.GetSMTPHost()
-	port := config.GetSMTPPort()
-	from := config.GetSMTPFrom()
-	msgIDHost := config.GetHost()
-
 	return &sender{
-		hostAddress: fmt.Sprintf("%s:%d", host, port),
-		from:        from,
-		auth:        smtp.PlainAuth("", username, password, host),
-		msgIDHost:   msgIDHost,
-		template:    t,
+		from:      config.GetSMTPFrom(),
+		msgIDHost: config.GetHost(),
+		template:  t,
+		queue:     queue,
 	}, nil
 }
 
 type sender struct {
-	hostAddress string
-	from        string
-	auth        smtp.Auth
-	msgIDHost   string
-	template    *template.Template
+	from      string
+	msgIDHost string
+	template  *template.Template
+	queue     *Queue
 }
@@ -17,6 +17,8 @@
 
 package email
 
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
 var (
 	newSignupTemplate = "email_new_signup.tmpl"
 	newSignupSubject  = "GoToSocial New Sign-Up"
@@ -33,12 +35,17 @@ type NewSignupData struct {
 	SignupUsername string
 	// Reason given on the sign-up form.
 	SignupReason string
+	// Handle of the account who created the invite
+	// used to sign up, eg., "@someone". Empty if the
+	// sign-up didn't use an invite.
+	InvitedBy string
 	// URL to open the sign-up in the settings panel.
 	SignupURL string
 }
 
 func (s *sender) SendNewSignupEmail(toAddresses []string, data NewSignupData) error {
-	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddresses...)
+	lang := emailLanguage("")
+	return s.sendTemplate(newSignupTemplate, lang, i18n.Translate(lang, newSignupSubject), data, toAddresses...)
 }
 
 var (
@@ -49,6 +56,9 @@ var (
 type SignupApprovedData struct {
 	// Username to be addressed.
 	Username string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
 	// URL of the instance to present to the receiver.
 	InstanceURL string
 	// Name of the instance to present to the receiver.
@@ -56,7 +66,8 @@ type SignupApprovedData struct {
 }
 
 func (s *sender) SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error {
-	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(signupApprovedTemplate, lang, i18n.Translate(lang, signupApprovedSubject), data, toAddress)
 }
 
 var (
@@ -67,6 +78,9 @@ var (
 type SignupRejectedData struct {
 	// Message to the rejected applicant.
 	Message string
+	// Locale of the receiver, used to pick
+	// the language the email is written in.
+	Locale string
 	// URL of the instance to present to the receiver.
 	InstanceURL string
 	// Name of the instance to present to the receiver.
@@ -74,5 +88,6 @@ type SignupRejectedData struct {
 }
 
 func (s *sender) SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error {
-	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+	lang := emailLanguage(data.Locale)
+	return s.sendTemplate(signupRejectedTemplate, lang, i18n.Translate(lang, signupRejectedSubject), data, toAddress)
 }
@@ -17,6 +17,8 @@
 
 package email
 
+import "github.com/superseriousbusiness/gotosocial/internal/i18n"
+
 const (
 	testTemplate = "email_test.tmpl"
 	testSubject  = "GoToSocial Test Email"
@@ -34,5 +36,8 @@ type TestData struct {
 }
 
 func (s *sender) SendTestEmail(toAddress string, data TestData) error {
-	return s.sendTemplate(testTemplate, testSubject, data, toAddress)
+	// Test emails skip the queue, so that
+	// any delivery error is reported back.
+	lang := emailLanguage("")
+	return s.sendTemplateNow(testTemplate, lang, i18n.Translate(lang, testSubject), data, toAddress)
 }
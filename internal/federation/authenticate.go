@@ -34,9 +34,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/httpsig"
 )
@@ -198,8 +200,11 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 			return pubKeyAuth, nil
 		}
 
-		// Dereference the account located at owner URI.
-		pubKeyAuth.Owner, _, err = f.GetAccountByURI(ctx,
+		// Dereference the account located at owner URI. Don't
+		// backfill it just because it sent us something; that
+		// happens when someone here follows the account instead.
+		pubKeyAuth.Owner, _, err = f.GetAccountByURI(
+			gtscontext.SetBackfill(ctx),
 			requestedUsername,
 			pubKeyAuth.OwnerURI,
 		)
@@ -237,6 +242,86 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	return pubKeyAuth, nil
 }
 
+// AuthenticateIntegrityProof authenticates the given FEP-8b32 object
+// integrity proof as having been created by the actor with given IRI,
+// using the ed25519 key published in the actor's 'assertionMethod'.
+// Proofs without a creation time, or created outside the window
+// given by integrity.MaxAge and integrity.MaxSkew, are rejected.
+//
+// The actor will be dereferenced if necessary, using the provided
+// username to generate a transport, and refreshed if the proof's key
+// is not the one we have stored, in case the key has since rotated.
+//
+// On success the (remote, non-suspended) actor account is returned.
+func (f *Federator) AuthenticateIntegrityProof(
+	ctx context.Context,
+	requestedUsername string,
+	actorIRI *url.URL,
+	proof *integrity.Proof,
+) (*gtsmodel.Account, error) {
+	if err := proof.CheckCreated(time.Now()); err != nil {
+		// Don't accept old proofs, which may
+		// be captured activities being replayed.
+		return nil, gtserror.Newf("proof created at %s: %w", proof.Created, err)
+	}
+
+	keyID, err := url.Parse(proof.VerificationMethod)
+	if err != nil {
+		return nil, gtserror.Newf("invalid verification method %s: %w", proof.VerificationMethod, err)
+	}
+
+	if keyID.Host != actorIRI.Host {
+		// Key can't be published on the actor's
+		// document if not on the same host, so save
+		// ourselves the effort of dereferencing.
+		return nil, gtserror.Newf("verification method %s not on host of actor %s", keyID, actorIRI)
+	}
+
+	if actorIRI.Host == config.GetHost() ||
+		actorIRI.Host == config.GetAccountDomain() {
+		// We don't accept our own
+		// activities relayed back to us.
+		return nil, gtserror.Newf("actor %s is local", actorIRI)
+	}
+
+	actor, _, err := f.GetAccountByURI(
+		gtscontext.SetBackfill(ctx),
+		requestedUsername,
+		actorIRI,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error getting actor %s: %w", actorIRI, err)
+	}
+
+	if actor.Ed25519PublicKeyURI != proof.VerificationMethod {
+		// Our stored copy of the actor may be outdated,
+		// refresh it to see if the key is now published.
+		actor, _, err = f.RefreshAccount(ctx,
+			requestedUsername,
+			actor,
+			nil,
+			dereferencing.Freshest,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error refreshing actor %s: %w", actorIRI, err)
+		}
+
+		if actor.Ed25519PublicKeyURI != proof.VerificationMethod {
+			return nil, gtserror.Newf("verification method %s not published by actor %s", keyID, actorIRI)
+		}
+	}
+
+	if !actor.SuspendedAt.IsZero() {
+		return nil, gtserror.Newf("actor %s suspended", actorIRI)
+	}
+
+	if !proof.Verify(actor.Ed25519PublicKey) {
+		return nil, gtserror.Newf("proof from %s did not verify", keyID)
+	}
+
+	return actor, nil
+}
+
 // derefPubKeyDBOnly tries to dereference the given
 // pubKey using only entries already in the database.
 //
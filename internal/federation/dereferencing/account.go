@@ -168,6 +168,10 @@ func (d *Dereferencer) getAccountByURI(ctx context.Context, requestUser string,
 			return nil, nil, gtserror.Newf("error stubbing account stats: %w", err)
 		}
 
+		// This is the first time we've seen this
+		// account, enqueue backfill of recent posts.
+		d.BackfillAccountAsync(ctx, requestUser, account)
+
 		return account, accountable, nil
 	}
 
@@ -275,6 +279,10 @@ func (d *Dereferencer) getAccountByUsernameDomain(
 			return nil, nil, gtserror.Newf("error stubbing account stats: %w", err)
 		}
 
+		// This is the first time we've seen this
+		// account, enqueue backfill of recent posts.
+		d.BackfillAccountAsync(ctx, requestUser, account)
+
 		return account, accountable, nil
 	}
 
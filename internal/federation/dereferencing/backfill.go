@@ -0,0 +1,338 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// maxBackfillPages is the maximum number of
+	// outbox pages we'll walk through per backfill,
+	// to protect against endless / useless pages.
+	maxBackfillPages = 10
+
+	// backfillHostInterval is the minimum time between
+	// the end of one backfill from a host, and the start
+	// of the next. Only one backfill runs per host at once.
+	backfillHostInterval = time.Second
+)
+
+// backfillHost is the backfill
+// rate-limiting state of a host.
+type backfillHost struct {
+	// running is whether a backfill
+	// from the host is in progress.
+	running bool
+
+	// next is the earliest time at which
+	// another backfill may be started.
+	next time.Time
+}
+
+// BackfillAccountAsync enqueues a backfill of the given remote
+// account's most recent statuses onto the dereference worker
+// queue. This is a no-op for local accounts, if backfill is
+// disabled in the instance configuration, or if called from
+// within another backfill (to prevent crawling the fediverse).
+func (d *Dereferencer) BackfillAccountAsync(
+	ctx context.Context,
+	requestUser string,
+	account *gtsmodel.Account,
+) {
+	if account.IsLocal() ||
+		gtscontext.Backfill(ctx) ||
+		config.GetInstanceFederationBackfill() <= 0 {
+		return
+	}
+
+	d.enqueueBackfill(requestUser, account)
+}
+
+// enqueueBackfill pushes a backfill of the given account onto
+// the dereference worker queue. If, when the worker picks it
+// up, a backfill from the same host is running or finished too
+// recently, it's pushed again after a delay rather than keeping
+// the worker waiting.
+func (d *Dereferencer) enqueueBackfill(
+	requestUser string,
+	account *gtsmodel.Account,
+) {
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		host := account.Domain
+		if wait := d.startBackfillHost(host); wait > 0 {
+			time.AfterFunc(wait, func() {
+				d.enqueueBackfill(requestUser, account)
+			})
+			return
+		}
+		defer d.endBackfillHost(host)
+
+		if err := d.backfillAccount(ctx, requestUser, account); err != nil {
+			log.Errorf(ctx, "error backfilling account %s: %v", account.URI, err)
+		}
+	})
+}
+
+// backfillAccount pages through the outbox of the given account,
+// dereferencing up to the configured number of recent statuses
+// created by the account and storing them in the database.
+//
+// Statuses are dereferenced the same way as any other status
+// fetched from remote, which means no notifications are created
+// and nothing is inserted into local timelines.
+func (d *Dereferencer) backfillAccount(
+	ctx context.Context,
+	requestUser string,
+	account *gtsmodel.Account,
+) error {
+	limit := config.GetInstanceFederationBackfill()
+	if limit <= 0 || account.OutboxURI == "" {
+		return nil
+	}
+
+	outboxURI, err := url.Parse(account.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("invalid outbox uri %q: %w", account.OutboxURI, err)
+	}
+
+	// Mark ctx as backfill so that accounts
+	// discovered in backfilled statuses don't
+	// in turn trigger their own backfill.
+	ctx = gtscontext.SetBackfill(ctx)
+
+	// Fetch the outbox collection itself.
+	collect, err := d.dereferenceCollection(ctx, requestUser, outboxURI)
+	if err != nil {
+		return err
+	}
+
+	var count int
+
+	// backfillItems dereferences any Create{Status}
+	// in the given items, returning false once the
+	// backfill limit has been reached.
+	backfillItems := func(next func() ap.TypeOrIRI) bool {
+		for count < limit {
+			item := next()
+			if item == nil {
+				return true
+			}
+
+			statusIRI := createdStatusIRI(item)
+			if statusIRI == nil {
+				continue
+			}
+
+			if statusIRI.Host != outboxURI.Host {
+				// If this status doesn't share a host with
+				// the outbox, we shouldn't trust it. Skip.
+				continue
+			}
+
+			if ctx.Err() != nil {
+				return false
+			}
+
+			// Search for status by URI. Note this may return an existing model
+			// we have stored with an error from attempted update, so check both.
+			status, _, _, err := d.getStatusByURI(ctx, requestUser, statusIRI)
+			if err != nil {
+				log.Debugf(ctx, "error getting status from outbox %s: %v", statusIRI, err)
+
+				if status == nil {
+					// This is only unactionable
+					// if no status was returned.
+					continue
+				}
+			}
+
+			if status.AccountURI != account.URI {
+				// Not a status by this
+				// account, don't count it.
+				continue
+			}
+
+			count++
+		}
+
+		return false
+	}
+
+	// Some implementations put items
+	// directly on the outbox collection.
+	if !backfillItems(collect.NextItem) {
+		return nil
+	}
+
+	// Else, get the first outbox page.
+	pageIRI := getCollectionFirstIRI(collect)
+	if pageIRI == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, maxBackfillPages)
+
+	for i := 0; i < maxBackfillPages; i++ {
+		pageIRIStr := pageIRI.String()
+
+		if pageIRI.Host != outboxURI.Host {
+			return gtserror.Newf("outbox page %s on different host to outbox", pageIRIStr)
+		}
+
+		// Check whether this page has already been deref'd.
+		if _, ok := seen[pageIRIStr]; ok {
+			return gtserror.Newf("self referencing outbox page(s): %s", pageIRIStr)
+		}
+
+		// Mark this outbox page as deref'd.
+		seen[pageIRIStr] = struct{}{}
+
+		page, err := d.dereferenceCollectionPage(ctx, requestUser, pageIRI)
+		if err != nil {
+			return err
+		}
+
+		if !backfillItems(page.NextItem) {
+			return nil
+		}
+
+		// Get the next page from iterator.
+		next := page.NextPage()
+		if next == nil || !next.IsIRI() {
+			return nil
+		}
+
+		pageIRI = next.GetIRI()
+	}
+
+	return nil
+}
+
+// startBackfillHost marks a backfill from the given host as
+// running, returning zero. If one is already running, or the
+// last one ended less than backfillHostInterval ago, it instead
+// returns how long to wait before trying again.
+func (d *Dereferencer) startBackfillHost(host string) time.Duration {
+	now := time.Now()
+
+	d.backfillHostsMu.Lock()
+	defer d.backfillHostsMu.Unlock()
+
+	// Tidy up any idle hosts
+	// whose wait has passed.
+	for h, b := range d.backfillHosts {
+		if !b.running && b.next.Before(now) {
+			delete(d.backfillHosts, h)
+		}
+	}
+
+	if b, ok := d.backfillHosts[host]; ok {
+		if b.running {
+			return backfillHostInterval
+		}
+
+		if wait := b.next.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	d.backfillHosts[host] = &backfillHost{running: true}
+	return 0
+}
+
+// endBackfillHost marks a backfill from the given
+// host as done, so that another may be started after
+// backfillHostInterval.
+func (d *Dereferencer) endBackfillHost(host string) {
+	d.backfillHostsMu.Lock()
+	d.backfillHosts[host] = &backfillHost{
+		next: time.Now().Add(backfillHostInterval),
+	}
+	d.backfillHostsMu.Unlock()
+}
+
+// createdStatusIRI returns the IRI of the status object of
+// the given outbox item, if it's a Create activity. Other
+// activity types, e.g. Announce, are not backfilled.
+func createdStatusIRI(item ap.TypeOrIRI) *url.URL {
+	t := item.GetType()
+	if t == nil || t.GetTypeName() != ap.ActivityCreate {
+		return nil
+	}
+
+	create, ok := t.(vocab.ActivityStreamsCreate)
+	if !ok {
+		return nil
+	}
+
+	objs := ap.ExtractObjects(create)
+	if len(objs) != 1 {
+		return nil
+	}
+
+	obj := objs[0]
+	if obj.IsIRI() {
+		return obj.GetIRI()
+	}
+
+	statusable, ok := ap.ToStatusable(obj.GetType())
+	if !ok {
+		return nil
+	}
+
+	return ap.GetJSONLDId(statusable)
+}
+
+// getCollectionFirstIRI returns the IRI of the
+// 'first' page of the given collection, if set.
+func getCollectionFirstIRI(collect ap.CollectionIterator) *url.URL {
+	withFirst, ok := collect.(interface {
+		GetActivityStreamsFirst() vocab.ActivityStreamsFirstProperty
+	})
+	if !ok {
+		return nil
+	}
+
+	first := withFirst.GetActivityStreamsFirst()
+	if first == nil {
+		return nil
+	}
+
+	if first.IsIRI() {
+		return first.GetIRI()
+	}
+
+	if t := first.GetType(); t != nil {
+		// Embedded first
+		// page, use its ID.
+		return ap.GetJSONLDId(t)
+	}
+
+	return nil
+}
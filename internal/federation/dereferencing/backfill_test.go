@@ -0,0 +1,274 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const (
+	backfillActor  = "http://fossbros-anonymous.io/users/foss_satan"
+	backfillOutbox = backfillActor + "/outbox"
+)
+
+type BackfillTestSuite struct {
+	DereferencerStandardTestSuite
+
+	// requested URIs, in order.
+	requested []string
+}
+
+// backfillCreate returns a Create of the note
+// with the given ID, by the backfill actor.
+func backfillCreate(noteID string) map[string]any {
+	return map[string]any{
+		"id":     noteID + "/activity",
+		"type":   "Create",
+		"actor":  backfillActor,
+		"object": noteID,
+	}
+}
+
+// backfillNote returns a public note with the
+// given ID, attributed to the backfill actor.
+func backfillNote(noteID string) map[string]any {
+	return map[string]any{
+		"@context":     "https://www.w3.org/ns/activitystreams",
+		"id":           noteID,
+		"type":         "Note",
+		"attributedTo": backfillActor,
+		"to":           "https://www.w3.org/ns/activitystreams#Public",
+		"cc":           backfillActor + "/followers",
+		"content":      "<p>backfill me!</p>",
+		"published":    "2024-01-01T12:00:00Z",
+	}
+}
+
+func (suite *BackfillTestSuite) SetupTest() {
+	suite.DereferencerStandardTestSuite.SetupTest()
+	suite.requested = nil
+
+	note1 := backfillActor + "/statuses/01HZBACKFILL1"
+	note2 := backfillActor + "/statuses/01HZBACKFILL2"
+	note3 := backfillActor + "/statuses/01HZBACKFILL3"
+	evil := "http://evil.example.org/users/foss_satan/statuses/01HZBACKFILL4"
+
+	docs := map[string]any{
+		backfillOutbox: map[string]any{
+			"@context":   "https://www.w3.org/ns/activitystreams",
+			"id":         backfillOutbox,
+			"type":       "OrderedCollection",
+			"totalItems": 4,
+			"first":      backfillOutbox + "?page=1",
+		},
+		backfillOutbox + "?page=1": map[string]any{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id":       backfillOutbox + "?page=1",
+			"type":     "OrderedCollectionPage",
+			"partOf":   backfillOutbox,
+			"next":     backfillOutbox + "?page=2",
+			"orderedItems": []any{
+				backfillCreate(note1),
+				backfillCreate(evil),
+				backfillCreate(note2),
+			},
+		},
+		backfillOutbox + "?page=2": map[string]any{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id":       backfillOutbox + "?page=2",
+			"type":     "OrderedCollectionPage",
+			"partOf":   backfillOutbox,
+			// Loops back round, should stop.
+			"next":         backfillOutbox + "?page=1",
+			"orderedItems": []any{backfillCreate(note3)},
+		},
+		note1: backfillNote(note1),
+		note2: backfillNote(note2),
+		note3: backfillNote(note3),
+		evil:  backfillNote(evil),
+	}
+
+	fallback := testrig.NewMockHTTPClient(nil, "../../../testrig/media")
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		doc, ok := docs[req.URL.String()]
+		if !ok {
+			return fallback.Do(req)
+		}
+
+		suite.requested = append(suite.requested, req.URL.String())
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			Request:       req,
+			StatusCode:    http.StatusOK,
+			Body:          io.NopCloser(bytes.NewReader(b)),
+			ContentLength: int64(len(b)),
+			Header:        http.Header{"Content-Type": {"application/activity+json"}},
+		}, nil
+	}, "")
+
+	converter := typeutils.NewConverter(&suite.state)
+	suite.dereferencer = dereferencing.NewDereferencer(
+		&suite.state,
+		converter,
+		testrig.NewTestTransportController(&suite.state, client),
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+// runBackfill pops the next queued dereference
+// job, which should be a backfill, and runs it.
+func (suite *BackfillTestSuite) runBackfill(ctx context.Context) {
+	job, ok := suite.state.Workers.Dereference.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected backfill to be queued")
+	}
+	job(ctx)
+}
+
+// storedStatuses returns which of the given
+// status URIs were stored by the backfill.
+func (suite *BackfillTestSuite) storedStatuses(ctx context.Context, uris ...string) []string {
+	var stored []string
+	for _, uri := range uris {
+		if _, err := suite.db.GetStatusByURI(ctx, uri); err == nil {
+			stored = append(stored, uri)
+		}
+	}
+	return stored
+}
+
+func (suite *BackfillTestSuite) TestBackfillAccount() {
+	ctx := context.Background()
+	config.SetInstanceFederationBackfill(10)
+
+	suite.dereferencer.BackfillAccountAsync(ctx, "admin", suite.testAccounts["remote_account_1"])
+	suite.runBackfill(ctx)
+
+	// All statuses on the actor's
+	// host should have been stored.
+	suite.Equal([]string{
+		backfillActor + "/statuses/01HZBACKFILL1",
+		backfillActor + "/statuses/01HZBACKFILL2",
+		backfillActor + "/statuses/01HZBACKFILL3",
+	}, suite.storedStatuses(ctx,
+		backfillActor+"/statuses/01HZBACKFILL1",
+		backfillActor+"/statuses/01HZBACKFILL2",
+		backfillActor+"/statuses/01HZBACKFILL3",
+		"http://evil.example.org/users/foss_satan/statuses/01HZBACKFILL4",
+	))
+
+	// The status on another host should never have
+	// been requested, and each page only once even
+	// though the second page links to the first.
+	suite.Equal([]string{
+		backfillOutbox,
+		backfillOutbox + "?page=1",
+		backfillActor + "/statuses/01HZBACKFILL1",
+		backfillActor + "/statuses/01HZBACKFILL2",
+		backfillOutbox + "?page=2",
+		backfillActor + "/statuses/01HZBACKFILL3",
+	}, suite.requested)
+}
+
+func (suite *BackfillTestSuite) TestBackfillAccountLimit() {
+	ctx := context.Background()
+	config.SetInstanceFederationBackfill(2)
+
+	suite.dereferencer.BackfillAccountAsync(ctx, "admin", suite.testAccounts["remote_account_1"])
+	suite.runBackfill(ctx)
+
+	// Only the first two statuses should have
+	// been fetched, without going to page two.
+	suite.Equal([]string{
+		backfillOutbox,
+		backfillOutbox + "?page=1",
+		backfillActor + "/statuses/01HZBACKFILL1",
+		backfillActor + "/statuses/01HZBACKFILL2",
+	}, suite.requested)
+}
+
+func (suite *BackfillTestSuite) TestBackfillAccountDisabled() {
+	ctx := context.Background()
+	account := suite.testAccounts["remote_account_1"]
+
+	// Nothing should be queued for local accounts,
+	// with backfill disabled, or from within backfill.
+	config.SetInstanceFederationBackfill(0)
+	suite.dereferencer.BackfillAccountAsync(ctx, "admin", account)
+
+	config.SetInstanceFederationBackfill(10)
+	suite.dereferencer.BackfillAccountAsync(ctx, "admin", suite.testAccounts["local_account_1"])
+	suite.dereferencer.BackfillAccountAsync(gtscontext.SetBackfill(ctx), "admin", account)
+
+	suite.Zero(suite.state.Workers.Dereference.Queue.Len())
+}
+
+func (suite *BackfillTestSuite) TestBackfillAccountThrottled() {
+	ctx := context.Background()
+	config.SetInstanceFederationBackfill(1)
+	account := suite.testAccounts["remote_account_1"]
+
+	suite.dereferencer.BackfillAccountAsync(ctx, "admin", account)
+	suite.dereferencer.BackfillAccountAsync(ctx, "admin", account)
+
+	// Run the first backfill.
+	suite.runBackfill(ctx)
+	suite.Len(suite.requested, 3)
+
+	// The second, for the same host right after,
+	// shouldn't make any requests, but should be
+	// put back on the queue for later instead.
+	suite.runBackfill(ctx)
+	suite.Len(suite.requested, 3)
+	suite.Zero(suite.state.Workers.Dereference.Queue.Len())
+
+	if !suite.Eventually(func() bool {
+		return suite.state.Workers.Dereference.Queue.Len() == 1
+	}, 5*time.Second, 100*time.Millisecond) {
+		suite.FailNow("timed out waiting for backfill to be requeued")
+	}
+
+	// Now it should run; the first status
+	// is already stored so isn't refetched.
+	suite.runBackfill(ctx)
+	suite.Len(suite.requested, 5)
+}
+
+func TestBackfillTestSuite(t *testing.T) {
+	suite.Run(t, new(BackfillTestSuite))
+}
@@ -0,0 +1,595 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/net/html"
+)
+
+const (
+	// previewCardFreshness is how long a fetched
+	// preview card is considered up-to-date for,
+	// before it's refetched the next time a status
+	// linking to it is created or edited.
+	previewCardFreshness = 7 * 24 * time.Hour
+
+	// Limits on how much of a linked
+	// page / oEmbed document we read.
+	maxPageSize   = 1 << 20  // 1MiB
+	maxOEmbedSize = 64 << 10 // 64KiB
+
+	// Limits on the length of
+	// card text fields, in runes.
+	maxCardTitle       = 200
+	maxCardDescription = 500
+	maxCardName        = 100
+)
+
+// UpdateStatusPreviewCard finds the first link in the given
+// status' content (excluding mentions and hashtags), fetches
+// the preview card for it, and sets it on the status, updating
+// the status in the database if the card changed. Statuses
+// without links have any existing preview card unset.
+func (d *Dereferencer) UpdateStatusPreviewCard(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) error {
+	var card *gtsmodel.PreviewCard
+
+	if links := text.ExtractLinks(status.Content); len(links) > 0 {
+		var err error
+
+		// Only the first link gets a card.
+		card, err = d.GetPreviewCard(ctx,
+			requestUser,
+			links[0],
+		)
+		if err != nil {
+			return err
+		}
+
+		if card.Empty() {
+			// Nothing worth
+			// showing here.
+			card = nil
+		}
+	}
+
+	var cardID string
+	if card != nil {
+		cardID = card.ID
+	}
+
+	if cardID == status.PreviewCardID {
+		// Nothing
+		// changed.
+		return nil
+	}
+
+	// Update the status with new card.
+	status.PreviewCardID = cardID
+	status.PreviewCard = card
+	if err := d.state.DB.UpdateStatus(ctx,
+		status,
+		"preview_card_id",
+	); err != nil {
+		return gtserror.Newf("error updating status: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreviewCard returns the preview card for the
+// page at given URL, fetching the page and any oEmbed
+// and preview image for it if there's no card for the
+// URL yet, or if the existing card has gone stale.
+//
+// If no usable metadata could be found for the page,
+// an empty card will be returned (and stored, so that
+// it's not refetched until stale). Check card.Empty().
+func (d *Dereferencer) GetPreviewCard(
+	ctx context.Context,
+	requestUser string,
+	pageURL string,
+) (
+	*gtsmodel.PreviewCard,
+	error,
+) {
+	// Parse str as valid URL object.
+	iri, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, gtserror.Newf("invalid page url %q: %w", pageURL, err)
+	}
+
+	// Acquire per-URL lock, so we
+	// don't fetch the same page in
+	// parallel for different statuses.
+	unlock := d.state.FedLocks.Lock(pageURL)
+	defer unlock()
+
+	// Look for an existing card for this URL.
+	card, err := d.state.DB.GetPreviewCardByURL(ctx, pageURL)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error fetching card from db: %w", err)
+	}
+
+	if card != nil && time.Since(card.FetchedAt) < previewCardFreshness {
+		// Existing card
+		// is still fresh.
+		return card, nil
+	}
+
+	// Don't go fetching pages
+	// from domains we've blocked.
+	blocked, err := d.state.DB.IsURIBlocked(ctx, iri)
+	if err != nil {
+		return nil, gtserror.Newf("error checking domain block: %w", err)
+	}
+
+	if blocked {
+		err := gtserror.Newf("domain %s is blocked", iri.Host)
+		return nil, gtserror.SetUnretrievable(err)
+	}
+
+	// Fetch transport for the provided request user from controller.
+	tsport, err := d.transportController.NewTransportForUsername(ctx,
+		requestUser,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("failed getting transport for %s: %w", requestUser, err)
+	}
+
+	// Fetch the page and parse metadata from it. A failure
+	// here still results in an (empty) card being stored,
+	// so that we don't hammer a broken page every time.
+	meta, err := fetchPageMeta(ctx, tsport, iri)
+	if err != nil {
+		log.Warnf(ctx, "error fetching preview card page %s: %v", pageURL, err)
+		meta = new(pageMeta)
+	}
+
+	if meta.oEmbedURL != nil {
+		// Page advertises oEmbed, this takes
+		// precedence over metadata in the page.
+		if err := fetchOEmbed(ctx, tsport, meta); err != nil {
+			log.Warnf(ctx, "error fetching oembed %s: %v", meta.oEmbedURL, err)
+		}
+	}
+
+	now := time.Now()
+
+	isNew := (card == nil)
+	if isNew {
+		// First time we
+		// see this URL.
+		card = &gtsmodel.PreviewCard{
+			ID:        id.NewULID(),
+			CreatedAt: now,
+			URL:       pageURL,
+		}
+	}
+
+	// Set latest details on the card.
+	oldImageURL := card.ImageRemoteURL
+	card.UpdatedAt = now
+	card.FetchedAt = now
+	card.Title = meta.title
+	card.Description = meta.description
+	card.Type = meta.cardType()
+	card.AuthorName = meta.authorName
+	card.AuthorURL = meta.authorURL
+	card.ProviderName = meta.providerName
+	card.ProviderURL = meta.providerURL
+	card.HTML = meta.html
+	card.Width = meta.width
+	card.Height = meta.height
+	card.EmbedURL = meta.embedURL
+	card.ImageRemoteURL = meta.imageURL()
+
+	switch {
+	case card.ImageRemoteURL == "":
+		// No preview image (anymore),
+		// remove any we had cached.
+		if err := d.mediaManager.UncachePreviewCardImage(ctx, card); err != nil {
+			log.Errorf(ctx, "error uncaching card image: %v", err)
+		}
+
+	case card.ImageRemoteURL != oldImageURL || !card.ImageCached():
+		// New or previously uncached preview image.
+		if err := d.mediaManager.CachePreviewCardImage(ctx,
+			card,
+			func(ctx context.Context) (io.ReadCloser, int64, error) {
+				imageURL, err := url.Parse(card.ImageRemoteURL)
+				if err != nil {
+					return nil, 0, err
+				}
+				return tsport.DereferenceMedia(ctx, imageURL)
+			},
+		); err != nil {
+			log.Warnf(ctx, "error caching card image %s: %v", card.ImageRemoteURL, err)
+		}
+	}
+
+	if isNew {
+		err = d.state.DB.PutPreviewCard(ctx, card)
+	} else {
+		err = d.state.DB.UpdatePreviewCard(ctx, card)
+	}
+
+	if err != nil {
+		return nil, gtserror.Newf("error storing card in db: %w", err)
+	}
+
+	return card, nil
+}
+
+// pageMeta contains the preview card
+// metadata gathered from a linked page,
+// and optionally its oEmbed document.
+type pageMeta struct {
+	title        string
+	description  string
+	authorName   string
+	authorURL    string
+	providerName string
+	providerURL  string
+
+	// OpenGraph / Twitter Card
+	// image, in order of preference.
+	images []string
+
+	// oEmbed specific fields.
+	oEmbedURL   *url.URL
+	oEmbedType  string
+	oEmbedThumb string
+	html        string
+	width       int
+	height      int
+	embedURL    string
+}
+
+// cardType returns the preview card
+// type appropriate for gathered metadata.
+func (m *pageMeta) cardType() string {
+	switch {
+	case m.oEmbedType == "photo" && m.embedURL != "":
+		return "photo"
+	case m.oEmbedType == "video" && m.html != "":
+		return "video"
+	case m.oEmbedType == "rich" && m.html != "":
+		return "rich"
+	default:
+		return "link"
+	}
+}
+
+// imageURL returns the preview image URL
+// to use from gathered metadata, if any.
+func (m *pageMeta) imageURL() string {
+	if m.oEmbedThumb != "" {
+		return m.oEmbedThumb
+	}
+	if len(m.images) > 0 {
+		return m.images[0]
+	}
+	return ""
+}
+
+// fetchPageMeta fetches the HTML page at given URL, and parses
+// OpenGraph, Twitter Card and basic HTML metadata from it.
+func fetchPageMeta(
+	ctx context.Context,
+	tsport transport.Transport,
+	pageURL *url.URL,
+) (*pageMeta, error) {
+	rsp, err := tsport.DereferencePage(ctx, pageURL, "text/html,application/xhtml+xml")
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure we were actually given an HTML page.
+	ct, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if ct != "text/html" && ct != "application/xhtml+xml" {
+		return nil, gtserror.Newf("unexpected content type %s", ct)
+	}
+
+	// Resolve relative links against the
+	// final URL, in case of redirects.
+	base := pageURL
+	if rsp.Request != nil && rsp.Request.URL != nil {
+		base = rsp.Request.URL
+	}
+
+	return parsePageMeta(io.LimitReader(rsp.Body, maxPageSize), base), nil
+}
+
+// parsePageMeta parses preview card metadata from given HTML
+// page, using base to resolve any relative URLs found in it.
+func parsePageMeta(r io.Reader, base *url.URL) *pageMeta {
+	var (
+		meta = new(pageMeta)
+
+		// Values found per-source, resolved
+		// in order of preference at the end.
+		ogTitle, twTitle, htmlTitle      string
+		ogDesc, twDesc, htmlDesc         string
+		ogImages, twImages               []string
+		ogSiteName, ogAuthor, htmlAuthor string
+		inTitle                          bool
+	)
+
+	z := html.NewTokenizer(r)
+
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// Finished (or
+			// broken) page.
+			break loop
+
+		case html.TextToken:
+			if inTitle && htmlTitle == "" {
+				htmlTitle = string(z.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				// All the metadata
+				// we want is in head.
+				break loop
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "title":
+				inTitle = true
+
+			case "body":
+				// All the metadata
+				// we want is in head.
+				break loop
+
+			case "meta":
+				// OpenGraph uses "property", Twitter
+				// Cards and basic HTML use "name".
+				key := strings.ToLower(cmp.Or(
+					attr(tok, "property"),
+					attr(tok, "name"),
+				))
+				val := strings.TrimSpace(attr(tok, "content"))
+				if val == "" {
+					continue
+				}
+
+				switch key {
+				case "og:title":
+					ogTitle = val
+				case "twitter:title":
+					twTitle = val
+				case "og:description":
+					ogDesc = val
+				case "twitter:description":
+					twDesc = val
+				case "description":
+					htmlDesc = val
+				case "og:image", "og:image:url", "og:image:secure_url":
+					ogImages = append(ogImages, val)
+				case "twitter:image", "twitter:image:src":
+					twImages = append(twImages, val)
+				case "og:site_name":
+					ogSiteName = val
+				case "author":
+					htmlAuthor = val
+				case "article:author":
+					ogAuthor = val
+				}
+
+			case "link":
+				rel := strings.ToLower(attr(tok, "rel"))
+				typ := strings.ToLower(attr(tok, "type"))
+				if rel == "alternate" && typ == "application/json+oembed" && meta.oEmbedURL == nil {
+					meta.oEmbedURL = resolveURL(base, attr(tok, "href"))
+				}
+			}
+		}
+	}
+
+	// Set most preferable found values.
+	meta.title = trimRunes(cmp.Or(ogTitle, twTitle, htmlTitle), maxCardTitle)
+	meta.description = trimRunes(cmp.Or(ogDesc, twDesc, htmlDesc), maxCardDescription)
+	meta.providerName = trimRunes(ogSiteName, maxCardName)
+	meta.providerURL = base.Scheme + "://" + base.Host
+
+	// article:author may be either
+	// a name or a link to the author.
+	if u := resolveURL(base, ogAuthor); u != nil && strings.Contains(ogAuthor, "/") {
+		meta.authorURL = u.String()
+	} else if htmlAuthor == "" {
+		htmlAuthor = ogAuthor
+	}
+	meta.authorName = trimRunes(htmlAuthor, maxCardName)
+
+	// Resolve any found images, preferring OpenGraph.
+	for _, img := range append(ogImages, twImages...) {
+		if u := resolveURL(base, img); u != nil {
+			meta.images = append(meta.images, u.String())
+		}
+	}
+
+	return meta
+}
+
+// oEmbed models the fields we use from an oEmbed
+// JSON response. See: https://oembed.com/#section2
+type oEmbed struct {
+	Type            string          `json:"type"`
+	Title           string          `json:"title"`
+	AuthorName      string          `json:"author_name"`
+	AuthorURL       string          `json:"author_url"`
+	ProviderName    string          `json:"provider_name"`
+	ProviderURL     string          `json:"provider_url"`
+	ThumbnailURL    string          `json:"thumbnail_url"`
+	URL             string          `json:"url"`
+	HTML            string          `json:"html"`
+	Width           json.RawMessage `json:"width"`
+	Height          json.RawMessage `json:"height"`
+	ThumbnailWidth  json.RawMessage `json:"thumbnail_width"`
+	ThumbnailHeight json.RawMessage `json:"thumbnail_height"`
+}
+
+// fetchOEmbed fetches the oEmbed document advertised
+// by a page, merging its details into given metadata.
+func fetchOEmbed(
+	ctx context.Context,
+	tsport transport.Transport,
+	meta *pageMeta,
+) error {
+	rsp, err := tsport.DereferencePage(ctx, meta.oEmbedURL, "application/json")
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	var oe oEmbed
+	dec := json.NewDecoder(io.LimitReader(rsp.Body, maxOEmbedSize))
+	if err := dec.Decode(&oe); err != nil {
+		return gtserror.Newf("error decoding oembed: %w", err)
+	}
+
+	switch oe.Type = strings.ToLower(oe.Type); oe.Type {
+	case "photo":
+		if u := resolveURL(meta.oEmbedURL, oe.URL); u != nil {
+			meta.embedURL = u.String()
+		}
+
+	case "video", "rich":
+		// Only ever trust a single https iframe.
+		meta.html = text.SanitizeEmbedHTML(oe.HTML)
+
+	case "link":
+		// Nothing extra.
+
+	default:
+		return gtserror.Newf("unknown oembed type %q", oe.Type)
+	}
+
+	meta.oEmbedType = oe.Type
+	meta.width = jsonInt(oe.Width)
+	meta.height = jsonInt(oe.Height)
+
+	// Prefer oEmbed values where given.
+	if oe.Title != "" {
+		meta.title = trimRunes(oe.Title, maxCardTitle)
+	}
+	if oe.AuthorName != "" {
+		meta.authorName = trimRunes(oe.AuthorName, maxCardName)
+	}
+	if u := resolveURL(meta.oEmbedURL, oe.AuthorURL); u != nil {
+		meta.authorURL = u.String()
+	}
+	if oe.ProviderName != "" {
+		meta.providerName = trimRunes(oe.ProviderName, maxCardName)
+	}
+	if u := resolveURL(meta.oEmbedURL, oe.ProviderURL); u != nil {
+		meta.providerURL = u.String()
+	}
+	if u := resolveURL(meta.oEmbedURL, oe.ThumbnailURL); u != nil {
+		meta.oEmbedThumb = u.String()
+	}
+
+	return nil
+}
+
+// attr returns the value of the
+// named attribute on token, if any.
+func attr(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// resolveURL resolves given (possibly relative)
+// http(s) link against base, returning nil if
+// it's empty, unparseable or not http(s).
+func resolveURL(base *url.URL, link string) *url.URL {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return nil
+	}
+
+	u, err := base.Parse(link)
+	if err != nil {
+		return nil
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+
+	return u
+}
+
+// trimRunes returns the given string with whitespace
+// collapsed, truncated to max runes (with ellipsis).
+func trimRunes(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > max {
+		return string(r[:max-1]) + "…"
+	}
+	return s
+}
+
+// jsonInt parses an oEmbed dimension, which some
+// providers give as a number and others as a string.
+func jsonInt(raw json.RawMessage) int {
+	s := strings.Trim(string(raw), `"`)
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0
+	}
+	return i
+}
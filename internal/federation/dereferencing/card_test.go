@@ -0,0 +1,237 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const (
+	testCardPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Fallback title</title>
+	<meta property="og:title" content="  Is   Water Wet? ">
+	<meta name="twitter:title" content="Twitter title">
+	<meta name="description" content="Is water wet? We're not sure. In this article, we ask an expert...">
+	<meta property="og:site_name" content="Example News">
+	<meta property="og:image" content="/images/water.jpg">
+	<meta name="author" content="Some Journalist">
+</head>
+<body>
+	<meta property="og:title" content="Not in head, ignored">
+</body>
+</html>`
+
+	testCardVideoPage = `<html><head>
+	<title>A video</title>
+	<link rel="alternate" type="application/json+oembed" href="https://video.example.org/oembed?url=watch">
+</head></html>`
+
+	testCardOEmbed = `{
+	"type": "video",
+	"version": "1.0",
+	"title": "A video, per oEmbed",
+	"author_name": "Video Person",
+	"author_url": "https://video.example.org/@person",
+	"provider_name": "Example Video",
+	"provider_url": "https://video.example.org/",
+	"html": "<iframe width=\"560\" height=\"315\" src=\"https://video.example.org/embed/watch\" allowfullscreen></iframe><script>alert('hi')</script>",
+	"width": 560,
+	"height": "315"
+}`
+)
+
+type CardTestSuite struct {
+	DereferencerStandardTestSuite
+
+	// number of requests made
+	// for the article page.
+	pageFetches atomic.Int32
+}
+
+func (suite *CardTestSuite) SetupTest() {
+	suite.DereferencerStandardTestSuite.SetupTest()
+	suite.pageFetches.Store(0)
+
+	image, err := os.ReadFile("../../../testrig/media/thoughtsofdog-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Serve our test pages rather than the standard
+	// mock responses, so we can see what's fetched.
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		var (
+			code = http.StatusOK
+			body []byte
+			ct   string
+		)
+
+		switch req.URL.String() {
+		case "https://news.example.org/water":
+			suite.pageFetches.Add(1)
+			body, ct = []byte(testCardPage), "text/html; charset=utf-8"
+		case "https://news.example.org/images/water.jpg":
+			body, ct = image, "image/jpeg"
+		case "https://video.example.org/watch":
+			body, ct = []byte(testCardVideoPage), "text/html"
+		case "https://video.example.org/oembed?url=watch":
+			body, ct = []byte(testCardOEmbed), "application/json"
+		case "https://news.example.org/feed.json":
+			body, ct = []byte(`{}`), "application/json"
+		default:
+			code, body, ct = http.StatusNotFound, []byte("not found"), "text/plain"
+		}
+
+		return &http.Response{
+			Request:       req,
+			StatusCode:    code,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Header:        http.Header{"Content-Type": {ct}},
+		}, nil
+	}, "")
+
+	converter := typeutils.NewConverter(&suite.state)
+	suite.dereferencer = dereferencing.NewDereferencer(
+		&suite.state,
+		converter,
+		testrig.NewTestTransportController(&suite.state, client),
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+func (suite *CardTestSuite) TestGetPreviewCardOpenGraph() {
+	ctx := context.Background()
+
+	card, err := suite.dereferencer.GetPreviewCard(ctx, "", "https://news.example.org/water")
+	suite.NoError(err)
+	suite.False(card.Empty())
+
+	// OpenGraph values should be preferred,
+	// falling back to plain HTML metadata.
+	suite.Equal("Is Water Wet?", card.Title)
+	suite.Equal("Is water wet? We're not sure. In this article, we ask an expert...", card.Description)
+	suite.Equal("link", card.Type)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Equal("https://news.example.org", card.ProviderURL)
+	suite.Equal("Some Journalist", card.AuthorName)
+	suite.Empty(card.HTML)
+
+	// Relative image should have been resolved,
+	// fetched, and stored as a local thumbnail.
+	suite.Equal("https://news.example.org/images/water.jpg", card.ImageRemoteURL)
+	suite.True(card.ImageCached())
+	suite.Equal("image/jpeg", card.ImageContentType)
+	suite.NotEmpty(card.Blurhash)
+	suite.LessOrEqual(card.ImageWidth, 512)
+	suite.LessOrEqual(card.ImageHeight, 512)
+
+	have, err := suite.storage.Has(ctx, card.ImagePath)
+	suite.NoError(err)
+	suite.True(have)
+
+	// Card should be stored, and fetching it
+	// again should not refetch the (fresh) page.
+	again, err := suite.dereferencer.GetPreviewCard(ctx, "", "https://news.example.org/water")
+	suite.NoError(err)
+	suite.Equal(card.ID, again.ID)
+	suite.EqualValues(1, suite.pageFetches.Load())
+
+	dbCard, err := suite.db.GetPreviewCardByImageURL(ctx, card.ImageURL)
+	suite.NoError(err)
+	suite.Equal(card.ID, dbCard.ID)
+}
+
+func (suite *CardTestSuite) TestGetPreviewCardOEmbed() {
+	card, err := suite.dereferencer.GetPreviewCard(context.Background(), "", "https://video.example.org/watch")
+	suite.NoError(err)
+
+	// oEmbed values should override the page,
+	// with the html sanitized down to the iframe.
+	suite.Equal("video", card.Type)
+	suite.Equal("A video, per oEmbed", card.Title)
+	suite.Equal("Video Person", card.AuthorName)
+	suite.Equal("https://video.example.org/@person", card.AuthorURL)
+	suite.Equal("Example Video", card.ProviderName)
+	suite.Equal(`<iframe width="560" height="315" src="https://video.example.org/embed/watch" allowfullscreen=""></iframe>`, card.HTML)
+	suite.Equal(560, card.Width)
+	suite.Equal(315, card.Height)
+	suite.False(card.ImageCached())
+}
+
+func (suite *CardTestSuite) TestGetPreviewCardNotHTML() {
+	card, err := suite.dereferencer.GetPreviewCard(context.Background(), "", "https://news.example.org/feed.json")
+	suite.NoError(err)
+
+	// Card should be stored,
+	// but there's nothing in it.
+	suite.NotEmpty(card.ID)
+	suite.True(card.Empty())
+}
+
+func (suite *CardTestSuite) TestUpdateStatusPreviewCard() {
+	ctx := context.Background()
+
+	status, err := suite.db.GetStatusByID(ctx, "01F8MHAMCHF6Y650WCRSCP4WMY")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status.Content = `<p>hey <span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> check <a href="http://localhost:8080/tags/water" class="mention hashtag" rel="tag">#<span>water</span></a> this out: <a href="https://news.example.org/water#top" rel="nofollow noreferrer noopener" target="_blank">https://news.example.org/water#top</a></p>`
+
+	// Mention and hashtag links should be skipped.
+	err = suite.dereferencer.UpdateStatusPreviewCard(ctx, "", status)
+	suite.NoError(err)
+	suite.NotEmpty(status.PreviewCardID)
+	suite.Equal("https://news.example.org/water", status.PreviewCard.URL)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal(status.PreviewCardID, dbStatus.PreviewCardID)
+	suite.Equal("Is Water Wet?", dbStatus.PreviewCard.Title)
+
+	// Removing the link should unset the card.
+	status.Content = "<p>never mind</p>"
+	err = suite.dereferencer.UpdateStatusPreviewCard(ctx, "", status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+
+	dbStatus, err = suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Empty(dbStatus.PreviewCardID)
+	suite.Nil(dbStatus.PreviewCard)
+}
+
+func TestCardTestSuite(t *testing.T) {
+	suite.Run(t, new(CardTestSuite))
+}
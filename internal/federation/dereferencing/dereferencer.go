@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -84,6 +85,7 @@ type Dereferencer struct {
 	transportController transport.Controller
 	mediaManager        *media.Manager
 	visibility          *visibility.Filter
+	policy              *policy.Filter
 
 	// in-progress dereferencing emoji. we already perform
 	// locks per-status and per-account so we don't need
@@ -103,6 +105,13 @@ type Dereferencer struct {
 	// form of the data as we currently see it.
 	handshakes   map[string][]*url.URL
 	handshakesMu sync.Mutex
+
+	// backfillHosts tracks outbox backfills
+	// in progress to, and the next time at which
+	// one may be started to, each remote host,
+	// used to rate-limit backfill.
+	backfillHosts   map[string]*backfillHost
+	backfillHostsMu sync.Mutex
 }
 
 // NewDereferencer returns a Dereferencer initialized with the given parameters.
@@ -119,7 +128,9 @@ func NewDereferencer(
 		transportController: transportController,
 		mediaManager:        mediaManager,
 		visibility:          visFilter,
+		policy:              policy.NewFilter(state),
 		derefEmojis:         make(map[string]*media.ProcessingEmoji),
 		handshakes:          make(map[string][]*url.URL),
+		backfillHosts:       make(map[string]*backfillHost),
 	}
 }
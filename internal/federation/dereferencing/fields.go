@@ -0,0 +1,299 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/net/html"
+)
+
+// VerifyAccountFields checks the URL values in the given
+// local account's profile fields for a rel="me" link back
+// to the account's profile, setting (or clearing) each
+// field's VerifiedAt accordingly, and updating the account
+// in the database if the verification of any field changed.
+//
+// If recheck is false, only fields that aren't currently
+// verified are checked, else all URL fields are checked.
+func (d *Dereferencer) VerifyAccountFields(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	recheck bool,
+) error {
+	// Gather unique URL field values to check.
+	var links []string
+	for _, field := range account.FieldsRaw {
+		if !recheck && !field.VerifiedAt.IsZero() {
+			continue
+		}
+
+		if fieldURL(field.Value) == nil ||
+			slices.Contains(links, field.Value) {
+			continue
+		}
+
+		links = append(links, field.Value)
+	}
+
+	if len(links) == 0 {
+		// Nothing
+		// to check.
+		return nil
+	}
+
+	// Fetch links as the instance account, as per preview cards.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return gtserror.Newf("failed getting transport: %w", err)
+	}
+
+	// The profile URLs we accept a link back to.
+	profileURLs := []string{account.URL, account.URI}
+
+	verified := make(map[string]bool, len(links))
+	for _, link := range links {
+		ok, err := d.checkRelMe(ctx, tsport, fieldURL(link), profileURLs)
+		if err != nil {
+			if !gone(err) {
+				// The page may still link back once the site
+				// recovers from timeout or server error etc.,
+				// so leave this field's verification as-is.
+				log.Warnf(ctx, "error verifying field link %s: %v", link, err)
+				continue
+			}
+
+			log.Debugf(ctx, "field link %s gone: %v", link, err)
+		}
+		verified[link] = ok
+	}
+
+	// Get a fresh copy of the account, as fetching
+	// the links may have taken a while, and the user
+	// may have changed their fields in the meantime.
+	account, err = d.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("error refetching account: %w", err)
+	}
+
+	var (
+		now       = time.Now()
+		changed   bool
+		fieldsRaw = make([]*gtsmodel.Field, len(account.FieldsRaw))
+		fields    = make([]*gtsmodel.Field, len(account.Fields))
+	)
+
+	// Copy fields before modifying them, as
+	// the account may be in use elsewhere.
+	for i, field := range account.FieldsRaw {
+		f := *field
+		fieldsRaw[i] = &f
+	}
+	for i, field := range account.Fields {
+		f := *field
+		fields[i] = &f
+	}
+
+	for i, field := range fieldsRaw {
+		ok, checked := verified[field.Value]
+		if !checked {
+			// Value changed since,
+			// or not rechecked.
+			continue
+		}
+
+		switch {
+		case ok && field.VerifiedAt.IsZero():
+			field.VerifiedAt = now
+		case !ok && !field.VerifiedAt.IsZero():
+			field.VerifiedAt = time.Time{}
+		default:
+			continue
+		}
+
+		// Formatted fields are
+		// 1:1 with raw fields.
+		if i < len(fields) {
+			fields[i].VerifiedAt = field.VerifiedAt
+		}
+
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	account.FieldsRaw = fieldsRaw
+	account.Fields = fields
+	if err := d.state.DB.UpdateAccount(ctx,
+		account,
+		"fields_raw",
+		"fields",
+	); err != nil {
+		return gtserror.Newf("error updating account: %w", err)
+	}
+
+	return nil
+}
+
+// checkRelMe fetches the HTML page at given URL and checks it
+// for an <a> or <link> with rel="me" to one of profileURLs.
+func (d *Dereferencer) checkRelMe(
+	ctx context.Context,
+	tsport transport.Transport,
+	pageURL *url.URL,
+	profileURLs []string,
+) (bool, error) {
+	// Don't go fetching pages
+	// from domains we've blocked.
+	blocked, err := d.state.DB.IsURIBlocked(ctx, pageURL)
+	if err != nil {
+		return false, gtserror.Newf("error checking domain block: %w", err)
+	}
+
+	if blocked {
+		return false, nil
+	}
+
+	rsp, err := tsport.DereferencePage(ctx, pageURL, "text/html,application/xhtml+xml")
+	if err != nil {
+		return false, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure we were actually given an HTML
+	// page, anything else can't link back.
+	ct, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if ct != "text/html" && ct != "application/xhtml+xml" {
+		return false, nil
+	}
+
+	// Resolve relative links against the
+	// final URL, in case of redirects.
+	base := pageURL
+	if rsp.Request != nil && rsp.Request.URL != nil {
+		base = rsp.Request.URL
+	}
+
+	return hasRelMe(io.LimitReader(rsp.Body, maxPageSize), base, profileURLs), nil
+}
+
+// gone returns whether the given error fetching a field
+// link was a client error response, eg., 404 Not Found,
+// meaning the link can't currently be verified, rather
+// than the fetch failing for some likely transient reason.
+func gone(err error) bool {
+	code := gtserror.StatusCode(err)
+	return code >= 400 && code < 500 &&
+		code != http.StatusRequestTimeout &&
+		code != http.StatusTooManyRequests
+}
+
+// hasRelMe returns whether the given HTML page contains
+// an <a> or <link> with rel="me" to one of profileURLs.
+func hasRelMe(r io.Reader, base *url.URL, profileURLs []string) bool {
+	z := html.NewTokenizer(r)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// Finished (or
+			// broken) page.
+			return false
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if tok.Data != "a" && tok.Data != "link" {
+				continue
+			}
+
+			// rel is a space-separated
+			// list of link relations.
+			rels := strings.Fields(strings.ToLower(attr(tok, "rel")))
+			if !slices.Contains(rels, "me") {
+				continue
+			}
+
+			href := resolveURL(base, attr(tok, "href"))
+			if href == nil {
+				continue
+			}
+
+			for _, profileURL := range profileURLs {
+				if sameURL(href.String(), profileURL) {
+					return true
+				}
+			}
+		}
+	}
+}
+
+// fieldURL returns the given profile field value
+// parsed as an absolute http(s) URL, or nil if
+// the value is anything other than just a URL.
+func fieldURL(value string) *url.URL {
+	if strings.ContainsAny(value, " \t\n") {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+
+	return u
+}
+
+// sameURL returns whether the given URLs are equal,
+// ignoring case of scheme + host, and trailing slash.
+func sameURL(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return strings.EqualFold(ua.Scheme, ub.Scheme) &&
+		strings.EqualFold(ua.Host, ub.Host) &&
+		strings.TrimSuffix(ua.Path, "/") == strings.TrimSuffix(ub.Path, "/") &&
+		ua.RawQuery == ub.RawQuery
+}
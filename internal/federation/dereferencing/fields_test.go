@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FieldsTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+func (suite *FieldsTestSuite) SetupTest() {
+	suite.DereferencerStandardTestSuite.SetupTest()
+
+	pages := map[string]string{
+		// Links back to profile URL with rel="me" among other rels.
+		"https://example.org/zork": `<html><head><title>zork</title></head><body>
+			<a href="http://localhost:8080/@the_mighty_zork/" rel="nofollow ME">fedi</a>
+		</body></html>`,
+
+		// Links back to actor URI via relative link (redirected to localhost).
+		"https://links.example.org/zork": `<html><head>
+			<link rel="me" href="/users/the_mighty_zork">
+		</head></html>`,
+
+		// Links back to profile, but without rel="me".
+		"https://blog.example.org/zork": `<html><body>
+			<a href="http://localhost:8080/@the_mighty_zork">fedi</a>
+		</body></html>`,
+
+		// Links with rel="me", but to someone else.
+		"https://other.example.org/zork": `<html><body>
+			<a href="http://localhost:8080/@1happyturtle" rel="me">fedi</a>
+		</body></html>`,
+	}
+
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == "https://links.example.org/zork" {
+			// Pretend we got redirected, so relative
+			// links should resolve against localhost.
+			redirected := req.Clone(req.Context())
+			redirected.URL, _ = redirected.URL.Parse("http://localhost:8080/zork")
+			req = redirected
+		}
+
+		switch req.URL.Host {
+		case "down.example.org":
+			// Site having a bad day.
+			code := http.StatusServiceUnavailable
+			return &http.Response{
+				Request:    req,
+				Status:     http.StatusText(code),
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		case "slow.example.org":
+			return nil, errors.New("i/o timeout")
+		}
+
+		code, ct := http.StatusOK, "text/html"
+		page, ok := pages[req.URL.String()]
+		if !ok && req.URL.Host == "localhost:8080" {
+			page, ok = pages["https://links.example.org/zork"]
+		}
+		if !ok {
+			code, ct, page = http.StatusNotFound, "text/plain", "not found"
+		}
+
+		return &http.Response{
+			Request:       req,
+			StatusCode:    code,
+			Body:          io.NopCloser(bytes.NewReader([]byte(page))),
+			ContentLength: int64(len(page)),
+			Header:        http.Header{"Content-Type": {ct}},
+		}, nil
+	}, "")
+
+	converter := typeutils.NewConverter(&suite.state)
+	suite.dereferencer = dereferencing.NewDereferencer(
+		&suite.state,
+		converter,
+		testrig.NewTestTransportController(&suite.state, client),
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+// setFields sets the given raw field name/value pairs
+// (and their verification) on account in the database.
+func (suite *FieldsTestSuite) setFields(account *gtsmodel.Account, fields ...*gtsmodel.Field) {
+	account.FieldsRaw = fields
+	account.Fields = make([]*gtsmodel.Field, len(fields))
+	for i, f := range fields {
+		cp := *f
+		account.Fields[i] = &cp
+	}
+
+	if err := suite.db.UpdateAccount(context.Background(),
+		account,
+		"fields_raw",
+		"fields",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FieldsTestSuite) TestVerifyAccountFields() {
+	ctx := context.Background()
+
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["local_account_1"]
+
+	previouslyVerified := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	suite.setFields(account,
+		&gtsmodel.Field{Name: "website", Value: "https://example.org/zork"},
+		&gtsmodel.Field{Name: "links", Value: "https://links.example.org/zork"},
+		&gtsmodel.Field{Name: "blog", Value: "https://blog.example.org/zork", VerifiedAt: previouslyVerified},
+		&gtsmodel.Field{Name: "other", Value: "https://other.example.org/zork"},
+		&gtsmodel.Field{Name: "not a link", Value: "example.org"},
+	)
+
+	// Check only unverified fields first;
+	// the blog shouldn't get unverified.
+	err := suite.dereferencer.VerifyAccountFields(ctx, account, false)
+	suite.NoError(err)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for i, fields := range [][]*gtsmodel.Field{
+		dbAccount.FieldsRaw,
+		dbAccount.Fields,
+	} {
+		suite.False(fields[0].VerifiedAt.IsZero(), i)
+		suite.False(fields[1].VerifiedAt.IsZero(), i)
+		suite.True(previouslyVerified.Equal(fields[2].VerifiedAt), i)
+		suite.True(fields[3].VerifiedAt.IsZero(), i)
+		suite.True(fields[4].VerifiedAt.IsZero(), i)
+	}
+
+	// Now recheck everything, the blog
+	// no longer links back, so should
+	// lose its verification, but the
+	// others should keep their time.
+	verifiedAt := dbAccount.FieldsRaw[0].VerifiedAt
+	err = suite.dereferencer.VerifyAccountFields(ctx, dbAccount, true)
+	suite.NoError(err)
+
+	dbAccount, err = suite.db.GetAccountByID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(verifiedAt.Equal(dbAccount.FieldsRaw[0].VerifiedAt))
+	suite.False(dbAccount.FieldsRaw[1].VerifiedAt.IsZero())
+	suite.True(dbAccount.FieldsRaw[2].VerifiedAt.IsZero())
+	suite.True(dbAccount.Fields[2].VerifiedAt.IsZero())
+}
+
+func (suite *FieldsTestSuite) TestVerifyAccountFieldsChangedMeanwhile() {
+	ctx := context.Background()
+
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["local_account_1"]
+
+	suite.setFields(account,
+		&gtsmodel.Field{Name: "website", Value: "https://example.org/zork"},
+	)
+
+	// User changes their field
+	// while we're verifying.
+	stale := new(gtsmodel.Account)
+	*stale = *account
+	suite.setFields(account,
+		&gtsmodel.Field{Name: "website", Value: "https://other.example.org/zork"},
+	)
+
+	err := suite.dereferencer.VerifyAccountFields(ctx, stale, false)
+	suite.NoError(err)
+
+	// New field value should be left
+	// alone, not verified by old value.
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("https://other.example.org/zork", dbAccount.FieldsRaw[0].Value)
+	suite.True(dbAccount.FieldsRaw[0].VerifiedAt.IsZero())
+}
+
+func (suite *FieldsTestSuite) TestVerifyAccountFieldsFetchFailed() {
+	ctx := context.Background()
+
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["local_account_1"]
+
+	previouslyVerified := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	suite.setFields(account,
+		&gtsmodel.Field{Name: "down", Value: "https://down.example.org/zork", VerifiedAt: previouslyVerified},
+		&gtsmodel.Field{Name: "slow", Value: "https://slow.example.org/zork", VerifiedAt: previouslyVerified},
+		&gtsmodel.Field{Name: "gone", Value: "https://gone.example.org/zork", VerifiedAt: previouslyVerified},
+	)
+
+	err := suite.dereferencer.VerifyAccountFields(ctx, account, true)
+	suite.NoError(err)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Server and transport errors should leave
+	// verification alone, but pages that are
+	// gone (404) should lose their verification.
+	suite.True(previouslyVerified.Equal(dbAccount.FieldsRaw[0].VerifiedAt))
+	suite.True(previouslyVerified.Equal(dbAccount.FieldsRaw[1].VerifiedAt))
+	suite.True(dbAccount.FieldsRaw[2].VerifiedAt.IsZero())
+	suite.True(dbAccount.Fields[2].VerifiedAt.IsZero())
+}
+
+func TestFieldsTestSuite(t *testing.T) {
+	suite.Run(t, new(FieldsTestSuite))
+}
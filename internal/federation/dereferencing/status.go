@@ -389,6 +389,11 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetUnretrievable(err)
 	}
 
+	// Statusables delivered to our inbox have
+	// already been run through federation
+	// policies, only check dereferenced ones.
+	checkPolicies := (apubStatus == nil)
+
 	if apubStatus == nil {
 		// Dereference latest version of the status.
 		rsp, err := tsport.Dereference(ctx, uri)
@@ -447,10 +452,26 @@ func (d *Dereferencer) enrichStatus(
 
 	// Ensure we have the author account of the status dereferenced (+ up-to-date). If this is a new status
 	// (i.e. status.AccountID == "") then any error here is irrecoverable. status.AccountID must ALWAYS be set.
-	if _, _, err := d.getAccountByURI(ctx, requestUser, attributedTo); err != nil && status.AccountID == "" {
+	author, _, err := d.getAccountByURI(ctx, requestUser, attributedTo)
+	if err != nil && status.AccountID == "" {
 		return nil, nil, gtserror.Newf("failed to dereference status author %s: %w", uri, err)
 	}
 
+	if checkPolicies && author != nil {
+		// Run the dereferenced statusable through federation
+		// policies, which may rewrite it in place, or reject it.
+		ok, err := d.policy.Statusable(ctx, author, apubStatus)
+		if err != nil {
+			return nil, nil, gtserror.Newf("error running federation policies for status %s: %w", uri, err)
+		}
+
+		if !ok {
+			// Return a checkable error type that can be ignored.
+			err := gtserror.Newf("dropping status rejected by federation policy: %s", uri)
+			return nil, nil, gtserror.SetNotPermitted(err)
+		}
+	}
+
 	// ActivityPub model was recently dereferenced, so assume that passed status
 	// may contain out-of-date information, convert AP model to our GTS model.
 	latestStatus, err := d.converter.ASStatusToStatus(ctx, apubStatus)
@@ -557,9 +578,66 @@ func (d *Dereferencer) enrichStatus(
 		}
 	}
 
+	if latestStatus.QuoteOfURI != "" && latestStatus.QuoteOfID == "" {
+		// Status quotes another we don't have stored
+		// yet; dereference it asynchronously, so that
+		// chains (or loops!) of quotes can't block us.
+		statusID, quoteURIStr := latestStatus.ID, latestStatus.QuoteOfURI
+		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			if err := d.fetchStatusQuote(ctx, requestUser, statusID, quoteURIStr); err != nil {
+				log.Errorf(ctx, "error fetching quote of status %s: %v", uri, err)
+			}
+		})
+	}
+
 	return latestStatus, apubStatus, nil
 }
 
+// fetchStatusQuote dereferences the status at quoteURIStr,
+// and if successful sets it as the quote of the status
+// with the given ID, updating the status in the database.
+func (d *Dereferencer) fetchStatusQuote(
+	ctx context.Context,
+	requestUser string,
+	statusID string,
+	quoteURIStr string,
+) error {
+	quoteURI, err := url.Parse(quoteURIStr)
+	if err != nil {
+		return gtserror.Newf("invalid quote uri %q: %w", quoteURIStr, err)
+	}
+
+	// Search for quoted status by URI. Note this may return an existing
+	// model we have stored with an error from attempted update, so check both.
+	quoteOf, _, _, err := d.getStatusByURI(ctx, requestUser, quoteURI)
+	if err != nil && quoteOf == nil {
+		return gtserror.Newf("error getting quoted status %s: %w", quoteURI, err)
+	}
+
+	// Fetch the latest stored version of
+	// the quoting status, it may have changed.
+	status, err := d.state.DB.GetStatusByID(
+		gtscontext.SetBarebones(ctx),
+		statusID,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting status %s: %w", statusID, err)
+	}
+
+	if status.QuoteOfURI != quoteURIStr {
+		// Quote was changed in the
+		// meantime, nothing to do.
+		return nil
+	}
+
+	status.QuoteOfID = quoteOf.ID
+	if err := d.state.DB.UpdateStatus(ctx, status, "quote_of_id"); err != nil {
+		return gtserror.Newf("error updating status %s: %w", statusID, err)
+	}
+
+	return nil
+}
+
 // isPermittedStatus returns whether the given status
 // is permitted to be stored on this instance, checking
 // whether the author is suspended, and passes visibility
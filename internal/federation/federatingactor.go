@@ -31,23 +31,27 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 // federatingActor wraps the pub.FederatingActor
 // with some custom GoToSocial-specific logic.
 type federatingActor struct {
+	federator       *Federator
 	sideEffectActor pub.DelegateActor
 	wrapped         pub.FederatingActor
 }
 
 // newFederatingActor returns a federatingActor.
-func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock) pub.FederatingActor {
-	sideEffectActor := pub.NewSideEffectActor(c, s2s, nil, db, clock)
+func newFederatingActor(f *Federator, db pub.Database, clock pub.Clock) pub.FederatingActor {
+	sideEffectActor := pub.NewSideEffectActor(f, f, nil, db, clock)
 	sideEffectActor.Serialize = ap.Serialize // hook in our own custom Serialize function
 
 	return &federatingActor{
+		federator:       f,
 		sideEffectActor: sideEffectActor,
 		wrapped:         pub.NewCustomActor(sideEffectActor, false, true, clock),
 	}
@@ -112,18 +116,32 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 	*/
 
 	// Resolve the activity, rejecting badly formatted / transient.
-	activity, ok, errWithCode := ap.ResolveIncomingActivity(r)
+	activity, proof, ok, errWithCode := ap.ResolveIncomingActivity(r)
 	if errWithCode != nil {
 		return false, errWithCode
 	} else if !ok { // transient
 		return false, nil
 	}
 
+	if proof != nil {
+		// The activity carries an object integrity proof. If this
+		// is valid for the activity actor, the activity is authentic
+		// regardless of who delivered it, so treat the actor as the
+		// requester. This allows accepting relayed / forwarded
+		// activities without having to re-fetch them from origin.
+		ctx = f.authenticateProof(ctx, activity, proof)
+	}
+
 	// Set additional context data. Primarily this means
 	// looking at the Activity and seeing which IRIs are
 	// involved in it tangentially.
 	ctx, err = f.sideEffectActor.PostInboxRequestBodyHook(ctx, r, activity)
-	if err != nil {
+	if errors.Is(err, errPolicyRejected) {
+		// A federation policy rejected this activity.
+		// As with blocked other IRIs, return 202 accepted
+		// but don't do any further processing of it.
+		return true, nil
+	} else if err != nil {
 		err := gtserror.Newf("error during post inbox request body hook: %w", err)
 		return false, gtserror.NewErrorInternalError(err)
 	}
@@ -225,6 +243,47 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 	return true, nil
 }
 
+// authenticateProof checks whether the given object integrity
+// proof on an incoming activity was created by the activity's
+// actor, and if so returns a context with the requesting
+// account replaced by the actor. Otherwise, the context
+// is returned unchanged.
+func (f *federatingActor) authenticateProof(
+	ctx context.Context,
+	activity pub.Activity,
+	proof *integrity.Proof,
+) context.Context {
+	requester := gtscontext.RequestingAccount(ctx)
+	receiver := gtscontext.ReceivingAccount(ctx)
+
+	actorIRIs := ap.GetActorIRIs(activity)
+	if len(actorIRIs) != 1 {
+		// Nothing
+		// to check.
+		return ctx
+	}
+
+	actorIRI := actorIRIs[0]
+	if actorIRI.String() == requester.URI {
+		// Delivered by the actor itself, who
+		// was authenticated by http signature.
+		return ctx
+	}
+
+	actor, err := f.federator.AuthenticateIntegrityProof(ctx,
+		receiver.Username,
+		actorIRI,
+		proof,
+	)
+	if err != nil {
+		log.Debugf(ctx, "could not authenticate integrity proof on activity from %s: %v", actorIRI, err)
+		return ctx
+	}
+
+	log.Debugf(ctx, "authenticated activity from %s relayed by %s", actor.URI, requester.URI)
+	return gtscontext.SetRequestingAccount(ctx, actor)
+}
+
 /*
 	Functions below are just lightly wrapped versions
 	of the original go-fed federatingActor functions.
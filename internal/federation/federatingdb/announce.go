@@ -69,6 +69,18 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	if ap.ExtractBoostOfURI(announce) == nil {
+		// Group actors relay other activities of their
+		// members (Likes, Updates, Deletes, etc) wrapped
+		// in an Announce. These aren't boosts, and we
+		// don't handle them (yet), so just ignore them.
+		log.Debugf(ctx,
+			"ignoring Announce from %s with no boostable object",
+			requestingAcct.URI,
+		)
+		return nil
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
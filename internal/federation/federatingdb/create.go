@@ -351,6 +351,15 @@ func (f *federatingDB) createStatusable(
 		)
 		return nil
 
+	case gtserror.IsQuarantined(err):
+		// Status is being held back for admin review,
+		// if they release it it'll be dereferenced then.
+		log.Infof(ctx,
+			"status %s looked like spam (%v); quarantining it",
+			ap.GetJSONLDId(statusable), err,
+		)
+		return nil
+
 	case gtserror.IsSpam(err):
 		// Log this at a higher level so admins can
 		// gauge how much spam is being sent to them.
@@ -460,6 +469,13 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityLike: could not convert type to like")
 	}
 
+	if ap.ExtractReaction(like) != "" {
+		// This Like is actually an emoji
+		// reaction (or an EmojiReact that
+		// was resolved as a Like), handle.
+		return f.activityReaction(ctx, like, receivingAccount, requestingAccount)
+	}
+
 	fave, err := f.converter.ASLikeToFave(ctx, like)
 	if err != nil {
 		return fmt.Errorf("activityLike: could not convert Like to fave: %w", err)
@@ -495,6 +511,50 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 	return nil
 }
 
+func (f *federatingDB) activityReaction(ctx context.Context, reactable ap.Reactable, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
+	reaction, err := f.converter.ASLikeToStatusReaction(ctx, reactable)
+	if err != nil {
+		return fmt.Errorf("activityReaction: could not convert Like to reaction: %w", err)
+	}
+
+	if reaction.AccountID != requestingAccount.ID {
+		return fmt.Errorf(
+			"activityReaction: requestingAccount %s is not Like actor account %s",
+			requestingAccount.URI, reaction.Account.URI,
+		)
+	}
+
+	// The custom emoji (if any) is only
+	// a placeholder at this point, it gets
+	// dereferenced + stored by the worker.
+	emoji := reaction.Emoji
+	reaction.Emoji = nil
+
+	reaction.ID = id.NewULID()
+
+	if err := f.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// The reaction already exists in the database, which
+			// means we've already handled side effects. We can
+			// just return nil here and be done with it.
+			return nil
+		}
+		return fmt.Errorf("activityReaction: database error inserting reaction: %w", err)
+	}
+
+	reaction.Emoji = emoji
+
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Receiving:      receivingAccount,
+		Requesting:     requestingAccount,
+	})
+
+	return nil
+}
+
 /*
 	FLAG HANDLERS
 */
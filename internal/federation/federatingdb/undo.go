@@ -150,6 +150,13 @@ func (f *federatingDB) undoLike(
 		return nil
 	}
 
+	if ap.ExtractReaction(Like) != "" {
+		// This Like is actually an emoji
+		// reaction (or an EmojiReact that
+		// was resolved as a Like), handle.
+		return f.undoReaction(ctx, receivingAccount, requestingAccount, Like)
+	}
+
 	fave, err := f.converter.ASLikeToFave(ctx, Like)
 	if err != nil {
 		return fmt.Errorf("undoLike: error converting ActivityStreams Like to fave: %w", err)
@@ -192,6 +199,57 @@ func (f *federatingDB) undoLike(
 	return nil
 }
 
+func (f *federatingDB) undoReaction(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	requestingAccount *gtsmodel.Account,
+	reactable ap.Reactable,
+) error {
+	reaction, err := f.converter.ASLikeToStatusReaction(ctx, reactable)
+	if err != nil {
+		return fmt.Errorf("undoReaction: error converting ActivityStreams Like to reaction: %w", err)
+	}
+
+	// Ensure addressee is reaction target.
+	if reaction.TargetAccountID != receivingAccount.ID {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAccount.ID {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// As with Likes, select using account, target status
+	// and emoji, rather than the URI of the reaction, as
+	// the Undo means "I don't want this reaction anymore".
+	reaction, err = f.state.DB.GetStatusReaction(
+		gtscontext.SetBarebones(ctx),
+		reaction.AccountID,
+		reaction.StatusID,
+		reaction.Name,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// We didn't have a reaction
+			// for this combo anyway, ignore.
+			return nil
+		}
+		// Real error.
+		return fmt.Errorf("undoReaction: db error getting reaction: %w", err)
+	}
+
+	// Delete the status reaction.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		return fmt.Errorf("undoReaction: db error deleting reaction %s: %w", reaction.ID, err)
+	}
+
+	log.Debug(ctx, "Reaction undone")
+	return nil
+}
+
 func (f *federatingDB) undoBlock(
 	ctx context.Context,
 	receivingAccount *gtsmodel.Account,
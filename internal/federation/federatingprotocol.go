@@ -38,6 +38,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// errPolicyRejected is returned from the post inbox
+// request body hook when a federation policy rejects
+// the incoming activity, which should then be dropped.
+var errPolicyRejected = errors.New("activity rejected by federation policy")
+
 type errOtherIRIBlocked struct {
 	account     string
 	domainBlock bool
@@ -93,6 +98,11 @@ func newErrOtherIRIBlocked(
 // In this case, the DelegateActor implementation must not write a response
 // to the ResponseWriter as is expected that the caller to PostInbox will
 // do so when handling the error.
+//
+// Note that GoToSocial calls this hook only after authentication, so the
+// activity is also run through the federation policy pipeline here, which
+// may rewrite it in place. If a policy rejects the activity, errPolicyRejected
+// is returned, and the activity should be accepted but not processed further.
 func (f *Federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Request, activity pub.Activity) (context.Context, error) {
 	// Extract any other IRIs involved in this activity.
 	otherIRIs := []*url.URL{}
@@ -164,9 +174,23 @@ func (f *Federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Reques
 	// duplicate entries now, so remove them.
 	otherIRIs = util.UniqueURIs(otherIRIs)
 
-	// Finished, set other IRIs on the context
-	// so they can be checked for blocks later.
+	// Set other IRIs on the context so
+	// they can be checked for blocks later.
 	ctx = gtscontext.SetOtherIRIs(ctx, otherIRIs)
+
+	// Run the activity through federation policies
+	// before anything is done with it. Requester is
+	// not set when this hook is called by itself.
+	requester := gtscontext.RequestingAccount(ctx)
+	ok, err := f.policyFilter.Activity(ctx, requester, activity)
+	if err != nil {
+		return nil, gtserror.Newf("error running federation policies: %w", err)
+	}
+
+	if !ok {
+		return ctx, errPolicyRejected
+	}
+
 	return ctx, nil
 }
 
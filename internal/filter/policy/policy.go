@@ -0,0 +1,259 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// actionReject means a policy rejected
+	// an activity, which should be dropped.
+	actionReject = "reject"
+
+	// actionRewrite means a policy modified
+	// an activity's object(s) in place.
+	actionRewrite = "rewrite"
+)
+
+// newAccountActivities are the types of activity
+// rejected by a reject_new_accounts policy.
+var newAccountActivities = []string{
+	ap.ActivityCreate,
+	ap.ActivityAnnounce,
+	ap.ActivityFollow,
+	ap.ActivityLike,
+}
+
+// Filter packages logic for running incoming federated
+// activities through the admin-configured, ordered
+// pipeline of federation policies.
+type Filter struct {
+	state *state.State
+}
+
+// NewFilter returns a new policy Filter
+// that will use the provided state.
+func NewFilter(state *state.State) *Filter {
+	return &Filter{state: state}
+}
+
+// Activity runs the given incoming activity, delivered by requester,
+// through the federation policy pipeline. Statusable objects of Create
+// and Update activities are rewritten in place by any policies that
+// modify them. Returns false if the activity was rejected by a policy,
+// in which case it should be dropped without further processing.
+func (f *Filter) Activity(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	activity pub.Activity,
+) (bool, error) {
+	policies, err := f.policiesFor(ctx, requester)
+	if err != nil {
+		return false, err
+	}
+
+	if len(policies) == 0 {
+		// Nothing
+		// to do.
+		return true, nil
+	}
+
+	var (
+		typeName    = activity.GetTypeName()
+		statusables []ap.Statusable
+	)
+
+	switch typeName {
+	case ap.ActivityCreate, ap.ActivityUpdate:
+		// Only Creates and Updates carry statuses for us to check.
+		statusables, _ = ap.ExtractStatusables(ap.ExtractObjects(activity))
+	}
+
+	return f.run(ctx,
+		policies,
+		requester,
+		ap.GetJSONLDId(activity),
+		typeName,
+		statusables,
+	)
+}
+
+// Statusable runs the given statusable, authored by given account,
+// through the federation policy pipeline, rewriting it in place if
+// necessary. This is for statusables that didn't arrive as part of
+// an activity delivered to our inbox, eg., ones we dereferenced.
+// Returns false if the statusable was rejected by a policy.
+func (f *Filter) Statusable(
+	ctx context.Context,
+	author *gtsmodel.Account,
+	statusable ap.Statusable,
+) (bool, error) {
+	policies, err := f.policiesFor(ctx, author)
+	if err != nil {
+		return false, err
+	}
+
+	if len(policies) == 0 {
+		// Nothing
+		// to do.
+		return true, nil
+	}
+
+	return f.run(ctx,
+		policies,
+		author,
+		ap.GetJSONLDId(statusable),
+		ap.ActivityCreate,
+		[]ap.Statusable{statusable},
+	)
+}
+
+// policiesFor returns the enabled federation
+// policies that apply to the given account.
+func (f *Filter) policiesFor(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*gtsmodel.FederationPolicy, error) {
+	if account == nil || account.IsLocal() {
+		// Policies are only
+		// for remote accounts.
+		return nil, nil
+	}
+
+	all, err := f.state.DB.GetFederationPolicies(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting federation policies: %w", err)
+	}
+
+	policies := make([]*gtsmodel.FederationPolicy, 0, len(all))
+	for _, policy := range all {
+		if !policy.IsDisabled() &&
+			policy.AppliesToDomain(account.Domain) {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies, nil
+}
+
+// run runs the given policies, in order, on an activity of typeName
+// from account, carrying the given statusables (if any). Returns
+// false as soon as any of the policies rejects the activity.
+func (f *Filter) run(
+	ctx context.Context,
+	policies []*gtsmodel.FederationPolicy,
+	account *gtsmodel.Account,
+	activityID *url.URL,
+	typeName string,
+	statusables []ap.Statusable,
+) (bool, error) {
+	for _, policy := range policies {
+		var action string
+
+		switch policy.Type {
+		case gtsmodel.FederationPolicyRejectAnnounces:
+			if typeName == ap.ActivityAnnounce {
+				action = actionReject
+			}
+
+		case gtsmodel.FederationPolicyRejectNewAccounts:
+			if !slices.Contains(newAccountActivities, typeName) {
+				continue
+			}
+
+			isNew, err := f.isNewAccount(ctx, policy, account)
+			if err != nil {
+				return false, err
+			}
+
+			if isNew {
+				action = actionReject
+			}
+
+		default:
+			// Remaining policy types are all
+			// run on the activity's statuses.
+			for _, statusable := range statusables {
+				switch applyStatusable(policy, statusable) {
+				case actionReject:
+					action = actionReject
+				case actionRewrite:
+					if action == "" {
+						action = actionRewrite
+					}
+				}
+			}
+		}
+
+		if action == "" {
+			// Policy didn't
+			// do anything.
+			continue
+		}
+
+		// Report what the policy did,
+		// so admins can gauge its effect.
+		log.WithContext(ctx).
+			WithField("policy", policy.ID).
+			WithField("type", policy.Type).
+			WithField("activity", activityID).
+			WithField("account", account.URI).
+			WithField("action", action).
+			Info("federation policy applied")
+		metrics.FederationPolicyAction(ctx, string(policy.Type), action)
+
+		if action == actionReject {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// isNewAccount returns whether the given account is younger than the
+// policy min account age, and isn't followed by any account on this
+// instance (in which case its activities are probably expected).
+func (f *Filter) isNewAccount(
+	ctx context.Context,
+	policy *gtsmodel.FederationPolicy,
+	account *gtsmodel.Account,
+) (bool, error) {
+	if time.Since(account.CreatedAt) >= policy.MinAccountAge {
+		// Old enough.
+		return false, nil
+	}
+
+	followerIDs, err := f.state.DB.GetAccountLocalFollowerIDs(ctx, account.ID)
+	if err != nil {
+		return false, gtserror.Newf("db error getting local followers: %w", err)
+	}
+
+	return len(followerIDs) == 0, nil
+}
@@ -0,0 +1,312 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// Public note from remote_account_1 with an image
+// attachment and mentions of three different accounts.
+const note = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "sensitive": "as:sensitive"
+    }
+  ],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/01J3C5WQ3ZGCSD4JXDJ6RAG0JG",
+  "type": "Note",
+  "summary": null,
+  "published": "2024-07-21T10:00:00Z",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "http://fossbros-anonymous.io/users/foss_satan/followers"
+  ],
+  "sensitive": false,
+  "content": "<p>Big SPOILERS for the season finale ahead</p>",
+  "attachment": [
+    {
+      "type": "Document",
+      "mediaType": "image/jpeg",
+      "url": "http://fossbros-anonymous.io/attachments/finale.jpg"
+    }
+  ],
+  "tag": [
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/the_mighty_zork",
+      "name": "@the_mighty_zork@localhost:8080"
+    },
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/1happyturtle",
+      "name": "@1happyturtle@localhost:8080"
+    },
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/admin",
+      "name": "@admin@localhost:8080"
+    }
+  ]
+}`
+
+type PolicyTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testAccounts map[string]*gtsmodel.Account
+
+	filter *policy.Filter
+}
+
+func (suite *PolicyTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *PolicyTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.filter = policy.NewFilter(&suite.state)
+
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *PolicyTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	suite.state.Caches.FederationPolicies.Clear()
+}
+
+func (suite *PolicyTestSuite) putPolicy(p *gtsmodel.FederationPolicy) {
+	p.ID = id.NewULID()
+	p.CreatedByAccountID = suite.testAccounts["admin_account"].ID
+	if err := suite.db.PutFederationPolicy(context.Background(), p); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *PolicyTestSuite) statusable() ap.Statusable {
+	rc := io.NopCloser(bytes.NewReader([]byte(note)))
+	statusable, err := ap.ResolveStatusable(context.Background(), rc)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return statusable
+}
+
+func (suite *PolicyTestSuite) TestNoPolicies() {
+	statusable := suite.statusable()
+
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], statusable)
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func (suite *PolicyTestSuite) TestKeywordReject() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:     gtsmodel.FederationPolicyKeywordReject,
+		Keywords: []string{"spoilers"},
+	})
+
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], suite.statusable())
+	suite.NoError(err)
+	suite.False(ok)
+
+	// Local accounts are never subject to policies.
+	ok, err = suite.filter.Statusable(context.Background(), suite.testAccounts["local_account_1"], suite.statusable())
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func (suite *PolicyTestSuite) TestKeywordRejectOtherDomain() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:     gtsmodel.FederationPolicyKeywordReject,
+		Domains:  []string{"example.org"},
+		Keywords: []string{"spoilers"},
+	})
+
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], suite.statusable())
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func (suite *PolicyTestSuite) TestKeywordRejectDisabled() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:     gtsmodel.FederationPolicyKeywordReject,
+		Keywords: []string{"spoilers"},
+		Disabled: func() *bool { b := true; return &b }(),
+	})
+
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], suite.statusable())
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func (suite *PolicyTestSuite) TestKeywordCW() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:           gtsmodel.FederationPolicyKeywordCW,
+		Keywords:       []string{"season finale"},
+		ContentWarning: "TV spoilers",
+	})
+
+	statusable := suite.statusable()
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], statusable)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Equal("TV spoilers", ap.ExtractSummary(statusable))
+	suite.True(ap.ExtractSensitive(statusable))
+}
+
+func (suite *PolicyTestSuite) TestStripMedia() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:    gtsmodel.FederationPolicyStripMedia,
+		Domains: []string{"fossbros-anonymous.io"},
+	})
+
+	statusable := suite.statusable()
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], statusable)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Zero(statusable.GetActivityStreamsAttachment().Len())
+}
+
+func (suite *PolicyTestSuite) TestForceUnlisted() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:    gtsmodel.FederationPolicyForceUnlisted,
+		Domains: []string{"fossbros-anonymous.io"},
+	})
+
+	statusable := suite.statusable()
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], statusable)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.False(containsPublic(ap.GetTo(statusable)))
+	suite.True(containsPublic(ap.GetCc(statusable)))
+}
+
+func (suite *PolicyTestSuite) TestMaxMentions() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:        gtsmodel.FederationPolicyMaxMentions,
+		MaxMentions: 3,
+	})
+
+	// Exactly 3 mentions is fine.
+	ok, err := suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], suite.statusable())
+	suite.NoError(err)
+	suite.True(ok)
+
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:        gtsmodel.FederationPolicyMaxMentions,
+		MaxMentions: 2,
+	})
+
+	ok, err = suite.filter.Statusable(context.Background(), suite.testAccounts["remote_account_1"], suite.statusable())
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *PolicyTestSuite) TestRejectAnnounces() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type:    gtsmodel.FederationPolicyRejectAnnounces,
+		Domains: []string{"fossbros-anonymous.io"},
+	})
+
+	announce := streams.NewActivityStreamsAnnounce()
+	ap.SetJSONLDId(announce, testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01J3C5WQ3ZGCSD4JXDJ6RAG0JG/activity"))
+
+	ok, err := suite.filter.Activity(context.Background(), suite.testAccounts["remote_account_1"], announce)
+	suite.NoError(err)
+	suite.False(ok)
+
+	// Follows aren't affected.
+	follow := streams.NewActivityStreamsFollow()
+	ap.SetJSONLDId(follow, testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/follows/01J3C5WQ3ZGCSD4JXDJ6RAG0JG"))
+
+	ok, err = suite.filter.Activity(context.Background(), suite.testAccounts["remote_account_1"], follow)
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func (suite *PolicyTestSuite) TestRejectNewAccounts() {
+	suite.putPolicy(&gtsmodel.FederationPolicy{
+		Type: gtsmodel.FederationPolicyRejectNewAccounts,
+
+		// Make every remote test account "new".
+		MinAccountAge: 100 * 365 * 24 * time.Hour,
+	})
+
+	follow := streams.NewActivityStreamsFollow()
+	ap.SetJSONLDId(follow, testrig.URLMustParse("http://example.org/users/Some_User/follows/01J3C5WQ3ZGCSD4JXDJ6RAG0JG"))
+
+	// remote_account_2 has no local followers.
+	ok, err := suite.filter.Activity(context.Background(), suite.testAccounts["remote_account_2"], follow)
+	suite.NoError(err)
+	suite.False(ok)
+
+	// Once a local account follows
+	// remote_account_2 it's let through.
+	if err := suite.db.PutFollow(context.Background(), &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/the_mighty_zork/follow/01J3C7E5T6Y4XM2N6NDHBC5Q5B",
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: suite.testAccounts["remote_account_2"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ok, err = suite.filter.Activity(context.Background(), suite.testAccounts["remote_account_2"], follow)
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func containsPublic(iris []*url.URL) bool {
+	for _, iri := range iris {
+		if pub.IsPublic(iri.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
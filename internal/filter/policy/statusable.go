@@ -0,0 +1,180 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// defaultContentWarning is used by keyword_cw
+// policies that don't set their own content warning.
+const defaultContentWarning = "Filtered content"
+
+// applyStatusable applies the given policy to the given
+// statusable, returning the action the policy took, if any.
+func applyStatusable(policy *gtsmodel.FederationPolicy, statusable ap.Statusable) string {
+	switch policy.Type {
+	case gtsmodel.FederationPolicyKeywordReject:
+		if matchesKeywords(statusable, policy.Keywords) {
+			return actionReject
+		}
+
+	case gtsmodel.FederationPolicyKeywordCW:
+		if matchesKeywords(statusable, policy.Keywords) &&
+			setContentWarning(statusable, policy.ContentWarning) {
+			return actionRewrite
+		}
+
+	case gtsmodel.FederationPolicyStripMedia:
+		if stripAttachments(statusable) {
+			return actionRewrite
+		}
+
+	case gtsmodel.FederationPolicyForceUnlisted:
+		if unlist(statusable) {
+			return actionRewrite
+		}
+
+	case gtsmodel.FederationPolicyMaxMentions:
+		if countMentions(statusable) > policy.MaxMentions {
+			return actionReject
+		}
+	}
+
+	return ""
+}
+
+// matchesKeywords returns whether the text of the given
+// statusable contains any of keywords, case-insensitively.
+func matchesKeywords(statusable ap.Statusable, keywords []string) bool {
+	content := ap.ExtractContent(statusable)
+
+	// Gather all the status text we
+	// have, including any translations.
+	parts := []string{
+		ap.ExtractName(statusable),
+		ap.ExtractSummary(statusable),
+		content.Content,
+	}
+	for _, c := range content.ContentMap {
+		parts = append(parts, c)
+	}
+
+	txt := strings.ToLower(text.SanitizeToPlaintext(strings.Join(parts, "\n")))
+
+	for _, keyword := range keywords {
+		if strings.Contains(txt, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setContentWarning marks the given statusable as sensitive, setting the
+// given content warning on it if it doesn't have one already. Returns
+// false if the statusable already had a content warning and was sensitive.
+func setContentWarning(statusable ap.Statusable, cw string) bool {
+	var changed bool
+
+	if ap.ExtractSummary(statusable) == "" {
+		if cw == "" {
+			cw = defaultContentWarning
+		}
+
+		summaryProp := streams.NewActivityStreamsSummaryProperty()
+		summaryProp.AppendXMLSchemaString(cw)
+		statusable.SetActivityStreamsSummary(summaryProp)
+		changed = true
+	}
+
+	if !ap.ExtractSensitive(statusable) {
+		sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
+		sensitiveProp.AppendXMLSchemaBoolean(true)
+		statusable.SetActivityStreamsSensitive(sensitiveProp)
+		changed = true
+	}
+
+	return changed
+}
+
+// stripAttachments removes all attachments from the given
+// statusable, returning false if it had none to begin with.
+func stripAttachments(statusable ap.Statusable) bool {
+	attachmentProp := statusable.GetActivityStreamsAttachment()
+	if attachmentProp == nil || attachmentProp.Len() == 0 {
+		return false
+	}
+
+	statusable.SetActivityStreamsAttachment(
+		streams.NewActivityStreamsAttachmentProperty(),
+	)
+	return true
+}
+
+// unlist moves the public collection from the To to the Cc of
+// the given statusable, so that it becomes unlisted rather than
+// public. Returns false if it wasn't addressed To public.
+func unlist(statusable ap.Statusable) bool {
+	var (
+		to     []*url.URL
+		public []*url.URL
+	)
+
+	for _, iri := range ap.GetTo(statusable) {
+		if pub.IsPublic(iri.String()) {
+			public = append(public, iri)
+		} else {
+			to = append(to, iri)
+		}
+	}
+
+	if len(public) == 0 {
+		return false
+	}
+
+	statusable.SetActivityStreamsTo(streams.NewActivityStreamsToProperty())
+	ap.AppendTo(statusable, to...)
+	ap.AppendCc(statusable, public...)
+	return true
+}
+
+// countMentions returns the number of
+// mention tags on the given statusable.
+func countMentions(statusable ap.Statusable) int {
+	tagsProp := statusable.GetActivityStreamsTag()
+	if tagsProp == nil {
+		return 0
+	}
+
+	var count int
+	for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+		if iter.IsActivityStreamsMention() {
+			count++
+		}
+	}
+
+	return count
+}
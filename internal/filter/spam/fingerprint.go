@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// Normalized text shorter than this is too
+	// generic to fingerprint (eg., "hi", "thanks!").
+	fingerprintMinLength = 20
+
+	// How long to remember which accounts have
+	// sent content with a given fingerprint.
+	fingerprintWindow = 24 * time.Hour
+
+	// Maximum number of fingerprints to remember.
+	fingerprintMax = 10000
+)
+
+// fingerprint returns a fingerprint of the text of the
+// given statusable, normalized so that the same message
+// sent to different people by different accounts gives
+// the same fingerprint. Returns "" for very short text.
+func fingerprint(statusable ap.Statusable) string {
+	content := ap.ExtractContent(statusable)
+	txt := text.SanitizeToPlaintext(
+		ap.ExtractSummary(statusable) + "\n" + content.Content,
+	)
+
+	// Drop links, and the mentions that
+	// spammers vary between messages.
+	txt = regexes.LinkScheme.ReplaceAllString(txt, "")
+	fields := strings.Fields(strings.ToLower(txt))
+	words := fields[:0]
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "@") {
+			words = append(words, field)
+		}
+	}
+
+	normalized := strings.Join(words, " ")
+	if utf8.RuneCountInString(normalized) < fingerprintMinLength {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// recentFingerprints tracks which accounts have recently
+// sent content with which fingerprints, so that the same
+// content sent by many accounts can be spotted.
+type recentFingerprints struct {
+	mu   sync.Mutex
+	seen map[string]map[string]time.Time
+}
+
+// add records that the account with given ID sent content
+// with the given fingerprint, returning how many *other*
+// accounts sent the same within the fingerprint window.
+func (r *recentFingerprints) add(fingerprint string, accountID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.seen == nil {
+		r.seen = make(map[string]map[string]time.Time)
+	}
+
+	accounts, ok := r.seen[fingerprint]
+	if !ok {
+		if len(r.seen) >= fingerprintMax {
+			r.prune(now)
+		}
+
+		accounts = make(map[string]time.Time)
+		r.seen[fingerprint] = accounts
+	}
+
+	var others int
+	for id, last := range accounts {
+		switch {
+		case now.Sub(last) > fingerprintWindow:
+			delete(accounts, id)
+		case id != accountID:
+			others++
+		}
+	}
+
+	accounts[accountID] = now
+	return others
+}
+
+// prune drops expired entries, and then arbitrary
+// ones if still at capacity. Must be called with lock.
+func (r *recentFingerprints) prune(now time.Time) {
+	for fingerprint, accounts := range r.seen {
+		for id, last := range accounts {
+			if now.Sub(last) > fingerprintWindow {
+				delete(accounts, id)
+			}
+		}
+
+		if len(accounts) == 0 {
+			delete(r.seen, fingerprint)
+		}
+	}
+
+	for fingerprint := range r.seen {
+		if len(r.seen) < fingerprintMax {
+			break
+		}
+		delete(r.seen, fingerprint)
+	}
+}
@@ -0,0 +1,243 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spam
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// How much an admin decision on a quarantined
+	// status shifts the score of its fingerprint
+	// signal. High enough that released content
+	// isn't quarantined again, and content that
+	// was deleted is quarantined straight away.
+	learnFingerprintScore = 50
+
+	// How much an admin decision on a quarantined
+	// status shifts the scores of its link domains.
+	learnLinkDomainScore = 20
+
+	// Comment set on learned signals.
+	learnComment = "learned from quarantined statuses"
+)
+
+// quarantine stores the given check's statusable as
+// a quarantined status, for review by admins. If the
+// status is already quarantined, this does nothing.
+func (f *Filter) quarantine(
+	ctx context.Context,
+	c *statusableCheck,
+	score int,
+	rules []string,
+) error {
+	uri := ap.GetJSONLDId(c.statusable)
+	if uri == nil {
+		return gtserror.New("status has no id")
+	}
+
+	_, err := f.state.DB.GetQuarantinedStatusByURI(ctx, uri.String())
+	if err == nil {
+		// Already quarantined, probably
+		// delivered to another receiver.
+		return nil
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting quarantined status: %w", err)
+	}
+
+	status := &gtsmodel.QuarantinedStatus{
+		ID:                 id.NewULID(),
+		URI:                uri.String(),
+		AccountID:          c.requester.ID,
+		Account:            c.requester,
+		ReceivingAccountID: c.receiver.ID,
+		ReceivingAccount:   c.receiver,
+		ContentWarning:     text.SanitizeToPlaintext(ap.ExtractSummary(c.statusable)),
+		Content:            text.SanitizeToHTML(ap.ExtractContent(c.statusable).Content),
+		Score:              score,
+		Rules:              rules,
+		Fingerprint:        c.fingerprint,
+	}
+
+	if urls := ap.GetURL(c.statusable); len(urls) != 0 {
+		status.URL = urls[0].String()
+	}
+
+	for _, link := range c.links {
+		domain := link.Hostname()
+		if !slices.Contains(status.LinkDomains, domain) {
+			status.LinkDomains = append(status.LinkDomains, domain)
+		}
+	}
+
+	if err := f.state.DB.PutQuarantinedStatus(ctx, status); err != nil {
+		return gtserror.Newf("db error putting quarantined status: %w", err)
+	}
+
+	return nil
+}
+
+// Learn updates spam signals from an admin decision on the
+// given quarantined status. If isSpam, its fingerprint and
+// link domains will add more to the score of future statuses.
+// If not, its fingerprint will subtract from future scores,
+// and its link domains will add less to them.
+func (f *Filter) Learn(
+	ctx context.Context,
+	status *gtsmodel.QuarantinedStatus,
+	isSpam bool,
+) error {
+	fingerprintScore := learnFingerprintScore
+	linkDomainScore := learnLinkDomainScore
+	if !isSpam {
+		fingerprintScore = -fingerprintScore
+		linkDomainScore = -linkDomainScore
+	}
+
+	if status.Fingerprint != "" {
+		if err := f.adjustSignal(ctx,
+			gtsmodel.SpamSignalFingerprint,
+			status.Fingerprint,
+			fingerprintScore,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, domain := range status.LinkDomains {
+		if !isSpam {
+			// Don't learn new link domains
+			// from statuses that weren't spam,
+			// just reduce existing signals.
+			if err := f.reduceLinkDomainSignals(ctx,
+				domain,
+				linkDomainScore,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := f.adjustSignal(ctx,
+			gtsmodel.SpamSignalLinkDomain,
+			domain,
+			linkDomainScore,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// adjustSignal adds the given score to the spam
+// signal with given type and value, creating it
+// if necessary. Signals adjusted down to zero
+// are deleted, as they no longer do anything.
+func (f *Filter) adjustSignal(
+	ctx context.Context,
+	signalType gtsmodel.SpamSignalType,
+	value string,
+	score int,
+) error {
+	signal, err := f.state.DB.GetSpamSignal(ctx, signalType, value)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting spam signal: %w", err)
+	}
+
+	if signal == nil {
+		// New signal.
+		signal = &gtsmodel.SpamSignal{
+			ID:      id.NewULID(),
+			Type:    signalType,
+			Value:   value,
+			Score:   score,
+			Comment: learnComment,
+		}
+
+		if err := f.state.DB.PutSpamSignal(ctx, signal); err != nil {
+			return gtserror.Newf("db error putting spam signal: %w", err)
+		}
+
+		return nil
+	}
+
+	return f.updateSignalScore(ctx, signal, signal.Score+score)
+}
+
+// reduceLinkDomainSignals adds the given (negative) score to
+// every link domain spam signal matching the given domain.
+func (f *Filter) reduceLinkDomainSignals(
+	ctx context.Context,
+	domain string,
+	score int,
+) error {
+	signals, err := f.state.DB.GetSpamSignals(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting spam signals: %w", err)
+	}
+
+	for _, signal := range signals {
+		if !signal.MatchesDomain(domain) || signal.Score <= 0 {
+			continue
+		}
+
+		// Cached signals must not be
+		// modified, so take a copy.
+		signal := *signal
+		if err := f.updateSignalScore(ctx,
+			&signal,
+			max(signal.Score+score, 0),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateSignalScore sets the given score on the
+// signal, deleting the signal if the score is 0.
+func (f *Filter) updateSignalScore(
+	ctx context.Context,
+	signal *gtsmodel.SpamSignal,
+	score int,
+) error {
+	if score == 0 {
+		if err := f.state.DB.DeleteSpamSignalByID(ctx, signal.ID); err != nil {
+			return gtserror.Newf("db error deleting spam signal: %w", err)
+		}
+		return nil
+	}
+
+	signal.Score = score
+	if err := f.state.DB.UpdateSpamSignal(ctx, signal, "score"); err != nil {
+		return gtserror.Newf("db error updating spam signal: %w", err)
+	}
+
+	return nil
+}
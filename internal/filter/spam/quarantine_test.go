@@ -0,0 +1,175 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spam_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type QuarantineTestSuite struct {
+	FilterStandardTestSuite
+}
+
+// Note that mentions only the receiver, with the given
+// id, and with the given text and html in its content.
+const quarantineNote = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/%s",
+  "type": "Note",
+  "published": "2024-02-24T07:06:14Z",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "content": "<p><span class=\"h-card\"><a href=\"http://localhost:8080/@the_mighty_zork\" class=\"u-url mention\">@<span>the_mighty_zork</span></a></span> %s</p>",
+  "tag": [
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/the_mighty_zork",
+      "name": "@the_mighty_zork@localhost:8080"
+    }
+  ]
+}`
+
+const (
+	spamText = `get more followers today, best prices guaranteed`
+	spamLink = `<a href=\"https://spammylink.org/\">https://spammylink.org/</a>`
+)
+
+func (suite *QuarantineTestSuite) statusOK(id string, requester string, content string) error {
+	ctx := context.Background()
+	rc := io.NopCloser(bytes.NewReader([]byte(fmt.Sprintf(quarantineNote, id, content))))
+
+	statusable, err := ap.ResolveStatusable(ctx, rc)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return suite.filter.StatusableOK(ctx,
+		suite.testAccounts["local_account_1"],
+		suite.testAccounts[requester],
+		statusable,
+	)
+}
+
+func (suite *QuarantineTestSuite) quarantined(id string) *gtsmodel.QuarantinedStatus {
+	status, err := suite.state.DB.GetQuarantinedStatusByURI(
+		context.Background(),
+		"http://fossbros-anonymous.io/users/foss_satan/statuses/"+id,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return status
+}
+
+func (suite *QuarantineTestSuite) TestQuarantineLearnSpam() {
+	ctx := context.Background()
+
+	// Mentions strangers + errant link.
+	err := suite.statusOK("1", "remote_account_1", spamText+" "+spamLink)
+	suite.True(gtserror.IsQuarantined(err), "expected Quarantined, got %+v", err)
+
+	status := suite.quarantined("1")
+	suite.Equal(50, status.Score)
+	suite.Equal([]string{"mentions_strangers", "errant_links"}, status.Rules)
+	suite.Equal([]string{"spammylink.org"}, status.LinkDomains)
+	suite.NotEmpty(status.Fingerprint)
+
+	// Admin deletes it as spam.
+	if err := suite.filter.Learn(ctx, status, true); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	signal, err := suite.state.DB.GetSpamSignal(ctx, gtsmodel.SpamSignalLinkDomain, "spammylink.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(20, signal.Score)
+
+	signal, err = suite.state.DB.GetSpamSignal(ctx, gtsmodel.SpamSignalFingerprint, status.Fingerprint)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(50, signal.Score)
+
+	// The same spam from someone else is
+	// now scored high enough to be dropped.
+	err = suite.statusOK("2", "remote_account_2", spamText+" "+spamLink)
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+	suite.False(gtserror.IsQuarantined(err), "expected not Quarantined, got %+v", err)
+}
+
+func (suite *QuarantineTestSuite) TestQuarantineLearnNotSpam() {
+	ctx := context.Background()
+
+	err := suite.statusOK("1", "remote_account_1", spamText+" "+spamLink)
+	suite.True(gtserror.IsQuarantined(err), "expected Quarantined, got %+v", err)
+
+	// Admin releases it.
+	status := suite.quarantined("1")
+	if err := suite.filter.Learn(ctx, status, false); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	signal, err := suite.state.DB.GetSpamSignal(ctx, gtsmodel.SpamSignalFingerprint, status.Fingerprint)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(-50, signal.Score)
+
+	// Link domains aren't learned from released statuses.
+	_, err = suite.state.DB.GetSpamSignal(ctx, gtsmodel.SpamSignalLinkDomain, "spammylink.org")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// The same message is now let through.
+	err = suite.statusOK("2", "remote_account_2", spamText+" "+spamLink)
+	suite.NoError(err)
+}
+
+func (suite *QuarantineTestSuite) TestQuarantineRepeatedContent() {
+	// Mentions strangers only, OK
+	// until a third account sends it.
+	err := suite.statusOK("1", "remote_account_1", spamText)
+	suite.NoError(err)
+
+	err = suite.statusOK("2", "remote_account_2", spamText)
+	suite.NoError(err)
+
+	err = suite.statusOK("3", "remote_account_3", spamText)
+	suite.True(gtserror.IsQuarantined(err), "expected Quarantined, got %+v", err)
+
+	status := suite.quarantined("3")
+	suite.Equal(70, status.Score)
+	suite.Equal([]string{"mentions_strangers", "repeated_content"}, status.Rules)
+	suite.Empty(status.LinkDomains)
+}
+
+func TestQuarantineTestSuite(t *testing.T) {
+	suite.Run(t, &QuarantineTestSuite{})
+}
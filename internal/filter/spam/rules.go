@@ -0,0 +1,188 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spam
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// Names of spam rules, as recorded
+	// on quarantined statuses for review.
+	ruleMentionFanOut    = "mention_fanout"
+	ruleMentionsStranger = "mentions_strangers"
+	ruleAttachments      = "attachments"
+	ruleErrantLinks      = "errant_links"
+	ruleLinkDomains      = "link_domains"
+	ruleRepeatedContent  = "repeated_content"
+	ruleNewAccount       = "new_account"
+
+	// Five or more mentions is how most
+	// spam waves fan out to their targets.
+	mentionFanOutCount = 5
+
+	// Accounts younger than this count as new.
+	newAccountAge = 7 * 24 * time.Hour
+
+	// Content sent by this many other accounts
+	// within the fingerprint window is repeated.
+	repeatedContentAccounts = 2
+)
+
+// statusableCheck contains a statusable
+// being spam checked, prepared for rules.
+type statusableCheck struct {
+	receiver    *gtsmodel.Account
+	requester   *gtsmodel.Account
+	statusable  ap.Statusable
+	mentions    []preppedMention
+	links       []*url.URL
+	fingerprint string
+	signals     []*gtsmodel.SpamSignal
+}
+
+// rule is one spam filter rule, which
+// returns the score to add for a status.
+type rule struct {
+	name  string
+	score func(ctx context.Context, f *Filter, c *statusableCheck) int
+}
+
+// rules are the spam filter rules that every
+// status reaching spam scoring is run through.
+var rules = []rule{
+	{
+		// Mentioning lots of people at
+		// once is rejected by default.
+		name: ruleMentionFanOut,
+		score: func(_ context.Context, _ *Filter, c *statusableCheck) int {
+			if len(c.mentions) >= mentionFanOutCount {
+				return 100
+			}
+			return 0
+		},
+	},
+	{
+		// Receiver doesn't follow (or request to
+		// follow) anyone else that is mentioned.
+		name: ruleMentionsStranger,
+		score: func(ctx context.Context, f *Filter, c *statusableCheck) int {
+			if !f.knowsOneMentioned(ctx, c.receiver, c.mentions) {
+				return 30
+			}
+			return 0
+		},
+	},
+	{
+		name: ruleAttachments,
+		score: func(_ context.Context, _ *Filter, c *statusableCheck) int {
+			if attachments, _ := ap.ExtractAttachments(c.statusable); len(attachments) != 0 {
+				return 20
+			}
+			return 0
+		},
+	},
+	{
+		// Links aside from mentions and hashtags.
+		name: ruleErrantLinks,
+		score: func(_ context.Context, _ *Filter, c *statusableCheck) int {
+			if len(c.links) != 0 {
+				return 20
+			}
+			return 0
+		},
+	},
+	{
+		// Each link domain signal matched by a
+		// link adds its score, once per signal.
+		name: ruleLinkDomains,
+		score: func(_ context.Context, _ *Filter, c *statusableCheck) int {
+			var score int
+			for _, signal := range c.signals {
+				if slices.ContainsFunc(c.links, func(link *url.URL) bool {
+					return signal.MatchesDomain(link.Hostname())
+				}) {
+					score += signal.Score
+				}
+			}
+			return score
+		},
+	},
+	{
+		// The same content sent by several accounts,
+		// plus the score of a matching fingerprint signal.
+		name: ruleRepeatedContent,
+		score: func(_ context.Context, f *Filter, c *statusableCheck) int {
+			if c.fingerprint == "" {
+				return 0
+			}
+
+			var score int
+			if f.recent.add(c.fingerprint, c.requester.ID) >= repeatedContentAccounts {
+				score += 40
+			}
+
+			for _, signal := range c.signals {
+				if signal.Type == gtsmodel.SpamSignalFingerprint &&
+					signal.Value == c.fingerprint {
+					score += signal.Score
+				}
+			}
+
+			return score
+		},
+	},
+	{
+		name: ruleNewAccount,
+		score: func(_ context.Context, _ *Filter, c *statusableCheck) int {
+			if time.Since(c.requester.CreatedAt) < newAccountAge {
+				return 20
+			}
+			return 0
+		},
+	},
+}
+
+// score runs the given check through all spam rules, returning
+// the total score, and the names of rules that added to it.
+func (f *Filter) score(ctx context.Context, c *statusableCheck) (int, []string) {
+	var (
+		total   int
+		matched []string
+	)
+
+	for _, rule := range rules {
+		score := rule.score(ctx, f, c)
+		if score == 0 {
+			continue
+		}
+
+		log.Tracef(ctx, "spam rule %s scored %d", rule.name, score)
+		total += score
+		matched = append(matched, rule.name)
+	}
+
+	return total, matched
+}
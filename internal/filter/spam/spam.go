@@ -22,11 +22,15 @@ import "github.com/superseriousbusiness/gotosocial/internal/state"
 // Filter packages logic for checking whether
 // given statuses should be considered spam.
 type Filter struct {
-	state *state.State
+	state  *state.State
+	recent *recentFingerprints
 }
 
 // NewFilter returns a new spam Filter
 // that will use the provided state.
 func NewFilter(state *state.State) *Filter {
-	return &Filter{state: state}
+	return &Filter{
+		state:  state,
+		recent: new(recentFingerprints),
+	}
 }
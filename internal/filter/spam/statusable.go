@@ -20,6 +20,7 @@ package spam
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"slices"
 	"strings"
@@ -54,6 +55,8 @@ type preppedMention struct {
 // If the statusable does not pass relevancy or spam checks, either
 // a Spam or NotRelevant error will be returned. Callers should use
 // gtserror.IsSpam() and gtserror.IsNotRelevant() to check for this.
+// If a Spam statusable was held in quarantine for admin review rather
+// than being rejected, gtserror.IsQuarantined() will also be true.
 //
 // If the returned error is not nil, but neither Spam or NotRelevant,
 // then it's an actual database error.
@@ -67,10 +70,11 @@ type preppedMention struct {
 // Otherwise check:
 //
 //  3. Receiver is locked and is followed by requester. Return nil.
-//  4. Five or more people are mentioned. Return Spam.
-//  5. Receiver follow (requests) a mentioned account. Return nil.
-//  6. Statusable has a media attachment. Return Spam.
-//  7. Statusable contains non-mention, non-hashtag links. Return Spam.
+//
+// Otherwise the statusable is scored by each spam rule in turn
+// (see rules.go). If the total score reaches the configured reject
+// score, Spam is returned. Otherwise, if it reaches the configured
+// quarantine score, the statusable is quarantined for admin review.
 func (f *Filter) StatusableOK(
 	ctx context.Context,
 	receiver *gtsmodel.Account,
@@ -125,38 +129,43 @@ func (f *Filter) StatusableOK(
 		return nil
 	}
 
-	// HEURISTIC 4: How many people are mentioned?
-	// If it's 5 or more we can assume this is spam.
-	mentionsLen := len(mentions)
-	if mentionsLen >= 5 {
-		err := errors.New("status mentions 5 or more people")
-		return gtserror.SetSpam(err)
+	// Gather everything the spam
+	// rules need to score this.
+	hashtags, _ := ap.ExtractHashtags(statusable)
+	signals, err := f.state.DB.GetSpamSignals(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting spam signals: %w", err)
 	}
 
-	// HEURISTIC 5: Four or fewer people are mentioned,
-	// do we follow (request) at least one of them?
-	// If so, we're probably interested in the message.
-	knowsOne := f.knowsOneMentioned(ctx, receiver, mentions)
-	if knowsOne {
-		return nil
+	check := &statusableCheck{
+		receiver:    receiver,
+		requester:   requester,
+		statusable:  statusable,
+		mentions:    mentions,
+		links:       f.errantLinks(ctx, statusable, mentions, hashtags),
+		fingerprint: fingerprint(statusable),
+		signals:     signals,
 	}
 
-	// HEURISTIC 6: Are there any media attachments?
-	attachments, _ := ap.ExtractAttachments(statusable)
-	hasAttachments := len(attachments) != 0
-	if hasAttachments {
-		err := errors.New("status has attachment(s)")
-		return gtserror.SetSpam(err)
-	}
+	score, matched := f.score(ctx, check)
 
-	// HEURISTIC 7: Are there any links in the post
-	// aside from mentions and hashtags? Include the
-	// summary/content warning when checking.
-	hashtags, _ := ap.ExtractHashtags(statusable)
-	hasErrantLinks := f.errantLinks(ctx, statusable, mentions, hashtags)
-	if hasErrantLinks {
-		err := errors.New("status has one or more non-mention, non-hashtag links")
+	var (
+		rejectScore     = config.GetInstanceFederationSpamRejectScore()
+		quarantineScore = config.GetInstanceFederationSpamQuarantineScore()
+	)
+
+	switch {
+	case rejectScore > 0 && score >= rejectScore:
+		err := fmt.Errorf("status scored %d (%s)", score, strings.Join(matched, ", "))
 		return gtserror.SetSpam(err)
+
+	case quarantineScore > 0 && score >= quarantineScore:
+		if err := f.quarantine(ctx, check, score, matched); err != nil {
+			return gtserror.Newf("error quarantining status: %w", err)
+		}
+
+		err := fmt.Errorf("status scored %d (%s)", score, strings.Join(matched, ", "))
+		return gtserror.SetQuarantined(gtserror.SetSpam(err))
 	}
 
 	// Looks OK.
@@ -352,15 +361,16 @@ func (f *Filter) knowsOneMentioned(
 	)
 }
 
-// errantLinks returns true if any http/https
-// link discovered in the statusable content + cw
-// is not either a mention link, or a hashtag link.
+// errantLinks returns any http/https links
+// discovered in the statusable content + cw
+// that are not either a mention link, or a
+// hashtag link.
 func (f *Filter) errantLinks(
 	ctx context.Context,
 	statusable ap.Statusable,
 	mentions []preppedMention,
 	hashtags []*gtsmodel.Tag,
-) bool {
+) []*url.URL {
 	// Concatenate the cw with the
 	// content to check for links in both.
 	cw := ap.ExtractSummary(statusable)
@@ -398,6 +408,7 @@ func (f *Filter) errantLinks(
 	// For each link in the status, try to
 	// match it to a hashtag or a mention.
 	// If we can't, we have an errant link.
+	var errant []*url.URL
 	for _, link := range links {
 		hashtagLink := slices.ContainsFunc(
 			hashtags,
@@ -463,10 +474,8 @@ func (f *Filter) errantLinks(
 		// Not a hashtag link
 		// or a mention link,
 		// so it's errant.
-		return true
+		errant = append(errant, link.URL)
 	}
 
-	// All links OK, or
-	// no links found.
-	return false
+	return errant
 }
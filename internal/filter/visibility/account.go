@@ -50,6 +50,7 @@ func (f *Filter) AccountVisible(ctx context.Context, requester *gtsmodel.Account
 		return &cache.CachedVisibility{
 			ItemID:      account.ID,
 			RequesterID: requesterID,
+			AccountID:   account.ID,
 			Type:        vtype,
 			Value:       visible,
 		}, nil
@@ -51,10 +51,12 @@ func (f *Filter) StatusHomeTimelineable(ctx context.Context, owner *gtsmodel.Acc
 
 		// Return visibility value.
 		return &cache.CachedVisibility{
-			ItemID:      status.ID,
-			RequesterID: requesterID,
-			Type:        vtype,
-			Value:       visible,
+			ItemID:           status.ID,
+			RequesterID:      requesterID,
+			AccountID:        status.AccountID,
+			BoostOfAccountID: status.BoostOfAccountID,
+			Type:             vtype,
+			Value:            visible,
 		}, nil
 	}, vtype, requesterID, status.ID)
 	if err != nil {
@@ -49,10 +49,12 @@ func (f *Filter) StatusPublicTimelineable(ctx context.Context, requester *gtsmod
 
 		// Return visibility value.
 		return &cache.CachedVisibility{
-			ItemID:      status.ID,
-			RequesterID: requesterID,
-			Type:        vtype,
-			Value:       visible,
+			ItemID:           status.ID,
+			RequesterID:      requesterID,
+			AccountID:        status.AccountID,
+			BoostOfAccountID: status.BoostOfAccountID,
+			Type:             vtype,
+			Value:            visible,
 		}, nil
 	}, vtype, requesterID, status.ID)
 	if err != nil {
@@ -91,6 +93,13 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	if status.Account.IsSilenced() {
+		// Silenced accounts are hidden from the public
+		// timeline, even for followers of the account.
+		log.Trace(ctx, "status author is silenced")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
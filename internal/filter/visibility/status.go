@@ -62,10 +62,12 @@ func (f *Filter) StatusVisible(ctx context.Context, requester *gtsmodel.Account,
 
 		// Return visibility value.
 		return &cache.CachedVisibility{
-			ItemID:      status.ID,
-			RequesterID: requesterID,
-			Type:        vtype,
-			Value:       visible,
+			ItemID:           status.ID,
+			RequesterID:      requesterID,
+			AccountID:        status.AccountID,
+			BoostOfAccountID: status.BoostOfAccountID,
+			Type:             vtype,
+			Value:            visible,
 		}, nil
 	}, vtype, requesterID, status.ID)
 	if err != nil {
@@ -90,6 +92,14 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	// Check whether status accounts are silenced.
+	visible, err = f.areStatusAccountsUnsilenced(ctx, requester, status)
+	if err != nil {
+		return false, gtserror.Newf("error checking status %s account silencing: %w", status.ID, err)
+	} else if !visible {
+		return false, nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -211,3 +221,56 @@ func (f *Filter) areStatusAccountsVisible(ctx context.Context, requester *gtsmod
 
 	return true, nil
 }
+
+// areStatusAccountsUnsilenced checks whether the status author, and the
+// boost-of author (if set), are silenced. The statuses of silenced accounts
+// are only visible to the account itself and to the account's followers.
+func (f *Filter) areStatusAccountsUnsilenced(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	visible, err := f.isSilencedAccountVisible(ctx, requester, status.Account)
+	if err != nil || !visible {
+		return false, err
+	}
+
+	if status.BoostOfID != "" {
+		return f.isSilencedAccountVisible(ctx, requester, status.BoostOfAccount)
+	}
+
+	return true, nil
+}
+
+// isSilencedAccountVisible returns whether statuses
+// by account are visible to requester, accounting
+// for the account being silenced by a moderator.
+func (f *Filter) isSilencedAccountVisible(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
+	if !account.IsSilenced() {
+		// Not silenced.
+		return true, nil
+	}
+
+	if requester == nil {
+		log.Trace(ctx, "silenced account status not visible to unauthed requester")
+		return false, nil
+	}
+
+	if requester.ID == account.ID {
+		// Silenced accounts can
+		// still see their own posts.
+		return true, nil
+	}
+
+	// Check requester follows silenced account.
+	follows, err := f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		account.ID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, account.ID, err)
+	}
+
+	if !follows {
+		log.Trace(ctx, "silenced account status not visible to non-follower")
+		return false, nil
+	}
+
+	return true, nil
+}
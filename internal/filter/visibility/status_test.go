@@ -20,6 +20,7 @@ package visibility_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -156,6 +157,37 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowingCached()
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestSilencedStatusVisibleOnlyToFollowers() {
+	ctx := context.Background()
+
+	// Silence local_account_2.
+	silenced := suite.testAccounts["local_account_2"]
+	silenced.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, silenced, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_2_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.VisibilityPublic, testStatus.Visibility)
+
+	for _, test := range []struct {
+		requester *gtsmodel.Account
+		visible   bool
+	}{
+		{silenced, true}, // Author.
+		{suite.testAccounts["local_account_1"], true}, // Follower.
+		{suite.testAccounts["admin_account"], false},  // Not follower.
+		{nil, false}, // Not authed.
+	} {
+		visible, err := suite.filter.StatusVisible(ctx, test.requester, testStatus)
+		suite.NoError(err)
+		suite.Equal(test.visible, visible)
+	}
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
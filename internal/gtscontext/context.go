@@ -44,6 +44,7 @@ const (
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	backfillKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -195,6 +196,21 @@ func SetFastFail(ctx context.Context) context.Context {
 	return context.WithValue(ctx, fastFailKey, struct{}{})
 }
 
+// Backfill returns whether the "backfill" context key has been set. This can
+// be used to indicate to the dereferencer that accounts fetched with this ctx
+// should not trigger an account backfill, for example because they're being
+// fetched as part of another backfill, or to authenticate an incoming request.
+func Backfill(ctx context.Context) bool {
+	_, ok := ctx.Value(backfillKey).(struct{})
+	return ok
+}
+
+// SetBackfill sets the "backfill" context flag and returns this wrapped context.
+// See Backfill() for further information on the "backfill" context flag.
+func SetBackfill(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backfillKey, struct{}{})
+}
+
 // Barebones returns whether the "barebones" context key has been set. This
 // can be used to indicate to the database, for example, that only a barebones
 // model need be returned, Allowing it to skip populating sub models.
@@ -39,6 +39,7 @@ const (
 	malformedKey
 	notRelevantKey
 	spamKey
+	quarantinedKey
 	notPermittedKey
 )
 
@@ -171,3 +172,17 @@ func IsSpam(err error) bool {
 func SetSpam(err error) error {
 	return errors.WithValue(err, spamKey, struct{}{})
 }
+
+// IsQuarantined checks error for a stored "quarantined" flag. This is
+// set alongside the "spam" flag when an incoming AP message was not
+// dropped as spam, but instead held back in quarantine for review.
+func IsQuarantined(err error) bool {
+	_, ok := errors.Value(err, quarantinedKey).(struct{})
+	return ok
+}
+
+// SetQuarantined will wrap the given error to store a "quarantined" flag,
+// returning wrapped error. See IsQuarantined() for example use-cases.
+func SetQuarantined(err error) error {
+	return errors.WithValue(err, quarantinedKey, struct{}{})
+}
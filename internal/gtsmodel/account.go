@@ -22,6 +22,7 @@
 package gtsmodel
 
 import (
+	"crypto/ed25519"
 	"crypto/rsa"
 	"slices"
 	"strings"
@@ -33,54 +34,58 @@ import (
 
 // Account represents either a local or a remote fediverse account, gotosocial or otherwise (mastodon, pleroma, etc).
 type Account struct {
-	ID                      string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt               time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
-	UpdatedAt               time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
-	FetchedAt               time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
-	Username                string           `bun:",nullzero,notnull,unique:usernamedomain"`                     // Username of the account, should just be a string of [a-zA-Z0-9_]. Can be added to domain to create the full username in the form ``[username]@[domain]`` eg., ``user_96@example.org``. Username and domain should be unique *with* each other
-	Domain                  string           `bun:",nullzero,unique:usernamedomain"`                             // Domain of the account, will be null if this is a local account, otherwise something like ``example.org``. Should be unique with username.
-	AvatarMediaAttachmentID string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
-	AvatarMediaAttachment   *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to avatarMediaAttachmentID
-	AvatarRemoteURL         string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
-	HeaderMediaAttachmentID string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
-	HeaderMediaAttachment   *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to headerMediaAttachmentID
-	HeaderRemoteURL         string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
-	DisplayName             string           `bun:""`                                                            // DisplayName for this account. Can be empty, then just the Username will be used for display purposes.
-	EmojiIDs                []string         `bun:"emojis,array"`                                                // Database IDs of any emojis used in this account's bio, display name, etc
-	Emojis                  []*Emoji         `bun:"attached_emojis,m2m:account_to_emojis"`                       // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
-	Fields                  []*Field         `bun:""`                                                            // A slice of of fields that this account has added to their profile.
-	FieldsRaw               []*Field         `bun:""`                                                            // The raw (unparsed) content of fields that this account has added to their profile, without conversion to HTML, only available when requester = target
-	Note                    string           `bun:""`                                                            // A note that this account has on their profile (ie., the account's bio/description of themselves)
-	NoteRaw                 string           `bun:""`                                                            // The raw contents of .Note without conversion to HTML, only available when requester = target
-	Memorial                *bool            `bun:",default:false"`                                              // Is this a memorial account, ie., has the user passed away?
-	AlsoKnownAsURIs         []string         `bun:"also_known_as_uris,array"`                                    // This account is associated with these account URIs.
-	AlsoKnownAs             []*Account       `bun:"-"`                                                           // This account is associated with these accounts (field not stored in the db).
-	MovedToURI              string           `bun:",nullzero"`                                                   // This account has (or claims to have) moved to this account URI. Even if this field is set the move may not yet have been processed. Check `move` for this.
-	MovedTo                 *Account         `bun:"-"`                                                           // This account has moved to this account (field not stored in the db).
-	MoveID                  string           `bun:"type:CHAR(26),nullzero"`                                      // ID of a Move in the database for this account. Only set if we received or created a Move activity for which this account URI was the origin.
-	Move                    *Move            `bun:"-"`                                                           // Move corresponding to MoveID, if set.
-	Bot                     *bool            `bun:",default:false"`                                              // Does this account identify itself as a bot?
-	Locked                  *bool            `bun:",default:true"`                                               // Does this account need an approval for new followers?
-	Discoverable            *bool            `bun:",default:false"`                                              // Should this account be shown in the instance's profile directory?
-	URI                     string           `bun:",nullzero,notnull,unique"`                                    // ActivityPub URI for this account.
-	URL                     string           `bun:",nullzero,unique"`                                            // Web URL for this account's profile
-	InboxURI                string           `bun:",nullzero,unique"`                                            // Address of this account's ActivityPub inbox, for sending activity to
-	SharedInboxURI          *string          `bun:""`                                                            // Address of this account's ActivityPub sharedInbox. Gotcha warning: this is a string pointer because it has three possible states: 1. We don't know yet if the account has a shared inbox -- null. 2. We know it doesn't have a shared inbox -- empty string. 3. We know it does have a shared inbox -- url string.
-	OutboxURI               string           `bun:",nullzero,unique"`                                            // Address of this account's activitypub outbox
-	FollowingURI            string           `bun:",nullzero,unique"`                                            // URI for getting the following list of this account
-	FollowersURI            string           `bun:",nullzero,unique"`                                            // URI for getting the followers list of this account
-	FeaturedCollectionURI   string           `bun:",nullzero,unique"`                                            // URL for getting the featured collection list of this account
-	ActorType               string           `bun:",nullzero,notnull"`                                           // What type of activitypub actor is this account?
-	PrivateKey              *rsa.PrivateKey  `bun:""`                                                            // Privatekey for signing activitypub requests, will only be defined for local accounts
-	PublicKey               *rsa.PublicKey   `bun:",notnull"`                                                    // Publickey for authorizing signed activitypub requests, will be defined for both local and remote accounts
-	PublicKeyURI            string           `bun:",nullzero,notnull,unique"`                                    // Web-reachable location of this account's public key
-	PublicKeyExpiresAt      time.Time        `bun:"type:timestamptz,nullzero"`                                   // PublicKey will expire/has expired at given time, and should be fetched again as appropriate. Only ever set for remote accounts.
-	SensitizedAt            time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account set to have all its media shown as sensitive?
-	SilencedAt              time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account silenced (eg., statuses only visible to followers, not public)?
-	SuspendedAt             time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
-	SuspensionOrigin        string           `bun:"type:CHAR(26),nullzero"`                                      // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
-	Settings                *AccountSettings `bun:"-"`                                                           // gtsmodel.AccountSettings for this account.
-	Stats                   *AccountStats    `bun:"-"`                                                           // gtsmodel.AccountStats for this account.
+	ID                      string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt               time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt               time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	FetchedAt               time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
+	Username                string             `bun:",nullzero,notnull,unique:usernamedomain"`                     // Username of the account, should just be a string of [a-zA-Z0-9_]. Can be added to domain to create the full username in the form ``[username]@[domain]`` eg., ``user_96@example.org``. Username and domain should be unique *with* each other
+	Domain                  string             `bun:",nullzero,unique:usernamedomain"`                             // Domain of the account, will be null if this is a local account, otherwise something like ``example.org``. Should be unique with username.
+	AvatarMediaAttachmentID string             `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
+	AvatarMediaAttachment   *MediaAttachment   `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to avatarMediaAttachmentID
+	AvatarRemoteURL         string             `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
+	HeaderMediaAttachmentID string             `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
+	HeaderMediaAttachment   *MediaAttachment   `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to headerMediaAttachmentID
+	HeaderRemoteURL         string             `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
+	DisplayName             string             `bun:""`                                                            // DisplayName for this account. Can be empty, then just the Username will be used for display purposes.
+	EmojiIDs                []string           `bun:"emojis,array"`                                                // Database IDs of any emojis used in this account's bio, display name, etc
+	Emojis                  []*Emoji           `bun:"attached_emojis,m2m:account_to_emojis"`                       // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
+	Fields                  []*Field           `bun:""`                                                            // A slice of of fields that this account has added to their profile.
+	FieldsRaw               []*Field           `bun:""`                                                            // The raw (unparsed) content of fields that this account has added to their profile, without conversion to HTML, only available when requester = target
+	Note                    string             `bun:""`                                                            // A note that this account has on their profile (ie., the account's bio/description of themselves)
+	NoteRaw                 string             `bun:""`                                                            // The raw contents of .Note without conversion to HTML, only available when requester = target
+	Memorial                *bool              `bun:",default:false"`                                              // Is this a memorial account, ie., has the user passed away?
+	AlsoKnownAsURIs         []string           `bun:"also_known_as_uris,array"`                                    // This account is associated with these account URIs.
+	AlsoKnownAs             []*Account         `bun:"-"`                                                           // This account is associated with these accounts (field not stored in the db).
+	MovedToURI              string             `bun:",nullzero"`                                                   // This account has (or claims to have) moved to this account URI. Even if this field is set the move may not yet have been processed. Check `move` for this.
+	MovedTo                 *Account           `bun:"-"`                                                           // This account has moved to this account (field not stored in the db).
+	MoveID                  string             `bun:"type:CHAR(26),nullzero"`                                      // ID of a Move in the database for this account. Only set if we received or created a Move activity for which this account URI was the origin.
+	Move                    *Move              `bun:"-"`                                                           // Move corresponding to MoveID, if set.
+	Bot                     *bool              `bun:",default:false"`                                              // Does this account identify itself as a bot?
+	Locked                  *bool              `bun:",default:true"`                                               // Does this account need an approval for new followers?
+	Discoverable            *bool              `bun:",default:false"`                                              // Should this account be shown in the instance's profile directory?
+	Indexable               *bool              `bun:",default:false"`                                              // Has this (remote) account opted in to having its public posts found via full-text search? For local accounts, see Settings.Indexable instead.
+	URI                     string             `bun:",nullzero,notnull,unique"`                                    // ActivityPub URI for this account.
+	URL                     string             `bun:",nullzero,unique"`                                            // Web URL for this account's profile
+	InboxURI                string             `bun:",nullzero,unique"`                                            // Address of this account's ActivityPub inbox, for sending activity to
+	SharedInboxURI          *string            `bun:""`                                                            // Address of this account's ActivityPub sharedInbox. Gotcha warning: this is a string pointer because it has three possible states: 1. We don't know yet if the account has a shared inbox -- null. 2. We know it doesn't have a shared inbox -- empty string. 3. We know it does have a shared inbox -- url string.
+	OutboxURI               string             `bun:",nullzero,unique"`                                            // Address of this account's activitypub outbox
+	FollowingURI            string             `bun:",nullzero,unique"`                                            // URI for getting the following list of this account
+	FollowersURI            string             `bun:",nullzero,unique"`                                            // URI for getting the followers list of this account
+	FeaturedCollectionURI   string             `bun:",nullzero,unique"`                                            // URL for getting the featured collection list of this account
+	ActorType               string             `bun:",nullzero,notnull"`                                           // What type of activitypub actor is this account?
+	PrivateKey              *rsa.PrivateKey    `bun:""`                                                            // Privatekey for signing activitypub requests, will only be defined for local accounts
+	PublicKey               *rsa.PublicKey     `bun:",notnull"`                                                    // Publickey for authorizing signed activitypub requests, will be defined for both local and remote accounts
+	PublicKeyURI            string             `bun:",nullzero,notnull,unique"`                                    // Web-reachable location of this account's public key
+	PublicKeyExpiresAt      time.Time          `bun:"type:timestamptz,nullzero"`                                   // PublicKey will expire/has expired at given time, and should be fetched again as appropriate. Only ever set for remote accounts.
+	Ed25519PrivateKey       ed25519.PrivateKey `bun:",nullzero"`                                                   // Ed25519 private key for creating object integrity proofs (FEP-8b32), will only be defined for local accounts
+	Ed25519PublicKey        ed25519.PublicKey  `bun:",nullzero"`                                                   // Ed25519 public key for verifying object integrity proofs, published as an 'assertionMethod' Multikey (FEP-521a). May be nil for remote accounts.
+	Ed25519PublicKeyURI     string             `bun:",nullzero"`                                                   // ID of the 'assertionMethod' Multikey holding Ed25519PublicKey, eg., https://example.org/users/someone#ed25519-key
+	SensitizedAt            time.Time          `bun:"type:timestamptz,nullzero"`                                   // When was this account set to have all its media shown as sensitive?
+	SilencedAt              time.Time          `bun:"type:timestamptz,nullzero"`                                   // When was this account silenced (eg., statuses only visible to followers, not public)?
+	SuspendedAt             time.Time          `bun:"type:timestamptz,nullzero"`                                   // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
+	SuspensionOrigin        string             `bun:"type:CHAR(26),nullzero"`                                      // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
+	Settings                *AccountSettings   `bun:"-"`                                                           // gtsmodel.AccountSettings for this account.
+	Stats                   *AccountStats      `bun:"-"`                                                           // gtsmodel.AccountStats for this account.
 }
 
 // IsLocal returns whether account is a local user account.
@@ -181,6 +186,19 @@ func (a *Account) IsSuspended() bool {
 	return !a.SuspendedAt.IsZero()
 }
 
+// IsSilenced returns true if account
+// has been silenced by a moderator.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
+// IsSensitized returns true if account
+// has been marked by a moderator as having
+// all of its media shown as sensitive.
+func (a *Account) IsSensitized() bool {
+	return !a.SensitizedAt.IsZero()
+}
+
 // IsMoving returns true if
 // account is Moving or has Moved.
 func (a *Account) IsMoving() bool {
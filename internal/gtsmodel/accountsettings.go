@@ -32,4 +32,6 @@ type AccountSettings struct {
 	CustomCSS         string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
 	EnableRSS         *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
 	HideCollections   *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	Indexable         *bool      `bun:",nullzero,notnull,default:false"`                             // Allow this account's public posts to be found by anyone via full-text search.
+	DisableEmbeds     *bool      `bun:",nullzero,notnull,default:false"`                             // Prevent this account's statuses from being embedded on other websites via oEmbed.
 }
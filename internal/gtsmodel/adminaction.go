@@ -73,6 +73,10 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionSensitive
+	AdminActionUnsensitive
+	AdminActionMemorialize
+	AdminActionUnmemorialize
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +95,14 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionSensitive:
+		return "sensitive"
+	case AdminActionUnsensitive:
+		return "unsensitive"
+	case AdminActionMemorialize:
+		return "memorialize"
+	case AdminActionUnmemorialize:
+		return "unmemorialize"
 	default:
 		return "unknown"
 	}
@@ -112,6 +124,34 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "sensitive":
+		return AdminActionSensitive
+	case "unsensitive":
+		return AdminActionUnsensitive
+	case "memorialize":
+		return AdminActionMemorialize
+	case "unmemorialize":
+		return AdminActionUnmemorialize
+	default:
+		return AdminActionUnknown
+	}
+}
+
+// Reverse returns the action type which reverses
+// this action type, eg., unsilence for silence, or
+// AdminActionUnknown if this type can't be reversed.
+//
+// Suspend can't be reversed, as suspending removes
+// the account's data, so unsuspending afterwards
+// would leave behind a broken, empty account.
+func (t AdminActionType) Reverse() AdminActionType {
+	switch t {
+	case AdminActionDisable:
+		return AdminActionReenable
+	case AdminActionSilence:
+		return AdminActionUnsilence
+	case AdminActionSensitive:
+		return AdminActionUnsensitive
 	default:
 		return AdminActionUnknown
 	}
@@ -134,6 +174,14 @@ type AdminAction struct {
 	ReportIDs      []string            `bun:"reports,array"`                                               // IDs of any reports cited when creating this action.
 	Reports        []*Report           `bun:"-"`                                                           // Reports corresponding to ReportIDs.
 	Errors         []string            `bun:",array"`                                                      // String value of any error(s) encountered while processing. May be helpful for admins to debug.
+	ExpiresAt      time.Time           `bun:"type:timestamptz,nullzero"`                                   // Time at which this action should be automatically reversed. Zero = never.
+	ReversedAt     time.Time           `bun:"type:timestamptz,nullzero"`                                   // Time at which this action was reversed or superseded by a later action on the same target.
+}
+
+// IsPendingExpiry returns whether this action
+// is due to be automatically reversed at ExpiresAt.
+func (a *AdminAction) IsPendingExpiry() bool {
+	return !a.ExpiresAt.IsZero() && a.ReversedAt.IsZero()
 }
 
 // Key returns a key for the AdminAction which is
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"strings"
+	"time"
+)
+
+// FederationPolicyType denotes what a federation policy
+// does to the incoming activities that it applies to.
+type FederationPolicyType string
+
+const (
+	// Reject statuses containing any of the policy keywords.
+	FederationPolicyKeywordReject FederationPolicyType = "keyword_reject"
+
+	// Put a content warning on statuses
+	// containing any of the policy keywords.
+	FederationPolicyKeywordCW FederationPolicyType = "keyword_cw"
+
+	// Remove media attachments from statuses.
+	FederationPolicyStripMedia FederationPolicyType = "strip_media"
+
+	// Change public statuses to unlisted.
+	FederationPolicyForceUnlisted FederationPolicyType = "force_unlisted"
+
+	// Reject statuses with more than the policy max mentions.
+	FederationPolicyMaxMentions FederationPolicyType = "max_mentions"
+
+	// Drop announces (boosts).
+	FederationPolicyRejectAnnounces FederationPolicyType = "reject_announces"
+
+	// Reject statuses, boosts, follows and likes from accounts
+	// younger than the policy min account age, unless they're
+	// already followed by an account on this instance.
+	FederationPolicyRejectNewAccounts FederationPolicyType = "reject_new_accounts"
+)
+
+// FederationPolicyTypes contains all valid federation policy types.
+var FederationPolicyTypes = []FederationPolicyType{
+	FederationPolicyKeywordReject,
+	FederationPolicyKeywordCW,
+	FederationPolicyStripMedia,
+	FederationPolicyForceUnlisted,
+	FederationPolicyMaxMentions,
+	FederationPolicyRejectAnnounces,
+	FederationPolicyRejectNewAccounts,
+}
+
+// FederationPolicy represents one admin-configured step in the pipeline
+// that incoming federated activities are run through before being
+// processed, which may either reject or rewrite the activity.
+type FederationPolicy struct {
+	ID                 string               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type               FederationPolicyType `bun:",nullzero,notnull"`                                           // what this policy does
+	Priority           int                  `bun:",notnull,default:0"`                                          // order this policy is run in, lowest first
+	Disabled           *bool                `bun:",nullzero,notnull,default:false"`                             // policy is kept but not run
+	Domains            []string             `bun:"domains,array"`                                               // domains (and their subdomains) this policy applies to, empty means all domains
+	Keywords           []string             `bun:"keywords,array"`                                              // case-insensitive keywords to match against status text (keyword_* policies)
+	ContentWarning     string               `bun:",nullzero"`                                                   // content warning to set (keyword_cw policy)
+	MaxMentions        int                  `bun:",nullzero"`                                                   // maximum mentions allowed (max_mentions policy)
+	MinAccountAge      time.Duration        `bun:",nullzero"`                                                   // minimum account age allowed (reject_new_accounts policy)
+	Comment            string               `bun:",nullzero"`                                                   // admin comment on why this policy exists
+	CreatedByAccountID string               `bun:"type:CHAR(26),nullzero,notnull"`                              // account ID of the admin who created this policy
+	CreatedByAccount   *Account             `bun:"-"`                                                           // account corresponding to CreatedByAccountID
+}
+
+// IsDisabled returns whether this policy is disabled.
+func (p *FederationPolicy) IsDisabled() bool {
+	return p.Disabled != nil && *p.Disabled
+}
+
+// AppliesToDomain returns whether this policy applies to activities
+// from the given (punycode) domain, ie., if the domain or one of its
+// parent domains is in the policy domains, or it has no domains set.
+func (p *FederationPolicy) AppliesToDomain(domain string) bool {
+	if len(p.Domains) == 0 {
+		return true
+	}
+
+	for _, d := range p.Domains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+
+	return false
+}
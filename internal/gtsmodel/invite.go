@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite code created by a local
+// account, which allows new accounts to sign up to this
+// instance even when open registration is closed.
+type Invite struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Code      string    `bun:",nullzero,notnull,unique"`                                    // code to give to invitees, used in the invite link
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account who created this invite
+	Account   *Account  `bun:"-"`                                                           // account corresponding to AccountID
+	ExpiresAt time.Time `bun:"type:timestamptz,nullzero"`                                   // time after which this invite can no longer be used, if set
+	MaxUses   int       `bun:",nullzero"`                                                   // number of times this invite may be used, 0 for unlimited
+	Uses      int       `bun:",notnull,default:0"`                                          // number of accounts that have signed up with this invite
+}
+
+// Expired returns whether this invite has expired.
+func (i *Invite) Expired() bool {
+	return !i.ExpiresAt.IsZero() && !time.Now().Before(i.ExpiresAt)
+}
+
+// UsedUp returns whether this invite
+// has reached its maximum number of uses.
+func (i *Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// Valid returns whether this invite
+// may still be used to sign up.
+func (i *Invite) Valid() bool {
+	return !i.Expired() && !i.UsedUp()
+}
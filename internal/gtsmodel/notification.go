@@ -39,12 +39,13 @@ type NotificationType string
 
 // Notification Types
 const (
-	NotificationFollow        NotificationType = "follow"         // NotificationFollow -- someone followed you
-	NotificationFollowRequest NotificationType = "follow_request" // NotificationFollowRequest -- someone requested to follow you
-	NotificationMention       NotificationType = "mention"        // NotificationMention -- someone mentioned you in their status
-	NotificationReblog        NotificationType = "reblog"         // NotificationReblog -- someone boosted one of your statuses
-	NotificationFave          NotificationType = "favourite"      // NotificationFave -- someone faved/liked one of your statuses
-	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
-	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
-	NotificationSignup        NotificationType = "admin.sign_up"  // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationFollow        NotificationType = "follow"                 // NotificationFollow -- someone followed you
+	NotificationFollowRequest NotificationType = "follow_request"         // NotificationFollowRequest -- someone requested to follow you
+	NotificationMention       NotificationType = "mention"                // NotificationMention -- someone mentioned you in their status
+	NotificationReblog        NotificationType = "reblog"                 // NotificationReblog -- someone boosted one of your statuses
+	NotificationFave          NotificationType = "favourite"              // NotificationFave -- someone faved/liked one of your statuses
+	NotificationPoll          NotificationType = "poll"                   // NotificationPoll -- a poll you voted in or created has ended
+	NotificationStatus        NotificationType = "status"                 // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationSignup        NotificationType = "admin.sign_up"          // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationEmojiReaction NotificationType = "pleroma:emoji_reaction" // NotificationEmojiReaction -- someone reacted to one of your statuses with an emoji.
 )
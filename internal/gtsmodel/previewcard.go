@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// PreviewCard represents a rich preview of a web page linked
+// to from one or more statuses, generated from the OpenGraph,
+// Twitter Card and / or oEmbed metadata of that page. Cards
+// are shared between all statuses linking to the same URL.
+type PreviewCard struct {
+	ID               string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt        time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt        time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	FetchedAt        time.Time `bun:"type:timestamptz,nullzero"`                                   // when was the linked page last fetched
+	URL              string    `bun:",nullzero,notnull,unique"`                                    // url of the linked page, as it appears in statuses
+	Title            string    `bun:",nullzero"`                                                   // title of the linked page
+	Description      string    `bun:",nullzero"`                                                   // description of the linked page
+	Type             string    `bun:",nullzero,notnull,default:'link'"`                            // type of the card: link, photo, video or rich
+	AuthorName       string    `bun:",nullzero"`                                                   // name of the author of the linked page
+	AuthorURL        string    `bun:",nullzero"`                                                   // url of the author of the linked page
+	ProviderName     string    `bun:",nullzero"`                                                   // name of the provider of the linked page, eg., the site name
+	ProviderURL      string    `bun:",nullzero"`                                                   // url of the provider of the linked page
+	HTML             string    `bun:",nullzero"`                                                   // sanitized oEmbed html for video or rich cards
+	Width            int       `bun:",nullzero"`                                                   // width of the oEmbed html or photo, in pixels
+	Height           int       `bun:",nullzero"`                                                   // height of the oEmbed html or photo, in pixels
+	EmbedURL         string    `bun:",nullzero"`                                                   // url of the full size photo, for photo cards
+	ImageRemoteURL   string    `bun:",nullzero"`                                                   // remote url of the preview image, if any
+	ImageURL         string    `bun:",nullzero"`                                                   // where can the preview image thumbnail be retrieved from the local server
+	ImagePath        string    `bun:",nullzero"`                                                   // path of the preview image thumbnail in the server storage system
+	ImageContentType string    `bun:",nullzero"`                                                   // MIME content type of the preview image thumbnail
+	ImageFileSize    int       `bun:",nullzero"`                                                   // size of the preview image thumbnail in bytes, for serving purposes
+	ImageWidth       int       `bun:",nullzero"`                                                   // width of the preview image thumbnail, in pixels
+	ImageHeight      int       `bun:",nullzero"`                                                   // height of the preview image thumbnail, in pixels
+	Blurhash         string    `bun:",nullzero"`                                                   // blurhash of the preview image thumbnail
+}
+
+// Empty returns true if no usable metadata
+// could be found for the linked page, in
+// which case the card should not be shown.
+func (c *PreviewCard) Empty() bool {
+	return c.Title == "" && c.HTML == "" && c.EmbedURL == ""
+}
+
+// ImageCached returns true if a preview image
+// thumbnail is stored locally for this card.
+func (c *PreviewCard) ImageCached() bool {
+	return c.ImagePath != ""
+}
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// QueuedEmail represents a fully assembled outgoing
+// email message, waiting in the database to be delivered.
+type QueuedEmail struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	From          string    `bun:"from_address,nullzero,notnull"`                               // envelope from address
+	To            []string  `bun:"to_addresses,array"`                                          // envelope recipient addresses
+	Subject       string    `bun:",nullzero"`                                                   // subject of the message, for display purposes only
+	Message       []byte    `bun:",nullzero,notnull"`                                           // complete message, including headers
+	Attempts      int       `bun:",notnull,default:0"`                                          // number of delivery attempts made so far
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when to next attempt delivery
+	LastError     string    `bun:",nullzero"`                                                   // error from the last failed delivery attempt
+	FailedAt      time.Time `bun:"type:timestamptz,nullzero"`                                   // when delivery was given up on, if it was
+}
+
+// Failed returns true if delivery
+// of this email has been given up on.
+func (q *QueuedEmail) Failed() bool {
+	return !q.FailedAt.IsZero()
+}
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"strings"
+	"time"
+)
+
+// QuarantinedStatus represents an incoming remote status that
+// scored high enough on the spam filter to be held back for
+// admin review, but not so high that it was dropped outright.
+type QuarantinedStatus struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	URI                string    `bun:",nullzero,notnull,unique"`                                    // activitypub URI of the quarantined status
+	URL                string    `bun:",nullzero"`                                                   // web url of the quarantined status
+	AccountID          string    `bun:"type:CHAR(26),nullzero,notnull"`                              // account ID of the status author
+	Account            *Account  `bun:"-"`                                                           // account corresponding to AccountID
+	ReceivingAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // account ID of the local account the status was delivered to
+	ReceivingAccount   *Account  `bun:"-"`                                                           // account corresponding to ReceivingAccountID
+	ContentWarning     string    `bun:",nullzero"`                                                   // sanitized content warning of the status, for review
+	Content            string    `bun:",nullzero"`                                                   // sanitized content of the status, for review
+	Score              int       `bun:",notnull,default:0"`                                          // total spam score the status was given
+	Rules              []string  `bun:"rules,array"`                                                 // names of the spam rules that matched the status
+	Fingerprint        string    `bun:",nullzero"`                                                   // fingerprint of the status content, see SpamSignalFingerprint
+	LinkDomains        []string  `bun:"link_domains,array"`                                          // domains of non-mention, non-hashtag links in the status
+}
+
+// SpamSignalType denotes what kind of
+// value a spam signal is matched against.
+type SpamSignalType string
+
+const (
+	// Value is a (punycode) domain, which is matched against
+	// the domains of links in a status, including subdomains.
+	SpamSignalLinkDomain SpamSignalType = "link_domain"
+
+	// Value is a fingerprint of normalized status
+	// text, matched against the text of a status.
+	SpamSignalFingerprint SpamSignalType = "fingerprint"
+)
+
+// SpamSignalTypes contains all valid spam signal types.
+var SpamSignalTypes = []SpamSignalType{
+	SpamSignalLinkDomain,
+	SpamSignalFingerprint,
+}
+
+// SpamSignal represents a value that adds to (or, if negative,
+// subtracts from) the spam score of an incoming status that
+// matches it. Spam signals may be created by admins directly,
+// or learned from admin decisions on quarantined statuses.
+type SpamSignal struct {
+	ID        string         `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time      `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time      `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type      SpamSignalType `bun:",nullzero,notnull,unique:spamsignaltypevalue"`                // what kind of value this is
+	Value     string         `bun:",nullzero,notnull,unique:spamsignaltypevalue"`                // value to match against
+	Score     int            `bun:",notnull,default:0"`                                          // score to add when matched
+	Comment   string         `bun:",nullzero"`                                                   // admin comment on this signal
+}
+
+// MatchesDomain returns whether this signal is a link domain
+// signal matching the given (punycode) domain, ie., if the
+// domain is the signal value or one of its subdomains.
+func (s *SpamSignal) MatchesDomain(domain string) bool {
+	if s.Type != SpamSignalLinkDomain {
+		return false
+	}
+
+	return domain == s.Value ||
+		strings.HasSuffix(domain, "."+s.Value)
+}
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// TrendType denotes the kind
+// of item that a trend is for.
+type TrendType uint8
+
+const (
+	TrendTypeTag    TrendType = 1
+	TrendTypeStatus TrendType = 2
+	TrendTypeLink   TrendType = 3
+)
+
+// String returns a stringified, lowercase
+// representation of this trend type.
+func (t TrendType) String() string {
+	switch t {
+	case TrendTypeTag:
+		return "tag"
+	case TrendTypeStatus:
+		return "status"
+	case TrendTypeLink:
+		return "link"
+	default:
+		return "unknown"
+	}
+}
+
+// TrendReview records whether an item that has been
+// found trending may be shown publicly in trends.
+// One is created, pending review, for each item the
+// first time it's found trending, and updated when
+// an admin approves or rejects the item.
+type TrendReview struct {
+	ID                  string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt           time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt           time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	TargetType          TrendType `bun:",nullzero,notnull,unique:trend_reviews_target_uniq"`          // type of the trending item
+	TargetID            string    `bun:",nullzero,notnull,unique:trend_reviews_target_uniq"`          // id of the trending tag or status, or url of the trending link
+	Approved            *bool     `bun:",nullzero"`                                                   // whether the item was approved (true) or rejected (false); nil if pending review
+	ReviewedByAccountID string    `bun:"type:CHAR(26),nullzero"`                                      // id of the admin account that reviewed the item, if reviewed
+	ReviewedAt          time.Time `bun:"type:timestamptz,nullzero"`                                   // when was the item reviewed, if reviewed
+}
+
+// Pending returns true if this
+// trend is still awaiting review.
+func (r *TrendReview) Pending() bool {
+	return r.Approved == nil
+}
+
+// TrendHistory is the usage of a
+// trending item over a single day.
+type TrendHistory struct {
+	Day      time.Time // start (midnight UTC) of the day
+	Uses     int       // number of statuses using the item that day
+	Accounts int       // number of distinct accounts using the item that day
+}
+
+// TrendUsage is the usage of a tag or link
+// over some span of time. Not stored in the database.
+type TrendUsage struct {
+	Uses     int // number of statuses using the item
+	Accounts int // number of distinct accounts using the item
+}
+
+// TrendingTag is a hashtag found to be trending
+// by the trends engine. Not stored in the database.
+type TrendingTag struct {
+	Tag     *Tag           // the trending tag
+	Score   float64        // trending score, higher is trendier
+	History []TrendHistory // daily usage, most recent day first
+	Review  *TrendReview   // review status of this trend
+}
+
+// TrendingStatus is a status found to be trending
+// by the trends engine. Not stored in the database.
+type TrendingStatus struct {
+	StatusID string       // id of the trending status
+	Score    float64      // trending score, higher is trendier
+	Review   *TrendReview // review status of this trend
+}
+
+// TrendingLink is a link found to be trending
+// by the trends engine. Not stored in the database.
+type TrendingLink struct {
+	URL     string         // normalized url of the trending link
+	Score   float64        // trending score, higher is trendier
+	History []TrendHistory // daily usage, most recent day first
+	Review  *TrendReview   // review status of this trend
+	Card    *PreviewCard   // preview card for the link, if fetched
+}
+
+// Trends is a snapshot of all items found to be
+// trending at a point in time, ordered by score,
+// highest first. Not stored in the database.
+type Trends struct {
+	Tags       []*TrendingTag
+	Statuses   []*TrendingStatus
+	Links      []*TrendingLink
+	ComputedAt time.Time
+}
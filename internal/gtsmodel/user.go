@@ -31,31 +31,149 @@ import (
 // Sign-ups that have been denied rather than
 // approved are stored as DeniedUser instead.
 type User struct {
-	ID                     string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Email                  string       `bun:",nullzero,unique"`                                            // confirmed email address for this user, this should be unique -- only one email address registered per instance, multiple users per email are not supported
-	AccountID              string       `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // The id of the local gtsmodel.Account entry for this user.
-	Account                *Account     `bun:"rel:belongs-to"`                                              // Pointer to the account of this user that corresponds to AccountID.
-	EncryptedPassword      string       `bun:",nullzero,notnull"`                                           // The encrypted password of this user, generated using https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword. A salt is included so we're safe against 🌈 tables.
-	SignUpIP               net.IP       `bun:",nullzero"`                                                   // IP this user used to sign up. Only stored for pending sign-ups.
-	InviteID               string       `bun:"type:CHAR(26),nullzero"`                                      // id of the user who invited this user (who let this joker in?)
-	Reason                 string       `bun:",nullzero"`                                                   // What reason was given for signing up when this user was created?
-	Locale                 string       `bun:",nullzero"`                                                   // In what timezone/locale is this user located?
-	CreatedByApplicationID string       `bun:"type:CHAR(26),nullzero"`                                      // Which application id created this user? See gtsmodel.Application
-	CreatedByApplication   *Application `bun:"rel:belongs-to"`                                              // Pointer to the application corresponding to createdbyapplicationID.
-	LastEmailedAt          time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this user last contacted by email.
-	ConfirmationToken      string       `bun:",nullzero"`                                                   // What confirmation token did we send this user/what are we expecting back?
-	ConfirmationSentAt     time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we send email confirmation to this user?
-	ConfirmedAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user confirm their email address
-	UnconfirmedEmail       string       `bun:",nullzero"`                                                   // Email address that hasn't yet been confirmed
-	Moderator              *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user a moderator?
-	Admin                  *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user an admin?
-	Disabled               *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user disabled from posting?
-	Approved               *bool        `bun:",nullzero,notnull,default:false"`                             // Has this user been approved by a moderator?
-	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
-	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
-	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	ID                       string               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt                time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt                time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Email                    string               `bun:",nullzero,unique"`                                            // confirmed email address for this user, this should be unique -- only one email address registered per instance, multiple users per email are not supported
+	AccountID                string               `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // The id of the local gtsmodel.Account entry for this user.
+	Account                  *Account             `bun:"rel:belongs-to"`                                              // Pointer to the account of this user that corresponds to AccountID.
+	EncryptedPassword        string               `bun:",nullzero,notnull"`                                           // The encrypted password of this user, generated using https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword. A salt is included so we're safe against 🌈 tables.
+	SignUpIP                 net.IP               `bun:",nullzero"`                                                   // IP this user used to sign up. Only stored for pending sign-ups.
+	InviteID                 string               `bun:"type:CHAR(26),nullzero"`                                      // id of the invite this user signed up with, if any (who let this joker in?)
+	Reason                   string               `bun:",nullzero"`                                                   // What reason was given for signing up when this user was created?
+	Locale                   string               `bun:",nullzero"`                                                   // In what timezone/locale is this user located?
+	CreatedByApplicationID   string               `bun:"type:CHAR(26),nullzero"`                                      // Which application id created this user? See gtsmodel.Application
+	CreatedByApplication     *Application         `bun:"rel:belongs-to"`                                              // Pointer to the application corresponding to createdbyapplicationID.
+	LastEmailedAt            time.Time            `bun:"type:timestamptz,nullzero"`                                   // When was this user last contacted by email.
+	ConfirmationToken        string               `bun:",nullzero"`                                                   // What confirmation token did we send this user/what are we expecting back?
+	ConfirmationSentAt       time.Time            `bun:"type:timestamptz,nullzero"`                                   // When did we send email confirmation to this user?
+	ConfirmedAt              time.Time            `bun:"type:timestamptz,nullzero"`                                   // When did the user confirm their email address
+	UnconfirmedEmail         string               `bun:",nullzero"`                                                   // Email address that hasn't yet been confirmed
+	Moderator                *bool                `bun:",nullzero,notnull,default:false"`                             // Is this user a moderator?
+	Admin                    *bool                `bun:",nullzero,notnull,default:false"`                             // Is this user an admin?
+	Disabled                 *bool                `bun:",nullzero,notnull,default:false"`                             // Is this user disabled from posting?
+	Approved                 *bool                `bun:",nullzero,notnull,default:false"`                             // Has this user been approved by a moderator?
+	ResetPasswordToken       string               `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
+	ResetPasswordSentAt      time.Time            `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
+	ExternalID               string               `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	EmailNotifyMention       *bool                `bun:",nullzero,notnull,default:false"`                             // Email this user when they're mentioned in a non-direct status?
+	EmailNotifyDirect        *bool                `bun:",nullzero,notnull,default:false"`                             // Email this user when they're mentioned in a direct status?
+	EmailNotifyFollow        *bool                `bun:",nullzero,notnull,default:false"`                             // Email this user when they're followed?
+	EmailNotifyFollowRequest *bool                `bun:",nullzero,notnull,default:false"`                             // Email this user when they receive a follow request?
+	EmailNotifyReport        *bool                `bun:",nullzero,notnull,default:true"`                              // Email this user when a report they created is closed?
+	EmailNotifyFrequency     EmailNotifyFrequency `bun:",nullzero,notnull,default:1"`                                 // How often to email this user about the above.
+	EmailDigestSentAt        time.Time            `bun:"type:timestamptz,nullzero"`                                   // When was this user last sent a digest email (or switched to digests)?
+	EmailUnsubscribeToken    string               `bun:",nullzero,unique"`                                            // Token used in one-click unsubscribe links sent to this user.
+	FeedToken                string               `bun:",nullzero,unique"`                                            // Token used to access this user's private feeds, eg. of lists.
+	LastActiveAt             time.Time            `bun:"type:timestamptz,nullzero"`                                   // When was this user last signed in (ie., using a token)? Only updated at most once a day.
+	DormantWarnedAt          time.Time            `bun:"type:timestamptz,nullzero"`                                   // When was this user emailed that their account is dormant? Zero if not dormant.
+}
+
+// EmailNotifies returns whether this user wants to
+// be emailed about the given email notification type.
+func (u *User) EmailNotifies(t EmailNotifyType) bool {
+	var notify *bool
+	switch t {
+	case EmailNotifyTypeMention:
+		notify = u.EmailNotifyMention
+	case EmailNotifyTypeDirect:
+		notify = u.EmailNotifyDirect
+	case EmailNotifyTypeFollow:
+		notify = u.EmailNotifyFollow
+	case EmailNotifyTypeFollowRequest:
+		notify = u.EmailNotifyFollowRequest
+	case EmailNotifyTypeReport:
+		notify = u.EmailNotifyReport
+	}
+	return notify != nil && *notify
+}
+
+// EmailNotifiesAny returns whether this user wants
+// to be emailed about any notification types at all.
+func (u *User) EmailNotifiesAny() bool {
+	for _, t := range EmailNotifyTypes {
+		if u.EmailNotifies(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailNotifyType denotes a type of event
+// that a user can choose to be emailed about.
+type EmailNotifyType string
+
+const (
+	EmailNotifyTypeMention       EmailNotifyType = "mention"        // Mentioned in a non-direct status.
+	EmailNotifyTypeDirect        EmailNotifyType = "direct"         // Mentioned in a direct status.
+	EmailNotifyTypeFollow        EmailNotifyType = "follow"         // Followed by someone.
+	EmailNotifyTypeFollowRequest EmailNotifyType = "follow_request" // Received a follow request.
+	EmailNotifyTypeReport        EmailNotifyType = "report"         // Created report was closed.
+)
+
+// EmailNotifyTypes contains all EmailNotifyType values.
+var EmailNotifyTypes = []EmailNotifyType{
+	EmailNotifyTypeMention,
+	EmailNotifyTypeDirect,
+	EmailNotifyTypeFollow,
+	EmailNotifyTypeFollowRequest,
+	EmailNotifyTypeReport,
+}
+
+// EmailNotifyFrequency denotes how often
+// a user wants to be emailed about events.
+type EmailNotifyFrequency int16
+
+const (
+	EmailNotifyFrequencyUnknown   EmailNotifyFrequency = 0
+	EmailNotifyFrequencyImmediate EmailNotifyFrequency = 1 // Send an email for each event.
+	EmailNotifyFrequencyDaily     EmailNotifyFrequency = 2 // Send a daily digest of events.
+	EmailNotifyFrequencyWeekly    EmailNotifyFrequency = 3 // Send a weekly digest of events.
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of EmailNotifyFrequency.
+func (f EmailNotifyFrequency) String() string {
+	switch f {
+	case EmailNotifyFrequencyImmediate:
+		return "immediate"
+	case EmailNotifyFrequencyDaily:
+		return "daily"
+	case EmailNotifyFrequencyWeekly:
+		return "weekly"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseEmailNotifyFrequency returns an EmailNotifyFrequency from
+// the given string, or EmailNotifyFrequencyUnknown if not valid.
+func ParseEmailNotifyFrequency(in string) EmailNotifyFrequency {
+	switch in {
+	case "immediate":
+		return EmailNotifyFrequencyImmediate
+	case "daily":
+		return EmailNotifyFrequencyDaily
+	case "weekly":
+		return EmailNotifyFrequencyWeekly
+	default:
+		return EmailNotifyFrequencyUnknown
+	}
+}
+
+// Period returns the period covered by
+// one digest email at this frequency, or
+// zero if this frequency is not a digest.
+func (f EmailNotifyFrequency) Period() time.Duration {
+	switch f {
+	case EmailNotifyFrequencyDaily:
+		return 24 * time.Hour
+	case EmailNotifyFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
 }
 
 // DeniedUser represents one user sign-up that
@@ -97,4 +215,5 @@ type NewSignup struct {
 	EmailVerified bool   // Mark submitted email address as already verified (optional).
 	ExternalID    string // ID of this user in external OIDC system (optional).
 	Admin         bool   // Mark new user as an admin user (optional).
+	InviteID      string // ID of the invite used to sign up (optional).
 }
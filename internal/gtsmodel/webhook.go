@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"slices"
+	"time"
+)
+
+// WebhookEvent denotes an event on this
+// instance that webhooks may subscribe to.
+type WebhookEvent string
+
+const (
+	// A new account signed up.
+	WebhookEventAccountCreated WebhookEvent = "account.created"
+
+	// An account sign-up was approved by a moderator.
+	WebhookEventAccountApproved WebhookEvent = "account.approved"
+
+	// A new report was filed, locally or by a remote instance.
+	WebhookEventReportCreated WebhookEvent = "report.created"
+
+	// A report was updated (eg., resolved) by a moderator.
+	WebhookEventReportUpdated WebhookEvent = "report.updated"
+
+	// A local account created a new status.
+	WebhookEventStatusCreated WebhookEvent = "status.created"
+
+	// A local account edited a status.
+	WebhookEventStatusUpdated WebhookEvent = "status.updated"
+)
+
+// WebhookEvents contains all valid webhook events.
+var WebhookEvents = []WebhookEvent{
+	WebhookEventAccountCreated,
+	WebhookEventAccountApproved,
+	WebhookEventReportCreated,
+	WebhookEventReportUpdated,
+	WebhookEventStatusCreated,
+	WebhookEventStatusUpdated,
+}
+
+// Webhook represents an admin-configured URL that a signed
+// JSON payload is POSTed to whenever a subscribed event occurs.
+type Webhook struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URL                string    `bun:",nullzero,notnull"`                                           // URL to POST event payloads to
+	Events             []string  `bun:"events,array"`                                                // events this webhook is subscribed to
+	Secret             string    `bun:",nullzero,notnull"`                                           // secret used to sign event payloads
+	Disabled           *bool     `bun:",nullzero,notnull,default:false"`                             // webhook is kept but not sent to
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // account ID of the admin who created this webhook
+}
+
+// IsDisabled returns whether this webhook is disabled.
+func (w *Webhook) IsDisabled() bool {
+	return w.Disabled != nil && *w.Disabled
+}
+
+// SubscribedTo returns whether this webhook is
+// enabled, and subscribed to the given event.
+func (w *Webhook) SubscribedTo(event WebhookEvent) bool {
+	return !w.IsDisabled() && slices.Contains(w.Events, string(event))
+}
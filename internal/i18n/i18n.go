@@ -0,0 +1,197 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package i18n provides translations of the user-facing
+// strings in email and web templates, loaded from message
+// catalogues in the `web-locale-base-dir` directory.
+//
+// Each catalogue is a JSON file named after the BCP47 tag
+// of its language (eg., `de.json`), which maps the English
+// source strings used in templates to their translation.
+// English is the source language, so it needs no catalogue,
+// and it is used as the fallback for any missing messages.
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is the language that template
+// source strings are written in, and the fallback
+// for languages which have no catalogue.
+const DefaultLanguage = "en"
+
+var (
+	// catalogues of translated messages by language tag.
+	catalogues = map[string]map[string]string{}
+
+	// languages in catalogues, DefaultLanguage first.
+	languages = []string{DefaultLanguage}
+
+	// matcher for languages.
+	matcher = language.NewMatcher([]language.Tag{language.English})
+)
+
+// Init loads message catalogues from the given directory,
+// replacing any previously loaded catalogues. A missing
+// directory is not an error: only English will be used.
+//
+// This function should only be called once, at startup,
+// since replacing the catalogues is not thread safe.
+func Init(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return gtserror.Newf("error reading locale dir %s: %w", dir, err)
+		}
+
+		log.Warnf(nil, "locale dir %s not found, only %s will be used", dir, DefaultLanguage)
+		entries = nil
+	}
+
+	var (
+		newCatalogues = map[string]map[string]string{}
+		newLanguages  = []string{DefaultLanguage}
+		tags          = []language.Tag{language.English}
+	)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		tag, err := language.Parse(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			return gtserror.Newf("error parsing %s as BCP47 language tag: %w", name, err)
+		}
+
+		lang := tag.String()
+		if lang == DefaultLanguage {
+			// Source language
+			// needs no catalogue.
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return gtserror.Newf("error reading catalogue %s: %w", name, err)
+		}
+
+		messages := make(map[string]string)
+		if err := json.Unmarshal(b, &messages); err != nil {
+			return gtserror.Newf("error parsing catalogue %s: %w", name, err)
+		}
+
+		newCatalogues[lang] = messages
+		newLanguages = append(newLanguages, lang)
+		tags = append(tags, tag)
+	}
+
+	catalogues = newCatalogues
+	languages = newLanguages
+	matcher = language.NewMatcher(tags)
+	return nil
+}
+
+// Languages returns the tags of all languages that
+// translations are available for, DefaultLanguage first.
+func Languages() []string {
+	return slices.Clone(languages)
+}
+
+// Match returns the available language which best
+// matches the given BCP47 language tags, in order
+// of preference. Unparseable or empty tags are
+// skipped. If nothing matches, DefaultLanguage
+// is returned.
+func Match(locales ...string) string {
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		if locale == "" {
+			continue
+		}
+
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return match(tags)
+}
+
+// MatchAcceptLanguage is like Match, but takes
+// the value of an HTTP Accept-Language header.
+func MatchAcceptLanguage(header string) string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return DefaultLanguage
+	}
+
+	return match(tags)
+}
+
+func match(tags []language.Tag) string {
+	if len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, i, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+
+	return languages[i]
+}
+
+// Translate returns the translation of msg in the given
+// language, or msg itself if there is none. If args are
+// given, the result is used as a format string for them.
+func Translate(lang string, msg string, args ...any) string {
+	if translated, ok := catalogues[lang][msg]; ok && translated != "" {
+		msg = translated
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+// Translator returns a function which translates
+// messages into the given language, suitable for
+// use as the "t" function in templates.
+func Translator(lang string) func(msg string, args ...any) string {
+	return func(msg string, args ...any) string {
+		return Translate(lang, msg, args...)
+	}
+}
@@ -0,0 +1,147 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package i18n_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
+)
+
+func TestTranslate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(
+		filepath.Join(dir, "de.json"),
+		[]byte(`{"Hello %s!": "Hallo %s!", "Sign in": "Anmelden", "Untranslated": ""}`),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := i18n.Init(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.Init("") })
+
+	if langs := i18n.Languages(); !slices.Equal(langs, []string{"en", "de"}) {
+		t.Fatalf("unexpected languages: %v", langs)
+	}
+
+	for i, test := range []struct {
+		lang     string
+		msg      string
+		args     []any
+		expected string
+	}{
+		{"de", "Sign in", nil, "Anmelden"},
+		{"de", "Hello %s!", []any{"zork"}, "Hallo zork!"},
+		{"de", "Untranslated", nil, "Untranslated"},
+		{"de", "Not in catalogue %s", []any{"at all"}, "Not in catalogue at all"},
+		{"en", "Hello %s!", []any{"zork"}, "Hello zork!"},
+		{"fr", "Sign in", nil, "Sign in"},
+		{"en", "100% literal", nil, "100% literal"},
+	} {
+		if translated := i18n.Translate(test.lang, test.msg, test.args...); translated != test.expected {
+			t.Errorf("test %d: expected %q, got %q", i, test.expected, translated)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"de.json", "nl.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(`{}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := i18n.Init(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.Init("") })
+
+	for i, test := range []struct {
+		locales  []string
+		expected string
+	}{
+		{nil, "en"},
+		{[]string{""}, "en"},
+		{[]string{"de"}, "de"},
+		{[]string{"de-AT"}, "de"},
+		{[]string{"en-GB", "de"}, "en"},
+		{[]string{"fr", "nl"}, "nl"},
+		{[]string{"fr"}, "en"},
+		{[]string{"not a locale!", "de"}, "de"},
+	} {
+		if lang := i18n.Match(test.locales...); lang != test.expected {
+			t.Errorf("test %d: expected %q, got %q", i, test.expected, lang)
+		}
+	}
+
+	for i, test := range []struct {
+		header   string
+		expected string
+	}{
+		{"", "en"},
+		{"de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7", "de"},
+		{"en-US,en;q=0.9,de;q=0.8", "en"},
+		{"fr-FR,fr;q=0.9", "en"},
+		{"fr-FR,nl;q=0.5", "nl"},
+		{"*", "en"},
+	} {
+		if lang := i18n.MatchAcceptLanguage(test.header); lang != test.expected {
+			t.Errorf("header test %d: expected %q, got %q", i, test.expected, lang)
+		}
+	}
+}
+
+func TestInitMissingDir(t *testing.T) {
+	if err := i18n.Init(filepath.Join(t.TempDir(), "nope")); err != nil {
+		t.Fatal(err)
+	}
+
+	if langs := i18n.Languages(); !slices.Equal(langs, []string{"en"}) {
+		t.Fatalf("unexpected languages: %v", langs)
+	}
+}
+
+func TestInitBadCatalogue(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "de.json"), []byte(`not json`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := i18n.Init(dir); err == nil {
+		t.Fatal("expected error loading bad catalogue")
+	}
+}
+
+func TestInitCatalogues(t *testing.T) {
+	// Ensure shipped catalogues load.
+	if err := i18n.Init("../../web/locale/"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.Init("") })
+
+	if !slices.Contains(i18n.Languages(), "de") {
+		t.Fatalf("expected de in languages: %v", i18n.Languages())
+	}
+}
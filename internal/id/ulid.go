@@ -54,6 +54,17 @@ func NewULIDFromTime(t time.Time) (string, error) {
 	return newUlid.String(), nil
 }
 
+// NewLowestULIDFromTime returns the lowest possible ULID string for the given time,
+// ie., one with no entropy, or an error if something goes wrong. This is useful as
+// a boundary when selecting IDs created before or after a certain time.
+func NewLowestULIDFromTime(t time.Time) (string, error) {
+	newUlid, err := ulid.New(ulid.Timestamp(t), nil)
+	if err != nil {
+		return "", err
+	}
+	return newUlid.String(), nil
+}
+
 // NewRandomULID returns a new ULID string using a random time in an ~80 year range around the current datetime, or an error if something goes wrong.
 func NewRandomULID() (string, error) {
 	b1, err := rand.Int(rand.Reader, big.NewInt(randomRange))
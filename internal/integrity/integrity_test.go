@@ -0,0 +1,255 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package integrity_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
+)
+
+func TestCanonicalize(t *testing.T) {
+	for _, test := range []struct {
+		in     string
+		expect string
+	}{
+		{
+			// RFC 8785 section 3.2.2 sample.
+			in:     `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001], "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/", "literals": [null, true, false]}`,
+			expect: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			// RFC 8785 section 3.2.3 sorting sample.
+			in:     `{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh", "1": "One", "\ud83d\ude00": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"}`,
+			expect: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			in:     `[0, -0, 1, -1, 100, 1e21, 1e20, 123456789012345680000, 0.000001, 0.0000001, -1.5e-10]`,
+			expect: `[0,0,1,-1,100,1e+21,100000000000000000000,123456789012345680000,0.000001,1e-7,-1.5e-10]`,
+		},
+		{
+			in:     `{"b": {"d": [], "c": {}}, "a": "<&>"}`,
+			expect: `{"a":"<&>","b":{"c":{},"d":[]}}`,
+		},
+	} {
+		var v any
+		if err := json.Unmarshal([]byte(test.in), &v); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := integrity.Canonicalize(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != test.expect {
+			t.Errorf("unexpected canonical form:\nrecv=%s\nexpct=%s", b, test.expect)
+		}
+	}
+}
+
+func TestMultikey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := integrity.EncodeMultikey(pub)
+	if !strings.HasPrefix(encoded, "z6Mk") {
+		t.Fatalf("unexpected ed25519 multikey prefix: %s", encoded)
+	}
+
+	decoded, err := integrity.DecodeMultikey(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pub.Equal(decoded) {
+		t.Fatalf("decoded multikey did not match original")
+	}
+
+	if _, err := integrity.DecodeMultikey("zQ3s"); err == nil {
+		t.Fatalf("expected error decoding non-ed25519 multikey")
+	}
+}
+
+func TestMultibase(t *testing.T) {
+	for _, in := range [][]byte{
+		{},
+		{0},
+		{0, 0, 1},
+		[]byte("hello world"),
+	} {
+		out, err := integrity.DecodeMultibase(integrity.EncodeMultibase(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != string(in) {
+			t.Errorf("multibase round trip failed: recv=%x expct=%x", out, in)
+		}
+	}
+
+	// Known base58btc value.
+	if enc := integrity.EncodeMultibase([]byte("hello world")); enc != "zStV1DL6CwTryKyV" {
+		t.Errorf("unexpected multibase encoding: %s", enc)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const keyID = "https://example.org/users/someone#ed25519-key"
+
+	doc := map[string]any{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id":       "https://example.org/users/someone/statuses/01HZ/activity",
+		"type":     "Create",
+		"actor":    "https://example.org/users/someone",
+		"to":       []string{"https://www.w3.org/ns/activitystreams#Public"},
+		"object": map[string]any{
+			"id":        "https://example.org/users/someone/statuses/01HZ",
+			"type":      "Note",
+			"content":   "<p>hello world! 🙂</p>",
+			"sensitive": false,
+			"replies":   map[string]any{"totalItems": 0},
+		},
+	}
+
+	signed, err := integrity.Sign(doc, keyID, priv, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := doc["proof"]; ok {
+		t.Fatal("original document was modified")
+	}
+
+	// Round-trip through JSON as a remote would.
+	b, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received map[string]any
+	if err := json.Unmarshal(b, &received); err != nil {
+		t.Fatal(err)
+	}
+
+	proof, err := integrity.Extract(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proof.VerificationMethod != keyID {
+		t.Fatalf("unexpected verification method: %s", proof.VerificationMethod)
+	}
+
+	if !proof.Verify(pub) {
+		t.Fatal("expected proof to verify")
+	}
+
+	// Verifying with another key should fail.
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	if proof.Verify(otherPub) {
+		t.Fatal("expected proof not to verify with other key")
+	}
+
+	// Tamper with the document contents.
+	received["object"].(map[string]any)["content"] = "<p>goodbye world!</p>"
+	proof, err = integrity.Extract(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proof.Verify(pub) {
+		t.Fatal("expected tampered proof not to verify")
+	}
+}
+
+func TestCheckCreated(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for _, test := range []struct {
+		created time.Time
+		expired bool
+	}{
+		{created: now, expired: false},
+		{created: now.Add(-time.Hour), expired: false},
+		{created: now.Add(time.Minute), expired: false},
+		{created: now.Add(-integrity.MaxAge - time.Minute), expired: true},
+		{created: now.Add(integrity.MaxSkew + time.Minute), expired: true},
+	} {
+		signed, err := integrity.Sign(
+			map[string]any{"type": "Create"},
+			"https://example.org/users/someone#ed25519-key",
+			priv,
+			test.created,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proof, err := integrity.Extract(signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = proof.CheckCreated(now)
+		if test.expired && err != integrity.ErrExpired {
+			t.Errorf("expected proof created at %s to be expired", test.created)
+		} else if !test.expired && err != nil {
+			t.Errorf("expected proof created at %s not to be expired, got %v", test.created, err)
+		}
+	}
+
+	// A proof without a creation
+	// time should also be expired.
+	if err := (&integrity.Proof{}).CheckCreated(now); err != integrity.ErrExpired {
+		t.Errorf("expected proof without creation time to be expired, got %v", err)
+	}
+}
+
+func TestExtractNoProof(t *testing.T) {
+	proof, err := integrity.Extract(map[string]any{"type": "Create"})
+	if proof != nil || err != nil {
+		t.Fatalf("expected nil proof and error, got %v %v", proof, err)
+	}
+
+	_, err = integrity.Extract(map[string]any{
+		"type": "Create",
+		"proof": map[string]any{
+			"type":        "RsaSignature2017",
+			"cryptosuite": "rsa-2017",
+		},
+	})
+	if err != integrity.ErrUnsupported {
+		t.Fatalf("expected unsupported error, got %v", err)
+	}
+}
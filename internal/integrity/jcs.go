@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Canonicalize serializes the given value as
+// JSON using the JSON Canonicalization Scheme.
+//
+// The value is expected to be something decoded
+// by encoding/json into an interface{}, ie., made
+// up of maps, slices, strings, float64s, bools and
+// nils. Other types are first round-tripped through
+// encoding/json to coerce them into this form.
+//
+// See: https://www.rfc-editor.org/rfc/rfc8785
+func Canonicalize(v any) ([]byte, error) {
+	var buf strings.Builder
+	if err := appendCanonical(&buf, v); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+func appendCanonical(buf *strings.Builder, v any) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+
+	case string:
+		appendString(buf, v)
+
+	case float64:
+		s, err := formatNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return err
+		}
+		return appendCanonical(buf, f)
+
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := appendCanonical(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+
+	case map[string]any:
+		// Keys must be sorted by
+		// their UTF-16 code units.
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, compareUTF16)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			appendString(buf, key)
+			buf.WriteByte(':')
+			if err := appendCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+
+	default:
+		// Not a generic JSON type,
+		// round-trip it through
+		// encoding/json to coerce.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		var generic any
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return err
+		}
+
+		return appendCanonical(buf, generic)
+	}
+
+	return nil
+}
+
+// appendString appends the given string as a JSON
+// string literal, escaping only what RFC 8785 requires.
+func appendString(buf *strings.Builder, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+				continue
+			}
+			if r == utf8.RuneError {
+				// Invalid UTF-8 gets replaced
+				// with the replacement char.
+				buf.WriteRune(utf8.RuneError)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatNumber formats the given float64 the
+// same way as ECMAScript's Number.toString().
+func formatNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errors.New("integrity: cannot canonicalize NaN or Inf")
+	}
+
+	if f == 0 {
+		// Includes negative zero.
+		return "0", nil
+	}
+
+	var sign string
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	// Get the shortest round-trip
+	// representation in exponent form,
+	// ie., "d.dddde±xx", then split it up.
+	e := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(e, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	n, err := strconv.Atoi(exp)
+	if err != nil {
+		return "", err
+	}
+
+	// In ECMAScript terms, k is the number
+	// of digits and n the decimal exponent
+	// such that value = digits * 10^(n-k).
+	k := len(digits)
+	n++
+
+	var out string
+	switch {
+	case k <= n && n <= 21:
+		out = digits + strings.Repeat("0", n-k)
+
+	case 0 < n && n <= 21:
+		out = digits[:n] + "." + digits[n:]
+
+	case -6 < n && n <= 0:
+		out = "0." + strings.Repeat("0", -n) + digits
+
+	default:
+		expSign := "+"
+		if n-1 < 0 {
+			expSign = "-"
+		}
+		expAbs := strconv.Itoa(abs(n - 1))
+
+		if k == 1 {
+			out = digits + "e" + expSign + expAbs
+		} else {
+			out = digits[:1] + "." + digits[1:] + "e" + expSign + expAbs
+		}
+	}
+
+	return sign + out, nil
+}
+
+// compareUTF16 compares the two strings
+// by their UTF-16 code unit representation.
+func compareUTF16(a, b string) int {
+	return slices.Compare(
+		utf16.Encode([]rune(a)),
+		utf16.Encode([]rune(b)),
+	)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
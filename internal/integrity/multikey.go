@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// base58btc alphabet, as used by multibase.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// multibase prefix for base58btc encoded data.
+const multibaseBase58BTC = 'z'
+
+// multicodec prefix for an ed25519 public key.
+var ed25519PubPrefix = []byte{0xed, 0x01}
+
+// EncodeMultikey encodes the given ed25519 public
+// key for use as a Multikey 'publicKeyMultibase'.
+//
+// See: https://www.w3.org/TR/controller-document/#multikey
+func EncodeMultikey(pub ed25519.PublicKey) string {
+	b := make([]byte, 0, len(ed25519PubPrefix)+len(pub))
+	b = append(b, ed25519PubPrefix...)
+	b = append(b, pub...)
+	return EncodeMultibase(b)
+}
+
+// DecodeMultikey decodes the given 'publicKeyMultibase'
+// value of a Multikey into an ed25519 public key.
+func DecodeMultikey(s string) (ed25519.PublicKey, error) {
+	b, err := DecodeMultibase(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != len(ed25519PubPrefix)+ed25519.PublicKeySize ||
+		b[0] != ed25519PubPrefix[0] || b[1] != ed25519PubPrefix[1] {
+		return nil, errors.New("integrity: multikey is not an ed25519 public key")
+	}
+
+	return ed25519.PublicKey(b[len(ed25519PubPrefix):]), nil
+}
+
+// EncodeMultibase encodes the given
+// data as a base58btc multibase string.
+func EncodeMultibase(b []byte) string {
+	var buf strings.Builder
+	buf.WriteByte(multibaseBase58BTC)
+
+	// Leading zero bytes are
+	// each encoded as a '1'.
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		buf.WriteByte(base58Alphabet[0])
+	}
+
+	var (
+		x    = new(big.Int).SetBytes(b)
+		base = big.NewInt(58)
+		mod  = new(big.Int)
+		out  []byte
+	)
+
+	for x.Sign() > 0 {
+		x.DivMod(x, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// Digits were generated least
+	// significant first, so reverse.
+	for i := len(out) - 1; i >= 0; i-- {
+		buf.WriteByte(out[i])
+	}
+
+	return buf.String()
+}
+
+// DecodeMultibase decodes the given
+// base58btc multibase encoded string.
+func DecodeMultibase(s string) ([]byte, error) {
+	if len(s) == 0 || s[0] != multibaseBase58BTC {
+		return nil, errors.New("integrity: unsupported multibase encoding")
+	}
+	s = s[1:]
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	var (
+		x    = new(big.Int)
+		base = big.NewInt(58)
+	)
+
+	for i := zeros; i < len(s); i++ {
+		idx := strings.IndexByte(base58Alphabet, s[i])
+		if idx < 0 {
+			return nil, errors.New("integrity: invalid base58btc character")
+		}
+		x.Mul(x, base)
+		x.Add(x, big.NewInt(int64(idx)))
+	}
+
+	b := x.Bytes()
+	out := make([]byte, zeros+len(b))
+	copy(out[zeros:], b)
+	return out, nil
+}
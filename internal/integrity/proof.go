@@ -0,0 +1,309 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package integrity implements FEP-8b32 object integrity
+// proofs for ActivityPub documents, using the Data Integrity
+// 'eddsa-jcs-2022' cryptosuite with Ed25519 keys.
+//
+// See: https://codeberg.org/fediverse/fep/src/branch/main/fep/8b32/fep-8b32.md
+package integrity
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"maps"
+	"time"
+)
+
+const (
+	// ContextDataIntegrity is the JSON-LD
+	// context for Data Integrity proofs.
+	ContextDataIntegrity = "https://w3id.org/security/data-integrity/v1"
+
+	// ContextMultikey is the JSON-LD context
+	// for Multikey verification methods.
+	ContextMultikey = "https://w3id.org/security/multikey/v1"
+
+	// ProofType is the only proof type supported.
+	ProofType = "DataIntegrityProof"
+
+	// Cryptosuite is the only cryptosuite supported.
+	Cryptosuite = "eddsa-jcs-2022"
+
+	// ProofPurpose is the purpose set on our
+	// proofs, matching the 'assertionMethod'
+	// relationship key is published under.
+	ProofPurpose = "assertionMethod"
+
+	// MaxAge is how long after creation a proof
+	// is still accepted, bounding the window in
+	// which a captured activity can be replayed.
+	// Matches Mastodon's http signature window.
+	MaxAge = 12 * time.Hour
+
+	// MaxSkew is how far in the future a proof's
+	// creation time may be, to allow for clocks
+	// that aren't quite in sync.
+	MaxSkew = time.Hour
+)
+
+var (
+	// ErrUnsupported is returned when a proof was
+	// found but is not of a type we can verify.
+	ErrUnsupported = errors.New("integrity: unsupported proof")
+
+	// ErrMalformed is returned when a proof or the
+	// document it is attached to cannot be parsed.
+	ErrMalformed = errors.New("integrity: malformed proof")
+
+	// ErrExpired is returned when a proof has no
+	// creation time, or one outside of the window
+	// given by MaxAge and MaxSkew.
+	ErrExpired = errors.New("integrity: expired proof")
+)
+
+// Proof is a parsed integrity proof
+// extracted from an ActivityPub document,
+// ready to verify against a public key.
+type Proof struct {
+	// VerificationMethod is the ID of the
+	// key that the proof claims to be from.
+	VerificationMethod string
+
+	// Created is when the proof
+	// claims to have been created.
+	Created time.Time
+
+	// signature decoded from 'proofValue'.
+	signature []byte
+
+	// hash data that the
+	// signature was made over.
+	hashData []byte
+}
+
+// Verify returns whether the proof
+// was created using the given key.
+func (p *Proof) Verify(pub ed25519.PublicKey) bool {
+	if len(pub) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(pub, p.hashData, p.signature)
+}
+
+// CheckCreated returns ErrExpired if the proof has
+// no creation time, or if, as of the given time, it
+// was created more than MaxAge ago or more than
+// MaxSkew in the future.
+func (p *Proof) CheckCreated(now time.Time) error {
+	if p.Created.IsZero() ||
+		p.Created.Before(now.Add(-MaxAge)) ||
+		p.Created.After(now.Add(MaxSkew)) {
+		return ErrExpired
+	}
+	return nil
+}
+
+// Sign returns a shallow copy of the given document
+// with an 'eddsa-jcs-2022' proof attached, created
+// using the given key ID and private key. The data
+// integrity context will be added to '@context'.
+//
+// The given document is not modified, so it is
+// safe to pass the same document to Sign() in
+// multiple goroutines.
+func Sign(
+	doc map[string]any,
+	keyID string,
+	key ed25519.PrivateKey,
+	created time.Time,
+) (map[string]any, error) {
+	// Copy the document, dropping
+	// any pre-existing proof.
+	doc = maps.Clone(doc)
+	delete(doc, "proof")
+	doc["@context"] = appendContext(doc["@context"], ContextDataIntegrity)
+
+	proof := map[string]any{
+		"type":               ProofType,
+		"cryptosuite":        Cryptosuite,
+		"verificationMethod": keyID,
+		"proofPurpose":       ProofPurpose,
+		"created":            created.UTC().Format(time.RFC3339),
+	}
+
+	hashData, err := hash(doc, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	proof["proofValue"] = EncodeMultibase(ed25519.Sign(key, hashData))
+	doc["proof"] = proof
+
+	return doc, nil
+}
+
+// Extract extracts an integrity proof from the
+// given document, preparing it for verification.
+// The document is not modified.
+//
+// If the document has no proof, nil is returned.
+// If the proof is not one we support, ErrUnsupported
+// is returned; if it can't be parsed, ErrMalformed.
+func Extract(doc map[string]any) (*Proof, error) {
+	raw, ok := doc["proof"]
+	if !ok {
+		return nil, nil
+	}
+
+	// A document may have multiple proofs,
+	// in which case we look for ours.
+	var proof map[string]any
+	switch raw := raw.(type) {
+	case map[string]any:
+		proof = raw
+	case []any:
+		for _, p := range raw {
+			if p, ok := p.(map[string]any); ok &&
+				p["cryptosuite"] == Cryptosuite {
+				proof = p
+				break
+			}
+		}
+	}
+
+	if proof == nil ||
+		proof["type"] != ProofType ||
+		proof["cryptosuite"] != Cryptosuite {
+		return nil, ErrUnsupported
+	}
+
+	if proof["proofPurpose"] != ProofPurpose {
+		return nil, ErrUnsupported
+	}
+
+	keyID, _ := proof["verificationMethod"].(string)
+	value, _ := proof["proofValue"].(string)
+	if keyID == "" || value == "" {
+		return nil, ErrMalformed
+	}
+
+	signature, err := DecodeMultibase(value)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, ErrMalformed
+	}
+
+	var created time.Time
+	if s, ok := proof["created"].(string); ok {
+		created, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, ErrMalformed
+		}
+	}
+
+	// Rebuild the unsecured document
+	// and the proof options that were
+	// used when creating the signature.
+	unsecured := maps.Clone(doc)
+	delete(unsecured, "proof")
+
+	options := maps.Clone(proof)
+	delete(options, "proofValue")
+
+	hashData, err := hash(unsecured, options)
+	if err != nil {
+		return nil, errors.Join(ErrMalformed, err)
+	}
+
+	return &Proof{
+		VerificationMethod: keyID,
+		Created:            created,
+		signature:          signature,
+		hashData:           hashData,
+	}, nil
+}
+
+// hash returns the data to sign / verify for the given
+// unsecured document and proof options, as described in
+// the eddsa-jcs-2022 transformation and hashing steps.
+func hash(unsecured, options map[string]any) ([]byte, error) {
+	if ctx, ok := unsecured["@context"]; ok {
+		// Proof configuration takes on
+		// the context of the document.
+		options = maps.Clone(options)
+		options["@context"] = ctx
+	}
+
+	canonicalDoc, err := Canonicalize(unsecured)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalOptions, err := Canonicalize(options)
+	if err != nil {
+		return nil, err
+	}
+
+	optionsHash := sha256.Sum256(canonicalOptions)
+	docHash := sha256.Sum256(canonicalDoc)
+
+	hashData := make([]byte, 0, 2*sha256.Size)
+	hashData = append(hashData, optionsHash[:]...)
+	hashData = append(hashData, docHash[:]...)
+	return hashData, nil
+}
+
+// appendContext appends the given JSON-LD
+// context to existing context value, if
+// it isn't already present.
+func appendContext(existing any, context string) any {
+	switch existing := existing.(type) {
+	case nil:
+		return context
+
+	case string:
+		if existing == context {
+			return existing
+		}
+		return []any{existing, context}
+
+	case []any:
+		for _, c := range existing {
+			if c == context {
+				return existing
+			}
+		}
+		// Copy to avoid touching
+		// the original's array.
+		ctxs := make([]any, 0, len(existing)+1)
+		ctxs = append(ctxs, existing...)
+		return append(ctxs, context)
+
+	default:
+		// Probably a single
+		// embedded context map.
+		return []any{existing, context}
+	}
+}
+
+// AppendContext appends the given JSON-LD context
+// to the '@context' of the given document, if it
+// isn't already present. The document is modified.
+func AppendContext(doc map[string]any, context string) {
+	doc["@context"] = appendContext(doc["@context"], context)
+}
@@ -0,0 +1,540 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package language
+
+import (
+	"embed"
+	"io/fs"
+	"math"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// Minimum number of letters in a text before
+	// trying to detect its language at all. Letters
+	// of ideographic scripts are weighted, see below.
+	minLetters = 20
+
+	// Letters of ideographic scripts carry much more
+	// information each, so count for more towards
+	// minLetters (and overrideLetters).
+	ideographWeight = 3
+
+	// Minimum confidence to use a detected
+	// language when none was given at all.
+	detectConfidence = 0.9
+
+	// Minimum confidence and number of letters
+	// to override a language that *was* given.
+	// This is deliberately strict, as clients
+	// usually know better than we do.
+	overrideConfidence = 0.98
+	overrideLetters    = 60
+
+	// Han without any kana could be Chinese,
+	// Japanese or something else, so detections
+	// from Han alone are capped at this confidence,
+	// which is never enough to override a given tag.
+	hanConfidence = 0.9
+
+	// Trigrams are far from independent, so
+	// scores are damped by this factor before
+	// being turned into probabilities, to avoid
+	// every detection looking near-certain.
+	scoreDamping = 3
+
+	// The models only know a handful of languages,
+	// and will happily pick the nearest of them for
+	// text in any other. So text is only classified
+	// if at least this share of its trigrams were
+	// seen in training by the best model...
+	minFit = 0.35
+
+	// ...if no more than this share of its letters
+	// never appeared in that model's training text...
+	maxUnseenLetters = 0.025
+
+	// ...and if the best model beats the runner-up
+	// by at least this much log probability per
+	// trigram, on average.
+	minMargin = 0.05
+)
+
+// Training texts for n-gram language models,
+// one file per language named by its base tag.
+//
+//go:embed corpus/*.txt
+var corpora embed.FS
+
+// scripts are the writing systems that text is
+// sorted into. Letters from other scripts still
+// count as letters, but will never be detected.
+var scripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Arabic", unicode.Arabic},
+	{"Han", unicode.Han},
+	{"Kana", unicode.Hiragana},
+	{"Kana", unicode.Katakana},
+	{"Hangul", unicode.Hangul},
+	{"Greek", unicode.Greek},
+	{"Hebrew", unicode.Hebrew},
+	{"Thai", unicode.Thai},
+	{"Devanagari", unicode.Devanagari},
+	{"Georgian", unicode.Georgian},
+	{"Armenian", unicode.Armenian},
+}
+
+// scriptLanguages are languages detected by
+// their script alone, without any n-gram model.
+var scriptLanguages = map[string]string{
+	"Hangul":     "ko",
+	"Greek":      "el",
+	"Hebrew":     "he",
+	"Thai":       "th",
+	"Devanagari": "hi",
+	"Georgian":   "ka",
+	"Armenian":   "hy",
+}
+
+// Detection is the result of guessing
+// the language that a text is written in.
+type Detection struct {
+	// Base BCP47 tag of the detected language.
+	Language string
+
+	// Confidence in the detection, from 0 to 1.
+	Confidence float64
+
+	// Weighted letter count of the text.
+	letters int
+}
+
+// Detect guesses the language of the given plaintext,
+// using trigram models for languages that share a script,
+// and the script alone for the rest. Mentions, hashtags,
+// links and emoji shortcodes are ignored. Returns false
+// if the text is too short, or of an unknown script.
+func Detect(text string) (Detection, bool) {
+	words, counts := tokenize(text)
+
+	// Kana is mixed with Han in Japanese,
+	// so count it alongside Han to find the
+	// dominant script, but remember how much.
+	kana := counts["Kana"]
+	counts["Han"] += kana
+	delete(counts, "Kana")
+
+	var (
+		script  string
+		letters int
+		total   int
+	)
+
+	for name, count := range counts {
+		total += count
+		if name == "" {
+			// Unknown script.
+			continue
+		}
+
+		if count > letters || (count == letters && name < script) {
+			script, letters = name, count
+		}
+	}
+
+	weighted := letters
+	if script == "Han" || script == "Hangul" {
+		weighted *= ideographWeight
+	}
+
+	if script == "" || weighted < minLetters {
+		return Detection{}, false
+	}
+
+	// Confidence can't be better
+	// than the share of letters
+	// in the dominant script.
+	share := float64(letters) / float64(total)
+
+	detection := Detection{letters: weighted}
+	switch lang, ok := scriptLanguages[script]; {
+	case ok:
+		detection.Language = lang
+		detection.Confidence = share
+
+	case script == "Han" && kana*10 >= letters:
+		detection.Language = "ja"
+		detection.Confidence = share
+
+	case script == "Han":
+		detection.Language = "zh"
+		detection.Confidence = min(share, hanConfidence)
+
+	default:
+		lang, probability := loadModels().classify(script, words)
+		if lang == "" {
+			return Detection{}, false
+		}
+
+		detection.Language = lang
+		detection.Confidence = share * probability
+	}
+
+	return detection, true
+}
+
+// Resolve returns the language to store for a status with the given
+// language tag (if any) and plaintext. With no given tag, a confident
+// detection is returned, or an empty string if there's none. A given
+// tag is only replaced if detection very strongly contradicts it, and
+// the given language is one that could have been detected instead.
+func Resolve(given string, text string) string {
+	detection, ok := Detect(text)
+
+	if given == "" {
+		if ok && detection.Confidence >= detectConfidence {
+			return detection.Language
+		}
+		return ""
+	}
+
+	if !ok ||
+		detection.Confidence < overrideConfidence ||
+		detection.letters < overrideLetters {
+		return given
+	}
+
+	base := baseOf(given)
+	if base == "" || base == detection.Language {
+		return given
+	}
+
+	if _, detectable := loadModels().detectable[base]; !detectable {
+		// We wouldn't be able to tell
+		// anyway, so give benefit of
+		// the doubt to the given tag.
+		return given
+	}
+
+	return detection.Language
+}
+
+// baseOf returns the base language of the given
+// tag, with Norwegian variants folded into "no".
+func baseOf(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return ""
+	}
+
+	base, _ := parsed.Base()
+	switch b := base.String(); b {
+	case "nb", "nn":
+		return "no"
+	default:
+		return b
+	}
+}
+
+// tokenize splits text into lowercase words of letters,
+// skipping mentions, hashtags, links and emoji shortcodes,
+// and counts letters of each script in those words.
+func tokenize(text string) ([]string, map[string]int) {
+	var (
+		words  []string
+		counts = make(map[string]int)
+	)
+
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "@") ||
+			strings.HasPrefix(field, "#") ||
+			strings.HasPrefix(field, ":") ||
+			strings.HasPrefix(field, "www.") ||
+			strings.Contains(field, "://") {
+			continue
+		}
+
+		for _, word := range strings.FieldsFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r)
+		}) {
+			word = strings.ToLower(word)
+			for _, r := range word {
+				if unicode.IsLetter(r) {
+					counts[scriptOf(r)]++
+				}
+			}
+			words = append(words, word)
+		}
+	}
+
+	return words, counts
+}
+
+// scriptOf returns the name of the script of
+// rune r, or an empty string if it's unknown.
+func scriptOf(r rune) string {
+	for _, script := range scripts {
+		if unicode.Is(script.table, r) {
+			return script.name
+		}
+	}
+	return ""
+}
+
+// trigrams calls fn for each trigram of the given
+// word, padded with a space on either side.
+func trigrams(word string, fn func(trigram string)) {
+	runes := []rune(" " + word + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		fn(string(runes[i : i+3]))
+	}
+}
+
+// model is a trigram model of one language.
+type model struct {
+	lang   string
+	script string
+
+	// Log probability of each trigram
+	// seen in training, and of any other.
+	logProbs map[string]float64
+	floor    float64
+
+	// Letters seen in training.
+	letters map[rune]struct{}
+}
+
+// models contains all trained
+// language models, by script.
+type models struct {
+	byScript   map[string][]*model
+	detectable map[string]struct{}
+}
+
+var (
+	trained     *models
+	trainedOnce sync.Once
+)
+
+// loadModels returns language models,
+// training them on first use.
+func loadModels() *models {
+	trainedOnce.Do(func() {
+		trained = train(corpora)
+	})
+	return trained
+}
+
+// train builds trigram models from the given
+// corpora, which must be valid, as embedded.
+func train(corpora fs.ReadDirFS) *models {
+	entries, err := corpora.ReadDir("corpus")
+	if err != nil {
+		panic(err)
+	}
+
+	var (
+		counts = make([]map[string]int, len(entries))
+		all    = make([]*model, len(entries))
+		vocab  = make(map[string]struct{})
+	)
+
+	for i, entry := range entries {
+		b, err := fs.ReadFile(corpora, path.Join("corpus", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		words, letters := tokenize(string(b))
+		counts[i] = make(map[string]int)
+		for _, word := range words {
+			trigrams(word, func(trigram string) {
+				counts[i][trigram]++
+				vocab[trigram] = struct{}{}
+			})
+		}
+
+		// Script of a model is the
+		// dominant one in its corpus.
+		var script string
+		for s, n := range letters {
+			if n > letters[script] {
+				script = s
+			}
+		}
+
+		all[i] = &model{
+			lang:    strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())),
+			script:  script,
+			letters: make(map[rune]struct{}),
+		}
+
+		for _, word := range words {
+			for _, r := range word {
+				all[i].letters[r] = struct{}{}
+			}
+		}
+	}
+
+	ms := &models{
+		byScript:   make(map[string][]*model),
+		detectable: make(map[string]struct{}),
+	}
+
+	// Add-half smoothing across
+	// the vocab of all languages.
+	const alpha = 0.5
+	v := float64(len(vocab))
+	for i, m := range all {
+		var total int
+		for _, n := range counts[i] {
+			total += n
+		}
+
+		denominator := float64(total) + alpha*v
+		m.logProbs = make(map[string]float64, len(counts[i]))
+		for trigram, n := range counts[i] {
+			m.logProbs[trigram] = math.Log((float64(n) + alpha) / denominator)
+		}
+		m.floor = math.Log(alpha / denominator)
+
+		ms.byScript[m.script] = append(ms.byScript[m.script], m)
+		ms.detectable[m.lang] = struct{}{}
+	}
+
+	for _, lang := range scriptLanguages {
+		ms.detectable[lang] = struct{}{}
+	}
+	ms.detectable["ja"] = struct{}{}
+	ms.detectable["zh"] = struct{}{}
+
+	return ms
+}
+
+// classify returns the most likely language of
+// the given words out of those with models in
+// the given script, along with its probability.
+// Returns an empty string if the words don't fit
+// any of the models well enough, or if the best
+// model isn't clearly better than the runner-up.
+func (ms *models) classify(script string, words []string) (string, float64) {
+	candidates := ms.byScript[script]
+	switch len(candidates) {
+	case 0:
+		return "", 0
+	case 1:
+		return candidates[0].lang, 1
+	}
+
+	var (
+		scores = make([]float64, len(candidates))
+		seen   = make([]int, len(candidates))
+		count  int
+		kept   []string
+	)
+
+	for _, word := range words {
+		if r := []rune(word); scriptOf(r[0]) != script {
+			// Ignore words from
+			// other scripts.
+			continue
+		}
+		kept = append(kept, word)
+
+		trigrams(word, func(trigram string) {
+			count++
+			for i, m := range candidates {
+				logProb, ok := m.logProbs[trigram]
+				if ok {
+					seen[i]++
+				} else {
+					logProb = m.floor
+				}
+				scores[i] += logProb
+			}
+		})
+	}
+
+	if count == 0 {
+		return "", 0
+	}
+
+	best, runnerUp := 0, 1
+	if scores[runnerUp] > scores[best] {
+		best, runnerUp = runnerUp, best
+	}
+	for i := 2; i < len(scores); i++ {
+		switch {
+		case scores[i] > scores[best]:
+			best, runnerUp = i, best
+		case scores[i] > scores[runnerUp]:
+			runnerUp = i
+		}
+	}
+
+	if float64(seen[best])/float64(count) < minFit ||
+		(scores[best]-scores[runnerUp])/float64(count) < minMargin ||
+		candidates[best].unseen(kept) > maxUnseenLetters {
+		// Probably a language
+		// we don't know at all.
+		return "", 0
+	}
+
+	// Softmax of damped scores,
+	// relative to the best one.
+
+	var sum float64
+	for _, score := range scores {
+		sum += math.Exp((score - scores[best]) / scoreDamping)
+	}
+
+	return candidates[best].lang, 1 / sum
+}
+
+// unseen returns the share of letters in the given
+// words that never appeared in the model's training.
+func (m *model) unseen(words []string) float64 {
+	var unseen, total int
+	for _, word := range words {
+		for _, r := range word {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+
+			total++
+			if _, ok := m.letters[r]; !ok {
+				unseen++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(unseen) / float64(total)
+}
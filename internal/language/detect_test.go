@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package language_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/language"
+)
+
+func TestDetect(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected string
+	}{
+		{"I just finished reading that new novel everyone keeps talking about, and honestly the ending was a bit disappointing.", "en"},
+		{"Ich habe gerade den neuen Roman fertig gelesen, über den alle reden, und ehrlich gesagt war das Ende etwas enttäuschend.", "de"},
+		{"Je viens de finir le nouveau roman dont tout le monde parle, et franchement la fin était un peu décevante.", "fr"},
+		{"Acabo de terminar la nueva novela de la que todo el mundo habla y, sinceramente, el final fue un poco decepcionante.", "es"},
+		{"Ho appena finito di leggere il nuovo romanzo di cui parlano tutti, e sinceramente il finale è stato un po' deludente.", "it"},
+		{"Acabei de ler o novo romance de que toda a gente fala e, sinceramente, o final foi um pouco dececionante.", "pt"},
+		{"Ik heb net de nieuwe roman uitgelezen waar iedereen het over heeft, en eerlijk gezegd was het einde een beetje teleurstellend.", "nl"},
+		{"Jag har precis läst klart den nya romanen som alla pratar om, och ärligt talat var slutet lite en besvikelse.", "sv"},
+		{"Właśnie skończyłem czytać nową powieść, o której wszyscy mówią, i szczerze mówiąc zakończenie było trochę rozczarowujące.", "pl"},
+		{"Yeni çıkan ve herkesin konuştuğu romanı az önce bitirdim, açıkçası sonu biraz hayal kırıklığıydı.", "tr"},
+		{"Saya baru saja selesai membaca novel baru yang dibicarakan semua orang, dan jujur saja akhirnya agak mengecewakan.", "id"},
+		{"Я только что дочитал новый роман, о котором все говорят, и честно говоря, концовка немного разочаровала.", "ru"},
+		{"Я щойно дочитав новий роман, про який усі говорять, і чесно кажучи, кінцівка трохи розчарувала.", "uk"},
+		{"لقد انتهيت للتو من قراءة الرواية الجديدة التي يتحدث عنها الجميع، وبصراحة كانت النهاية مخيبة للآمال قليلا.", "ar"},
+		{"همین الان رمان تازه‌ای را که همه درباره‌اش حرف می‌زنند تمام کردم و راستش پایانش کمی ناامیدکننده بود.", "fa"},
+		{"みんなが話題にしている新しい小説をちょうど読み終えたけど、正直なところ結末は少し残念だった。", "ja"},
+		{"我刚读完大家都在讨论的那本新小说，说实话结局有点令人失望。", "zh"},
+		{"모두가 이야기하는 새 소설을 방금 다 읽었는데, 솔직히 결말이 조금 실망스러웠어요.", "ko"},
+		{"Μόλις τελείωσα το καινούργιο μυθιστόρημα για το οποίο μιλάνε όλοι, και ειλικρινά το τέλος ήταν λίγο απογοητευτικό.", "el"},
+		{"@someone@example.org Je viens de finir le nouveau roman dont tout le monde parle https://example.org/some/english/words #books", "fr"},
+	} {
+		detection, ok := language.Detect(test.text)
+		if !ok {
+			t.Errorf("expected %s detection for %q, got none", test.expected, test.text)
+			continue
+		}
+
+		if detection.Language != test.expected {
+			t.Errorf("expected %s for %q, got %s (%.2f)", test.expected, test.text, detection.Language, detection.Confidence)
+		}
+	}
+}
+
+func TestDetectTooShort(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"lol",
+		"good morning",
+		"@someone@example.org https://example.org/a/long/link/that/should/not/count",
+		"12345 67890 !!! :blobcat: :blobfox:",
+	} {
+		if detection, ok := language.Detect(text); ok {
+			t.Errorf("expected no detection for %q, got %s", text, detection.Language)
+		}
+	}
+}
+
+func TestResolveUnknown(t *testing.T) {
+	// Languages in scripts we have models for,
+	// but which aren't any of those languages.
+	for _, text := range []string{
+		// Vietnamese.
+		"Tôi vừa đọc xong cuốn tiểu thuyết mới mà mọi người đang bàn tán, và thật lòng mà nói thì cái kết hơi đáng thất vọng.",
+		// Estonian.
+		"Lugesin just läbi selle uue romaani, millest kõik räägivad, ja ausalt öeldes oli lõpp pisut pettumust valmistav.",
+		"Ilm oli sel nädalal imeline, nii et käisime koeraga pikalt jõe ääres jalutamas.",
+		// Latvian.
+		"Es tikko izlasīju jauno romānu, par kuru visi runā, un godīgi sakot, beigas bija nedaudz vilšanos radošas.",
+		// Slovene.
+		"Pravkar sem prebral novi roman, o katerem vsi govorijo, in iskreno povedano je bil konec nekoliko razočaranje.",
+		"Vreme je bilo ta teden čudovito, zato smo se s psom odpravili na dolg sprehod ob reki.",
+		// Swahili.
+		"Nimemaliza kusoma riwaya mpya ambayo kila mtu anaizungumzia, na kusema kweli mwisho wake ulikuwa wa kukatisha tamaa kidogo.",
+		"Hali ya hewa imekuwa nzuri sana wiki hii, kwa hiyo tulitembea kwa muda mrefu kando ya mto pamoja na mbwa.",
+		// Icelandic.
+		"Ég var að klára að lesa nýju skáldsöguna sem allir eru að tala um, og satt að segja var endirinn svolítið vonbrigði.",
+		// Croatian.
+		"Upravo sam pročitao novi roman o kojem svi pričaju, i iskreno rečeno kraj je bio pomalo razočaravajući.",
+		// Slovak.
+		"Práve som dočítal ten nový román, o ktorom všetci hovoria, a úprimne povedané koniec bol trochu sklamaním.",
+	} {
+		if resolved := language.Resolve("", text); resolved != "" {
+			t.Errorf("expected no language for %q, got %s", text, resolved)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	const (
+		german  = "Ich habe gerade den neuen Roman fertig gelesen, über den alle reden, und ehrlich gesagt war das Ende etwas enttäuschend."
+		chinese = "我刚读完大家都在讨论的那本新小说，说实话结局有点令人失望。我刚读完大家都在讨论的那本新小说。"
+	)
+
+	for _, test := range []struct {
+		given    string
+		text     string
+		expected string
+	}{
+		// Nothing given, detected.
+		{"", german, "de"},
+		// Nothing given, nothing detected.
+		{"", "good morning", ""},
+		// Given agrees with detection.
+		{"de-AT", german, "de-AT"},
+		// Given strongly contradicted.
+		{"en", german, "de"},
+		// Given contradicted, but text too short to be sure.
+		{"en", "Ich habe gerade den neuen Roman fertig gelesen.", "en"},
+		// Given language can't be detected, so trust it.
+		{"eo", german, "eo"},
+		// Han alone never overrides.
+		{"ja", chinese, "ja"},
+	} {
+		if resolved := language.Resolve(test.given, test.text); resolved != test.expected {
+			t.Errorf("expected %q for given %q and text %q, got %q", test.expected, test.given, test.text, resolved)
+		}
+	}
+}
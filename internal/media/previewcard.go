@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"image/jpeg"
+	"io"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// CachePreviewCardImage dereferences the preview image
+// for given card using the given data function, and stores
+// a resized JPEG thumbnail of it in storage, updating the
+// image fields of the card. Any previously stored thumbnail
+// for the card is removed. Note the card itself is NOT
+// updated in the database, that's left to the caller.
+func (m *Manager) CachePreviewCardImage(
+	ctx context.Context,
+	card *gtsmodel.PreviewCard,
+	data DataFunc,
+) error {
+	// Fetch the local instance account for card path generation.
+	instanceAcc, err := m.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error fetching instance account: %w", err)
+	}
+
+	// Load image from provided data func.
+	rc, sz, err := data(ctx)
+	if err != nil {
+		return gtserror.Newf("error executing data function: %w", err)
+	}
+
+	defer func() {
+		// Ensure data reader gets closed on return.
+		if err := rc.Close(); err != nil {
+			log.Errorf(ctx, "error closing data reader: %v", err)
+		}
+	}()
+
+	// Preview images are subject to the same
+	// limits as any other image we'd accept.
+	maxSize := config.GetMediaImageMaxSize()
+
+	// Check that provided size isn't beyond max. We check beforehand
+	// so that we don't attempt to decode the image if not needed.
+	if sz > 0 && sz > int64(maxSize) {
+		sz := bytesize.Size(sz) // improves log readability
+		return gtserror.Newf("given image size %s greater than max allowed %s", sz, maxSize)
+	}
+
+	// Decode the image, limiting the reader in
+	// case the given size was misreported. This
+	// handles jpeg, png, gif and webp for us.
+	img, err := decodeImage(
+		io.LimitReader(rc, int64(maxSize)),
+		imaging.AutoOrientation(true),
+	)
+	if err != nil {
+		return gtserror.Newf("error decoding image: %w", err)
+	}
+
+	// Get smaller thumbnail image.
+	thumb := img.Thumbnail()
+	img = nil
+
+	// Generate blurhash from thumb.
+	hash, err := thumb.Blurhash()
+	if err != nil {
+		return gtserror.Newf("error generating blurhash: %w", err)
+	}
+
+	// Use a new path ID on every (re)cache,
+	// to get around needing to do browser
+	// cache invalidation of the old image.
+	pathID, err := id.NewRandomULID()
+	if err != nil {
+		return gtserror.Newf("error generating path id: %w", err)
+	}
+
+	// Calculate new thumbnail path.
+	path := uris.StoragePathForAttachment(
+		instanceAcc.ID,
+		string(TypeCard),
+		string(SizeSmall),
+		pathID,
+
+		// Always encode card
+		// thumbnails as jpg.
+		"jpg",
+	)
+
+	// Stream-encode the JPEG thumbnail image into our storage driver.
+	fileSize, err := m.state.Storage.PutStream(ctx, path, thumb.ToJPEG(&jpeg.Options{
+
+		// Good enough for
+		// a thumbnail.
+		Quality: 70,
+	}))
+	if err != nil {
+		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
+	}
+
+	if card.ImagePath != "" {
+		// Remove the *old* thumbnail now the new one is stored.
+		if err := m.state.Storage.Delete(ctx, card.ImagePath); err != nil &&
+			!storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting old card image %s from storage: %v", card.ImagePath, err)
+		}
+	}
+
+	// Set the new thumbnail details on card.
+	card.ImagePath = path
+	card.ImageURL = uris.URIForAttachment(
+		instanceAcc.ID,
+		string(TypeCard),
+		string(SizeSmall),
+		pathID,
+		"jpg",
+	)
+	card.ImageContentType = mimeImageJpeg
+	card.ImageFileSize = int(fileSize)
+	card.ImageWidth = thumb.Width()
+	card.ImageHeight = thumb.Height()
+	card.Blurhash = hash
+
+	return nil
+}
+
+// UncachePreviewCardImage removes the stored preview
+// image thumbnail for the given card, if any, unsetting
+// the image fields of the card. Note the card itself is
+// NOT updated in the database, that's left to the caller.
+func (m *Manager) UncachePreviewCardImage(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	if card.ImagePath == "" {
+		// Nothing to do.
+		return nil
+	}
+
+	// Remove the thumbnail from storage.
+	if err := m.state.Storage.Delete(ctx, card.ImagePath); err != nil &&
+		!storage.IsNotFound(err) {
+		return gtserror.Newf("error deleting card image %s from storage: %w", card.ImagePath, err)
+	}
+
+	// Unset image details on card.
+	card.ImagePath = ""
+	card.ImageURL = ""
+	card.ImageContentType = ""
+	card.ImageFileSize = 0
+	card.ImageWidth = 0
+	card.ImageHeight = 0
+	card.Blurhash = ""
+
+	return nil
+}
@@ -59,6 +59,7 @@ const (
 	TypeHeader     Type = "header"     // TypeHeader is the key for profile header requests
 	TypeAvatar     Type = "avatar"     // TypeAvatar is the key for profile avatar requests
 	TypeEmoji      Type = "emoji"      // TypeEmoji is the key for emoji type requests
+	TypeCard       Type = "card"       // TypeCard is the key for preview card image requests
 )
 
 // AdditionalMediaInfo represents additional information that
@@ -380,6 +380,8 @@ func resolveGTSModel(typ string, data []byte) (interface{}, error) {
 		value = new(gtsmodel.Status)
 	case reflect.TypeOf((*gtsmodel.StatusFave)(nil)).String():
 		value = new(gtsmodel.StatusFave)
+	case reflect.TypeOf((*gtsmodel.StatusReaction)(nil)).String():
+		value = new(gtsmodel.StatusReaction)
 	default:
 		return nil, gtserror.Newf("unknown type: %s", typ)
 	}
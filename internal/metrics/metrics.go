@@ -30,6 +30,7 @@ import (
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -41,6 +42,10 @@ const (
 	serviceName = "GoToSocial"
 )
 
+// policyActions counts actions taken by federation
+// policies, nil if metrics aren't initialized.
+var policyActions metric.Int64Counter
+
 func Initialize(db db.DB) error {
 	if !config.GetMetricsEnabled() {
 		return nil
@@ -124,9 +129,30 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
+	policyActions, err = meter.Int64Counter(
+		"gotosocial.federation.policy_actions",
+		metric.WithDescription("Number of incoming activities rejected or rewritten by federation policies"),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// FederationPolicyAction records that a federation policy of
+// the given type took the given action on an incoming activity.
+func FederationPolicyAction(ctx context.Context, policyType string, action string) {
+	if policyActions == nil {
+		return
+	}
+
+	policyActions.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("policy", policyType),
+		attribute.String("action", action),
+	))
+}
+
 func InstrumentGin() gin.HandlerFunc {
 	return otelginmetrics.Middleware(serviceName)
 }
@@ -20,6 +20,7 @@
 package metrics
 
 import (
+	"context"
 	"errors"
 
 	"github.com/gin-gonic/gin"
@@ -35,6 +36,8 @@ func Initialize(db db.DB) error {
 	return nil
 }
 
+func FederationPolicyAction(ctx context.Context, policyType string, action string) {}
+
 func InstrumentGin() gin.HandlerFunc {
 	return func(c *gin.Context) {}
 }
@@ -31,5 +31,14 @@ func Gzip() gin.HandlerFunc {
 		return func(ctx *gin.Context) {}
 	}
 
-	return gzip.Gzip(gzip.DefaultCompression)
+	return gzip.Gzip(
+		gzip.DefaultCompression,
+
+		// Don't compress streaming responses, as
+		// compression would buffer server-sent
+		// events until the stream is closed.
+		gzip.WithExcludedPaths([]string{
+			"/api/v1/streaming",
+		}),
+	)
 }
@@ -29,6 +29,7 @@ import (
 	"net/http"
 	"runtime"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -40,6 +41,20 @@ import (
 // token represents a request that is being processed.
 type token struct{}
 
+// throttleReleaseKey is the gin context key under which
+// Throttle stores the func to release a request's token.
+const throttleReleaseKey = "gts.throttle.release"
+
+// ReleaseThrottle releases the throttling token (if any) held by the
+// given request early, and removes it from the backlog count. This is
+// intended for long-lived handlers, like server-sent event streams,
+// which would otherwise hold a token for the lifetime of the request.
+func ReleaseThrottle(c *gin.Context) {
+	if release, ok := c.Get(throttleReleaseKey); ok {
+		release.(func())()
+	}
+}
+
 // Throttle returns a gin middleware that performs throttling of incoming requests,
 // ensuring that only a certain number of requests are handled concurrently, to reduce
 // congestion of the server.
@@ -96,12 +111,15 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
-		// Always decrement request counter.
-		defer func() { requestCount.Add(-1) }()
-
 		// Increment request count.
 		n := requestCount.Add(1)
 
+		// Always decrement request counter,
+		// (once, may be released early).
+		var once sync.Once
+		decr := func() { requestCount.Add(-1) }
+		defer once.Do(decr)
+
 		// Check whether the request
 		// count is over queue limit.
 		if n > int64(queueLimit) {
@@ -129,11 +147,17 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 			// received a token, allowing
 			// request to be processed.
 
-			defer func() {
+			var tokOnce sync.Once
+			release := func() {
 				// when we're finished, return
 				// this token to the bucket.
-				tokens <- tok
-			}()
+				tokOnce.Do(func() { tokens <- tok })
+				once.Do(decr)
+			}
+			defer release()
+
+			// Allow handler to release early.
+			c.Set(throttleReleaseKey, release)
 
 			// Process
 			// request!
@@ -141,6 +141,59 @@ func testThrottlingMiddleware(t *testing.T, cpuMulti int, retryAfter time.Durati
 	}
 }
 
+func TestThrottlingMiddlewareRelease(t *testing.T) {
+	const cpuMulti = 2
+
+	// Calculate expected request limit + queue.
+	limit := runtime.GOMAXPROCS(0) * cpuMulti
+	queueLimit := limit * cpuMulti
+
+	// Gin test http engine
+	// (used for ctx init).
+	e := gin.New()
+
+	// Add middleware to the gin engine handler stack.
+	e.Use(middleware.Throttle(cpuMulti, time.Second))
+
+	// Set a blocking gin handler that releases its throttle token first.
+	e.Handle("GET", "/", func(ctx *gin.Context) {
+		middleware.ReleaseThrottle(ctx)
+		<-ctx.Done()
+	})
+
+	var cncls []func()
+	defer func() {
+		for _, cncl := range cncls {
+			cncl()
+		}
+	}()
+
+	// More requests than the queue limit should all be let through.
+	for i := 0; i < queueLimit+limit; i++ {
+		// Prepare a gin test context.
+		r := httptest.NewRequest("GET", "/", nil)
+		rw := httptest.NewRecorder()
+
+		// Wrap request with new cancel context.
+		ctx, cncl := context.WithCancel(r.Context())
+		r = r.WithContext(ctx)
+		cncls = append(cncls, cncl)
+
+		// Pass req through
+		// engine handler.
+		go e.ServeHTTP(rw, r)
+		time.Sleep(time.Millisecond)
+
+		// Get http result.
+		res := rw.Result()
+
+		// Check status == 200 (default, i.e not set).
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("status code was set (%d) with queueLimit=%d and request=%d", res.StatusCode, queueLimit, i)
+		}
+	}
+}
+
 func blockingHandler() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		<-ctx.Done()
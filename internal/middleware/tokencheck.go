@@ -19,11 +19,13 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -39,8 +41,8 @@ import (
 // or has been disabled, then the middleware will return early. Otherwise, the User will be set on the
 // gin context for further processing by other functions.
 //
-// Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
-// middleware will return early. Otherwise, it will set the Account on the gin context too.
+// Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended or memorialized,
+// then the middleware will return early. Otherwise, it will set the Account on the gin context too.
 //
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
@@ -116,6 +118,21 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 				return
 			}
 
+			if util.PtrValueOr(user.Account.Memorial, false) {
+				log.Warnf(ctx, "authenticated user %s's account (accountId=%s) has been memorialized", userID, user.AccountID)
+				return
+			}
+
+			// Keep track of when the user was last active, for
+			// finding dormant accounts. Only update this once a
+			// day at most, to avoid a db write on every request.
+			if now := time.Now(); now.Sub(user.LastActiveAt) > 24*time.Hour {
+				user.LastActiveAt = now
+				if err := dbConn.UpdateUser(ctx, user, "last_active_at"); err != nil {
+					log.Errorf(ctx, "database error updating last active time for user %s: %s", userID, err)
+				}
+			}
+
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
 		}
 
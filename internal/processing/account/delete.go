@@ -458,6 +458,18 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting faves targeting account: %w", err)
 	}
 
+	// Delete all emoji reactions owned by given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, "", account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions by account: %w", err)
+	}
+
+	// Delete all emoji reactions targeting given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, account.ID, ""); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions targeting account: %w", err)
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	// Delete all poll votes owned by given account.
@@ -505,6 +517,7 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 	account.AlsoKnownAsURIs = nil
 	account.MovedToURI = ""
 	account.Discoverable = util.Ptr(false)
+	account.Indexable = util.Ptr(false)
 	account.SuspendedAt = now
 	account.SuspensionOrigin = origin
 
@@ -523,6 +536,7 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 		"also_known_as_uris",
 		"moved_to_uri",
 		"discoverable",
+		"indexable",
 		"suspended_at",
 		"suspension_origin",
 	}
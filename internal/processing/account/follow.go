@@ -80,6 +80,14 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		)
 	}
 
+	// Memorial accounts can't make new
+	// follows, nor gain new followers.
+	if util.PtrValueOr(requestingAccount.Memorial, false) ||
+		util.PtrValueOr(targetAccount.Memorial, false) {
+		const text = "memorial accounts can't follow or be followed"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
 	// Neither follows nor follow requests, so
 	// create and store a new follow request.
 	followID, err := id.NewRandomULID()
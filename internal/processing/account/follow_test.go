@@ -19,12 +19,14 @@ package account_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -158,6 +160,29 @@ func (suite *FollowTestSuite) TestFollowRequestLocal() {
 	suite.Equal(targetAccount.ID, cMsg.Target.ID)
 }
 
+func (suite *FollowTestSuite) TestFollowRequestMemorial() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_2"]
+
+	// Memorialize turtle.
+	targetAccount.Memorial = util.Ptr(true)
+	if err := suite.state.DB.UpdateAccount(ctx, targetAccount, "memorial"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Admin shouldn't be able to follow request turtle.
+	_, errWithCode := suite.accountProcessor.FollowCreate(
+		ctx,
+		requestingAccount,
+		&apimodel.AccountFollowRequest{
+			ID: targetAccount.ID,
+		})
+	suite.EqualError(errWithCode, "memorial accounts can't follow or be followed")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
 func TestFollowTestS(t *testing.T) {
 	suite.Run(t, new(FollowTestSuite))
 }
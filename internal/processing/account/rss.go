@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 const (
@@ -36,16 +37,16 @@ const (
 
 type GetRSSFeed func() (string, gtserror.WithCode)
 
-// GetRSSFeedForUsername returns a function to return the RSS feed of a local account
-// with the given username, and the last-modified time (time that the account last
-// posted a status eligible to be included in the rss feed).
+// GetRSSFeedForUsername returns a function to return the feed of a local account
+// with the given username in the given format, and the last-modified time (time that
+// the account last posted a status eligible to be included in the feed).
 //
 // To save db calls, callers to this function should only call the returned GetRSSFeed
 // func if the last-modified time is newer than the last-modified time they have cached.
 //
-// If the account has not yet posted an RSS-eligible status, the returned last-modified
-// time will be zero, and the GetRSSFeed func will return a valid RSS xml with no items.
-func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string) (GetRSSFeed, time.Time, gtserror.WithCode) {
+// If the account has not yet posted a feed-eligible status, the returned last-modified
+// time will be zero, and the GetRSSFeed func will return a valid feed with no items.
+func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string, format typeutils.FeedFormat) (GetRSSFeed, time.Time, gtserror.WithCode) {
 	var (
 		never = time.Time{}
 	)
@@ -99,6 +100,9 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 			Image:       image,
 		}
 
+		// URL of the feed itself.
+		feedURL := account.URL + "/feed." + format.String()
+
 		// If the account has never posted anything, just use
 		// account creation time as Updated value for the feed;
 		// we could use time.Now() here but this would likely
@@ -108,7 +112,7 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 		// since we already know there's no eligible statuses.
 		if lastPostAt.IsZero() {
 			feed.Updated = account.CreatedAt
-			return stringifyFeed(feed)
+			return p.stringifyFeed(ctx, feed, nil, format, feedURL)
 		}
 
 		// Account has posted at least one status that's
@@ -118,24 +122,13 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 		feed.Updated = lastPostAt
 
 		// Retrieve latest statuses as they'd be shown on the web view of the account profile.
-		statuses, err := p.state.DB.GetAccountWebStatuses(ctx, account.ID, rssFeedLength, "")
+		statuses, err := p.state.DB.GetAccountWebStatuses(ctx, account.ID, rssFeedLength, false, false, "")
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err = fmt.Errorf("db error getting account web statuses: %w", err)
 			return "", gtserror.NewErrorInternalError(err)
 		}
 
-		// Add each status to the rss feed.
-		for _, status := range statuses {
-			item, err := p.converter.StatusToRSSItem(ctx, status)
-			if err != nil {
-				err = gtserror.Newf("error converting status to feed item: %w", err)
-				return "", gtserror.NewErrorInternalError(err)
-			}
-
-			feed.Add(item)
-		}
-
-		return stringifyFeed(feed)
+		return p.stringifyFeed(ctx, feed, statuses, format, feedURL)
 	}, lastPostAt, nil
 }
 
@@ -168,14 +161,20 @@ func (p *Processor) rssImageForAccount(ctx context.Context, account *gtsmodel.Ac
 	}, nil
 }
 
-func stringifyFeed(feed *feeds.Feed) (string, gtserror.WithCode) {
-	// Stringify the feed. Even with no statuses,
-	// this will still produce valid rss xml.
-	rss, err := feed.ToRss()
+func (p *Processor) stringifyFeed(
+	ctx context.Context,
+	feed *feeds.Feed,
+	statuses []*gtsmodel.Status,
+	format typeutils.FeedFormat,
+	feedURL string,
+) (string, gtserror.WithCode) {
+	// Add statuses and stringify the feed. Even with
+	// no statuses, this will still produce a valid feed.
+	str, err := p.converter.StatusesToFeed(ctx, feed, statuses, format, feedURL)
 	if err != nil {
-		err := gtserror.Newf("error converting feed to rss string: %w", err)
+		err := gtserror.Newf("error converting feed to %s string: %w", format, err)
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
-	return rss, nil
+	return str, nil
 }
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 type GetRSSTestSuite struct {
@@ -29,7 +30,7 @@ type GetRSSTestSuite struct {
 }
 
 func (suite *GetRSSTestSuite) TestGetAccountRSSAdmin() {
-	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "admin")
+	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "admin", typeutils.FeedFormatRSS)
 	suite.NoError(err)
 	suite.EqualValues(1634726497, lastModified.Unix())
 
@@ -39,7 +40,7 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSAdmin() {
 }
 
 func (suite *GetRSSTestSuite) TestGetAccountRSSZork() {
-	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "the_mighty_zork")
+	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "the_mighty_zork", typeutils.FeedFormatRSS)
 	suite.NoError(err)
 	suite.EqualValues(1702200240, lastModified.Unix())
 
@@ -64,7 +65,7 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSZorkNoPosts() {
 		}
 	}
 
-	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(ctx, "the_mighty_zork")
+	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(ctx, "the_mighty_zork", typeutils.FeedFormatRSS)
 	suite.NoError(err)
 	suite.Empty(lastModified)
 
@@ -73,6 +74,24 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSZorkNoPosts() {
 	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?><rss version=\"2.0\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">\n  <channel>\n    <title>Posts from @the_mighty_zork@localhost:8080</title>\n    <link>http://localhost:8080/@the_mighty_zork</link>\n    <description>Posts from @the_mighty_zork@localhost:8080</description>\n    <pubDate>Fri, 20 May 2022 11:09:18 +0000</pubDate>\n    <lastBuildDate>Fri, 20 May 2022 11:09:18 +0000</lastBuildDate>\n    <image>\n      <url>http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg</url>\n      <title>Avatar for @the_mighty_zork@localhost:8080</title>\n      <link>http://localhost:8080/@the_mighty_zork</link>\n    </image>\n  </channel>\n</rss>", feed)
 }
 
+func (suite *GetRSSTestSuite) TestGetAccountAtomAdmin() {
+	getFeed, _, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "admin", typeutils.FeedFormatAtom)
+	suite.NoError(err)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?><feed xmlns=\"http://www.w3.org/2005/Atom\">\n  <title>Posts from @admin@localhost:8080</title>\n  <id>http://localhost:8080/@admin</id>\n  <updated>2021-10-20T10:41:37Z</updated>\n  <subtitle>Posts from @admin@localhost:8080</subtitle>\n  <link href=\"http://localhost:8080/@admin\"></link>\n  <entry>\n    <title>open to see some puppies</title>\n    <updated>2021-10-20T12:36:45Z</updated>\n    <id>http://localhost:8080/@admin/statuses/01F8MHAAY43M6RJ473VQFCVH37</id>\n    <content type=\"html\">🐕🐕🐕🐕🐕</content>\n    <published>2021-10-20T12:36:45Z</published>\n    <link href=\"http://localhost:8080/@admin/statuses/01F8MHAAY43M6RJ473VQFCVH37\" rel=\"alternate\"></link>\n    <summary type=\"html\">@admin@localhost:8080 made a new post: &#34;🐕🐕🐕🐕🐕&#34;</summary>\n    <author>\n      <name>@admin@localhost:8080</name>\n    </author>\n  </entry>\n  <entry>\n    <title>hello world! #welcome ! first post on the instance :rainbow: !</title>\n    <updated>2021-10-20T11:36:45Z</updated>\n    <id>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</id>\n    <content type=\"html\">hello world! #welcome ! first post on the instance &lt;img src=&#34;http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png&#34; title=&#34;:rainbow:&#34; alt=&#34;:rainbow:&#34; width=&#34;25&#34; height=&#34;25&#34;/&gt; !</content>\n    <published>2021-10-20T11:36:45Z</published>\n    <link href=\"http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R\" rel=\"alternate\"></link>\n    <link href=\"http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg\" rel=\"enclosure\" type=\"image/jpeg\" length=\"62529\"></link>\n    <summary type=\"html\">@admin@localhost:8080 posted 1 attachment: &#34;hello world! #welcome ! first post on the instance :rainbow: !&#34;</summary>\n    <author>\n      <name>@admin@localhost:8080</name>\n    </author>\n  </entry>\n</feed>", feed)
+}
+
+func (suite *GetRSSTestSuite) TestGetAccountJSONAdmin() {
+	getFeed, _, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "admin", typeutils.FeedFormatJSON)
+	suite.NoError(err)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+	suite.Equal("{\n  \"version\": \"https://jsonfeed.org/version/1.1\",\n  \"title\": \"Posts from @admin@localhost:8080\",\n  \"home_page_url\": \"http://localhost:8080/@admin\",\n  \"feed_url\": \"http://localhost:8080/@admin/feed.json\",\n  \"description\": \"Posts from @admin@localhost:8080\",\n  \"items\": [\n    {\n      \"id\": \"http://localhost:8080/@admin/statuses/01F8MHAAY43M6RJ473VQFCVH37\",\n      \"url\": \"http://localhost:8080/@admin/statuses/01F8MHAAY43M6RJ473VQFCVH37\",\n      \"title\": \"open to see some puppies\",\n      \"content_html\": \"🐕🐕🐕🐕🐕\",\n      \"summary\": \"@admin@localhost:8080 made a new post: \\\"🐕🐕🐕🐕🐕\\\"\",\n      \"date_published\": \"2021-10-20T12:36:45Z\",\n      \"date_modified\": \"2021-10-20T12:36:45Z\",\n      \"author\": {\n        \"name\": \"@admin@localhost:8080\"\n      },\n      \"authors\": [\n        {\n          \"name\": \"@admin@localhost:8080\"\n        }\n      ]\n    },\n    {\n      \"id\": \"http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R\",\n      \"url\": \"http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R\",\n      \"title\": \"hello world! #welcome ! first post on the instance :rainbow: !\",\n      \"content_html\": \"hello world! #welcome ! first post on the instance \\u003cimg src=\\\"http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png\\\" title=\\\":rainbow:\\\" alt=\\\":rainbow:\\\" width=\\\"25\\\" height=\\\"25\\\"/\\u003e !\",\n      \"summary\": \"@admin@localhost:8080 posted 1 attachment: \\\"hello world! #welcome ! first post on the instance :rainbow: !\\\"\",\n      \"image\": \"http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg\",\n      \"date_published\": \"2021-10-20T11:36:45Z\",\n      \"date_modified\": \"2021-10-20T11:36:45Z\",\n      \"author\": {\n        \"name\": \"@admin@localhost:8080\"\n      },\n      \"authors\": [\n        {\n          \"name\": \"@admin@localhost:8080\"\n        }\n      ],\n      \"attachments\": [\n        {\n          \"url\": \"http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg\",\n          \"mime_type\": \"image/jpeg\",\n          \"size\": 62529\n        }\n      ]\n    }\n  ]\n}", feed)
+}
+
 func TestGetRSSTestSuite(t *testing.T) {
 	suite.Run(t, new(GetRSSTestSuite))
 }
@@ -139,10 +139,14 @@ func (p *Processor) StatusesGet(
 
 // WebStatusesGet fetches a number of statuses (in descending order)
 // from the given account. It selects only statuses which are suitable
-// for showing on the public web profile of an account.
+// for showing on the public web profile of an account: replies are
+// only included if withReplies is true, and if mediaOnly is true,
+// only statuses with media attachments are included.
 func (p *Processor) WebStatusesGet(
 	ctx context.Context,
 	targetAccountID string,
+	withReplies bool,
+	mediaOnly bool,
 	maxID string,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	account, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
@@ -159,7 +163,16 @@ func (p *Processor) WebStatusesGet(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	statuses, err := p.state.DB.GetAccountWebStatuses(ctx, targetAccountID, 10, maxID)
+	// Path of the profile tab being paged through.
+	path := "/@" + account.Username
+	switch {
+	case mediaOnly:
+		path += "/media"
+	case withReplies:
+		path += "/with_replies"
+	}
+
+	statuses, err := p.state.DB.GetAccountWebStatuses(ctx, targetAccountID, 10, withReplies, mediaOnly, maxID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -189,7 +202,7 @@ func (p *Processor) WebStatusesGet(
 
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
-		Path:           "/@" + account.Username,
+		Path:           path,
 		NextMaxIDValue: nextMaxIDValue,
 	})
 }
@@ -121,6 +121,16 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 				Name:  text.SanitizeToPlaintext(name),
 				Value: text.SanitizeToPlaintext(value),
 			}
+
+			// Keep the verification of any unchanged field
+			// value; new values get verified after update.
+			for _, oldRaw := range account.FieldsRaw {
+				if oldRaw.Value == fieldRaw.Value {
+					fieldRaw.VerifiedAt = oldRaw.VerifiedAt
+					break
+				}
+			}
+
 			fieldsRaw = append(fieldsRaw, fieldRaw)
 		}
 
@@ -165,7 +175,9 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		// Process the raw fields we stored earlier.
 		account.Fields = make([]*gtsmodel.Field, 0, len(account.FieldsRaw))
 		for _, fieldRaw := range account.FieldsRaw {
-			field := &gtsmodel.Field{}
+			field := &gtsmodel.Field{
+				VerifiedAt: fieldRaw.VerifiedAt,
+			}
 
 			// Name stays plain, but we still need to
 			// see if there are any emojis set in it.
@@ -298,6 +310,14 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Settings.HideCollections = form.HideCollections
 	}
 
+	if form.Indexable != nil {
+		account.Settings.Indexable = form.Indexable
+	}
+
+	if form.DisableEmbeds != nil {
+		account.Settings.DisableEmbeds = form.DisableEmbeds
+	}
+
 	if err := p.state.DB.UpdateAccount(ctx, account); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account %s: %s", account.ID, err))
 	}
@@ -331,6 +331,69 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateNoteNotFields() {
 	suite.Equal(fieldsBefore, len(dbAccount.Fields))
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateFieldsKeepVerified() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Pretend a field was
+	// previously verified.
+	verifiedAt := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	testAccount.FieldsRaw = []*gtsmodel.Field{
+		{
+			Name:       "website",
+			Value:      "https://example.org/zork",
+			VerifiedAt: verifiedAt,
+		},
+		{
+			Name:       "old blog",
+			Value:      "https://blog.example.org/zork",
+			VerifiedAt: verifiedAt,
+		},
+	}
+
+	var (
+		ctx          = context.Background()
+		updateFields = []apimodel.UpdateField{
+			{
+				// Renamed, same value.
+				Name:  func() *string { s := "homepage"; return &s }(),
+				Value: func() *string { s := "https://example.org/zork"; return &s }(),
+			},
+			{
+				// Changed value.
+				Name:  func() *string { s := "new blog"; return &s }(),
+				Value: func() *string { s := "https://newblog.example.org/zork"; return &s }(),
+			},
+		}
+	)
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &updateFields,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Unchanged value should stay verified,
+	// changed value needs verifying again.
+	suite.NotNil(apiAccount.Fields[0].VerifiedAt)
+	suite.Nil(apiAccount.Fields[1].VerifiedAt)
+
+	// We should have an update in the client api channel.
+	msg, _ := suite.getClientMsg(5 * time.Second)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ActorPerson, msg.APObjectType)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(verifiedAt.Equal(dbAccount.FieldsRaw[0].VerifiedAt))
+	suite.True(verifiedAt.Equal(dbAccount.Fields[0].VerifiedAt))
+	suite.True(dbAccount.FieldsRaw[1].VerifiedAt.IsZero())
+	suite.True(dbAccount.Fields[1].VerifiedAt.IsZero())
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
@@ -19,11 +19,15 @@ package admin_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -94,7 +98,246 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"disable\" \"reenable\" \"silence\" \"unsilence\" \"sensitive\" \"unsensitive\" \"suspend\" \"unsuspend\" \"memorialize\" \"unmemorialize\"]")
+	suite.Empty(actionID)
+}
+
+func (suite *AccountTestSuite) TestAccountActionSilenceTimed() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSilence.String(),
+			Text:     "please stop posting about your cat for a bit",
+			TargetID: suite.testAccounts["local_account_1"].ID,
+			Duration: util.Ptr(1),
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Target account should be silenced,
+	// and the user emailed about it.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(targetAcct.IsSilenced())
+	suite.Contains(suite.sentEmails["zork@example.org"], "has limited your account")
+	suite.Contains(suite.sentEmails["zork@example.org"], "please stop posting about your cat for a bit")
+
+	// Wait for the silence to
+	// expire and be reversed.
+	if !testrig.WaitFor(func() bool {
+		action, err := suite.db.GetAdminAction(ctx, actionID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return !action.ReversedAt.IsZero()
+	}) {
+		suite.FailNow("timed out waiting for admin action to expire")
+	}
+
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	targetAcct, err = suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(targetAcct.IsSilenced())
+	suite.Contains(suite.sentEmails["zork@example.org"], "has lifted the limit on your account")
+}
+
+func (suite *AccountTestSuite) TestAccountActionSuspendTimed() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSuspend.String(),
+			TargetID: suite.testAccounts["local_account_1"].ID,
+			Duration: util.Ptr(60),
+		}
+	)
+
+	// Suspension removes account data, so
+	// it can't be undone automatically.
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "admin action type suspend cannot expire")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Empty(actionID)
+
+	// Target account should be untouched.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(targetAcct.IsSuspended())
+}
+
+func (suite *AccountTestSuite) TestAccountActionSilenceVisibilityCache() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		visFilter = visibility.NewFilter(&suite.state)
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSilence.String(),
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	// Cache public timeline visibility of
+	// a status by the target, and by another.
+	for _, status := range []*gtsmodel.Status{
+		suite.testStatuses["local_account_1_status_1"],
+		suite.testStatuses["local_account_2_status_1"],
+	} {
+		if _, err := visFilter.StatusPublicTimelineable(ctx, nil, status); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.NoError(errWithCode)
+
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Only the target's cached
+	// visibility should be gone.
+	cached := func(status *gtsmodel.Status) bool {
+		_, ok := suite.state.Caches.Visibility.GetOne("Type,RequesterID,ItemID",
+			cache.VisibilityTypePublic, "noauth", status.ID,
+		)
+		return ok
+	}
+	suite.False(cached(suite.testStatuses["local_account_1_status_1"]))
+	suite.True(cached(suite.testStatuses["local_account_2_status_1"]))
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "only local accounts can be disabled or reenabled")
+	suite.Empty(actionID)
+}
+
+func (suite *AccountTestSuite) TestAccountActionMemorialize() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionMemorialize.String(),
+			Text:     "rest in peace zork",
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Target account should be a memorial,
+	// and the user should not be emailed.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*targetAcct.Memorial)
+	suite.Empty(suite.sentEmails["zork@example.org"])
+
+	// Memorializing can't expire.
+	request.Type = gtsmodel.AdminActionUnmemorialize.String()
+	request.Duration = util.Ptr(60)
+	_, errWithCode = suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.EqualError(errWithCode, "admin action type unmemorialize cannot expire")
+
+	// But it can be undone.
+	request.Duration = nil
+	_, errWithCode = suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.NoError(errWithCode)
+
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	targetAcct, err = suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*targetAcct.Memorial)
+}
+
+func (suite *AccountTestSuite) TestAccountActionMemorializeRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionMemorialize.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "only local accounts can be memorialized or unmemorialized")
 	suite.Empty(actionID)
 }
 
@@ -19,16 +19,39 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// accountActionTypes contains all admin
+// action types which can target an account.
+var accountActionTypes = []gtsmodel.AdminActionType{
+	gtsmodel.AdminActionDisable,
+	gtsmodel.AdminActionReenable,
+	gtsmodel.AdminActionSilence,
+	gtsmodel.AdminActionUnsilence,
+	gtsmodel.AdminActionSensitive,
+	gtsmodel.AdminActionUnsensitive,
+	gtsmodel.AdminActionSuspend,
+	gtsmodel.AdminActionUnsuspend,
+	gtsmodel.AdminActionMemorialize,
+	gtsmodel.AdminActionUnmemorialize,
+}
+
 func (p *Processor) AccountAction(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
@@ -40,15 +63,11 @@ func (p *Processor) AccountAction(
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
-	switch gtsmodel.NewAdminActionType(request.Type) {
-	case gtsmodel.AdminActionSuspend:
-		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
-
-	default:
-		// TODO: add more types to this slice when adding
-		//       more types to the switch statement above.
-		supportedTypes := []string{
-			gtsmodel.AdminActionSuspend.String(),
+	actionType := gtsmodel.NewAdminActionType(request.Type)
+	if !slices.Contains(accountActionTypes, actionType) {
+		supportedTypes := make([]string, len(accountActionTypes))
+		for i, t := range accountActionTypes {
+			supportedTypes[i] = t.String()
 		}
 
 		err := fmt.Errorf(
@@ -58,45 +77,471 @@ func (p *Processor) AccountAction(
 
 		return "", gtserror.NewErrorBadRequest(err, err.Error())
 	}
+
+	if targetAcct.IsRemote() &&
+		(actionType == gtsmodel.AdminActionDisable ||
+			actionType == gtsmodel.AdminActionReenable) {
+		const text = "only local accounts can be disabled or reenabled"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if targetAcct.IsRemote() &&
+		(actionType == gtsmodel.AdminActionMemorialize ||
+			actionType == gtsmodel.AdminActionUnmemorialize) {
+		const text = "only local accounts can be memorialized or unmemorialized"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	var expiresAt time.Time
+	if duration := util.PtrValueOr(request.Duration, 0); duration != 0 {
+		if duration < 0 {
+			const text = "duration must not be negative"
+			return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		if actionType.Reverse() == gtsmodel.AdminActionUnknown {
+			text := fmt.Sprintf("admin action type %s cannot expire", actionType)
+			return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		expiresAt = time.Now().Add(time.Duration(duration) * time.Second)
+	}
+
+	action := &gtsmodel.AdminAction{
+		ID:             id.NewULID(),
+		TargetCategory: gtsmodel.AdminActionCategoryAccount,
+		TargetID:       targetAcct.ID,
+		Target:         targetAcct,
+		Type:           actionType,
+		AccountID:      adminAcct.ID,
+		Text:           request.Text,
+		SendEmail:      util.Ptr(util.PtrValueOr(request.SendEmail, true)),
+		ExpiresAt:      expiresAt,
+	}
+
+	return action.ID, p.runAccountAction(ctx, adminAcct, targetAcct, action)
 }
 
-func (p *Processor) accountActionSuspend(
+// runAccountAction runs the given account action, taking
+// care of superseding earlier timed actions on the target,
+// scheduling expiry of this action (if set), and emailing
+// the target account's user (if requested).
+func (p *Processor) runAccountAction(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
 	targetAcct *gtsmodel.Account,
-	text string,
-) (string, gtserror.WithCode) {
-	actionID := id.NewULID()
+	action *gtsmodel.AdminAction,
+) gtserror.WithCode {
+	var actionF func(context.Context, *gtsmodel.Account, *gtsmodel.Account) error
+
+	switch action.Type {
+	case gtsmodel.AdminActionDisable:
+		actionF = p.accountActionDisable
+	case gtsmodel.AdminActionReenable:
+		actionF = p.accountActionReenable
+	case gtsmodel.AdminActionSilence:
+		actionF = p.accountActionSilence
+	case gtsmodel.AdminActionUnsilence:
+		actionF = p.accountActionUnsilence
+	case gtsmodel.AdminActionSensitive:
+		actionF = p.accountActionSensitive
+	case gtsmodel.AdminActionUnsensitive:
+		actionF = p.accountActionUnsensitive
+	case gtsmodel.AdminActionSuspend:
+		actionF = p.accountActionSuspend
+	case gtsmodel.AdminActionUnsuspend:
+		actionF = p.accountActionUnsuspend
+	case gtsmodel.AdminActionMemorialize:
+		actionF = p.accountActionMemorialize
+	case gtsmodel.AdminActionUnmemorialize:
+		actionF = p.accountActionUnmemorialize
+	default:
+		err := gtserror.Newf("unsupported account action type %s", action.Type)
+		return gtserror.NewErrorInternalError(err)
+	}
 
-	errWithCode := p.actions.Run(
+	return p.actions.Run(
 		ctx,
-		&gtsmodel.AdminAction{
-			ID:             actionID,
-			TargetCategory: gtsmodel.AdminActionCategoryAccount,
-			TargetID:       targetAcct.ID,
-			Target:         targetAcct,
-			Type:           gtsmodel.AdminActionSuspend,
-			AccountID:      adminAcct.ID,
-			Text:           text,
-		},
+		action,
 		func(ctx context.Context) gtserror.MultiError {
-			if err := p.state.Workers.Client.Process(
-				ctx,
-				&messages.FromClientAPI{
-					APObjectType:   ap.ActorPerson,
-					APActivityType: ap.ActivityDelete,
-					Origin:         adminAcct,
-					Target:         targetAcct,
-				},
-			); err != nil {
-				errs := gtserror.NewMultiError(1)
+			var errs gtserror.MultiError
+
+			if err := actionF(ctx, adminAcct, targetAcct); err != nil {
 				errs.Append(err)
 				return errs
 			}
 
-			return nil
+			// Mark any earlier timed actions on this
+			// account that this one reverses or replaces.
+			if err := p.supersedeAccountActions(ctx, action); err != nil {
+				errs.Append(err)
+			}
+
+			if action.IsPendingExpiry() {
+				if err := p.scheduleActionExpiry(ctx, action); err != nil {
+					errs.Append(err)
+				}
+			}
+
+			if *action.SendEmail {
+				if err := p.emailAccountAction(ctx, targetAcct, action); err != nil {
+					errs.Append(err)
+				}
+			}
+
+			return errs
 		},
 	)
+}
+
+func (p *Processor) accountActionSuspend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.state.Workers.Client.Process(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityDelete,
+			Origin:         adminAcct,
+			Target:         targetAcct,
+		},
+	)
+}
+
+func (p *Processor) accountActionUnsuspend(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	// Account data was removed when suspended,
+	// so all we can do here is lift the suspension.
+	// Local users will need to reset their password.
+	targetAcct.SuspendedAt = time.Time{}
+	targetAcct.SuspensionOrigin = ""
+
+	if err := p.state.DB.UpdateAccount(
+		ctx,
+		targetAcct,
+		"suspended_at",
+		"suspension_origin",
+	); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) accountActionSilence(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SilencedAt = time.Now()
+	return p.updateSilenced(ctx, targetAcct)
+}
+
+func (p *Processor) accountActionUnsilence(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SilencedAt = time.Time{}
+	return p.updateSilenced(ctx, targetAcct)
+}
+
+func (p *Processor) updateSilenced(ctx context.Context, targetAcct *gtsmodel.Account) error {
+	if err := p.state.DB.UpdateAccount(ctx, targetAcct, "silenced_at"); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+
+	// Visibility of the account's statuses
+	// has changed for everyone, so invalidate
+	// cached visibilities of them.
+	p.state.Caches.Visibility.InvalidateAccount(targetAcct.ID)
+	return nil
+}
+
+func (p *Processor) accountActionSensitive(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SensitizedAt = time.Now()
+	if err := p.state.DB.UpdateAccount(ctx, targetAcct, "sensitized_at"); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+	return nil
+}
+
+func (p *Processor) accountActionUnsensitive(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SensitizedAt = time.Time{}
+	if err := p.state.DB.UpdateAccount(ctx, targetAcct, "sensitized_at"); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+	return nil
+}
+
+func (p *Processor) accountActionDisable(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.updateDisabled(ctx, targetAcct, true)
+}
+
+func (p *Processor) accountActionReenable(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.updateDisabled(ctx, targetAcct, false)
+}
+
+func (p *Processor) updateDisabled(ctx context.Context, targetAcct *gtsmodel.Account, disabled bool) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	// Disabled users can't log in or use their
+	// existing tokens, but their data is kept.
+	user.Disabled = &disabled
+
+	// Clear any dormancy warning on reenable, so
+	// a still-dormant user gets a fresh warning
+	// rather than being disabled again right away.
+	if !disabled {
+		user.DormantWarnedAt = time.Time{}
+	}
+
+	if err := p.state.DB.UpdateUser(ctx, user, "disabled", "dormant_warned_at"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	// As with silencing, this changes the
+	// visibility of all the account's statuses.
+	p.state.Caches.Visibility.InvalidateAccount(targetAcct.ID)
+	return nil
+}
+
+func (p *Processor) accountActionMemorialize(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.updateMemorial(ctx, targetAcct, true)
+}
+
+func (p *Processor) accountActionUnmemorialize(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.updateMemorial(ctx, targetAcct, false)
+}
+
+func (p *Processor) updateMemorial(ctx context.Context, targetAcct *gtsmodel.Account, memorial bool) error {
+	// Memorial accounts can't log in, post,
+	// or follow, but are otherwise left as-is.
+	targetAcct.Memorial = &memorial
+	if err := p.state.DB.UpdateAccount(ctx, targetAcct, "memorial"); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+
+	// Federate the change, so remote
+	// instances can show it too.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       targetAcct,
+		Origin:         targetAcct,
+	})
+
+	return nil
+}
+
+// supersedeAccountActions marks any earlier timed actions
+// on the target of the given action, of the same type or
+// the type that it reverses, as reversed. This ensures that
+// eg., an unsilence is not undone by a previous silence's
+// expiry, or that a later silence is not lifted early.
+func (p *Processor) supersedeAccountActions(ctx context.Context, action *gtsmodel.AdminAction) error {
+	pending, err := p.state.DB.GetAdminActionsPendingExpiry(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting admin actions pending expiry: %w", err)
+	}
+
+	for _, earlier := range pending {
+		if earlier.ID == action.ID ||
+			earlier.TargetCategory != action.TargetCategory ||
+			earlier.TargetID != action.TargetID {
+			continue
+		}
+
+		if earlier.Type != action.Type &&
+			earlier.Type.Reverse() != action.Type {
+			continue
+		}
+
+		// Cancel the earlier action's expiry,
+		// and mark it reversed in the database.
+		p.state.Workers.Scheduler.Cancel(earlier.ID)
+		earlier.ReversedAt = time.Now()
+		if err := p.state.DB.UpdateAdminAction(ctx, earlier, "reversed_at"); err != nil {
+			return gtserror.Newf("db error updating admin action %s: %w", earlier.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// emailAccountAction emails the user of the given
+// target account, if local, to inform them of action.
+func (p *Processor) emailAccountAction(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+	action *gtsmodel.AdminAction,
+) error {
+	if targetAcct.IsRemote() {
+		// Can't email remote accounts.
+		return nil
+	}
+
+	if action.Type == gtsmodel.AdminActionMemorialize ||
+		action.Type == gtsmodel.AdminActionUnmemorialize {
+		// The person behind a memorial account
+		// has passed away; don't email them.
+		return nil
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.Email == "" {
+		// Nowhere to send to.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	data := email.AccountActionData{
+		Username:     targetAcct.Username,
+		Locale:       user.Locale,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		ActionType:   action.Type.String(),
+		Text:         action.Text,
+	}
+
+	if !action.ExpiresAt.IsZero() {
+		data.ExpiresAt = action.ExpiresAt.UTC().Format(time.RFC1123)
+	}
+
+	if err := p.email.SendAccountActionEmail(user.Email, data); err != nil {
+		return gtserror.Newf("error emailing user: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleActionExpiries schedules automatic reversal of
+// all admin actions that have an expiry time set, and have
+// not yet been reversed. Actions which expired while the
+// instance wasn't running will be reversed immediately.
+func (p *Processor) ScheduleActionExpiries(ctx context.Context) error {
+	actions, err := p.state.DB.GetAdminActionsPendingExpiry(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting admin actions pending expiry: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, action := range actions {
+		if err := p.scheduleActionExpiry(ctx, action); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (p *Processor) scheduleActionExpiry(ctx context.Context, action *gtsmodel.AdminAction) error {
+	ok := p.state.Workers.Scheduler.AddOnce(
+		action.ID,
+		action.ExpiresAt,
+		p.onActionExpiry(action.ID),
+	)
+
+	if !ok {
+		// Failed to add the action to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding admin action %s to scheduler", action.ID)
+	}
+
+	atStr := action.ExpiresAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled admin action expiry for %s at '%s'", action.ID, atStr)
+	return nil
+}
+
+// onActionExpiry returns a callback function to be used by
+// the scheduler when the given admin action expires, which
+// runs a new admin action reversing the expired one.
+func (p *Processor) onActionExpiry(actionID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of action from database.
+		action, err := p.state.DB.GetAdminAction(ctx, actionID)
+		if err != nil {
+			log.Errorf(ctx, "error getting admin action %s from db: %v", actionID, err)
+			return
+		}
+
+		if !action.IsPendingExpiry() {
+			// Action was already reversed or superseded.
+			log.Debugf(ctx, "admin action %s no longer pending expiry", actionID)
+			return
+		}
+
+		adminAcct, err := p.state.DB.GetAccountByID(ctx, action.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting admin account %s from db: %v", action.AccountID, err)
+			return
+		}
+
+		targetAcct, err := p.state.DB.GetAccountByID(ctx, action.TargetID)
+		if err != nil {
+			log.Errorf(ctx, "error getting target account %s from db: %v", action.TargetID, err)
+			return
+		}
 
-	return actionID, errWithCode
+		// Run the reversal as a new action on behalf of the
+		// original moderator; this supersedes the expired one.
+		reversal := &gtsmodel.AdminAction{
+			ID:             id.NewULID(),
+			TargetCategory: action.TargetCategory,
+			TargetID:       action.TargetID,
+			Target:         targetAcct,
+			Type:           action.Type.Reverse(),
+			AccountID:      action.AccountID,
+			Text:           fmt.Sprintf("automatically reversed %s action %s on expiry", action.Type, action.ID),
+			SendEmail:      action.SendEmail,
+		}
+
+		if errWithCode := p.runAccountAction(ctx, adminAcct, targetAcct, reversal); errWithCode != nil {
+			// Most likely a conflicting action is already running;
+			// the action remains pending, and will be retried when
+			// expiries are next scheduled (ie., on restart).
+			log.Errorf(ctx, "error reversing expired admin action %s: %v", actionID, errWithCode)
+		}
+	}
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
@@ -40,6 +41,7 @@ type Processor struct {
 	media     *media.Manager
 	transport transport.Controller
 	email     email.Sender
+	spam      *spam.Filter
 
 	// admin Actions currently
 	// undergoing processing
@@ -70,6 +72,7 @@ func New(
 		media:     mediaManager,
 		transport: transportController,
 		email:     emailSender,
+		spam:      spam.NewFilter(state),
 		actions: &Actions{
 			r:     make(map[string]*gtsmodel.AdminAction),
 			state: state,
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// EmailFailedGet returns a page of outgoing emails
+// whose delivery has been given up on, newest first.
+func (p *Processor) EmailFailedGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	emails, err := p.state.DB.GetFailedQueuedEmails(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(emails)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := emails[count-1].ID
+	hi := emails[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, e := range emails {
+		items = append(items, p.converter.QueuedEmailToAdminAPIQueuedEmail(e))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/email/failed",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// EmailFailedRetry resets the failed email with the given
+// ID, so that it will be retried by the email queue worker.
+func (p *Processor) EmailFailedRetry(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminQueuedEmail, gtserror.WithCode) {
+	email, errWithCode := p.getFailedEmail(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Reset delivery state so the
+	// worker picks this up again.
+	email.Attempts = 0
+	email.NextAttemptAt = time.Time{}
+	email.FailedAt = time.Time{}
+
+	if err := p.state.DB.UpdateQueuedEmail(ctx, email,
+		"attempts",
+		"next_attempt_at",
+		"failed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating email: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.QueuedEmailToAdminAPIQueuedEmail(email), nil
+}
+
+// EmailFailedDelete deletes the failed email with the given ID.
+func (p *Processor) EmailFailedDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminQueuedEmail, gtserror.WithCode) {
+	email, errWithCode := p.getFailedEmail(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteQueuedEmailByID(ctx, email.ID); err != nil {
+		err := gtserror.Newf("db error deleting email: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.QueuedEmailToAdminAPIQueuedEmail(email), nil
+}
+
+func (p *Processor) getFailedEmail(ctx context.Context, id string) (*gtsmodel.QueuedEmail, gtserror.WithCode) {
+	email, err := p.state.DB.GetQueuedEmailByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if email == nil || !email.Failed() {
+		// Only failed emails are
+		// visible to admins here.
+		err := fmt.Errorf("failed email %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return email, nil
+}
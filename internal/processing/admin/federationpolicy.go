@@ -0,0 +1,258 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// FederationPoliciesGet returns all federation
+// policies, in the order they're run in.
+func (p *Processor) FederationPoliciesGet(ctx context.Context) ([]*apimodel.FederationPolicy, gtserror.WithCode) {
+	policies, err := p.state.DB.GetFederationPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicies := make([]*apimodel.FederationPolicy, len(policies))
+	for i, policy := range policies {
+		apiPolicies[i] = toAPIFederationPolicy(policy)
+	}
+
+	return apiPolicies, nil
+}
+
+// FederationPolicyGet returns the federation policy with the given ID.
+func (p *Processor) FederationPolicyGet(ctx context.Context, id string) (*apimodel.FederationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getFederationPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return toAPIFederationPolicy(policy), nil
+}
+
+// FederationPolicyCreate creates a new federation policy
+// from the given form, marking it as created by admin.
+func (p *Processor) FederationPolicyCreate(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	form *apimodel.FederationPolicyRequest,
+) (*apimodel.FederationPolicy, gtserror.WithCode) {
+	if form.Type == nil || *form.Type == "" {
+		const text = "federation policy type must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	policy := &gtsmodel.FederationPolicy{
+		ID:                 id.NewULID(),
+		Type:               gtsmodel.FederationPolicyType(*form.Type),
+		Disabled:           util.Ptr(false),
+		CreatedByAccountID: admin.ID,
+		CreatedByAccount:   admin,
+	}
+
+	if errWithCode := applyFederationPolicyForm(policy, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutFederationPolicy(ctx, policy); err != nil {
+		err := gtserror.Newf("error inserting into database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIFederationPolicy(policy), nil
+}
+
+// FederationPolicyUpdate updates the federation policy
+// with the given ID, using any fields set on the form.
+func (p *Processor) FederationPolicyUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.FederationPolicyRequest,
+) (*apimodel.FederationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getFederationPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Type != nil && *form.Type != string(policy.Type) {
+		const text = "federation policy type cannot be changed, create a new policy instead"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode := applyFederationPolicyForm(policy, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateFederationPolicy(ctx, policy); err != nil {
+		err := gtserror.Newf("error updating database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIFederationPolicy(policy), nil
+}
+
+// FederationPolicyDelete deletes the federation policy with
+// the given ID, returning the policy as it was before deletion.
+func (p *Processor) FederationPolicyDelete(ctx context.Context, id string) (*apimodel.FederationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getFederationPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteFederationPolicyByID(ctx, id); err != nil {
+		err := gtserror.Newf("error deleting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIFederationPolicy(policy), nil
+}
+
+// getFederationPolicy fetches the federation
+// policy with given ID, wrapping any error.
+func (p *Processor) getFederationPolicy(ctx context.Context, id string) (*gtsmodel.FederationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetFederationPolicyByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return policy, nil
+
+	// Policy does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "federation policy not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// applyFederationPolicyForm sets any fields present on the
+// given form on the policy, normalizing and then validating.
+func applyFederationPolicyForm(policy *gtsmodel.FederationPolicy, form *apimodel.FederationPolicyRequest) gtserror.WithCode {
+	if form.Priority != nil {
+		policy.Priority = *form.Priority
+	}
+
+	if form.Enabled != nil {
+		policy.Disabled = util.Ptr(!*form.Enabled)
+	}
+
+	if form.Domains != nil {
+		domains := make([]string, 0, len(*form.Domains))
+		for _, domain := range *form.Domains {
+			domain = strings.TrimSpace(domain)
+			if domain == "" {
+				continue
+			}
+
+			// Store domains in punycode, as we match
+			// them against (punycode) account domains.
+			domain, err := util.Punify(domain)
+			if err != nil {
+				err := gtserror.Newf("invalid domain %s: %w", domain, err)
+				return gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			if !slices.Contains(domains, domain) {
+				domains = append(domains, domain)
+			}
+		}
+		policy.Domains = domains
+	}
+
+	if form.Keywords != nil {
+		keywords := make([]string, 0, len(*form.Keywords))
+		for _, keyword := range *form.Keywords {
+			keyword = strings.TrimSpace(keyword)
+			if keyword != "" && !slices.Contains(keywords, keyword) {
+				keywords = append(keywords, keyword)
+			}
+		}
+		policy.Keywords = keywords
+	}
+
+	if form.ContentWarning != nil {
+		policy.ContentWarning = strings.TrimSpace(*form.ContentWarning)
+	}
+
+	if form.MaxMentions != nil {
+		policy.MaxMentions = *form.MaxMentions
+	}
+
+	if form.MinAccountAge != nil {
+		policy.MinAccountAge = time.Duration(*form.MinAccountAge) * time.Second
+	}
+
+	if form.Comment != nil {
+		policy.Comment = strings.TrimSpace(*form.Comment)
+	}
+
+	if err := validate.FederationPolicy(policy); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
+
+// toAPIFederationPolicy performs a simple conversion
+// of database model FederationPolicy to API model.
+func toAPIFederationPolicy(policy *gtsmodel.FederationPolicy) *apimodel.FederationPolicy {
+	apiPolicy := &apimodel.FederationPolicy{
+		ID:             policy.ID,
+		Type:           string(policy.Type),
+		Priority:       policy.Priority,
+		Enabled:        !policy.IsDisabled(),
+		Domains:        policy.Domains,
+		Keywords:       policy.Keywords,
+		ContentWarning: policy.ContentWarning,
+		MaxMentions:    policy.MaxMentions,
+		MinAccountAge:  int64(policy.MinAccountAge / time.Second),
+		Comment:        policy.Comment,
+		CreatedBy:      policy.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(policy.CreatedAt),
+		UpdatedAt:      util.FormatISO8601(policy.UpdatedAt),
+	}
+
+	// Always serialize as arrays.
+	if apiPolicy.Domains == nil {
+		apiPolicy.Domains = []string{}
+	}
+	if apiPolicy.Keywords == nil {
+		apiPolicy.Keywords = []string{}
+	}
+
+	return apiPolicy
+}
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type FederationPolicyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *FederationPolicyTestSuite) TestFederationPolicyLifecycle() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	apiPolicy, errWithCode := suite.adminProcessor.FederationPolicyCreate(ctx, adminAcct, &apimodel.FederationPolicyRequest{
+		Type:          util.Ptr("reject_new_accounts"),
+		Domains:       &[]string{" Example.org ", "example.org", "ÖRNSKÖLDSVIK.example"},
+		MinAccountAge: util.Ptr(int64(86400)),
+		Comment:       util.Ptr("spam wave"),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("reject_new_accounts", apiPolicy.Type)
+	suite.True(apiPolicy.Enabled)
+	suite.Equal([]string{"example.org", "xn--rnskldsvik-dcbe.example"}, apiPolicy.Domains)
+	suite.Equal(int64(86400), apiPolicy.MinAccountAge)
+	suite.Equal(adminAcct.ID, apiPolicy.CreatedBy)
+
+	// Stored policy should have the age as a duration.
+	dbPolicy, err := suite.state.DB.GetFederationPolicyByID(ctx, apiPolicy.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(24*time.Hour, dbPolicy.MinAccountAge)
+
+	// Disable it.
+	apiPolicy, errWithCode = suite.adminProcessor.FederationPolicyUpdate(ctx, apiPolicy.ID, &apimodel.FederationPolicyRequest{
+		Enabled: util.Ptr(false),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(apiPolicy.Enabled)
+	suite.Equal("spam wave", apiPolicy.Comment)
+
+	// Type can't be changed.
+	_, errWithCode = suite.adminProcessor.FederationPolicyUpdate(ctx, apiPolicy.ID, &apimodel.FederationPolicyRequest{
+		Type: util.Ptr("keyword_reject"),
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	apiPolicies, errWithCode := suite.adminProcessor.FederationPoliciesGet(ctx)
+	suite.Nil(errWithCode)
+	suite.Len(apiPolicies, 1)
+
+	_, errWithCode = suite.adminProcessor.FederationPolicyDelete(ctx, apiPolicy.ID)
+	suite.Nil(errWithCode)
+
+	_, errWithCode = suite.adminProcessor.FederationPolicyGet(ctx, apiPolicy.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FederationPolicyTestSuite) TestFederationPolicyCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, form := range []*apimodel.FederationPolicyRequest{
+		// No type.
+		{Keywords: &[]string{"spam"}},
+
+		// Unknown type.
+		{Type: util.Ptr("reject_everything")},
+
+		// Keyword policy without keywords.
+		{Type: util.Ptr("keyword_reject")},
+
+		// Strip media must be limited to domains.
+		{Type: util.Ptr("strip_media")},
+
+		// Bad domain.
+		{Type: util.Ptr("force_unlisted"), Domains: &[]string{"https://example.org/"}},
+	} {
+		_, errWithCode := suite.adminProcessor.FederationPolicyCreate(ctx, adminAcct, form)
+		if suite.NotNil(errWithCode) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		}
+	}
+}
+
+func TestFederationPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(FederationPolicyTestSuite))
+}
@@ -0,0 +1,361 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// QuarantinedStatusesGet returns a page of statuses
+// held back by the spam filter, newest first.
+func (p *Processor) QuarantinedStatusesGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	statuses, err := p.state.DB.GetQuarantinedStatuses(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(statuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := statuses[count-1].ID
+	hi := statuses[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, status := range statuses {
+		apiStatus, err := p.converter.QuarantinedStatusToAdminAPIQuarantinedStatus(ctx, status)
+		if err != nil {
+			err := gtserror.Newf("error converting quarantined status: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiStatus)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/spam/quarantine",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// QuarantinedStatusGet returns the quarantined status with the given ID.
+func (p *Processor) QuarantinedStatusGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiQuarantinedStatus(ctx, status)
+}
+
+// QuarantinedStatusRelease releases the quarantined status with
+// the given ID, so that it's fetched from its origin server and
+// processed as normal. The spam filter learns that the status
+// was not spam, so that similar statuses score lower in future.
+func (p *Processor) QuarantinedStatusRelease(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert now, while we can
+	// still fetch the accounts.
+	apiStatus, errWithCode := p.apiQuarantinedStatus(ctx, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	uri, err := url.Parse(status.URI)
+	if err != nil {
+		err := gtserror.Newf("error parsing status uri: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.spam.Learn(ctx, status, false); err != nil {
+		err := gtserror.Newf("error updating spam signals: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteQuarantinedStatusByID(ctx, status.ID); err != nil {
+		err := gtserror.Newf("db error deleting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process the status as though it were just forwarded
+	// to the receiver: fetch the authentic status from the
+	// origin server, and do the rest asynchronously.
+	p.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		APIRI:          uri,
+		Receiving:      status.ReceivingAccount,
+		Requesting:     status.Account,
+	})
+
+	return apiStatus, nil
+}
+
+// QuarantinedStatusDelete deletes the quarantined status
+// with the given ID, without processing it. The spam filter
+// learns that the status was spam, so that similar statuses
+// score higher in future.
+func (p *Processor) QuarantinedStatusDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiStatus, errWithCode := p.apiQuarantinedStatus(ctx, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.spam.Learn(ctx, status, true); err != nil {
+		err := gtserror.Newf("error updating spam signals: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteQuarantinedStatusByID(ctx, status.ID); err != nil {
+		err := gtserror.Newf("db error deleting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStatus, nil
+}
+
+func (p *Processor) getQuarantinedStatus(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, gtserror.WithCode) {
+	status, err := p.state.DB.GetQuarantinedStatusByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return status, nil
+
+	// Status does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "quarantined status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+func (p *Processor) apiQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	apiStatus, err := p.converter.QuarantinedStatusToAdminAPIQuarantinedStatus(ctx, status)
+	if err != nil {
+		err := gtserror.Newf("error converting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStatus, nil
+}
+
+// SpamSignalsGet returns all spam signals.
+func (p *Processor) SpamSignalsGet(ctx context.Context) ([]*apimodel.SpamSignal, gtserror.WithCode) {
+	signals, err := p.state.DB.GetSpamSignals(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSignals := make([]*apimodel.SpamSignal, len(signals))
+	for i, signal := range signals {
+		apiSignals[i] = toAPISpamSignal(signal)
+	}
+
+	return apiSignals, nil
+}
+
+// SpamSignalCreate creates a new spam signal from the given form.
+func (p *Processor) SpamSignalCreate(
+	ctx context.Context,
+	form *apimodel.SpamSignalRequest,
+) (*apimodel.SpamSignal, gtserror.WithCode) {
+	if form.Type == nil || form.Value == nil || form.Score == nil {
+		const text = "spam signal type, value and score must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	signal := &gtsmodel.SpamSignal{
+		ID:    id.NewULID(),
+		Type:  gtsmodel.SpamSignalType(*form.Type),
+		Value: strings.TrimSpace(*form.Value),
+	}
+
+	if signal.Type == gtsmodel.SpamSignalLinkDomain {
+		// Store domains in punycode, as we
+		// match them against link hostnames.
+		domain, err := util.Punify(strings.ToLower(signal.Value))
+		if err != nil {
+			err := gtserror.Newf("invalid domain %s: %w", signal.Value, err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		signal.Value = domain
+	}
+
+	if errWithCode := applySpamSignalForm(signal, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	_, err := p.state.DB.GetSpamSignal(ctx, signal.Type, signal.Value)
+	if err == nil {
+		const text = "spam signal with this type and value already exists"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutSpamSignal(ctx, signal); err != nil {
+		err := gtserror.Newf("error inserting into database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPISpamSignal(signal), nil
+}
+
+// SpamSignalUpdate updates the spam signal with
+// the given ID, using any fields set on the form.
+func (p *Processor) SpamSignalUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.SpamSignalRequest,
+) (*apimodel.SpamSignal, gtserror.WithCode) {
+	signal, errWithCode := p.getSpamSignal(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if (form.Type != nil && *form.Type != string(signal.Type)) ||
+		(form.Value != nil && *form.Value != signal.Value) {
+		const text = "spam signal type and value cannot be changed, create a new signal instead"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode := applySpamSignalForm(signal, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateSpamSignal(ctx, signal); err != nil {
+		err := gtserror.Newf("error updating database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPISpamSignal(signal), nil
+}
+
+// SpamSignalDelete deletes the spam signal with the given
+// ID, returning the signal as it was before deletion.
+func (p *Processor) SpamSignalDelete(ctx context.Context, id string) (*apimodel.SpamSignal, gtserror.WithCode) {
+	signal, errWithCode := p.getSpamSignal(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteSpamSignalByID(ctx, id); err != nil {
+		err := gtserror.Newf("error deleting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPISpamSignal(signal), nil
+}
+
+func (p *Processor) getSpamSignal(ctx context.Context, id string) (*gtsmodel.SpamSignal, gtserror.WithCode) {
+	signal, err := p.state.DB.GetSpamSignalByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return signal, nil
+
+	// Signal does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "spam signal not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// applySpamSignalForm sets any updatable fields present
+// on the given form on the signal, and then validates.
+func applySpamSignalForm(signal *gtsmodel.SpamSignal, form *apimodel.SpamSignalRequest) gtserror.WithCode {
+	if form.Score != nil {
+		signal.Score = *form.Score
+	}
+
+	if form.Comment != nil {
+		signal.Comment = strings.TrimSpace(*form.Comment)
+	}
+
+	if err := validate.SpamSignal(signal); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
+
+// toAPISpamSignal performs a simple conversion
+// of database model SpamSignal to API model.
+func toAPISpamSignal(signal *gtsmodel.SpamSignal) *apimodel.SpamSignal {
+	return &apimodel.SpamSignal{
+		ID:        signal.ID,
+		Type:      string(signal.Type),
+		Value:     signal.Value,
+		Score:     signal.Score,
+		Comment:   signal.Comment,
+		CreatedAt: util.FormatISO8601(signal.CreatedAt),
+		UpdatedAt: util.FormatISO8601(signal.UpdatedAt),
+	}
+}
@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type SpamTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *SpamTestSuite) putQuarantinedStatus(fingerprint string) *gtsmodel.QuarantinedStatus {
+	status := &gtsmodel.QuarantinedStatus{
+		ID:                 id.NewULID(),
+		URI:                "http://fossbros-anonymous.io/users/foss_satan/statuses/" + id.NewULID(),
+		AccountID:          suite.testAccounts["remote_account_1"].ID,
+		ReceivingAccountID: suite.testAccounts["local_account_1"].ID,
+		Content:            "<p>get more followers today</p>",
+		Score:              50,
+		Rules:              []string{"mentions_strangers", "errant_links"},
+		Fingerprint:        fingerprint,
+		LinkDomains:        []string{"spammylink.org"},
+	}
+
+	if err := suite.state.DB.PutQuarantinedStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+func (suite *SpamTestSuite) TestQuarantinedStatusDelete() {
+	ctx := context.Background()
+	status := suite.putQuarantinedStatus("deadbeef")
+
+	apiStatuses, errWithCode := suite.adminProcessor.QuarantinedStatusesGet(ctx, nil)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiStatuses.Items, 1)
+
+	apiStatus, errWithCode := suite.adminProcessor.QuarantinedStatusDelete(ctx, status.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(status.URI, apiStatus.URI)
+	suite.Equal("foss_satan", apiStatus.Account.Username)
+	suite.Equal("the_mighty_zork", apiStatus.ReceivingAccount.Username)
+
+	_, err := suite.state.DB.GetQuarantinedStatusByID(ctx, status.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Deleting as spam should have taught the filter
+	// about the fingerprint and the link domain.
+	apiSignals, errWithCode := suite.adminProcessor.SpamSignalsGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(apiSignals, 2) {
+		suite.Equal("fingerprint", apiSignals[0].Type)
+		suite.Equal("deadbeef", apiSignals[0].Value)
+		suite.Equal(50, apiSignals[0].Score)
+		suite.Equal("link_domain", apiSignals[1].Type)
+		suite.Equal("spammylink.org", apiSignals[1].Value)
+		suite.Equal(20, apiSignals[1].Score)
+	}
+}
+
+func (suite *SpamTestSuite) TestQuarantinedStatusRelease() {
+	ctx := context.Background()
+
+	// Admin has said links to this domain are spammy.
+	_, errWithCode := suite.adminProcessor.SpamSignalCreate(ctx, &apimodel.SpamSignalRequest{
+		Type:  util.Ptr("link_domain"),
+		Value: util.Ptr("spammylink.org"),
+		Score: util.Ptr(30),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	status := suite.putQuarantinedStatus("deadbeef")
+	if _, errWithCode := suite.adminProcessor.QuarantinedStatusRelease(ctx, status.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, err := suite.state.DB.GetQuarantinedStatusByID(ctx, status.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Releasing should have marked the fingerprint
+	// as not spam, and reduced the link domain score.
+	signal, err := suite.state.DB.GetSpamSignal(ctx, gtsmodel.SpamSignalFingerprint, "deadbeef")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(-50, signal.Score)
+
+	signal, err = suite.state.DB.GetSpamSignal(ctx, gtsmodel.SpamSignalLinkDomain, "spammylink.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(10, signal.Score)
+}
+
+func (suite *SpamTestSuite) TestSpamSignalLifecycle() {
+	ctx := context.Background()
+
+	apiSignal, errWithCode := suite.adminProcessor.SpamSignalCreate(ctx, &apimodel.SpamSignalRequest{
+		Type:    util.Ptr("link_domain"),
+		Value:   util.Ptr(" ÖRNSKÖLDSVIK.example "),
+		Score:   util.Ptr(50),
+		Comment: util.Ptr("link spam"),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("xn--rnskldsvik-dcbe.example", apiSignal.Value)
+
+	// Can't create the same signal twice.
+	_, errWithCode = suite.adminProcessor.SpamSignalCreate(ctx, &apimodel.SpamSignalRequest{
+		Type:  util.Ptr("link_domain"),
+		Value: util.Ptr("xn--rnskldsvik-dcbe.example"),
+		Score: util.Ptr(10),
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	apiSignal, errWithCode = suite.adminProcessor.SpamSignalUpdate(ctx, apiSignal.ID, &apimodel.SpamSignalRequest{
+		Score: util.Ptr(80),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(80, apiSignal.Score)
+	suite.Equal("link spam", apiSignal.Comment)
+
+	// Value can't be changed.
+	_, errWithCode = suite.adminProcessor.SpamSignalUpdate(ctx, apiSignal.ID, &apimodel.SpamSignalRequest{
+		Value: util.Ptr("example.org"),
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Score can't be 0.
+	_, errWithCode = suite.adminProcessor.SpamSignalUpdate(ctx, apiSignal.ID, &apimodel.SpamSignalRequest{
+		Score: util.Ptr(0),
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.adminProcessor.SpamSignalDelete(ctx, apiSignal.ID)
+	suite.Nil(errWithCode)
+
+	apiSignals, errWithCode := suite.adminProcessor.SpamSignalsGet(ctx)
+	suite.Nil(errWithCode)
+	suite.Empty(apiSignals)
+}
+
+func TestSpamTestSuite(t *testing.T) {
+	suite.Run(t, new(SpamTestSuite))
+}
@@ -0,0 +1,325 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// TrendsTagsGet returns up to limit currently trending hashtags,
+// trendiest first, skipping the first offset of them. Unlike the
+// public trends, this includes hashtags pending review or rejected.
+func (p *Processor) TrendsTagsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	var tags []*gtsmodel.TrendingTag
+	if trends := p.state.Caches.Trends.Load(); trends != nil {
+		tags = page(trends.Tags, limit, offset)
+	}
+
+	apiTags := make([]*apimodel.AdminTrendsTag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.converter.TrendingTagToAdminAPITag(ctx, tag)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s: %v", tag.Tag.ID, err)
+			continue
+		}
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// TrendsStatusesGet returns up to limit currently trending statuses,
+// trendiest first, skipping the first offset of them. Unlike the
+// public trends, this includes statuses pending review or rejected.
+func (p *Processor) TrendsStatusesGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	var statuses []*gtsmodel.TrendingStatus
+	if trends := p.state.Caches.Trends.Load(); trends != nil {
+		statuses = page(trends.Statuses, limit, offset)
+	}
+
+	apiStatuses := make([]*apimodel.AdminTrendsStatus, 0, len(statuses))
+	for _, trending := range statuses {
+		status, err := p.state.DB.GetStatusByID(ctx, trending.StatusID)
+		if err != nil {
+			// Likely deleted since
+			// trends were computed.
+			log.Debugf(ctx, "error getting status %s: %v", trending.StatusID, err)
+			continue
+		}
+
+		apiStatus, err := p.trendingStatusToAdminAPIStatus(ctx, account, status, trending.Review)
+		if err != nil {
+			log.Errorf(ctx, "error converting status %s: %v", status.ID, err)
+			continue
+		}
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
+// TrendsLinksGet returns up to limit currently trending links,
+// trendiest first, skipping the first offset of them. Unlike the
+// public trends, this includes links pending review or rejected.
+func (p *Processor) TrendsLinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsLink, gtserror.WithCode) {
+	var links []*gtsmodel.TrendingLink
+	if trends := p.state.Caches.Trends.Load(); trends != nil {
+		links = page(trends.Links, limit, offset)
+	}
+
+	apiLinks := make([]*apimodel.AdminTrendsLink, 0, len(links))
+	for _, link := range links {
+		apiLinks = append(apiLinks, p.converter.TrendingLinkToAdminAPILink(link))
+	}
+
+	return apiLinks, nil
+}
+
+// TrendsTagReview approves (or rejects) the hashtag
+// with the given ID for showing publicly in trends.
+func (p *Processor) TrendsTagReview(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagID string,
+	approve bool,
+) (*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	tag, err := p.state.DB.GetTag(ctx, tagID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		err := fmt.Errorf("tag %s not found", tagID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	review, errWithCode := p.reviewTrend(ctx, account, gtsmodel.TrendTypeTag, tag.ID, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Use a copy of the trending tag from cache,
+	// if it's trending, so history is included.
+	trending := &gtsmodel.TrendingTag{Tag: tag}
+	if trends := p.state.Caches.Trends.Load(); trends != nil {
+		for _, t := range trends.Tags {
+			if t.Tag.ID == tag.ID {
+				t2 := *t
+				trending = &t2
+				break
+			}
+		}
+	}
+	trending.Review = review
+
+	apiTag, err := p.converter.TrendingTagToAdminAPITag(ctx, trending)
+	if err != nil {
+		err := gtserror.Newf("error converting tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiTag, nil
+}
+
+// TrendsStatusReview approves (or rejects) the status
+// with the given ID for showing publicly in trends.
+func (p *Processor) TrendsStatusReview(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	statusID string,
+	approve bool,
+) (*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := fmt.Errorf("status %s not found", statusID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	review, errWithCode := p.reviewTrend(ctx, account, gtsmodel.TrendTypeStatus, status.ID, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiStatus, err := p.trendingStatusToAdminAPIStatus(ctx, account, status, review)
+	if err != nil {
+		err := gtserror.Newf("error converting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStatus, nil
+}
+
+// TrendsLinkReview approves (or rejects) the trending
+// link with the given ID for showing publicly in trends.
+func (p *Processor) TrendsLinkReview(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	linkID string,
+	approve bool,
+) (*apimodel.AdminTrendsLink, gtserror.WithCode) {
+	// Trending links have no database entry of their
+	// own, just a review, whose ID is used as theirs.
+	existing, err := p.state.DB.GetTrendReviewByID(ctx, linkID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting review: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing == nil || existing.TargetType != gtsmodel.TrendTypeLink {
+		err := fmt.Errorf("link %s not found", linkID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	review, errWithCode := p.reviewTrend(ctx, account, gtsmodel.TrendTypeLink, existing.TargetID, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Use a copy of the trending link from cache,
+	// if it's trending, so history is included.
+	trending := &gtsmodel.TrendingLink{URL: review.TargetID}
+	if trends := p.state.Caches.Trends.Load(); trends != nil {
+		for _, l := range trends.Links {
+			if l.URL == review.TargetID {
+				l2 := *l
+				trending = &l2
+				break
+			}
+		}
+	}
+	trending.Review = review
+
+	return p.converter.TrendingLinkToAdminAPILink(trending), nil
+}
+
+// reviewTrend approves (or rejects) the given trend
+// target on behalf of the given admin account, storing
+// the review in the database and the trends cache.
+func (p *Processor) reviewTrend(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	targetType gtsmodel.TrendType,
+	targetID string,
+	approve bool,
+) (*gtsmodel.TrendReview, gtserror.WithCode) {
+	existing, err := p.state.DB.GetTrendReview(ctx, targetType, targetID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting review: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Take a copy of any existing
+	// review, as it may be in use
+	// by the trends cache.
+	var review gtsmodel.TrendReview
+	if existing != nil {
+		review = *existing
+	}
+
+	review.Approved = util.Ptr(approve)
+	review.ReviewedByAccountID = account.ID
+	review.ReviewedAt = time.Now()
+
+	if existing != nil {
+		err = p.state.DB.UpdateTrendReview(ctx, &review,
+			"approved",
+			"reviewed_by_account_id",
+			"reviewed_at",
+		)
+	} else {
+		review.ID = id.NewULID()
+		review.TargetType = targetType
+		review.TargetID = targetID
+		err = p.state.DB.PutTrendReview(ctx, &review)
+	}
+
+	if err != nil {
+		err := gtserror.Newf("db error storing review: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Apply review to cached trends, so it
+	// takes effect without a recompute.
+	p.state.Caches.Trends.SetReview(&review)
+
+	return &review, nil
+}
+
+// trendingStatusToAdminAPIStatus converts the given
+// trending status to its admin api representation.
+func (p *Processor) trendingStatusToAdminAPIStatus(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	review *gtsmodel.TrendReview,
+) (*apimodel.AdminTrendsStatus, error) {
+	apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, account, statusfilter.FilterContextNone, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.AdminTrendsStatus{
+		Status:         *apiStatus,
+		RequiresReview: review.Pending(),
+	}, nil
+}
+
+// page returns up to limit of the given
+// items, skipping the first offset of them.
+func page[T any](items []T, limit int, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
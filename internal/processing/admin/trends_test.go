@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TrendsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *TrendsTestSuite) TestTrendsTagReview() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		tag       = testrig.NewTestTags()["welcome"]
+	)
+
+	// Cache a snapshot with
+	// the tag pending review.
+	suite.state.Caches.Trends.Store(&gtsmodel.Trends{
+		Tags: []*gtsmodel.TrendingTag{{
+			Tag:    tag,
+			Score:  1,
+			Review: &gtsmodel.TrendReview{ID: "01J2M1HPFSS54S60Y0KYV23KJE", TargetType: gtsmodel.TrendTypeTag, TargetID: tag.ID},
+		}},
+	})
+
+	apiTags, errWithCode := suite.adminProcessor.TrendsTagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	if suite.Len(apiTags, 1) {
+		suite.Equal(tag.ID, apiTags[0].ID)
+		suite.True(apiTags[0].RequiresReview)
+		suite.False(apiTags[0].Trendable)
+	}
+
+	apiTag, errWithCode := suite.adminProcessor.TrendsTagReview(ctx, adminAcct, tag.ID, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(apiTag.RequiresReview)
+	suite.True(apiTag.Trendable)
+
+	// Review should be stored in the db...
+	review, err := suite.state.DB.GetTrendReview(ctx, gtsmodel.TrendTypeTag, tag.ID)
+	suite.NoError(err)
+	suite.True(*review.Approved)
+	suite.Equal(adminAcct.ID, review.ReviewedByAccountID)
+
+	// ...and applied to the cached snapshot.
+	cached := suite.state.Caches.Trends.Load().Tags[0].Review
+	suite.True(*cached.Approved)
+
+	// Now reject it again.
+	apiTag, errWithCode = suite.adminProcessor.TrendsTagReview(ctx, adminAcct, tag.ID, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(apiTag.RequiresReview)
+	suite.False(apiTag.Trendable)
+
+	review, err = suite.state.DB.GetTrendReview(ctx, gtsmodel.TrendTypeTag, tag.ID)
+	suite.NoError(err)
+	suite.False(*review.Approved)
+}
+
+func (suite *TrendsTestSuite) TestTrendsTagReviewNotFound() {
+	_, errWithCode := suite.adminProcessor.TrendsTagReview(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		"01J2M1HPFSS54S60Y0KYV23KJE",
+		true,
+	)
+	suite.EqualError(errWithCode, "tag 01J2M1HPFSS54S60Y0KYV23KJE not found")
+}
+
+func (suite *TrendsTestSuite) TestTrendsLinkReview() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		review    = &gtsmodel.TrendReview{
+			ID:         "01J2M1HPFSS54S60Y0KYV23KJE",
+			TargetType: gtsmodel.TrendTypeLink,
+			TargetID:   "https://example.org/article",
+		}
+	)
+
+	if err := suite.state.DB.PutTrendReview(ctx, review); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiLink, errWithCode := suite.adminProcessor.TrendsLinkReview(ctx, adminAcct, review.ID, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(review.ID, apiLink.ID)
+	suite.Equal("https://example.org/article", apiLink.URL)
+	suite.True(apiLink.Trendable)
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, &TrendsTestSuite{})
+}
@@ -0,0 +1,203 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// WebhooksGet returns all webhooks.
+func (p *Processor) WebhooksGet(ctx context.Context) ([]*apimodel.Webhook, gtserror.WithCode) {
+	webhooks, err := p.state.DB.GetWebhooks(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiWebhooks := make([]*apimodel.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		apiWebhooks[i] = toAPIWebhook(webhook)
+	}
+
+	return apiWebhooks, nil
+}
+
+// WebhookGet returns the webhook with the given ID.
+func (p *Processor) WebhookGet(ctx context.Context, id string) (*apimodel.Webhook, gtserror.WithCode) {
+	webhook, errWithCode := p.getWebhook(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return toAPIWebhook(webhook), nil
+}
+
+// WebhookCreate creates a new webhook from the given
+// form, marking it as created by admin. If no secret
+// is provided, a random one will be generated.
+func (p *Processor) WebhookCreate(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	form *apimodel.WebhookRequest,
+) (*apimodel.Webhook, gtserror.WithCode) {
+	webhook := &gtsmodel.Webhook{
+		ID:                 id.NewULID(),
+		Secret:             uuid.NewString(),
+		Disabled:           util.Ptr(false),
+		CreatedByAccountID: admin.ID,
+	}
+
+	if errWithCode := applyWebhookForm(webhook, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutWebhook(ctx, webhook); err != nil {
+		err := gtserror.Newf("error inserting into database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIWebhook(webhook), nil
+}
+
+// WebhookUpdate updates the webhook with
+// the given ID, using any fields set on the form.
+func (p *Processor) WebhookUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.WebhookRequest,
+) (*apimodel.Webhook, gtserror.WithCode) {
+	webhook, errWithCode := p.getWebhook(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := applyWebhookForm(webhook, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateWebhook(ctx, webhook); err != nil {
+		err := gtserror.Newf("error updating database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIWebhook(webhook), nil
+}
+
+// WebhookDelete deletes the webhook with the given
+// ID, returning the webhook as it was before deletion.
+func (p *Processor) WebhookDelete(ctx context.Context, id string) (*apimodel.Webhook, gtserror.WithCode) {
+	webhook, errWithCode := p.getWebhook(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteWebhookByID(ctx, id); err != nil {
+		err := gtserror.Newf("error deleting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIWebhook(webhook), nil
+}
+
+// getWebhook fetches the webhook
+// with given ID, wrapping any error.
+func (p *Processor) getWebhook(ctx context.Context, id string) (*gtsmodel.Webhook, gtserror.WithCode) {
+	webhook, err := p.state.DB.GetWebhookByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return webhook, nil
+
+	// Webhook does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "webhook not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// applyWebhookForm sets any fields present on the given
+// form on the webhook, normalizing and then validating.
+func applyWebhookForm(webhook *gtsmodel.Webhook, form *apimodel.WebhookRequest) gtserror.WithCode {
+	if form.URL != nil {
+		webhook.URL = strings.TrimSpace(*form.URL)
+	}
+
+	if form.Events != nil {
+		events := make([]string, 0, len(*form.Events))
+		for _, event := range *form.Events {
+			event = strings.TrimSpace(event)
+			if event != "" && !slices.Contains(events, event) {
+				events = append(events, event)
+			}
+		}
+		webhook.Events = events
+	}
+
+	if form.Secret != nil {
+		webhook.Secret = strings.TrimSpace(*form.Secret)
+	}
+
+	if form.Enabled != nil {
+		webhook.Disabled = util.Ptr(!*form.Enabled)
+	}
+
+	if err := validate.Webhook(webhook); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
+
+// toAPIWebhook performs a simple conversion
+// of database model Webhook to API model.
+func toAPIWebhook(webhook *gtsmodel.Webhook) *apimodel.Webhook {
+	apiWebhook := &apimodel.Webhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Secret:    webhook.Secret,
+		Enabled:   !webhook.IsDisabled(),
+		CreatedAt: util.FormatISO8601(webhook.CreatedAt),
+		UpdatedAt: util.FormatISO8601(webhook.UpdatedAt),
+	}
+
+	// Always serialize as array.
+	if apiWebhook.Events == nil {
+		apiWebhook.Events = []string{}
+	}
+
+	return apiWebhook
+}
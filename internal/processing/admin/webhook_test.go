@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type WebhookTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *WebhookTestSuite) TestWebhookLifecycle() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	apiWebhook, errWithCode := suite.adminProcessor.WebhookCreate(ctx, adminAcct, &apimodel.WebhookRequest{
+		URL:    util.Ptr(" https://moderation-bot.example.org/hooks/gts "),
+		Events: &[]string{"report.created", "report.created", "report.updated"},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("https://moderation-bot.example.org/hooks/gts", apiWebhook.URL)
+	suite.Equal([]string{"report.created", "report.updated"}, apiWebhook.Events)
+	suite.True(apiWebhook.Enabled)
+
+	// Secret should have been generated.
+	suite.NotEmpty(apiWebhook.Secret)
+
+	// Disable it and set our own secret.
+	apiWebhook, errWithCode = suite.adminProcessor.WebhookUpdate(ctx, apiWebhook.ID, &apimodel.WebhookRequest{
+		Secret:  util.Ptr("some-very-secret-secret"),
+		Enabled: util.Ptr(false),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(apiWebhook.Enabled)
+	suite.Equal("some-very-secret-secret", apiWebhook.Secret)
+	suite.Equal([]string{"report.created", "report.updated"}, apiWebhook.Events)
+
+	apiWebhooks, errWithCode := suite.adminProcessor.WebhooksGet(ctx)
+	suite.Nil(errWithCode)
+	suite.Len(apiWebhooks, 1)
+
+	_, errWithCode = suite.adminProcessor.WebhookDelete(ctx, apiWebhook.ID)
+	suite.Nil(errWithCode)
+
+	_, errWithCode = suite.adminProcessor.WebhookGet(ctx, apiWebhook.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *WebhookTestSuite) TestWebhookCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, form := range []*apimodel.WebhookRequest{
+		// No URL.
+		{Events: &[]string{"report.created"}},
+
+		// Not an http(s) URL.
+		{URL: util.Ptr("ftp://example.org/hook"), Events: &[]string{"report.created"}},
+
+		// No events.
+		{URL: util.Ptr("https://example.org/hook")},
+
+		// Unknown event.
+		{URL: util.Ptr("https://example.org/hook"), Events: &[]string{"account.deleted"}},
+
+		// Secret too short.
+		{URL: util.Ptr("https://example.org/hook"), Events: &[]string{"report.created"}, Secret: util.Ptr("hunter2")},
+	} {
+		_, errWithCode := suite.adminProcessor.WebhookCreate(ctx, adminAcct, form)
+		if suite.NotNil(errWithCode) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		}
+	}
+}
+
+func TestWebhookTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookTestSuite))
+}
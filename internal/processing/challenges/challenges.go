@@ -0,0 +1,180 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+type Processor struct {
+	state *state.State
+}
+
+func New(state *state.State) Processor {
+	return Processor{
+		state: state,
+	}
+}
+
+// Form is a form that can be protected by a challenge.
+type Form int
+
+const (
+	FormSignUp Form = iota
+	FormSignIn
+)
+
+// kind returns the kind of challenge configured
+// for form, or "" if form needs no challenge.
+func (f Form) kind() challenge.Kind {
+	switch f {
+	case FormSignUp:
+		return challenge.Kind(config.GetAccountsChallengeSignUp())
+	case FormSignIn:
+		return challenge.Kind(config.GetAccountsChallengeSignIn())
+	default:
+		return ""
+	}
+}
+
+// Issue returns a new challenge that must be solved
+// to submit form, or nil if form needs no challenge.
+// Only a limited number of challenges are issued to
+// each ip, so that they can't be requested en masse.
+func (p *Processor) Issue(
+	ctx context.Context,
+	form Form,
+	ip string,
+) (*challenge.Challenge, gtserror.WithCode) {
+	kind := form.kind()
+	if kind == "" {
+		return nil, nil
+	}
+
+	if !p.state.Caches.Challenges.Issue(ip) {
+		err := errors.New("too many challenges requested, please wait a while and try again")
+		return nil, gtserror.NewErrorTooManyRequests(err, err.Error())
+	}
+
+	challenger, err := challenge.New(kind)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	c, err := challenger.Issue(cache.ChallengeTTL)
+	if err != nil {
+		err := fmt.Errorf("error issuing %s challenge: %w", kind, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.state.Caches.Challenges.Pending.Set(c.ID, c)
+	return c, nil
+}
+
+// Check checks that answer solves the challenge with the
+// given ID, which must have been issued for form. Failed
+// answers count toward the cooldown for ip, and while ip
+// is cooling down, all answers are refused.
+//
+// If form needs no challenge, Check only checks cooldown.
+func (p *Processor) Check(
+	ctx context.Context,
+	form Form,
+	ip string,
+	id string,
+	answer string,
+) gtserror.WithCode {
+	if errWithCode := p.Cooldown(ip); errWithCode != nil {
+		return errWithCode
+	}
+
+	kind := form.kind()
+	if kind == "" {
+		return nil
+	}
+
+	// Take the challenge so it
+	// can't be answered again,
+	// whether this answer is
+	// right or wrong.
+	c, ok := p.state.Caches.Challenges.Take(id)
+	if !ok || c.Kind != kind {
+		p.Fail(ip)
+		err := errors.New("challenge not found or expired, please reload the page and try again")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	challenger, err := challenge.New(kind)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !challenger.Check(c, answer) {
+		p.Fail(ip)
+		err := errors.New("challenge answer was not correct, please reload the page and try again")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
+
+// Cooldown returns an error if ip has failed too many
+// challenges or sign-ins lately, and must wait a while.
+func (p *Processor) Cooldown(ip string) gtserror.WithCode {
+	if !p.state.Caches.Challenges.CoolingDown(ip) {
+		return nil
+	}
+
+	err := fmt.Errorf(
+		"too many failed attempts, please wait %s and try again",
+		config.GetAccountsChallengeCooldown(),
+	)
+	return gtserror.NewErrorTooManyRequests(err, err.Error())
+}
+
+// Fail records a failed attempt from ip, eg., a
+// wrong password, toward the cooldown for ip.
+func (p *Processor) Fail(ip string) {
+	p.state.Caches.Challenges.Fail(ip)
+}
+
+// CaptchaImage returns a PNG image for
+// the pending captcha with the given ID.
+func (p *Processor) CaptchaImage(ctx context.Context, id string) ([]byte, gtserror.WithCode) {
+	c, ok := p.state.Caches.Challenges.Pending.Get(id)
+	if !ok || c.Kind != challenge.KindCaptcha || c.Expired() {
+		err := fmt.Errorf("captcha %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	img, err := (&challenge.Captcha{}).Image(c)
+	if err != nil {
+		err := fmt.Errorf("error drawing captcha: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return img, nil
+}
@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenges_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/challenges"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ChallengesTestSuite struct {
+	suite.Suite
+	state      state.State
+	challenges challenges.Processor
+}
+
+func (suite *ChallengesTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	suite.challenges = challenges.New(&suite.state)
+}
+
+// solve brute forces the answer to a PoW challenge.
+func (suite *ChallengesTestSuite) solve(c *challenge.Challenge) string {
+	pow := &challenge.PoW{Difficulty: c.Difficulty}
+	for i := 0; ; i++ {
+		if answer := strconv.Itoa(i); pow.Check(c, answer) {
+			return answer
+		}
+	}
+}
+
+func (suite *ChallengesTestSuite) TestNoChallenge() {
+	ctx := context.Background()
+
+	c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.1")
+	suite.NoError(errWithCode)
+	suite.Nil(c)
+
+	errWithCode = suite.challenges.Check(ctx, challenges.FormSignUp, "127.0.0.1", "", "")
+	suite.NoError(errWithCode)
+}
+
+func (suite *ChallengesTestSuite) TestPoW() {
+	ctx := context.Background()
+	config.SetAccountsChallengeSignUp(config.AccountsChallengePoW)
+
+	c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.1")
+	suite.NoError(errWithCode)
+	suite.Equal(challenge.KindPoW, c.Kind)
+
+	answer := suite.solve(c)
+	errWithCode = suite.challenges.Check(ctx, challenges.FormSignUp, "127.0.0.1", c.ID, answer)
+	suite.NoError(errWithCode)
+
+	// Same answer can't be used twice.
+	errWithCode = suite.challenges.Check(ctx, challenges.FormSignUp, "127.0.0.1", c.ID, answer)
+	suite.EqualError(errWithCode, "challenge not found or expired, please reload the page and try again")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *ChallengesTestSuite) TestWrongForm() {
+	ctx := context.Background()
+	config.SetAccountsChallengeSignUp(config.AccountsChallengePoW)
+	config.SetAccountsChallengeSignIn(config.AccountsChallengeCaptcha)
+
+	// A solved sign-up challenge
+	// can't be used to sign in.
+	c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.1")
+	suite.NoError(errWithCode)
+
+	errWithCode = suite.challenges.Check(ctx, challenges.FormSignIn, "127.0.0.1", c.ID, suite.solve(c))
+	suite.EqualError(errWithCode, "challenge not found or expired, please reload the page and try again")
+}
+
+func (suite *ChallengesTestSuite) TestCooldown() {
+	ctx := context.Background()
+	config.SetAccountsChallengeSignIn(config.AccountsChallengeCaptcha)
+	config.SetAccountsChallengeCooldownAttempts(3)
+
+	// Fail the captcha three times.
+	for i := 0; i < 3; i++ {
+		c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignIn, "127.0.0.1")
+		suite.NoError(errWithCode)
+
+		errWithCode = suite.challenges.Check(ctx, challenges.FormSignIn, "127.0.0.1", c.ID, "nope")
+		suite.EqualError(errWithCode, "challenge answer was not correct, please reload the page and try again")
+	}
+
+	// Now even the right answer is refused.
+	c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignIn, "127.0.0.1")
+	suite.NoError(errWithCode)
+
+	errWithCode = suite.challenges.Check(ctx, challenges.FormSignIn, "127.0.0.1", c.ID, c.Text)
+	suite.EqualError(errWithCode, "too many failed attempts, please wait 15m0s and try again")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// Other IPs aren't affected.
+	errWithCode = suite.challenges.Check(ctx, challenges.FormSignIn, "127.0.0.2", c.ID, c.Text)
+	suite.NoError(errWithCode)
+}
+
+func (suite *ChallengesTestSuite) TestIssueLimit() {
+	ctx := context.Background()
+	config.SetAccountsChallengeSignUp(config.AccountsChallengePoW)
+
+	// Use up the challenges for one IP.
+	for i := 0; i < cache.ChallengeIssueLimit; i++ {
+		_, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.1")
+		suite.NoError(errWithCode)
+	}
+
+	// No more are issued to that IP.
+	c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.1")
+	suite.Nil(c)
+	suite.EqualError(errWithCode, "too many challenges requested, please wait a while and try again")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// Other IPs aren't affected.
+	c, errWithCode = suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.2")
+	suite.NoError(errWithCode)
+	suite.NotNil(c)
+}
+
+func (suite *ChallengesTestSuite) TestCaptchaImage() {
+	ctx := context.Background()
+	config.SetAccountsChallengeSignUp(config.AccountsChallengeCaptcha)
+
+	c, errWithCode := suite.challenges.Issue(ctx, challenges.FormSignUp, "127.0.0.1")
+	suite.NoError(errWithCode)
+
+	img, errWithCode := suite.challenges.CaptchaImage(ctx, c.ID)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(img)
+
+	_, errWithCode = suite.challenges.CaptchaImage(ctx, "nonexistent")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestChallengesTestSuite(t *testing.T) {
+	suite.Run(t, new(ChallengesTestSuite))
+}
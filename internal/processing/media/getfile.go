@@ -88,6 +88,12 @@ func (p *Processor) GetFile(
 			wantedMediaID,
 			mediaSize,
 		)
+	case media.TypeCard:
+		return p.getCardContent(ctx,
+			owningAccountID,
+			wantedMediaID,
+			mediaSize,
+		)
 	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
 		return p.getAttachmentContent(ctx,
 			requester,
@@ -295,6 +301,54 @@ func (p *Processor) getEmojiContent(
 	}
 }
 
+func (p *Processor) getCardContent(
+	ctx context.Context,
+	ownerID string,
+	pathID string,
+	sizeStr media.Size,
+) (
+	*apimodel.Content,
+	gtserror.WithCode,
+) {
+	// Card images only come in one size.
+	if sizeStr != media.SizeSmall {
+		const text = "invalid preview card size"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Reconstruct card image URL to search for it.
+	// As refreshed card images use a newly generated
+	// path ID, this is not necessarily the card ID.
+	imageURL := uris.URIForAttachment(
+		ownerID,
+		string(media.TypeCard),
+		string(media.SizeSmall),
+		pathID,
+		"jpg",
+	)
+
+	// Search for card with given image URL in the database.
+	card, err := p.state.DB.GetPreviewCardByImageURL(ctx, imageURL)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error fetching preview card from database: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if card == nil {
+		const text = "preview card not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.getContent(ctx,
+		card.ImagePath,
+		&apimodel.Content{
+			ContentType:    card.ImageContentType,
+			ContentLength:  int64(card.ImageFileSize),
+			ContentUpdated: card.UpdatedAt,
+		},
+	)
+}
+
 // getContent performs the final file fetching of
 // stored content at path in storage. This is
 // populated in the apimodel.Content{} and returned.
@@ -342,6 +396,8 @@ func parseType(s string) (media.Type, error) {
 		return media.TypeAvatar, nil
 	case string(media.TypeEmoji):
 		return media.TypeEmoji, nil
+	case string(media.TypeCard):
+		return media.TypeCard, nil
 	}
 	return "", fmt.Errorf("%s not a recognized media.Type", s)
 }
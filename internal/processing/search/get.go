@@ -24,6 +24,7 @@ import (
 	"net/mail"
 	"net/url"
 	"strings"
+	"time"
 
 	"codeberg.org/gruf/go-kv"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -32,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -119,21 +121,6 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -221,6 +208,19 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		// URI searches only ever have one
+		// page of results, so skip if offset.
+		if offset > 0 {
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -372,6 +372,12 @@ func (p *Processor) accountsByUsernameDomain(
 		)
 	}
 
+	if offset > 0 {
+		// Exact matches only ever
+		// have one page of results.
+		return nil
+	}
+
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
@@ -845,11 +851,30 @@ func (p *Processor) statusesByText(
 		fromAccountID = parsed.fromAccountID
 	}
 
+	// Date operators narrow whatever
+	// range was given by maxID and minID.
+	if parsed.maxID != "" && (maxID == "" || parsed.maxID < maxID) {
+		maxID = parsed.maxID
+	}
+	if parsed.minID > minID {
+		minID = parsed.minID
+	}
+
+	if query == "" &&
+		fromAccountID == "" &&
+		!parsed.mediaOnly &&
+		parsed.maxID == "" &&
+		parsed.minID == "" {
+		// Nothing to search for.
+		return nil
+	}
+
 	statuses, err := p.state.DB.SearchForStatuses(
 		ctx,
 		requestingAccountID,
 		query,
 		fromAccountID,
+		parsed.mediaOnly,
 		maxID,
 		minID,
 		limit,
@@ -872,6 +897,12 @@ type parsedQuery struct {
 	query string
 	// fromAccountID is the account from a successfully resolved `from:` operator, if present.
 	fromAccountID string
+	// mediaOnly is true if a `has:media` operator was present.
+	mediaOnly bool
+	// maxID is derived from a `before:` or `during:` operator, if present.
+	maxID string
+	// minID is derived from an `after:` or `during:` operator, if present.
+	minID string
 }
 
 // parseQuery parses query text and handles any search operator terms present.
@@ -885,6 +916,35 @@ func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsed
 			if err != nil {
 				return
 			}
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "has:"); hasPrefix {
+			if arg != "media" {
+				err = gtserror.Newf(
+					"the 'has:' search operator only supports 'media', but %q was provided",
+					arg,
+				)
+				return
+			}
+			parsed.mediaOnly = true
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "before:"); hasPrefix {
+			parsed.maxID, err = parseDateOperatorArg("before", arg, 0)
+			if err != nil {
+				return
+			}
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "after:"); hasPrefix {
+			// After a date means from the start of the next day.
+			parsed.minID, err = parseDateOperatorArg("after", arg, 24*time.Hour)
+			if err != nil {
+				return
+			}
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "during:"); hasPrefix {
+			parsed.minID, err = parseDateOperatorArg("during", arg, 0)
+			if err != nil {
+				return
+			}
+			parsed.maxID, err = parseDateOperatorArg("during", arg, 24*time.Hour)
+			if err != nil {
+				return
+			}
 		} else {
 			nonOperatorQueryParts = append(nonOperatorQueryParts, queryPart)
 		}
@@ -893,6 +953,29 @@ func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsed
 	return
 }
 
+// parseDateOperatorArg attempts to parse the argument of a date operator
+// like before: as a YYYY-MM-DD date in UTC, and returns the lowest ID
+// possible for the start of that date, plus the given offset.
+func parseDateOperatorArg(operator string, date string, offset time.Duration) (string, error) {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return "", gtserror.Newf(
+			"the '%s:' search operator couldn't parse its argument as a YYYY-MM-DD date: %w",
+			operator, err,
+		)
+	}
+
+	boundary, err := id.NewLowestULIDFromTime(t.Add(offset))
+	if err != nil {
+		return "", gtserror.Newf(
+			"the '%s:' search operator couldn't use its argument as a date: %w",
+			operator, err,
+		)
+	}
+
+	return boundary, nil
+}
+
 // parseFromOperatorArg attempts to parse the from: operator's argument as an account name,
 // and returns the account ID if possible. Allows specifying an account name with or without a leading @.
 func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string) (string, error) {
@@ -73,6 +73,20 @@ func (p *Processor) packageAccounts(
 			continue
 		}
 
+		if account.IsSilenced() && account.ID != requestingAccount.ID {
+			// Silenced accounts are only
+			// searchable by their followers.
+			follows, err := p.state.DB.IsFollowing(ctx, requestingAccount.ID, account.ID)
+			if err != nil {
+				err = gtserror.Newf("error checking follow between searching account %s and searched account %s: %w", requestingAccount.ID, account.ID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if !follows {
+				continue
+			}
+		}
+
 		var apiAccount *apimodel.Account
 		if blocked {
 			apiAccount, err = p.converter.AccountToAPIAccountBlocked(ctx, account)
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
@@ -50,6 +51,11 @@ func (p *Processor) Create(
 	*apimodel.Status,
 	gtserror.WithCode,
 ) {
+	if util.PtrValueOr(requester.Memorial, false) {
+		const text = "memorial accounts can't post"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
 	// Ensure account populated; we'll need settings.
 	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
 		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
@@ -109,6 +115,15 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuotedStatusID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -199,6 +214,49 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quotedStatusID string) gtserror.WithCode {
+	if quotedStatusID == "" {
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quoteOf, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quotedStatusID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quoteOf, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quoteOf,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Only allow quoting statuses that are
+	// already visible to the public, so that
+	// quotes can't leak followers-only posts.
+	switch quoteOf.Visibility {
+	case gtsmodel.VisibilityPublic,
+		gtsmodel.VisibilityUnlocked:
+	default:
+		const text = "quoted status must be public or unlisted"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Set status fields from quoteOf.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfURI = quoteOf.URI
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -360,9 +418,11 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 }
 
 func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
-	if form.Language != "" {
-		status.Language = form.Language
-	} else {
+	// Prefer the given language, unless status text strongly
+	// contradicts it. Without one, use the detected language,
+	// and only fall back to the account default after that.
+	status.Language = language.Resolve(form.Language, form.Status)
+	if status.Language == "" {
 		status.Language = accountDefaultLanguage
 	}
 	if status.Language == "" {
@@ -19,6 +19,7 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -242,6 +243,42 @@ func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	suite.Equal("zh-Hans", *apiStatus.Language)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessLanguageDetected() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "Heute Morgen habe ich endlich den Garten aufgeräumt, und jetzt sitze ich mit einem Kaffee auf der Terrasse.",
+			MediaIDs:    []string{},
+			Poll:        nil,
+			InReplyToID: "",
+			Sensitive:   false,
+			SpoilerText: "",
+			Visibility:  apimodel.VisibilityPublic,
+			ScheduledAt: "",
+			Language:    "",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: nil,
+			Boostable: nil,
+			Replyable: nil,
+			Likeable:  nil,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Detected language should be used
+	// instead of the account default.
+	suite.Equal("de", *apiStatus.Language)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	ctx := context.Background()
 
@@ -286,6 +323,91 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+// quoteForm returns a status create form
+// quoting the status with the given ID.
+func quoteForm(quotedStatusID string) *apimodel.AdvancedStatusCreateForm {
+	return &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:         "look at this!",
+			Visibility:     apimodel.VisibilityPublic,
+			Language:       "en",
+			ContentType:    apimodel.StatusContentTypePlain,
+			QuotedStatusID: quotedStatusID,
+		},
+	}
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoted := suite.testStatuses["local_account_2_status_1"]
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, quoteForm(quoted.ID))
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	if suite.NotNil(apiStatus.Quote) {
+		suite.Equal(quoted.ID, apiStatus.Quote.ID)
+	}
+
+	dbStatus, dbErr := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.Equal(quoted.ID, dbStatus.QuoteOfID)
+	suite.Equal(quoted.URI, dbStatus.QuoteOfURI)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteNotVisible() {
+	ctx := context.Background()
+
+	// Admin doesn't follow turtle, so
+	// can't see their followers-only post.
+	creatingAccount := suite.testAccounts["admin_account"]
+	creatingApplication := suite.testApplications["admin_account"]
+	quoted := suite.testStatuses["local_account_2_status_7"]
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, quoteForm(quoted.ID))
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "target status not found")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteNotPublic() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Zork can see both of these, but
+	// neither should be quotable.
+	for _, key := range []string{
+		"local_account_2_status_6", // direct
+		"local_account_2_status_7", // followers-only
+	} {
+		quoted := suite.testStatuses[key]
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, quoteForm(quoted.ID))
+		suite.Nil(apiStatus, key)
+		suite.EqualError(errWithCode, "quoted status must be public or unlisted", key)
+		suite.Equal(http.StatusForbidden, errWithCode.Code(), key)
+	}
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteMissing() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, quoteForm("01HZNOTAREALSTATUSID00000"))
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "target status not found")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -0,0 +1,240 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// Default dimensions, in pixels, of
+	// the frame that statuses are embedded
+	// in, if the embedder doesn't ask for
+	// anything smaller.
+	embedWidth  = 400
+	embedHeight = 400
+
+	// Time in seconds that consumers
+	// may cache oEmbed responses for.
+	oEmbedCacheAge = 86400
+)
+
+// EmbedGet returns the web representation of the status with the
+// given ID, for rendering in a frame on another website, provided
+// that the status may be embedded. See embeddableStatus.
+func (p *Processor) EmbedGet(ctx context.Context, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.embeddableStatus(ctx, func() (*gtsmodel.Status, error) {
+		return p.state.DB.GetStatusByID(ctx, targetStatusID)
+	})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	webStatus, err := p.converter.StatusToWebStatus(ctx, targetStatus, nil)
+	if err != nil {
+		err = gtserror.Newf("error converting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return webStatus, nil
+}
+
+// EmbedHTML returns the HTML snippet for embedding the status
+// with the given ID on another website, or an empty string if
+// the status may not be embedded. See embeddableStatus.
+func (p *Processor) EmbedHTML(ctx context.Context, targetStatusID string) string {
+	targetStatus, errWithCode := p.embeddableStatus(ctx, func() (*gtsmodel.Status, error) {
+		return p.state.DB.GetStatusByID(ctx, targetStatusID)
+	})
+	if errWithCode != nil {
+		if errWithCode.Code() != http.StatusNotFound {
+			log.Errorf(ctx, "error checking if status is embeddable: %v", errWithCode)
+		}
+		return ""
+	}
+
+	return embedHTML(targetStatus, embedWidth, embedHeight)
+}
+
+// OEmbedGet returns an oEmbed response for the status at the
+// given web URL or ActivityPub URI, with an HTML snippet for
+// embedding the status in a frame no larger than the given
+// dimensions. 0 means no maximum. Only statuses that may be
+// embedded are returned, see embeddableStatus.
+func (p *Processor) OEmbedGet(
+	ctx context.Context,
+	statusURL string,
+	maxWidth int,
+	maxHeight int,
+) (*apimodel.OEmbed, gtserror.WithCode) {
+	u, err := url.Parse(statusURL)
+	if err != nil || u.Host != config.GetHost() {
+		const text = "url is not a status on this instance"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	// Drop anything not part
+	// of the status URL / URI.
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	targetStatus, errWithCode := p.embeddableStatus(ctx, func() (*gtsmodel.Status, error) {
+		status, err := p.state.DB.GetStatusByURL(ctx, u.String())
+		if errors.Is(err, db.ErrNoEntries) {
+			// Try again with the URI.
+			status, err = p.state.DB.GetStatusByURI(ctx, u.String())
+		}
+		return status, err
+	})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	width, height := embedWidth, embedHeight
+	if maxWidth > 0 && maxWidth < width {
+		width = maxWidth
+	}
+	if maxHeight > 0 && maxHeight < height {
+		height = maxHeight
+	}
+
+	author := targetStatus.Account
+	authorName := author.DisplayName
+	if authorName == "" {
+		authorName = author.Username
+	}
+
+	providerName := config.GetHost()
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		log.Errorf(ctx, "db error getting instance: %v", err)
+	} else if instance.Title != "" {
+		providerName = instance.Title
+	}
+
+	return &apimodel.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        "Post by @" + author.Username + "@" + config.GetAccountDomain(),
+		AuthorName:   authorName,
+		AuthorURL:    author.URL,
+		ProviderName: providerName,
+		ProviderURL:  config.GetProtocol() + "://" + config.GetHost(),
+		CacheAge:     oEmbedCacheAge,
+		HTML:         embedHTML(targetStatus, width, height),
+		Width:        width,
+		Height:       height,
+	}, nil
+}
+
+// embeddableStatus gets the target status using the given
+// function, returning a not found error unless it may be
+// embedded on other websites. That is, the status must be
+// a local Public or Unlisted status that isn't a boost or
+// local-only, its author must be visible to the public, and
+// must not have opted out of having their statuses embedded.
+func (p *Processor) embeddableStatus(
+	ctx context.Context,
+	getTargetFromDB func() (*gtsmodel.Status, error),
+) (*gtsmodel.Status, gtserror.WithCode) {
+	const text = "target status not found"
+
+	targetStatus, visible, errWithCode := p.c.GetTargetStatusBy(ctx,
+		nil, // requester
+		getTargetFromDB,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !targetStatus.IsLocal() || targetStatus.BoostOfID != "" {
+		err := gtserror.Newf("status %s is not embeddable", targetStatus.ID)
+		return nil, gtserror.NewErrorNotFound(err, text)
+	}
+
+	switch targetStatus.Visibility {
+	case gtsmodel.VisibilityPublic:
+		// Public statuses may be embedded
+		// if they're visible without auth.
+
+	case gtsmodel.VisibilityUnlocked:
+		// Unlisted statuses are never visible without
+		// auth, so that they can't be discovered, but
+		// an embed is only shown where someone chose
+		// to put it. Just make sure it's not local-only
+		// and its author is visible.
+		visible = false
+		if !util.PtrValueOr(targetStatus.Federated, true) {
+			break
+		}
+
+		var err error
+		visible, err = p.filter.AccountVisible(ctx, nil, targetStatus.Account)
+		if err != nil {
+			err := gtserror.Newf("error checking account visibility: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+	default:
+		visible = false
+	}
+
+	if !visible {
+		err := gtserror.Newf("status %s is not embeddable", targetStatus.ID)
+		return nil, gtserror.NewErrorNotFound(err, text)
+	}
+
+	author := targetStatus.Account
+	if author.Settings == nil {
+		var err error
+		author.Settings, err = p.state.DB.GetAccountSettings(ctx, author.ID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account settings: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if author.IsSuspended() || util.PtrValueOr(author.Settings.DisableEmbeds, false) {
+		err := gtserror.Newf("author of status %s has disabled embeds", targetStatus.ID)
+		return nil, gtserror.NewErrorNotFound(err, text)
+	}
+
+	return targetStatus, nil
+}
+
+// embedHTML returns an HTML snippet for embedding the given
+// status on another website, in a frame of the given size.
+func embedHTML(status *gtsmodel.Status, width int, height int) string {
+	return `<iframe src="` + html.EscapeString(status.URL+"/embed") + `"` +
+		` class="gotosocial-embed" style="max-width: 100%; border: 0"` +
+		` width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) + `"` +
+		` allowfullscreen="allowfullscreen" loading="lazy"></iframe>`
+}
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type EmbedTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *EmbedTestSuite) TestOEmbedGet() {
+	ctx := context.Background()
+	status := suite.testStatuses["admin_account_status_1"]
+
+	oEmbed, errWithCode := suite.status.OEmbedGet(ctx, status.URL, 0, 0)
+	suite.NoError(errWithCode)
+	suite.Equal("rich", oEmbed.Type)
+	suite.Equal("Post by @admin@localhost:8080", oEmbed.Title)
+	suite.Equal("admin", oEmbed.AuthorName)
+	suite.Equal("http://localhost:8080/@admin", oEmbed.AuthorURL)
+	suite.Equal("http://localhost:8080", oEmbed.ProviderURL)
+	suite.Equal(400, oEmbed.Width)
+	suite.Equal(400, oEmbed.Height)
+	suite.Equal(`<iframe src="http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/embed" class="gotosocial-embed" style="max-width: 100%; border: 0" width="400" height="400" allowfullscreen="allowfullscreen" loading="lazy"></iframe>`, oEmbed.HTML)
+}
+
+func (suite *EmbedTestSuite) TestOEmbedGetByURIWithMaxSize() {
+	ctx := context.Background()
+	status := suite.testStatuses["local_account_2_status_3"] // unlisted
+
+	oEmbed, errWithCode := suite.status.OEmbedGet(ctx, status.URI+"?foo=bar", 300, 1000)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(300, oEmbed.Width)
+	suite.Equal(400, oEmbed.Height)
+	suite.Contains(oEmbed.HTML, `src="`+status.URL+`/embed"`)
+}
+
+func (suite *EmbedTestSuite) TestOEmbedGetNotEmbeddable() {
+	ctx := context.Background()
+
+	for _, statusURL := range []string{
+		// Followers-only.
+		suite.testStatuses["local_account_1_status_5"].URL,
+		// Direct.
+		suite.testStatuses["local_account_2_status_6"].URL,
+		// Unlisted, but local-only.
+		suite.testStatuses["local_account_1_status_2"].URL,
+		// Remote.
+		suite.testStatuses["remote_account_1_status_1"].URL,
+		// Doesn't exist.
+		"http://localhost:8080/@admin/statuses/01J3Q5V9XWJ0XN6ZKTT3EG0ZZB",
+		// Not a URL.
+		"%%%",
+	} {
+		_, errWithCode := suite.status.OEmbedGet(ctx, statusURL, 0, 0)
+		suite.Equal(http.StatusNotFound, errWithCode.Code(), statusURL)
+	}
+}
+
+func (suite *EmbedTestSuite) TestEmbedsDisabled() {
+	ctx := context.Background()
+	status := suite.testStatuses["admin_account_status_1"]
+
+	// Embeddable to begin with.
+	suite.NotEmpty(suite.status.EmbedHTML(ctx, status.ID))
+
+	settings, err := suite.db.GetAccountSettings(ctx, status.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	settings.DisableEmbeds = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "disable_embeds"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// No longer embeddable.
+	suite.Empty(suite.status.EmbedHTML(ctx, status.ID))
+
+	_, errWithCode := suite.status.EmbedGet(ctx, status.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	_, errWithCode = suite.status.OEmbedGet(ctx, status.URL, 0, 0)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestEmbedTestSuite(t *testing.T) {
+	suite.Run(t, new(EmbedTestSuite))
+}
@@ -0,0 +1,275 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// getReactableStatus fetches the target status for an emoji
+// reaction (unwrapping boosts), ensuring it's visible to, and
+// can be reacted to by, the requester. The given emoji name is
+// also parsed, returning the reaction name + custom emoji (if any).
+func (p *Processor) getReactableStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	name string,
+) (
+	*gtsmodel.Status,
+	string,
+	*gtsmodel.Emoji,
+	gtserror.WithCode,
+) {
+	name, emoji, errWithCode := p.parseReactionName(ctx, name)
+	if errWithCode != nil {
+		return nil, "", nil, errWithCode
+	}
+
+	// Get target status and ensure it's not a boost.
+	target, errWithCode := p.c.GetVisibleTargetStatus(
+		ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, "", nil, errWithCode
+	}
+
+	target, errWithCode = p.c.UnwrapIfBoost(
+		ctx,
+		requester,
+		target,
+	)
+	if errWithCode != nil {
+		return nil, "", nil, errWithCode
+	}
+
+	// Reactions are a kind of
+	// fave, so use same policy.
+	if !*target.Likeable {
+		err := errors.New("status is not reactable")
+		return nil, "", nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return target, name, emoji, nil
+}
+
+// parseReactionName parses the given emoji reaction name, which
+// may be a unicode emoji, or a custom emoji shortcode (with or
+// without surrounding colons, and optionally @domain for remote
+// emojis), returning a normalized name and any custom emoji.
+func (p *Processor) parseReactionName(
+	ctx context.Context,
+	name string,
+) (string, *gtsmodel.Emoji, gtserror.WithCode) {
+	name = strings.TrimSpace(name)
+
+	if err := validate.UnicodeEmoji(name); err == nil {
+		// Plain old unicode emoji.
+		return name, nil, nil
+	}
+
+	// Not unicode, so should be custom emoji.
+	shortcode := strings.Trim(name, ":")
+	shortcode, domain, _ := strings.Cut(shortcode, "@")
+
+	if err := validate.EmojiShortcode(shortcode); err != nil {
+		const text = "reaction must be a unicode emoji, or a custom emoji shortcode"
+		return "", nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+		return "", nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if emoji == nil || *emoji.Disabled {
+		const text = "custom emoji not found"
+		return "", nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return ":" + emoji.Shortcode + ":", emoji, nil
+}
+
+// ReactionCreate adds an emoji reaction for the requester, targeting
+// the given status (no-op if the reaction already exists).
+func (p *Processor) ReactionCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	name string,
+) (*apimodel.Status, gtserror.WithCode) {
+	target, name, emoji, errWithCode := p.getReactableStatus(ctx, requester, targetID, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	existing, err := p.state.DB.GetStatusReaction(ctx, requester.ID, target.ID, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		// Status already reacted
+		// to with this emoji.
+		return p.c.GetAPIStatus(ctx, requester, target)
+	}
+
+	// Create and store a new reaction.
+	reactionID := id.NewULID()
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		Name:            name,
+		URI:             uris.GenerateURIForLike(requester.Username, reactionID),
+	}
+
+	if emoji != nil {
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		err := gtserror.Newf("db error putting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process new reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         target.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, target)
+}
+
+// ReactionRemove removes an emoji reaction by the requester, targeting
+// the given status (no-op if the reaction doesn't exist).
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	name string,
+) (*apimodel.Status, gtserror.WithCode) {
+	target, name, _, errWithCode := p.getReactableStatus(ctx, requester, targetID, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	existing, err := p.state.DB.GetStatusReaction(ctx, requester.ID, target.ID, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing == nil {
+		// Status not reacted
+		// to with this emoji.
+		return p.c.GetAPIStatus(ctx, requester, target)
+	}
+
+	// We have a reaction to remove.
+	if err := p.state.DB.DeleteStatusReactionByID(ctx, existing.ID); err != nil {
+		err := gtserror.Newf("db error removing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process remove reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       existing,
+		Origin:         requester,
+		Target:         target.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, target)
+}
+
+// ReactionsGet returns the emoji reactions to the given status, grouped
+// by emoji, filtered according to blocks. If name is set, only reactions
+// using that emoji will be returned.
+func (p *Processor) ReactionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	name string,
+) ([]*apimodel.EmojiReaction, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	reactions, err := p.state.DB.GetStatusReactions(ctx, target.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if name != "" {
+		name, _, errWithCode = p.parseReactionName(ctx, name)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		// Only keep reactions using given emoji.
+		filtered := make([]*gtsmodel.StatusReaction, 0, len(reactions))
+		for _, reaction := range reactions {
+			if reaction.Name == name {
+				filtered = append(filtered, reaction)
+			}
+		}
+		reactions = filtered
+	}
+
+	apiReactions, err := p.converter.StatusReactionsToAPIEmojiReactions(ctx, reactions, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReactions, nil
+}
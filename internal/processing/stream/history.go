@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+
+	"codeberg.org/gruf/go-kv"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// History returns recent messages for the given account and stream type posted
+// since the message with given ID, for replay to clients that are reconnecting.
+func (p *Processor) History(ctx context.Context, account *gtsmodel.Account, streamType string, sinceID string) []stream.Message {
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"account", account.ID},
+		{"streamType", streamType},
+		{"sinceID", sinceID},
+	}...)
+	l.Debug("received stream history request")
+	return p.streams.History(account.ID, sinceID, streamType)
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type HistoryTestSuite struct {
+	StreamTestSuite
+}
+
+func (suite *HistoryTestSuite) TestStreamHistory() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	// Post a message with no stream open,
+	// this should not be kept for replay.
+	suite.streamProcessor.FiltersChanged(ctx, account)
+
+	history := suite.streamProcessor.History(ctx, account, stream.TimelineHome, "")
+	suite.Empty(history)
+
+	// Nor for a stream that isn't replayable.
+	plainStream, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineDirect)
+	suite.NoError(errWithCode)
+	suite.streamProcessor.FiltersChanged(ctx, account)
+	plainStream.Close()
+
+	history = suite.streamProcessor.History(ctx, account, stream.TimelineHome, "")
+	suite.Empty(history)
+
+	// Open and close a replayable stream, messages
+	// posted after should be kept for reconnection.
+	replayStream, errWithCode := suite.streamProcessor.OpenReplay(ctx, account, stream.TimelineDirect)
+	suite.NoError(errWithCode)
+	replayStream.Close()
+
+	suite.streamProcessor.FiltersChanged(ctx, account)
+
+	history = suite.streamProcessor.History(ctx, account, stream.TimelineHome, "")
+	if !suite.Len(history, 1) {
+		suite.FailNow("")
+	}
+	first := history[0]
+	suite.NotEmpty(first.ID)
+	suite.Equal(stream.EventTypeFiltersChanged, first.Event)
+	suite.Equal([]string{stream.TimelineHome}, first.Stream)
+
+	// Messages for other stream types are not included.
+	history = suite.streamProcessor.History(ctx, account, stream.TimelineDirect, "")
+	suite.Empty(history)
+
+	// Post another message with a stream open.
+	openStream, errWithCode := suite.streamProcessor.OpenReplay(ctx, account, stream.TimelineHome)
+	suite.NoError(errWithCode)
+	defer openStream.Close()
+
+	suite.streamProcessor.Delete(ctx, "01FN3VJGFH10KR7S2PB0GFJZYG")
+
+	msg, ok := openStream.Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeDelete, msg.Event)
+	suite.NotEmpty(msg.ID)
+
+	// Only the message since first should be returned.
+	history = suite.streamProcessor.History(ctx, account, stream.TimelineHome, first.ID)
+	if !suite.Len(history, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(msg, history[0])
+}
+
+func TestHistoryTestSuite(t *testing.T) {
+	suite.Run(t, &HistoryTestSuite{})
+}
@@ -36,3 +36,14 @@ func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamT
 	l.Debug("received open stream request")
 	return p.streams.Open(account.ID, streamType), nil
 }
+
+// OpenReplay is like Open, but additionally keeps a short history of messages
+// posted to the account, for replay via History() when the client reconnects.
+func (p *Processor) OpenReplay(ctx context.Context, account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode) {
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"account", account.ID},
+		{"streamType", streamType},
+	}...)
+	l.Debug("received open replay stream request")
+	return p.streams.OpenReplay(account.ID, streamType), nil
+}
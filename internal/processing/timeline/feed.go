@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Number of statuses to
+// include in tag and list feeds.
+const feedLength = 20
+
+// GetFeed returns a stringified feed.
+type GetFeed func() (string, gtserror.WithCode)
+
+// TagFeedGet returns a function to return a feed of public statuses using the given
+// tag in the given format, and the last-modified time (time of the newest status).
+//
+// Tag feeds are only available if the instance exposes its public timeline, as
+// otherwise there's no other way to see public statuses without logging in.
+func (p *Processor) TagFeedGet(
+	ctx context.Context,
+	tagName string,
+	format typeutils.FeedFormat,
+) (GetFeed, time.Time, gtserror.WithCode) {
+	var never = time.Time{}
+
+	if !config.GetInstanceExposePublicTimeline() {
+		err := gtserror.New("public timeline not exposed")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	tag, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, never, errWithCode
+	}
+
+	if tag == nil || !*tag.Useable || !*tag.Listable {
+		err := gtserror.New("tag was not found, or not useable/listable on this instance")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	statuses, err := p.state.DB.GetTagTimeline(ctx, tag.ID, "", "", "", feedLength)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting statuses: %w", err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	statuses = p.feedableStatuses(ctx, statuses, func(s *gtsmodel.Status) (bool, error) {
+		if localOnly(s) {
+			return false, nil
+		}
+		return p.filter.StatusTagTimelineable(ctx, nil, s)
+	})
+
+	tagURL := uris.URIForTag(tag.Name)
+	feed := &feeds.Feed{
+		Title:       "Posts tagged #" + tag.Name,
+		Description: "Public posts tagged #" + tag.Name,
+		Link:        &feeds.Link{Href: tagURL},
+		Updated:     feedUpdated(statuses, tag.CreatedAt),
+	}
+
+	return p.getFeed(ctx, feed, statuses, format, tagURL+"/feed."+format.String()),
+		feedLastModified(statuses),
+		nil
+}
+
+// ListFeedGet returns a function to return a feed of the list with the given ID,
+// owned by the local user with the given username, in the given format, and the
+// last-modified time (time of the newest status). The private feed token of the
+// user is required, as list feeds may include followers-only statuses.
+func (p *Processor) ListFeedGet(
+	ctx context.Context,
+	username string,
+	listID string,
+	token string,
+	format typeutils.FeedFormat,
+) (GetFeed, time.Time, gtserror.WithCode) {
+	var never = time.Time{}
+
+	if token == "" {
+		err := gtserror.New("no feed token given")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	user, err := p.state.DB.GetUserByFeedToken(ctx, token)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting user by feed token: %w", err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	// Don't let on whether it was the token,
+	// username, or list that didn't match up.
+	if user == nil || user.Account.Username != username {
+		err := gtserror.New("no user found with matching username and feed token")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	list, err := p.state.DB.GetListByID(ctx, listID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting list: %w", err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	if list == nil || list.AccountID != user.AccountID {
+		err := gtserror.Newf("list with id %s not found for account %s", listID, user.AccountID)
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	statuses, err := p.state.DB.GetListTimeline(ctx, list.ID, "", "", "", feedLength)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting statuses: %w", err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	statuses = p.feedableStatuses(ctx, statuses, func(s *gtsmodel.Status) (bool, error) {
+		return p.filter.StatusHomeTimelineable(ctx, user.Account, s)
+	})
+
+	listURL := user.Account.URL + "/lists/" + list.ID
+	feed := &feeds.Feed{
+		Title:       list.Title,
+		Description: "Posts in list " + list.Title + " of @" + user.Account.Username + "@" + config.GetAccountDomain(),
+		Link:        &feeds.Link{Href: user.Account.URL},
+		Updated:     feedUpdated(statuses, list.CreatedAt),
+	}
+
+	return p.getFeed(ctx, feed, statuses, format, listURL+"/feed."+format.String()+"?token="+token),
+		feedLastModified(statuses),
+		nil
+}
+
+// feedableStatuses returns the given statuses
+// that are timelineable by the given function.
+func (p *Processor) feedableStatuses(
+	ctx context.Context,
+	statuses []*gtsmodel.Status,
+	timelineable func(*gtsmodel.Status) (bool, error),
+) []*gtsmodel.Status {
+	feedable := make([]*gtsmodel.Status, 0, len(statuses))
+	for _, s := range statuses {
+		ok, err := timelineable(s)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if ok {
+			feedable = append(feedable, s)
+		}
+	}
+	return feedable
+}
+
+// localOnly returns true if the given status is
+// not federated, and so must never be shown to
+// anyone not logged in to this instance.
+func localOnly(s *gtsmodel.Status) bool {
+	return !util.PtrValueOr(s.Federated, true)
+}
+
+// getFeed returns a function to stringify the given feed
+// with the given statuses in the given format, on demand.
+func (p *Processor) getFeed(
+	ctx context.Context,
+	feed *feeds.Feed,
+	statuses []*gtsmodel.Status,
+	format typeutils.FeedFormat,
+	feedURL string,
+) GetFeed {
+	return func() (string, gtserror.WithCode) {
+		str, err := p.converter.StatusesToFeed(ctx, feed, statuses, format, feedURL)
+		if err != nil {
+			err := gtserror.Newf("error converting feed to %s string: %w", format, err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+		return str, nil
+	}
+}
+
+// feedUpdated returns the time of the newest
+// of the given statuses, or else the given time.
+func feedUpdated(statuses []*gtsmodel.Status, otherwise time.Time) time.Time {
+	if len(statuses) == 0 {
+		return otherwise
+	}
+	return statuses[0].CreatedAt
+}
+
+// feedLastModified returns the time of the newest of the
+// given statuses, or a zero time if there are none.
+func feedLastModified(statuses []*gtsmodel.Status) time.Time {
+	return feedUpdated(statuses, time.Time{})
+}
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type FeedTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *FeedTestSuite) TestTagFeedGet() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(true)
+
+	getFeed, lastModified, errWithCode := suite.timeline.TagFeedGet(ctx, "welcome", typeutils.FeedFormatAtom)
+	suite.NoError(errWithCode)
+	suite.EqualValues(1634729805, lastModified.Unix())
+
+	feed, errWithCode := getFeed()
+	suite.NoError(errWithCode)
+	suite.Contains(feed, "<title>Posts tagged #welcome</title>")
+	suite.Contains(feed, "<id>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</id>")
+}
+
+func (suite *FeedTestSuite) TestTagFeedGetLocalOnly() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(true)
+
+	// Make the only status tagged #welcome local-only.
+	status, err := suite.db.GetStatusByID(ctx, "01F8MH75CBF9JFX4ZAD54N0W0R")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	status.Federated = util.Ptr(false)
+	if err := suite.db.UpdateStatus(ctx, status, "federated"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	getFeed, lastModified, errWithCode := suite.timeline.TagFeedGet(ctx, "welcome", typeutils.FeedFormatAtom)
+	suite.NoError(errWithCode)
+	suite.True(lastModified.IsZero())
+
+	feed, errWithCode := getFeed()
+	suite.NoError(errWithCode)
+	suite.NotContains(feed, "01F8MH75CBF9JFX4ZAD54N0W0R")
+}
+
+func (suite *FeedTestSuite) TestTagFeedGetNotExposed() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(false)
+
+	_, _, errWithCode := suite.timeline.TagFeedGet(ctx, "welcome", typeutils.FeedFormatRSS)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FeedTestSuite) TestListFeedGet() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		listID  = "01H0G8E4Q2J3FE3JDWJVWEDCD1"
+		token   = suite.setFeedToken(account)
+	)
+
+	getFeed, lastModified, errWithCode := suite.timeline.ListFeedGet(ctx, account.Username, listID, token, typeutils.FeedFormatJSON)
+	suite.NoError(errWithCode)
+	suite.False(lastModified.IsZero())
+
+	feed, errWithCode := getFeed()
+	suite.NoError(errWithCode)
+	suite.Contains(feed, `"title": "Cool Ass Posters From This Instance"`)
+	suite.Contains(feed, `"feed_url": "http://localhost:8080/@the_mighty_zork/lists/`+listID+`/feed.json?token=`+token+`"`)
+	suite.Contains(feed, `"items": [`)
+}
+
+func (suite *FeedTestSuite) TestListFeedGetBadToken() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		listID  = "01H0G8E4Q2J3FE3JDWJVWEDCD1"
+		token   = suite.setFeedToken(account)
+	)
+
+	for _, test := range []struct {
+		username string
+		listID   string
+		token    string
+	}{
+		{account.Username, listID, ""},
+		{account.Username, listID, "not a token"},
+		{"admin", listID, token},
+		{account.Username, "01HEWV37MHV8BAC8ANFGVRRM5D", token},
+	} {
+		_, _, errWithCode := suite.timeline.ListFeedGet(ctx, test.username, test.listID, test.token, typeutils.FeedFormatRSS)
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+// setFeedToken sets a private feed token
+// for the user of the given account.
+func (suite *FeedTestSuite) setFeedToken(account *gtsmodel.Account) string {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.FeedToken = "01J3Q5V9XWJ0XN6ZKTT3EG0ZZB"
+	if err := suite.db.UpdateUser(ctx, user, "feed_token"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return user.FeedToken
+}
+
+func TestFeedTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedTestSuite))
+}
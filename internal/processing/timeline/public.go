@@ -41,6 +41,7 @@ func (p *Processor) PublicTimelineGet(
 	minID string,
 	limit int,
 	local bool,
+	languages []string,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	const maxAttempts = 3
 	var (
@@ -75,7 +76,7 @@ outer:
 		// Select slightly more than the limit to try to avoid situations where
 		// we filter out all the entries, and have to make another db call.
 		// It's cheaper to select more in 1 query than it is to do multiple queries.
-		statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, sinceID, minID, limit+5, local)
+		statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, sinceID, minID, limit+5, local, languages)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err = gtserror.Newf("db error getting statuses: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
@@ -157,14 +158,19 @@ outer:
 		}
 	}
 
+	extraQueryParams := []string{
+		"local=" + strconv.FormatBool(local),
+	}
+	for _, lang := range languages {
+		extraQueryParams = append(extraQueryParams, "languages[]="+lang)
+	}
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/timelines/public",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
-		ExtraQueryParams: []string{
-			"local=" + strconv.FormatBool(local),
-		},
+		Items:            items,
+		Path:             "/api/v1/timelines/public",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
 	})
 }
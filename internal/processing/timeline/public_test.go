@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 )
 
 type PublicTestSuite struct {
@@ -47,6 +48,7 @@ func (suite *PublicTestSuite) TestPublicTimelineGet() {
 		minID,
 		limit,
 		local,
+		nil,
 	)
 
 	// We should have some statuses,
@@ -80,6 +82,7 @@ func (suite *PublicTestSuite) TestPublicTimelineGetNotEmpty() {
 		minID,
 		limit,
 		local,
+		nil,
 	)
 
 	// We should have a status even though
@@ -91,6 +94,52 @@ func (suite *PublicTestSuite) TestPublicTimelineGetNotEmpty() {
 	suite.Equal(`http://localhost:8080/api/v1/timelines/public?limit=1&min_id=01HE7XJ1CG84TBKH5V9XKBVGF5&local=false`, resp.PrevLink)
 }
 
+func (suite *PublicTestSuite) TestPublicTimelineGetLanguages() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		maxID     = ""
+		sinceID   = ""
+		minID     = ""
+		limit     = 10
+		local     = false
+	)
+
+	// No test statuses are in French.
+	resp, errWithCode := suite.timeline.PublicTimelineGet(
+		ctx,
+		requester,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+		local,
+		[]string{"fr"},
+	)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+
+	// But they're all in English, and
+	// the language filter is kept when
+	// paging through the timeline.
+	resp, errWithCode = suite.timeline.PublicTimelineGet(
+		ctx,
+		requester,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+		local,
+		[]string{"en", "fr"},
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Items)
+	for _, item := range resp.Items {
+		suite.Equal("en", *item.(*apimodel.Status).Language)
+	}
+	suite.Contains(resp.NextLink, "&local=false&languages[]=en&languages[]=fr")
+}
+
 func TestPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(PublicTestSuite))
 }
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Number of statuses to show
+// per page of web timelines.
+const webTimelineLength = 20
+
+// WebPublicTimelineGet returns a page of the local timeline, older than
+// maxID (or from the top if empty), as web statuses to show on the web
+// view of the timeline.
+//
+// Like tag feeds, this is only available if the instance exposes its
+// public timeline.
+func (p *Processor) WebPublicTimelineGet(
+	ctx context.Context,
+	maxID string,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	if !config.GetInstanceExposePublicTimeline() {
+		err := gtserror.New("public timeline not exposed")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, "", "", webTimelineLength, true, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.packageWebResponse(ctx, statuses, "/public", func(s *gtsmodel.Status) (bool, error) {
+		if localOnly(s) {
+			return false, nil
+		}
+		return p.filter.StatusPublicTimelineable(ctx, nil, s)
+	})
+}
+
+// WebTagTimelineGet returns a page of public statuses using the given
+// tag, older than maxID (or from the top if empty), as web statuses to
+// show on the web view of the tag. Unknown tags have no statuses.
+//
+// Like tag feeds, this is only available if the instance exposes its
+// public timeline.
+func (p *Processor) WebTagTimelineGet(
+	ctx context.Context,
+	tagName string,
+	maxID string,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	if !config.GetInstanceExposePublicTimeline() {
+		err := gtserror.New("public timeline not exposed")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	tag, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil || !*tag.Useable || !*tag.Listable {
+		// Nothing to show.
+		return util.EmptyPageableResponse(), nil
+	}
+
+	statuses, err := p.state.DB.GetTagTimeline(ctx, tag.ID, maxID, "", "", webTimelineLength)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.packageWebResponse(ctx, statuses, "/tags/"+tag.Name, func(s *gtsmodel.Status) (bool, error) {
+		if localOnly(s) {
+			return false, nil
+		}
+		return p.filter.StatusTagTimelineable(ctx, nil, s)
+	})
+}
+
+// packageWebResponse converts the given statuses that are
+// timelineable by the given function to web statuses, and
+// packages them in a response that pages down from the
+// last of the given statuses, at the given web path.
+func (p *Processor) packageWebResponse(
+	ctx context.Context,
+	statuses []*gtsmodel.Status,
+	path string,
+	timelineable func(*gtsmodel.Status) (bool, error),
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	count := len(statuses)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Set next value before filtering and
+		// converting, so caller can still page.
+		nextMaxIDValue = statuses[count-1].ID
+	)
+
+	for _, s := range p.feedableStatuses(ctx, statuses, timelineable) {
+		item, err := p.converter.StatusToWebStatus(ctx, s, nil)
+		if err != nil {
+			log.Errorf(ctx, "error converting to web status: %v", err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:          items,
+		Path:           path,
+		NextMaxIDValue: nextMaxIDValue,
+	})
+}
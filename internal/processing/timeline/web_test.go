@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type WebTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *WebTestSuite) TestWebPublicTimelineGet() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(true)
+
+	resp, errWithCode := suite.timeline.WebPublicTimelineGet(ctx, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEmpty(resp.Items)
+
+	for _, item := range resp.Items {
+		status := item.(*apimodel.Status)
+		suite.Equal(apimodel.VisibilityPublic, status.Visibility)
+		suite.NotContains(status.Account.Acct, "@")             // local
+		suite.NotEqual("01F8MHCP5P2NWYQ416SBA0XSEV", status.ID) // local-only
+	}
+	suite.Contains(resp.NextLink, "http://localhost:8080/public?max_id=")
+}
+
+func (suite *WebTestSuite) TestWebTagTimelineGet() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(true)
+
+	resp, errWithCode := suite.timeline.WebTagTimelineGet(ctx, "welcome", "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(resp.Items, 1)
+	suite.Equal("http://localhost:8080/tags/welcome?max_id=01F8MH75CBF9JFX4ZAD54N0W0R", resp.NextLink)
+
+	// Unknown tags just have no statuses.
+	resp, errWithCode = suite.timeline.WebTagTimelineGet(ctx, "nonexistent", "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(resp.Items)
+}
+
+func (suite *WebTestSuite) TestWebTagTimelineGetLocalOnly() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(true)
+
+	// Make the only status tagged #welcome local-only.
+	status, err := suite.db.GetStatusByID(ctx, "01F8MH75CBF9JFX4ZAD54N0W0R")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	status.Federated = util.Ptr(false)
+	if err := suite.db.UpdateStatus(ctx, status, "federated"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp, errWithCode := suite.timeline.WebTagTimelineGet(ctx, "welcome", "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(resp.Items)
+}
+
+func (suite *WebTestSuite) TestWebTimelinesNotExposed() {
+	ctx := context.Background()
+	config.SetInstanceExposePublicTimeline(false)
+
+	_, errWithCode := suite.timeline.WebPublicTimelineGet(ctx, "")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	_, errWithCode = suite.timeline.WebTagTimelineGet(ctx, "welcome", "")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestWebTestSuite(t *testing.T) {
+	suite.Run(t, new(WebTestSuite))
+}
@@ -0,0 +1,196 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TagsGet returns up to limit trending hashtags, trendiest
+// first, skipping the first offset of them. Only hashtags
+// that may be shown publicly in trends are returned.
+func (p *Processor) TagsGet(ctx context.Context, limit int, offset int) ([]apimodel.Tag, gtserror.WithCode) {
+	apiTags := make([]apimodel.Tag, 0, limit)
+
+	trends := p.state.Caches.Trends.Load()
+	if trends == nil {
+		// Not (yet) computed.
+		return apiTags, nil
+	}
+
+	for _, tag := range trends.Tags {
+		if len(apiTags) == limit {
+			break
+		}
+
+		if !shownPublicly(tag.Review) {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		apiTag, err := p.converter.TrendingTagToAPITag(ctx, tag)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s: %v", tag.Tag.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// StatusesGet returns up to limit trending statuses, trendiest
+// first, skipping the first offset of them. Only statuses that
+// may be shown publicly in trends, and which are visible to the
+// requester (which may be nil), are returned.
+func (p *Processor) StatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Status, gtserror.WithCode) {
+	apiStatuses := make([]*apimodel.Status, 0, limit)
+
+	trends := p.state.Caches.Trends.Load()
+	if trends == nil {
+		// Not (yet) computed.
+		return apiStatuses, nil
+	}
+
+	var filters []*gtsmodel.Filter
+	var compiledMutes *usermute.CompiledUserMuteList
+	if requester != nil {
+		var err error
+		filters, err = p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		mutes, err := p.state.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), requester.ID, nil)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve mutes for account %s: %w", requester.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		compiledMutes = usermute.NewCompiledUserMuteList(mutes)
+	}
+
+	for _, trending := range trends.Statuses {
+		if len(apiStatuses) == limit {
+			break
+		}
+
+		if !shownPublicly(trending.Review) {
+			continue
+		}
+
+		status, err := p.state.DB.GetStatusByID(ctx, trending.StatusID)
+		if err != nil {
+			// Likely deleted since
+			// trends were computed.
+			log.Debugf(ctx, "error getting status %s: %v", trending.StatusID, err)
+			continue
+		}
+
+		timelineable, err := p.filter.StatusPublicTimelineable(ctx, requester, status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if !timelineable {
+			continue
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester, statusfilter.FilterContextPublic, filters, compiledMutes)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			continue
+		}
+		if err != nil {
+			log.Errorf(ctx, "error converting to api status: %v", err)
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
+// LinksGet returns up to limit trending links, trendiest
+// first, skipping the first offset of them. Only links
+// that may be shown publicly in trends are returned.
+func (p *Processor) LinksGet(ctx context.Context, limit int, offset int) ([]apimodel.TrendsLink, gtserror.WithCode) {
+	apiLinks := make([]apimodel.TrendsLink, 0, limit)
+
+	trends := p.state.Caches.Trends.Load()
+	if trends == nil {
+		// Not (yet) computed.
+		return apiLinks, nil
+	}
+
+	for _, link := range trends.Links {
+		if len(apiLinks) == limit {
+			break
+		}
+
+		if !shownPublicly(link.Review) {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		apiLinks = append(apiLinks, p.converter.TrendingLinkToAPILink(link))
+	}
+
+	return apiLinks, nil
+}
+
+// shownPublicly returns whether a trending item with the given
+// review may be shown publicly in trends: approved items
+// always may, rejected items never may, and pending items
+// only may when review of trends is not required.
+func shownPublicly(review *gtsmodel.TrendReview) bool {
+	if review != nil && review.Approved != nil {
+		return *review.Approved
+	}
+	return !config.GetInstanceTrendsReviewRequired()
+}
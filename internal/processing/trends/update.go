@@ -0,0 +1,609 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// trendsFrequency is how
+	// often trends are recomputed.
+	trendsFrequency = 15 * time.Minute
+
+	// trendsDays is the number of days of statuses
+	// that tag and link trends are computed from, and
+	// the number of days of usage history returned.
+	trendsDays = 7
+
+	// trendsStatusesMaxAge is the maximum
+	// age of a status for it to trend.
+	trendsStatusesMaxAge = 48 * time.Hour
+
+	// trendsStatusesHalfLife is the age at which
+	// a trending status' score is halved, so that
+	// newer statuses are favoured over older ones.
+	trendsStatusesHalfLife = 12 * time.Hour
+
+	// trendsMinAccounts is the minimum number of
+	// distinct accounts that must have used a tag
+	// or link in the past day for it to trend.
+	trendsMinAccounts = 2
+
+	// trendsMinInteractions is the minimum
+	// number of boosts plus faves that a
+	// status must have for it to trend.
+	trendsMinInteractions = 2
+
+	// trendsMaxItems is the maximum number of
+	// each kind of trending item that is kept.
+	trendsMaxItems = 50
+
+	// trendsLinksPageSize is the number of statuses
+	// fetched at a time when tallying link usage.
+	trendsLinksPageSize = 200
+
+	// trendsLinksMaxStatuses is the maximum number
+	// of the most recent statuses whose content is
+	// scanned for links when tallying link usage.
+	trendsLinksMaxStatuses = 20000
+)
+
+// ScheduleTrends schedules a recurring job to
+// recompute trends, starting straight away, if
+// trends are enabled on this instance.
+func (p *Processor) ScheduleTrends() error {
+	if !config.GetInstanceTrendsEnabled() {
+		return nil
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@trends",  // id
+		time.Now(), // start
+		trendsFrequency,
+		func(ctx context.Context, now time.Time) {
+			if err := p.UpdateTrends(ctx, now); err != nil {
+				log.Errorf(ctx, "error updating trends: %v", err)
+			}
+		},
+	) {
+		return errors.New("failed to schedule @trends")
+	}
+	return nil
+}
+
+// UpdateTrends recomputes trending hashtags, statuses and links
+// as of the given time, from the local and federated public
+// statuses of the past few days, and caches the results.
+func (p *Processor) UpdateTrends(ctx context.Context, now time.Time) error {
+	tagUsages, err := p.tagUsages(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	linkUsages, err := p.linkUsages(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	tags, err := p.trendingTags(ctx, tagUsages, now)
+	if err != nil {
+		return err
+	}
+
+	trendingStatuses, err := p.trendingStatuses(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	links, err := p.trendingLinks(ctx, linkUsages, now)
+	if err != nil {
+		return err
+	}
+
+	p.state.Caches.Trends.Store(&gtsmodel.Trends{
+		Tags:       tags,
+		Statuses:   trendingStatuses,
+		Links:      links,
+		ComputedAt: now,
+	})
+
+	return nil
+}
+
+// tagUsages returns the usage of tags by statuses in each
+// sliding window and calendar day of the past few days,
+// as counted by the database, keyed by tag ID.
+func (p *Processor) tagUsages(ctx context.Context, now time.Time) (map[string]*usage, error) {
+	usages := make(map[string]*usage)
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	for i := 0; i < trendsDays; i++ {
+		// Count accounts in the 24 hour
+		// window ending i days before now.
+		until := now.Add(-time.Duration(i) * 24 * time.Hour)
+		counts, err := p.state.DB.CountTagUsage(ctx, until.Add(-24*time.Hour), until)
+		if err != nil {
+			return nil, gtserror.Newf("db error counting tag usage: %w", err)
+		}
+
+		for tagID, count := range counts {
+			usageFor(usages, tagID).windows[i] = count.Accounts
+		}
+
+		// Count uses and accounts on the
+		// calendar day i days before today.
+		day := today.AddDate(0, 0, -i)
+		until = day.AddDate(0, 0, 1)
+		if until.After(now) {
+			until = now
+		}
+		counts, err = p.state.DB.CountTagUsage(ctx, day, until)
+		if err != nil {
+			return nil, gtserror.Newf("db error counting tag usage: %w", err)
+		}
+
+		for tagID, count := range counts {
+			usageFor(usages, tagID).days[i] = count
+		}
+	}
+
+	return usages, nil
+}
+
+// linkUsages returns the usage of links by the statuses of the
+// past few days, keyed by link, tallied from the content of the
+// most recent statuses, up to the maximum that are scanned.
+func (p *Processor) linkUsages(ctx context.Context, now time.Time) (map[string]*usage, error) {
+	var (
+		tallies = make(map[string]*tally)
+		since   = now.Add(-trendsDays * 24 * time.Hour)
+		page    = paging.Page{Limit: trendsLinksPageSize}
+		total   int
+	)
+
+	for total < trendsLinksMaxStatuses {
+		statuses, err := p.state.DB.GetTrendableStatuses(ctx, since, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			// Reached the end.
+			break
+		}
+
+		for _, status := range statuses {
+			for _, link := range text.ExtractLinks(status.Content) {
+				t := tallies[link]
+				if t == nil {
+					t = new(tally)
+					tallies[link] = t
+				}
+				t.add(status, now)
+			}
+		}
+
+		total += len(statuses)
+		page.Max = paging.MaxID(statuses[len(statuses)-1].ID)
+	}
+
+	usages := make(map[string]*usage, len(tallies))
+	for link, t := range tallies {
+		usages[link] = t.usage()
+	}
+
+	return usages, nil
+}
+
+// trendingTags returns trending tags
+// according to the given tag usages.
+func (p *Processor) trendingTags(ctx context.Context, usages map[string]*usage, now time.Time) ([]*gtsmodel.TrendingTag, error) {
+	scored := topScored(usages)
+
+	tagIDs := make([]string, 0, len(scored))
+	for _, s := range scored {
+		tagIDs = append(tagIDs, s.key)
+	}
+
+	tags, err := p.state.DB.GetTags(ctx, tagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting tags: %w", err)
+	}
+
+	trending := make([]*gtsmodel.TrendingTag, 0, len(tags))
+	for _, tag := range tags {
+		if (tag.Useable != nil && !*tag.Useable) ||
+			(tag.Listable != nil && !*tag.Listable) {
+			// Only useable +
+			// listable tags trend.
+			continue
+		}
+
+		trending = append(trending, &gtsmodel.TrendingTag{
+			Tag:     tag,
+			Score:   usages[tag.ID].score(),
+			History: usages[tag.ID].history(now),
+		})
+	}
+
+	// Tags come back in ID order, so
+	// re-sort them by score once more.
+	slices.SortStableFunc(trending, func(a, b *gtsmodel.TrendingTag) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	reviews, err := p.reviewsFor(ctx, gtsmodel.TrendTypeTag, tagIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range trending {
+		t.Review = reviews[t.Tag.ID]
+	}
+
+	return trending, nil
+}
+
+// trendingStatuses returns trending statuses of the past couple of
+// days, scored by their number of boosts and faves, decayed by age.
+func (p *Processor) trendingStatuses(ctx context.Context, now time.Time) ([]*gtsmodel.TrendingStatus, error) {
+	counts, err := p.state.DB.CountTrendableStatusesInteractions(ctx, now.Add(-trendsStatusesMaxAge))
+	if err != nil {
+		return nil, gtserror.Newf("db error counting interactions: %w", err)
+	}
+
+	trending := make([]*gtsmodel.TrendingStatus, 0, len(counts))
+	for statusID, count := range counts {
+		if count < trendsMinInteractions {
+			continue
+		}
+
+		// Status IDs are ULIDs, so
+		// they encode creation time.
+		createdAt, err := ulid.ParseStrict(statusID)
+		if err != nil {
+			log.Debugf(ctx, "error parsing status id %s: %v", statusID, err)
+			continue
+		}
+
+		// Halve the score for every
+		// half-life the status has aged.
+		age := now.Sub(ulid.Time(createdAt.Time()))
+		decay := math.Pow(0.5, float64(age)/float64(trendsStatusesHalfLife))
+
+		trending = append(trending, &gtsmodel.TrendingStatus{
+			StatusID: statusID,
+			Score:    float64(count) * decay,
+		})
+	}
+
+	slices.SortFunc(trending, func(a, b *gtsmodel.TrendingStatus) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.StatusID, a.StatusID)
+	})
+
+	// Take the top scoring statuses, skipping those
+	// by accounts that haven't opted in to discovery.
+	top := make([]*gtsmodel.TrendingStatus, 0, trendsMaxItems)
+	statusIDs := make([]string, 0, trendsMaxItems)
+	for _, t := range trending {
+		if len(top) == trendsMaxItems {
+			break
+		}
+
+		if !p.discoverable(ctx, t.StatusID) {
+			continue
+		}
+
+		top = append(top, t)
+		statusIDs = append(statusIDs, t.StatusID)
+	}
+	trending = top
+
+	reviews, err := p.reviewsFor(ctx, gtsmodel.TrendTypeStatus, statusIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range trending {
+		t.Review = reviews[t.StatusID]
+	}
+
+	return trending, nil
+}
+
+// discoverable returns whether the author of the status with
+// given ID is discoverable, and so their status may trend.
+func (p *Processor) discoverable(ctx context.Context, statusID string) bool {
+	status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), statusID)
+	if err != nil {
+		log.Debugf(ctx, "error getting status %s: %v", statusID, err)
+		return false
+	}
+
+	account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), status.AccountID)
+	if err != nil {
+		log.Debugf(ctx, "error getting account %s: %v", status.AccountID, err)
+		return false
+	}
+
+	return !account.IsSuspended() &&
+		account.Discoverable != nil &&
+		*account.Discoverable
+}
+
+// trendingLinks returns trending links
+// according to the given link usages.
+func (p *Processor) trendingLinks(ctx context.Context, usages map[string]*usage, now time.Time) ([]*gtsmodel.TrendingLink, error) {
+	scored := topScored(usages)
+
+	links := make([]string, 0, len(scored))
+	for _, s := range scored {
+		links = append(links, s.key)
+	}
+
+	reviews, err := p.reviewsFor(ctx, gtsmodel.TrendTypeLink, links, now)
+	if err != nil {
+		return nil, err
+	}
+
+	trending := make([]*gtsmodel.TrendingLink, 0, len(scored))
+	for _, s := range scored {
+		// Links in public statuses should have had
+		// a preview card fetched for them already.
+		card, err := p.state.DB.GetPreviewCardByURL(ctx, s.key)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting preview card: %w", err)
+		}
+
+		trending = append(trending, &gtsmodel.TrendingLink{
+			URL:     s.key,
+			Score:   s.score,
+			History: usages[s.key].history(now),
+			Review:  reviews[s.key],
+			Card:    card,
+		})
+	}
+
+	return trending, nil
+}
+
+// reviewsFor returns a map of the given target IDs to
+// their trend review, creating pending reviews for any
+// targets that have none. Pending reviews older than the
+// trends window, for targets no longer trending, are
+// deleted, so they don't pile up in the database.
+func (p *Processor) reviewsFor(
+	ctx context.Context,
+	targetType gtsmodel.TrendType,
+	targetIDs []string,
+	now time.Time,
+) (map[string]*gtsmodel.TrendReview, error) {
+	existing, err := p.state.DB.GetTrendReviews(ctx, targetType)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting %s reviews: %w", targetType, err)
+	}
+
+	reviews := make(map[string]*gtsmodel.TrendReview, len(targetIDs))
+	for _, review := range existing {
+		reviews[review.TargetID] = review
+	}
+
+	for _, targetID := range targetIDs {
+		if _, ok := reviews[targetID]; ok {
+			continue
+		}
+
+		// First time this target has
+		// trended, create pending review.
+		review := &gtsmodel.TrendReview{
+			ID:         id.NewULID(),
+			TargetType: targetType,
+			TargetID:   targetID,
+		}
+
+		if err := p.state.DB.PutTrendReview(ctx, review); err != nil {
+			return nil, gtserror.Newf("db error putting %s review: %w", targetType, err)
+		}
+
+		reviews[targetID] = review
+	}
+
+	cutoff := now.Add(-trendsDays * 24 * time.Hour)
+	for _, review := range existing {
+		if !review.Pending() ||
+			review.CreatedAt.After(cutoff) ||
+			slices.Contains(targetIDs, review.TargetID) {
+			continue
+		}
+
+		// Stale pending review, tidy it up.
+		if err := p.state.DB.DeleteTrendReviewByID(ctx, review.ID); err != nil {
+			log.Errorf(ctx, "db error deleting %s review: %v", targetType, err)
+		}
+		delete(reviews, review.TargetID)
+	}
+
+	return reviews, nil
+}
+
+// usage is the use of a tag or link by statuses.
+type usage struct {
+	// windows holds the number of distinct accounts
+	// that used the item within each sliding 24 hour
+	// window, where window i ended i days before now.
+	windows [trendsDays]int
+
+	// days holds the use of the item on each
+	// calendar day (UTC), where index i is
+	// the day i days ago.
+	days [trendsDays]gtsmodel.TrendUsage
+}
+
+// usageFor returns the usage for key in
+// given map, allocating it if necessary.
+func usageFor(usages map[string]*usage, key string) *usage {
+	u := usages[key]
+	if u == nil {
+		u = new(usage)
+		usages[key] = u
+	}
+	return u
+}
+
+// score returns the trending score of the item. This is based on how
+// many more accounts used the item in the past day than would have
+// been expected from the days before; items used by fewer accounts
+// than expected, or than the trending minimum, score 0.
+func (u *usage) score() float64 {
+	observed := float64(u.windows[0])
+	if observed < trendsMinAccounts {
+		return 0
+	}
+
+	var expected float64
+	for _, accounts := range u.windows[1:] {
+		expected += float64(accounts)
+	}
+	expected = max(1, expected/(trendsDays-1))
+
+	if observed <= expected {
+		return 0
+	}
+
+	return math.Pow(observed-expected, 2) / expected
+}
+
+// history returns the daily usage
+// of the item, most recent day first.
+func (u *usage) history(now time.Time) []gtsmodel.TrendHistory {
+	today := now.UTC().Truncate(24 * time.Hour)
+	history := make([]gtsmodel.TrendHistory, trendsDays)
+	for i := range history {
+		history[i] = gtsmodel.TrendHistory{
+			Day:      today.AddDate(0, 0, -i),
+			Uses:     u.days[i].Uses,
+			Accounts: u.days[i].Accounts,
+		}
+	}
+	return history
+}
+
+// tally tallies the use of a link by statuses,
+// keeping the sets of accounts that used it.
+type tally struct {
+	windows  [trendsDays]map[string]struct{}
+	days     [trendsDays]map[string]struct{}
+	daysUses [trendsDays]int
+}
+
+// add tallies the use of the link by the given status.
+func (t *tally) add(status *gtsmodel.Status, now time.Time) {
+	window := int(now.Sub(status.CreatedAt) / (24 * time.Hour))
+	if window >= 0 && window < trendsDays {
+		t.windows[window] = addAccount(t.windows[window], status.AccountID)
+	}
+
+	day := daysBetween(status.CreatedAt, now)
+	if day >= 0 && day < trendsDays {
+		t.days[day] = addAccount(t.days[day], status.AccountID)
+		t.daysUses[day]++
+	}
+}
+
+// usage returns the usage counted by the tally.
+func (t *tally) usage() *usage {
+	u := new(usage)
+	for i := range u.windows {
+		u.windows[i] = len(t.windows[i])
+		u.days[i] = gtsmodel.TrendUsage{
+			Uses:     t.daysUses[i],
+			Accounts: len(t.days[i]),
+		}
+	}
+	return u
+}
+
+// addAccount adds given account ID to given
+// set of accounts, allocating it if necessary.
+func addAccount(accounts map[string]struct{}, accountID string) map[string]struct{} {
+	if accounts == nil {
+		accounts = make(map[string]struct{})
+	}
+	accounts[accountID] = struct{}{}
+	return accounts
+}
+
+// daysBetween returns the number of calendar
+// days (UTC) from the day of t to that of now.
+func daysBetween(t time.Time, now time.Time) int {
+	day := t.UTC().Truncate(24 * time.Hour)
+	today := now.UTC().Truncate(24 * time.Hour)
+	return int(today.Sub(day) / (24 * time.Hour))
+}
+
+// scored is a key
+// and its score.
+type scored struct {
+	key   string
+	score float64
+}
+
+// topScored returns the keys of the given usages with a
+// non-zero score, sorted by score, highest first, up to
+// the maximum number of trending items.
+func topScored(usages map[string]*usage) []scored {
+	var top []scored
+	for key, u := range usages {
+		if score := u.score(); score > 0 {
+			top = append(top, scored{key, score})
+		}
+	}
+
+	slices.SortFunc(top, func(a, b scored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.key, b.key)
+	})
+
+	if len(top) > trendsMaxItems {
+		top = top[:trendsMaxItems]
+	}
+
+	return top
+}
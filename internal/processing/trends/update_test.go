@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type UpdateTestSuite struct {
+	TrendsStandardTestSuite
+}
+
+func (suite *UpdateTestSuite) TestUpdateTrends() {
+	var (
+		ctx = context.Background()
+
+		// Midday today, so all statuses made in the
+		// hours just before now fall on the same day.
+		now = time.Now().UTC().Truncate(24 * time.Hour).Add(12 * time.Hour)
+
+		welcome = suite.testTags["welcome"]
+		hashtag = suite.testTags["Hashtag"]
+		content = `<p>read this: <a href="https://Example.org/article#intro" rel="nofollow noreferrer noopener" target="_blank">https://example.org/article</a></p>`
+	)
+
+	// #welcome + link are used by three accounts today,
+	// and by one account on one of the days before.
+	suite.putStatus(suite.testAccounts["admin_account"], now.Add(-1*time.Hour), []*gtsmodel.Tag{welcome}, content)
+	faved := suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-2*time.Hour), []*gtsmodel.Tag{welcome}, content)
+	suite.putStatus(suite.testAccounts["remote_account_1"], now.Add(-3*time.Hour), []*gtsmodel.Tag{welcome}, content)
+	suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-72*time.Hour), []*gtsmodel.Tag{welcome}, content)
+
+	// Only one account used #hashtag, so it doesn't trend.
+	notDiscoverable := suite.putStatus(suite.testAccounts["local_account_2"], now.Add(-1*time.Hour), []*gtsmodel.Tag{hashtag}, "<p>hello</p>")
+
+	// Both statuses are faved twice, but local_account_2
+	// isn't discoverable, so only the first can trend.
+	suite.putFave(suite.testAccounts["admin_account"], faved)
+	suite.putFave(suite.testAccounts["remote_account_1"], faved)
+	suite.putFave(suite.testAccounts["admin_account"], notDiscoverable)
+	suite.putFave(suite.testAccounts["local_account_1"], notDiscoverable)
+
+	if err := suite.trends.UpdateTrends(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	trends := suite.state.Caches.Trends.Load()
+	suite.NotNil(trends)
+
+	if suite.Len(trends.Tags, 1) {
+		tag := trends.Tags[0]
+		suite.Equal(welcome.ID, tag.Tag.ID)
+		suite.Greater(tag.Score, 0.0)
+		suite.True(tag.Review.Pending())
+		suite.Len(tag.History, 7)
+		suite.Equal(3, tag.History[0].Uses)
+		suite.Equal(3, tag.History[0].Accounts)
+		suite.Equal(1, tag.History[3].Uses)
+	}
+
+	if suite.Len(trends.Statuses, 1) {
+		suite.Equal(faved.ID, trends.Statuses[0].StatusID)
+		suite.True(trends.Statuses[0].Review.Pending())
+	}
+
+	if suite.Len(trends.Links, 1) {
+		suite.Equal("https://example.org/article", trends.Links[0].URL)
+		suite.True(trends.Links[0].Review.Pending())
+	}
+
+	// Updating again should reuse
+	// the same pending reviews.
+	linkReviewID := trends.Links[0].Review.ID
+	if err := suite.trends.UpdateTrends(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	trends = suite.state.Caches.Trends.Load()
+	suite.Equal(linkReviewID, trends.Links[0].Review.ID)
+
+	reviews, err := suite.db.GetTrendReviews(ctx, gtsmodel.TrendTypeLink)
+	suite.NoError(err)
+	suite.Len(reviews, 1)
+}
+
+func (suite *UpdateTestSuite) TestGetTrendsReview() {
+	var (
+		ctx     = context.Background()
+		now     = time.Now()
+		welcome = suite.testTags["welcome"]
+	)
+
+	suite.putStatus(suite.testAccounts["admin_account"], now.Add(-1*time.Hour), []*gtsmodel.Tag{welcome}, "")
+	suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-2*time.Hour), []*gtsmodel.Tag{welcome}, "")
+
+	if err := suite.trends.UpdateTrends(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Review is required by default,
+	// so pending tag isn't shown yet.
+	tags, errWithCode := suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(tags)
+
+	// Without review required,
+	// pending tag is shown.
+	config.SetInstanceTrendsReviewRequired(false)
+	tags, errWithCode = suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	if suite.Len(tags, 1) {
+		suite.Equal("welcome", tags[0].Name)
+		suite.Len(*tags[0].History, 7)
+	}
+
+	// Offset past the tag
+	// shows nothing.
+	tags, errWithCode = suite.trends.TagsGet(ctx, 10, 1)
+	suite.NoError(errWithCode)
+	suite.Empty(tags)
+
+	// Once rejected, tag isn't
+	// shown whatever the config.
+	review := *suite.state.Caches.Trends.Load().Tags[0].Review
+	review.Approved = util.Ptr(false)
+	suite.state.Caches.Trends.SetReview(&review)
+
+	tags, errWithCode = suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(tags)
+
+	// And once approved, it's
+	// shown whatever the config.
+	config.SetInstanceTrendsReviewRequired(true)
+	review.Approved = util.Ptr(true)
+	suite.state.Caches.Trends.SetReview(&review)
+
+	tags, errWithCode = suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Len(tags, 1)
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &UpdateTestSuite{})
+}
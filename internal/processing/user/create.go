@@ -19,14 +19,17 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
@@ -37,6 +40,11 @@ import (
 // App should be the app used to create the user+account.
 // If nil, the instance app will be used.
 //
+// If the form contains an invite code, the invite must be
+// valid. Sign-ups with an invite skip the backlog limit (but
+// not the daily sign-up limit), and are approved automatically
+// if the instance is configured to do so.
+//
 // Precondition: the form's fields should have already been
 // validated and normalized by the caller.
 func (p *Processor) Create(
@@ -44,34 +52,20 @@ func (p *Processor) Create(
 	app *gtsmodel.Application,
 	form *apimodel.AccountCreateRequest,
 ) (*gtsmodel.User, gtserror.WithCode) {
-	const (
-		usersPerDay = 10
-		regBacklog  = 20
-	)
-
-	// Ensure no more than usersPerDay
-	// have registered in the last 24h.
-	newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
-	if err != nil {
-		err := fmt.Errorf("db error counting new users: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
-	}
-
-	if newUsersCount >= usersPerDay {
-		err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
-	}
-
-	// Ensure the new users backlog isn't full.
-	backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
-	if err != nil {
-		err := fmt.Errorf("db error counting registration backlog length: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	var invite *gtsmodel.Invite
+	if form.Invite != "" {
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.signupInvite(ctx, form.Invite)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
 	}
 
-	if backlogLen >= regBacklog {
-		err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	// Invited sign-ups are vouched for, so skip
+	// the backlog limit, but still apply the daily
+	// limit in case an invite code gets leaked.
+	if errWithCode := p.checkSignupLimits(ctx, invite != nil); errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
@@ -109,7 +103,7 @@ func (p *Processor) Create(
 		}
 	}
 
-	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
+	newSignup := gtsmodel.NewSignup{
 		Username: form.Username,
 		Email:    form.Email,
 		Password: form.Password,
@@ -117,8 +111,34 @@ func (p *Processor) Create(
 		SignUpIP: form.IP,
 		Locale:   form.Locale,
 		AppID:    app.ID,
-	})
+	}
+
+	if invite != nil {
+		// Use up the invite. This checks max uses
+		// again, in case another sign-up got there
+		// first since we fetched the invite.
+		if err := p.state.DB.IncrementInviteUses(ctx, invite); err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err := errors.New("this invite has already been used the maximum number of times")
+				return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			}
+			err := fmt.Errorf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		newSignup.InviteID = invite.ID
+		newSignup.PreApproved = config.GetAccountsInvitesApproved()
+	}
+
+	user, err := p.state.DB.NewSignup(ctx, newSignup)
 	if err != nil {
+		if invite != nil {
+			// Give back the invite use
+			// taken up by this sign-up.
+			if err := p.state.DB.DecrementInviteUses(ctx, invite); err != nil {
+				log.Errorf(ctx, "db error giving back invite use: %v", err)
+			}
+		}
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -138,6 +158,92 @@ func (p *Processor) Create(
 	return user, nil
 }
 
+// SignupInviteGet returns the invite with the given code,
+// including the account that created it, for showing on
+// the sign-up page. An error is returned if the invite
+// can't be used to sign up with.
+func (p *Processor) SignupInviteGet(ctx context.Context, code string) (*apimodel.Invite, gtserror.WithCode) {
+	invite, errWithCode := p.signupInvite(ctx, code)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite, true)
+	if err != nil {
+		err := fmt.Errorf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
+
+// signupInvite returns the invite with the given code,
+// or an error if it can't be used to sign up with.
+func (p *Processor) signupInvite(ctx context.Context, code string) (*gtsmodel.Invite, gtserror.WithCode) {
+	if !config.GetAccountsInvitesEnabled() {
+		err := errors.New("invites are not enabled on this instance")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	invite, err := p.state.DB.GetInviteByCode(ctx, code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Invites made by accounts that have since been
+	// deleted or suspended are not valid anymore.
+	if invite == nil || !invite.Valid() ||
+		invite.Account == nil || invite.Account.IsSuspended() {
+		err := errors.New("this invite is not valid, or has expired")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return invite, nil
+}
+
+// checkSignupLimits returns an error if too many sign-ups were
+// approved recently, or (if not invited) too many are waiting approval.
+func (p *Processor) checkSignupLimits(ctx context.Context, invited bool) gtserror.WithCode {
+	const (
+		usersPerDay = 10
+		regBacklog  = 20
+	)
+
+	// Ensure no more than usersPerDay
+	// have registered in the last 24h.
+	newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		err := fmt.Errorf("db error counting new users: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if newUsersCount >= usersPerDay {
+		err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if invited {
+		// Invited sign-ups
+		// skip the backlog.
+		return nil
+	}
+
+	// Ensure the new users backlog isn't full.
+	backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
+	if err != nil {
+		err := fmt.Errorf("db error counting registration backlog length: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if backlogLen >= regBacklog {
+		err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return nil
+}
+
 // TokenForNewUser generates an OAuth Bearer token
 // for a new user (with account) created by Create().
 func (p *Processor) TokenForNewUser(
@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CreateTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *CreateTestSuite) form(invite string) *apimodel.AccountCreateRequest {
+	return &apimodel.AccountCreateRequest{
+		Username:  "invited_person",
+		Email:     "invited@example.org",
+		Password:  "very-good-password-123",
+		Agreement: true,
+		Locale:    "en",
+		Invite:    invite,
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	ctx := context.Background()
+	testrig.StartNoopWorkers(&suite.state)
+	defer testrig.StopWorkers(&suite.state)
+
+	config.SetAccountsInvitesEnabled(true)
+	config.SetAccountsInvitesApproved(true)
+
+	invite := testrig.NewTestInvites()["local_account_1_invite"]
+
+	user, errWithCode := suite.user.Create(ctx, nil, suite.form(invite.Code))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// User should be linked to
+	// the invite and pre-approved.
+	suite.Equal(invite.ID, user.InviteID)
+	suite.True(util.PtrValueOr(user.Approved, false))
+
+	// Invite should be used once more.
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(invite.Uses+1, dbInvite.Uses)
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteNotApproved() {
+	ctx := context.Background()
+	testrig.StartNoopWorkers(&suite.state)
+	defer testrig.StopWorkers(&suite.state)
+
+	config.SetAccountsInvitesEnabled(true)
+
+	invite := testrig.NewTestInvites()["local_account_1_invite"]
+
+	user, errWithCode := suite.user.Create(ctx, nil, suite.form(invite.Code))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(invite.ID, user.InviteID)
+	suite.False(util.PtrValueOr(user.Approved, false))
+}
+
+func (suite *CreateTestSuite) TestCreateWithExpiredInvite() {
+	config.SetAccountsInvitesEnabled(true)
+
+	invite := testrig.NewTestInvites()["admin_account_invite_expired"]
+
+	_, errWithCode := suite.user.Create(context.Background(), nil, suite.form(invite.Code))
+	suite.EqualError(errWithCode, "this invite is not valid, or has expired")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteDisabled() {
+	config.SetAccountsInvitesEnabled(false)
+
+	invite := testrig.NewTestInvites()["local_account_1_invite"]
+
+	_, errWithCode := suite.user.Create(context.Background(), nil, suite.form(invite.Code))
+	suite.EqualError(errWithCode, "invites are not enabled on this instance")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteDailyLimit() {
+	ctx := context.Background()
+
+	config.SetAccountsInvitesEnabled(true)
+
+	// Approve enough sign-ups to hit the daily limit.
+	for i := 0; i < 10; i++ {
+		if _, err := suite.db.NewSignup(ctx, gtsmodel.NewSignup{
+			Username:    fmt.Sprintf("new_person_%d", i),
+			Email:       fmt.Sprintf("new_person_%d@example.org", i),
+			Password:    "very-good-password-123",
+			AppID:       testrig.NewTestApplications()["application_1"].ID,
+			PreApproved: true,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	invite := testrig.NewTestInvites()["local_account_1_invite"]
+
+	_, errWithCode := suite.user.Create(ctx, nil, suite.form(invite.Code))
+	suite.EqualError(errWithCode, "this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Invite should not have been used.
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(invite.Uses, dbInvite.Uses)
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// EmailNotificationsGet returns the email notification preferences of the given user.
+func (p *Processor) EmailNotificationsGet(ctx context.Context, user *gtsmodel.User) *apimodel.EmailNotifications {
+	return p.converter.UserToAPIEmailNotifications(user)
+}
+
+// EmailNotificationsUpdate updates the email notification
+// preferences of the given user with any set form values.
+func (p *Processor) EmailNotificationsUpdate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.EmailNotificationsUpdateRequest,
+) (*apimodel.EmailNotifications, gtserror.WithCode) {
+	var columns []string
+
+	for _, field := range []struct {
+		value  *bool
+		dst    **bool
+		column string
+	}{
+		{form.Mention, &user.EmailNotifyMention, "email_notify_mention"},
+		{form.Direct, &user.EmailNotifyDirect, "email_notify_direct"},
+		{form.Follow, &user.EmailNotifyFollow, "email_notify_follow"},
+		{form.FollowRequest, &user.EmailNotifyFollowRequest, "email_notify_follow_request"},
+		{form.Report, &user.EmailNotifyReport, "email_notify_report"},
+	} {
+		if field.value != nil {
+			*field.dst = util.Ptr(*field.value)
+			columns = append(columns, field.column)
+		}
+	}
+
+	if form.Frequency != nil {
+		frequency := gtsmodel.ParseEmailNotifyFrequency(*form.Frequency)
+		if frequency == gtsmodel.EmailNotifyFrequencyUnknown {
+			const text = "frequency must be one of immediate, daily, weekly"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		if frequency != user.EmailNotifyFrequency {
+			// Start a new digest period from now, so
+			// a digest doesn't include anything that
+			// may already have been emailed, and isn't
+			// sent as soon as the frequency is changed.
+			user.EmailNotifyFrequency = frequency
+			user.EmailDigestSentAt = time.Now()
+			columns = append(columns, "email_notify_frequency", "email_digest_sent_at")
+		}
+	}
+
+	if len(columns) == 0 {
+		// Nothing to change.
+		return p.converter.UserToAPIEmailNotifications(user), nil
+	}
+
+	if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.UserToAPIEmailNotifications(user), nil
+}
+
+// EmailGetUserForUnsubscribeToken retrieves the user (with account)
+// from the database for the given email unsubscribe token string.
+func (p *Processor) EmailGetUserForUnsubscribeToken(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		err := errors.New("no token provided")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	user, err := p.state.DB.GetUserByEmailUnsubscribeToken(ctx, token)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// No user found for this token.
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.PopulateUser(ctx, user); err != nil {
+		// We need the account for a local user.
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// EmailUnsubscribe processes an email unsubscribe request, usually
+// initiated as a result of clicking on an unsubscribe link in an
+// email, or a one-click unsubscribe from the user's email client.
+//
+// The unsubscribe type should be either an email notification type,
+// to stop emails of only that type, or "all" to stop all of them.
+func (p *Processor) EmailUnsubscribe(ctx context.Context, token string, unsubscribeType string) (*gtsmodel.User, gtserror.WithCode) {
+	user, errWithCode := p.EmailGetUserForUnsubscribeToken(ctx, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var (
+		off  = new(bool)
+		form = &apimodel.EmailNotificationsUpdateRequest{}
+	)
+
+	switch gtsmodel.EmailNotifyType(unsubscribeType) {
+	case gtsmodel.EmailNotifyTypeMention:
+		form.Mention = off
+	case gtsmodel.EmailNotifyTypeDirect:
+		form.Direct = off
+	case gtsmodel.EmailNotifyTypeFollow:
+		form.Follow = off
+	case gtsmodel.EmailNotifyTypeFollowRequest:
+		form.FollowRequest = off
+	case gtsmodel.EmailNotifyTypeReport:
+		form.Report = off
+	case "all":
+		form.Mention = off
+		form.Direct = off
+		form.Follow = off
+		form.FollowRequest = off
+		form.Report = off
+	default:
+		const text = "unknown unsubscribe type"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if _, errWithCode := p.EmailNotificationsUpdate(ctx, user, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return user, nil
+}
@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type EmailNotificationsTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailNotificationsDefaults() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	prefs := suite.user.EmailNotificationsGet(ctx, user)
+	suite.Equal(&apimodel.EmailNotifications{
+		Report:    true,
+		Frequency: "immediate",
+	}, prefs)
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailNotificationsUpdate() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	prefs, errWithCode := suite.user.EmailNotificationsUpdate(ctx, user, &apimodel.EmailNotificationsUpdateRequest{
+		Mention:   util.Ptr(true),
+		Report:    util.Ptr(false),
+		Frequency: util.Ptr("daily"),
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(&apimodel.EmailNotifications{
+		Mention:   true,
+		Frequency: "daily",
+	}, prefs)
+
+	// Changes should be stored.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbUser.EmailNotifies(gtsmodel.EmailNotifyTypeMention))
+	suite.False(dbUser.EmailNotifies(gtsmodel.EmailNotifyTypeReport))
+	suite.Equal(gtsmodel.EmailNotifyFrequencyDaily, dbUser.EmailNotifyFrequency)
+	suite.False(dbUser.EmailDigestSentAt.IsZero())
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailNotificationsUpdateBadFrequency() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.user.EmailNotificationsUpdate(ctx, user, &apimodel.EmailNotificationsUpdateRequest{
+		Frequency: util.Ptr("hourly"),
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("Bad Request: frequency must be one of immediate, daily, weekly", errWithCode.Safe())
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailUnsubscribe() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.EmailNotifyMention = util.Ptr(true)
+	user.EmailNotifyFollow = util.Ptr(true)
+	user.EmailUnsubscribeToken = "3b4a0e36-7f36-4b5d-b3b1-1a3a8c8d6e0d"
+	if err := suite.db.UpdateUser(ctx, user,
+		"email_notify_mention",
+		"email_notify_follow",
+		"email_unsubscribe_token",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Unsubscribe from just mentions.
+	_, errWithCode := suite.user.EmailUnsubscribe(ctx, user.EmailUnsubscribeToken, "mention")
+	suite.NoError(errWithCode)
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbUser.EmailNotifies(gtsmodel.EmailNotifyTypeMention))
+	suite.True(dbUser.EmailNotifies(gtsmodel.EmailNotifyTypeFollow))
+	suite.True(dbUser.EmailNotifies(gtsmodel.EmailNotifyTypeReport))
+
+	// Unsubscribe from everything else.
+	_, errWithCode = suite.user.EmailUnsubscribe(ctx, user.EmailUnsubscribeToken, "all")
+	suite.NoError(errWithCode)
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbUser.EmailNotifiesAny())
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailUnsubscribeBadToken() {
+	_, errWithCode := suite.user.EmailUnsubscribe(context.Background(), "not a real token", "all")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestEmailNotificationsTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailNotificationsTestSuite))
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// FeedTokenGet returns the private feed token of the given user.
+func (p *Processor) FeedTokenGet(ctx context.Context, user *gtsmodel.User) *apimodel.FeedToken {
+	return &apimodel.FeedToken{Token: user.FeedToken}
+}
+
+// FeedTokenRotate generates a new private feed token for the given
+// user, so that any feed links with the previous one stop working.
+func (p *Processor) FeedTokenRotate(ctx context.Context, user *gtsmodel.User) (*apimodel.FeedToken, gtserror.WithCode) {
+	user.FeedToken = uuid.NewString()
+	if err := p.state.DB.UpdateUser(ctx, user, "feed_token"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.FeedToken{Token: user.FeedToken}, nil
+}
+
+// FeedTokenRevoke removes the private feed token of the
+// given user, so that any feed links with it stop working.
+func (p *Processor) FeedTokenRevoke(ctx context.Context, user *gtsmodel.User) (*apimodel.FeedToken, gtserror.WithCode) {
+	user.FeedToken = ""
+	if err := p.state.DB.UpdateUser(ctx, user, "feed_token"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.FeedToken{}, nil
+}
@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type FeedTokenTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *FeedTokenTestSuite) TestFeedTokenRotateRevoke() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// No token to begin with.
+	suite.Empty(suite.user.FeedTokenGet(ctx, user).Token)
+
+	// Rotating should give us a new token,
+	// which can be used to get the user.
+	token, errWithCode := suite.user.FeedTokenRotate(ctx, user)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(token.Token)
+
+	dbUser, err := suite.db.GetUserByFeedToken(ctx, token.Token)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(user.ID, dbUser.ID)
+
+	// Rotating again should invalidate the old token.
+	newToken, errWithCode := suite.user.FeedTokenRotate(ctx, user)
+	suite.NoError(errWithCode)
+	suite.NotEqual(token.Token, newToken.Token)
+
+	_, err = suite.db.GetUserByFeedToken(ctx, token.Token)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Revoking should leave no token at all.
+	token, errWithCode = suite.user.FeedTokenRevoke(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Empty(token.Token)
+
+	_, err = suite.db.GetUserByFeedToken(ctx, newToken.Token)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestFeedTokenTestSuite(t *testing.T) {
+	suite.Run(t, &FeedTokenTestSuite{})
+}
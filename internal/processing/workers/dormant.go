@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// dormantCheckFrequency is how often
+// to check for dormant local accounts.
+const dormantCheckFrequency = 24 * time.Hour
+
+// ScheduleDormantAccounts schedules a recurring job to warn,
+// and possibly later disable, dormant local accounts.
+func (p *Processor) ScheduleDormantAccounts() error {
+	if !p.workers.Scheduler.AddRecurring(
+		"@dormant",  // id
+		time.Time{}, // start
+		dormantCheckFrequency,
+		func(ctx context.Context, now time.Time) {
+			if err := p.CheckDormantAccounts(ctx, now); err != nil {
+				log.Errorf(ctx, "error checking dormant accounts: %v", err)
+			}
+		},
+	) {
+		return errors.New("failed to schedule @dormant")
+	}
+	return nil
+}
+
+// CheckDormantAccounts finds local users who haven't signed in or
+// posted for accounts-dormant-months as of the given time, and emails
+// them a warning. If accounts-dormant-disable is set, those who were
+// warned longer than accounts-dormant-grace-period ago, and still
+// haven't been active since, are disabled.
+func (p *Processor) CheckDormantAccounts(ctx context.Context, now time.Time) error {
+	months := config.GetAccountsDormantMonths()
+	if months <= 0 {
+		// Not enabled.
+		return nil
+	}
+
+	// Only users who haven't signed in since
+	// the cutoff may be dormant, but check warned
+	// users too, in case they've been back since.
+	cutoff := now.AddDate(0, -months, 0)
+	users, err := p.surface.State.DB.GetUsersInactiveSince(ctx, cutoff)
+	if err != nil {
+		return gtserror.Newf("db error getting inactive users: %w", err)
+	}
+
+	for _, user := range users {
+		if err := p.checkDormantUser(ctx, user, cutoff, now); err != nil {
+			log.Errorf(ctx, "error checking if user %s is dormant: %v", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) checkDormantUser(
+	ctx context.Context,
+	user *gtsmodel.User,
+	cutoff time.Time,
+	now time.Time,
+) error {
+	if user.ConfirmedAt.IsZero() ||
+		!*user.Approved ||
+		*user.Disabled ||
+		*user.Admin ||
+		*user.Moderator {
+		// Pending and disabled users are
+		// handled elsewhere, and admins and
+		// mods are never considered dormant.
+		return nil
+	}
+
+	account, err := p.surface.State.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		user.AccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	if account.IsSuspended() ||
+		util.PtrValueOr(account.Memorial, false) {
+		// Nothing to warn about.
+		return nil
+	}
+
+	if err := p.surface.State.DB.PopulateAccountStats(ctx, account); err != nil {
+		return gtserror.Newf("db error getting account stats: %w", err)
+	}
+
+	// Account was last active when it
+	// was created, signed in, or posted,
+	// whichever was most recent.
+	lastActive := user.CreatedAt
+	for _, t := range []time.Time{
+		user.LastActiveAt,
+		account.Stats.LastStatusAt,
+	} {
+		if t.After(lastActive) {
+			lastActive = t
+		}
+	}
+
+	if lastActive.After(cutoff) {
+		if !user.DormantWarnedAt.IsZero() {
+			// User came back after being
+			// warned, so clear the warning.
+			user.DormantWarnedAt = time.Time{}
+			if err := p.surface.State.DB.UpdateUser(ctx, user, "dormant_warned_at"); err != nil {
+				return gtserror.Newf("db error updating user: %w", err)
+			}
+		}
+		return nil
+	}
+
+	disable := config.GetAccountsDormantDisable()
+	gracePeriod := config.GetAccountsDormantGracePeriod()
+
+	if user.DormantWarnedAt.IsZero() {
+		// Newly dormant, warn the user.
+		var disableAt time.Time
+		if disable {
+			disableAt = now.Add(gracePeriod)
+		}
+
+		user.Account = account
+		if err := p.surface.emailUserDormant(ctx, user, lastActive, disableAt); err != nil {
+			return err
+		}
+
+		user.DormantWarnedAt = now
+		if err := p.surface.State.DB.UpdateUser(ctx, user, "dormant_warned_at"); err != nil {
+			return gtserror.Newf("db error updating user: %w", err)
+		}
+
+		log.Infof(ctx, "warned dormant user %s (@%s)", user.ID, account.Username)
+		return nil
+	}
+
+	if !disable || now.Before(user.DormantWarnedAt.Add(gracePeriod)) {
+		// Already warned, and
+		// nothing more to do yet.
+		return nil
+	}
+
+	// Grace period is over, so disable the user. As with
+	// disabling by an admin, their data is kept, and an
+	// admin can reenable them later if they come back.
+	user.Disabled = util.Ptr(true)
+	if err := p.surface.State.DB.UpdateUser(ctx, user, "disabled"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	// Disabling changes the visibility
+	// of all the account's statuses.
+	p.surface.State.Caches.Visibility.InvalidateAccount(user.AccountID)
+
+	log.Infof(ctx, "disabled dormant user %s (@%s)", user.ID, account.Username)
+	return nil
+}
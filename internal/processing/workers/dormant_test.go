@@ -0,0 +1,103 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type DormantTestSuite struct {
+	WorkersTestSuite
+}
+
+func (suite *DormantTestSuite) TestCheckDormantAccounts() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	config.SetAccountsDormantMonths(1)
+	config.SetAccountsDormantDisable(true)
+	config.SetAccountsDormantGracePeriod(time.Hour)
+
+	var (
+		ctx = context.Background()
+		db  = testStructs.State.DB
+		now = time.Now().AddDate(1, 0, 0).Truncate(time.Second)
+	)
+
+	// Turtle was warned a while ago,
+	// but has signed in again since.
+	turtle := new(gtsmodel.User)
+	*turtle = *suite.testUsers["local_account_2"]
+	turtle.LastActiveAt = now.Add(-24 * time.Hour)
+	turtle.DormantWarnedAt = now.Add(-48 * time.Hour)
+	if err := db.UpdateUser(ctx, turtle, "last_active_at", "dormant_warned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := testStructs.Processor.Workers().CheckDormantAccounts(ctx, now); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Zork hasn't been seen for
+	// ages, so should be warned.
+	zork, err := db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(zork.DormantWarnedAt.Equal(now))
+	suite.False(*zork.Disabled)
+
+	// Turtle's warning should be cleared.
+	turtle, err = db.GetUserByID(ctx, turtle.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(turtle.DormantWarnedAt)
+	suite.False(*turtle.Disabled)
+
+	// Admin is never considered dormant.
+	admin, err := db.GetUserByID(ctx, suite.testUsers["admin_account"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(admin.DormantWarnedAt)
+
+	// Check again after the grace period;
+	// zork should now be disabled.
+	if err := testStructs.Processor.Workers().CheckDormantAccounts(ctx, now.Add(2*time.Hour)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	zork, err = db.GetUserByID(ctx, zork.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(util.PtrValueOr(zork.Disabled, false))
+	suite.False(*admin.Disabled)
+}
+
+func TestDormantTestSuite(t *testing.T) {
+	suite.Run(t, &DormantTestSuite{})
+}
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// emailDigestFrequency is how often to check
+// whether any users are due a digest email.
+const emailDigestFrequency = time.Hour
+
+// ScheduleEmailDigests schedules a recurring job to
+// email notification digests to users who want them.
+func (p *Processor) ScheduleEmailDigests() error {
+	if !p.workers.Scheduler.AddRecurring(
+		"@emaildigest", // id
+		time.Time{},    // start
+		emailDigestFrequency,
+		func(ctx context.Context, now time.Time) {
+			if err := p.SendEmailDigests(ctx, now); err != nil {
+				log.Errorf(ctx, "error sending email digests: %v", err)
+			}
+		},
+	) {
+		return errors.New("failed to schedule @emaildigest")
+	}
+	return nil
+}
+
+// SendEmailDigests emails notification digests to each
+// user who wants them, and is due one at the given time.
+func (p *Processor) SendEmailDigests(ctx context.Context, now time.Time) error {
+	users, err := p.surface.State.DB.GetUsersDueEmailDigest(ctx, now)
+	if err != nil {
+		return gtserror.Newf("db error getting users due digest: %w", err)
+	}
+
+	for _, user := range users {
+		if err := p.surface.emailUserDigest(ctx, user, now); err != nil {
+			log.Errorf(ctx, "error emailing digest to user %s: %v", user.ID, err)
+		}
+	}
+
+	return nil
+}
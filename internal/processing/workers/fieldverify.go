@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// fieldVerifyFrequency is how often to recheck
+// the rel="me" links in local users' profile fields.
+const fieldVerifyFrequency = 24 * time.Hour
+
+// fieldVerifyPageSize is the number of accounts
+// fetched at a time when rechecking all fields.
+const fieldVerifyPageSize = 100
+
+// ScheduleFieldVerification schedules a recurring job to recheck
+// the verification of links in local users' profile fields.
+func (p *Processor) ScheduleFieldVerification() error {
+	if !p.workers.Scheduler.AddRecurring(
+		"@fieldverify", // id
+		time.Time{},    // start
+		fieldVerifyFrequency,
+		func(ctx context.Context, _ time.Time) {
+			if err := p.VerifyAllAccountFields(ctx); err != nil {
+				log.Errorf(ctx, "error verifying account fields: %v", err)
+			}
+		},
+	) {
+		return errors.New("failed to schedule @fieldverify")
+	}
+	return nil
+}
+
+// VerifyAllAccountFields queues a recheck of the links in
+// the profile fields of each local user that has any fields.
+func (p *Processor) VerifyAllAccountFields(ctx context.Context) error {
+	page := paging.Page{Limit: fieldVerifyPageSize}
+
+	for {
+		accounts, err := p.surface.State.DB.GetLocalAccountsWithFields(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting accounts: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			// Reached the end.
+			return nil
+		}
+
+		for _, account := range accounts {
+			p.utils.verifyAccountFields(account, true)
+		}
+
+		// Use last ID as the next 'maxID'.
+		page.Max = paging.MaxID(accounts[len(accounts)-1].ID)
+	}
+}
@@ -94,6 +94,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.CreateLike(ctx, cMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.CreateEmojiReact(ctx, cMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.CreateAnnounce(ctx, cMsg)
@@ -166,6 +170,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.UndoFave(ctx, cMsg)
 
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.UndoEmojiReact(ctx, cMsg)
+
 		// UNDO ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.UndoAnnounce(ctx, cMsg)
@@ -227,6 +235,10 @@ func (p *clientAPI) CreateUser(ctx context.Context, cMsg *messages.FromClientAPI
 		log.Errorf(ctx, "error emailing confirm: %v", err)
 	}
 
+	if err := p.surface.webhookUser(ctx, gtsmodel.WebhookEventAccountCreated, newUser); err != nil {
+		log.Errorf(ctx, "error sending account created webhook: %v", err)
+	}
+
 	return nil
 }
 
@@ -255,6 +267,13 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error federating status: %v", err)
 	}
 
+	// Fetch preview card for any link in status.
+	p.utils.fetchPreviewCard(status)
+
+	if err := p.surface.webhookStatus(ctx, gtsmodel.WebhookEventStatusCreated, status); err != nil {
+		log.Errorf(ctx, "error sending status created webhook: %v", err)
+	}
+
 	return nil
 }
 
@@ -348,6 +367,14 @@ func (p *clientAPI) CreateFollowReq(ctx context.Context, cMsg *messages.FromClie
 		log.Errorf(ctx, "error federating follow request: %v", err)
 	}
 
+	// Backfill recent posts from the target
+	// account (if remote), so that the
+	// origin has something to look at.
+	p.federate.BackfillAccountAsync(ctx,
+		cMsg.Origin.Username,
+		cMsg.Target,
+	)
+
 	return nil
 }
 
@@ -377,6 +404,28 @@ func (p *clientAPI) CreateLike(ctx context.Context, cMsg *messages.FromClientAPI
 	return nil
 }
 
+func (p *clientAPI) CreateEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	// Ensure reaction populated.
+	if err := p.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating status reaction: %w", err)
+	}
+
+	if err := p.surface.notifyEmojiReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying emoji reaction: %v", err)
+	}
+
+	if err := p.federate.EmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating emoji reaction: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) CreateAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	boost, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -472,6 +521,13 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error streaming status edit: %v", err)
 	}
 
+	// Links may have changed, refetch preview card.
+	p.utils.fetchPreviewCard(status)
+
+	if err := p.surface.webhookStatus(ctx, gtsmodel.WebhookEventStatusUpdated, status); err != nil {
+		log.Errorf(ctx, "error sending status updated webhook: %v", err)
+	}
+
 	return nil
 }
 
@@ -485,6 +541,9 @@ func (p *clientAPI) UpdateAccount(ctx context.Context, cMsg *messages.FromClient
 		log.Errorf(ctx, "error federating account update: %v", err)
 	}
 
+	// Verify any new links in profile fields.
+	p.utils.verifyAccountFields(account, false)
+
 	return nil
 }
 
@@ -494,6 +553,10 @@ func (p *clientAPI) UpdateReport(ctx context.Context, cMsg *messages.FromClientA
 		return gtserror.Newf("%T not parseable as *gtsmodel.Report", cMsg.GTSModel)
 	}
 
+	if err := p.surface.webhookReport(ctx, gtsmodel.WebhookEventReportUpdated, report); err != nil {
+		log.Errorf(ctx, "error sending report updated webhook: %v", err)
+	}
+
 	if report.Account.IsRemote() {
 		// Report creator is a remote account,
 		// we shouldn't try to email them!
@@ -628,6 +691,19 @@ func (p *clientAPI) UndoFave(ctx context.Context, cMsg *messages.FromClientAPI)
 	return nil
 }
 
+func (p *clientAPI) UndoEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	if err := p.federate.UndoEmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating emoji reaction undo: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -779,6 +855,10 @@ func (p *clientAPI) ReportAccount(ctx context.Context, cMsg *messages.FromClient
 		log.Errorf(ctx, "error emailing report opened: %v", err)
 	}
 
+	if err := p.surface.webhookReport(ctx, gtsmodel.WebhookEventReportCreated, report); err != nil {
+		log.Errorf(ctx, "error sending report created webhook: %v", err)
+	}
+
 	return nil
 }
 
@@ -832,6 +912,10 @@ func (p *clientAPI) AcceptUser(ctx context.Context, cMsg *messages.FromClientAPI
 		log.Errorf(ctx, "error emailing: %v", err)
 	}
 
+	if err := p.surface.webhookUser(ctx, gtsmodel.WebhookEventAccountApproved, newUser); err != nil {
+		log.Errorf(ctx, "error sending account approved webhook: %v", err)
+	}
+
 	return nil
 }
 
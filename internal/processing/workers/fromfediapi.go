@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -90,6 +91,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityLike:
 			return p.fediAPI.CreateLike(ctx, fMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.CreateEmojiReact(ctx, fMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.CreateAnnounce(ctx, fMsg)
@@ -232,6 +237,9 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Fetch preview card for any link in status.
+	p.utils.fetchPreviewCard(status)
+
 	return nil
 }
 
@@ -290,6 +298,27 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
+	if util.PtrValueOr(followRequest.TargetAccount.Memorial, false) {
+		// Local account is a memorial,
+		// which can't gain new followers.
+		if err := p.state.DB.RejectFollowRequest(
+			ctx,
+			followRequest.AccountID,
+			followRequest.TargetAccountID,
+		); err != nil {
+			return gtserror.Newf("error rejecting follow request: %w", err)
+		}
+
+		if err := p.federate.RejectFollow(
+			ctx,
+			p.surface.Converter.FollowRequestToFollow(ctx, followRequest),
+		); err != nil {
+			log.Errorf(ctx, "error federating follow request reject: %v", err)
+		}
+
+		return nil
+	}
+
 	if *followRequest.TargetAccount.Locked {
 		// Local account is locked: just notify the follow request.
 		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
@@ -359,6 +388,51 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) er
 	return nil
 }
 
+func (p *fediAPI) CreateEmojiReact(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	if placeholder := reaction.Emoji; placeholder != nil &&
+		reaction.EmojiID == "" {
+		// Reaction uses a custom emoji which
+		// we may not have yet, dereference it.
+		emoji, err := p.federate.GetEmoji(ctx,
+			placeholder.Shortcode,
+			placeholder.Domain,
+			placeholder.ImageRemoteURL,
+			media.AdditionalEmojiInfo{
+				URI:                  &placeholder.URI,
+				ImageRemoteURL:       &placeholder.ImageRemoteURL,
+				ImageStaticRemoteURL: &placeholder.ImageStaticRemoteURL,
+			},
+			false,
+		)
+		if err != nil && emoji == nil {
+			return gtserror.Newf("error getting reaction emoji %s: %w", placeholder.URI, err)
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+
+		if err := p.state.DB.UpdateStatusReaction(ctx, reaction, "emoji_id"); err != nil {
+			return gtserror.Newf("error updating status reaction: %w", err)
+		}
+	}
+
+	// Ensure reaction populated.
+	if err := p.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating status reaction: %w", err)
+	}
+
+	if err := p.surface.notifyEmojiReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying emoji reaction: %v", err)
+	}
+
+	return nil
+}
+
 func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	boost, ok := fMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -499,6 +573,10 @@ func (p *fediAPI) CreateFlag(ctx context.Context, fMsg *messages.FromFediAPI) er
 		log.Errorf(ctx, "error emailing report opened: %v", err)
 	}
 
+	if err := p.surface.webhookReport(ctx, gtsmodel.WebhookEventReportCreated, incomingReport); err != nil {
+		log.Errorf(ctx, "error sending report created webhook: %v", err)
+	}
+
 	return nil
 }
 
@@ -589,6 +667,9 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error streaming status edit: %v", err)
 	}
 
+	// Links may have changed, refetch preview card.
+	p.utils.fetchPreviewCard(status)
+
 	return nil
 }
 
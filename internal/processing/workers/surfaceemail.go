@@ -26,9 +26,17 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // emailUserReportClosed emails the user who created the
@@ -39,15 +47,14 @@ func (s *Surface) emailUserReportClosed(ctx context.Context, report *gtsmodel.Re
 		return gtserror.Newf("db error getting user: %w", err)
 	}
 
-	if user.ConfirmedAt.IsZero() ||
-		!*user.Approved ||
-		*user.Disabled ||
-		user.Email == "" {
-		// Only email users who:
-		// - are confirmed
-		// - are approved
-		// - are not disabled
-		// - have an email address
+	if !emailable(user) {
+		return nil
+	}
+
+	if !user.EmailNotifies(gtsmodel.EmailNotifyTypeReport) ||
+		user.EmailNotifyFrequency != gtsmodel.EmailNotifyFrequencyImmediate {
+		// User doesn't want this email right
+		// now, it may go in a digest instead.
 		return nil
 	}
 
@@ -60,18 +67,440 @@ func (s *Surface) emailUserReportClosed(ctx context.Context, report *gtsmodel.Re
 		return gtserror.Newf("error populating report: %w", err)
 	}
 
+	unsubscribeToken, err := s.emailUnsubscribeToken(ctx, user)
+	if err != nil {
+		return err
+	}
+
 	reportClosedData := email.ReportClosedData{
 		Username:             report.Account.Username,
+		Locale:               user.Locale,
 		InstanceURL:          instance.URI,
 		InstanceName:         instance.Title,
 		ReportTargetUsername: report.TargetAccount.Username,
 		ReportTargetDomain:   report.TargetAccount.Domain,
 		ActionTakenComment:   report.ActionTaken,
+		UnsubscribeURL: uris.GenerateURIForEmailUnsubscribe(
+			unsubscribeToken,
+			string(gtsmodel.EmailNotifyTypeReport),
+		),
 	}
 
 	return s.EmailSender.SendReportClosedEmail(user.Email, reportClosedData)
 }
 
+// emailUserNotification emails the target of the given notification
+// about it, if they've asked to be emailed about notifications of
+// this type as they happen (rather than in a digest).
+func (s *Surface) emailUserNotification(ctx context.Context, notif *gtsmodel.Notification) error {
+	if err := s.State.DB.PopulateNotification(ctx, notif); err != nil {
+		return gtserror.Newf("error populating notification: %w", err)
+	}
+
+	emailType, ok := emailNotifyType(notif)
+	if !ok {
+		// Not a type of
+		// notification
+		// we email about.
+		return nil
+	}
+
+	user, err := s.State.DB.GetUserByAccountID(ctx, notif.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !emailable(user) {
+		return nil
+	}
+
+	if !user.EmailNotifies(emailType) ||
+		user.EmailNotifyFrequency != gtsmodel.EmailNotifyFrequencyImmediate {
+		// User doesn't want this email right
+		// now, it may go in a digest instead.
+		return nil
+	}
+
+	instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	unsubscribeToken, err := s.emailUnsubscribeToken(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	// Assemble email contents and send the email.
+	if err := s.EmailSender.SendNotificationEmail(
+		user.Email,
+		email.NotificationData{
+			Username:     notif.TargetAccount.Username,
+			Locale:       user.Locale,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			Notification: notificationEmailItem(notif, emailType),
+			UnsubscribeURL: uris.GenerateURIForEmailUnsubscribe(
+				unsubscribeToken,
+				string(emailType),
+			),
+		},
+	); err != nil {
+		return err
+	}
+
+	// Email sent, update the user
+	// entry with the emailed time.
+	user.LastEmailedAt = time.Now()
+	if err := s.State.DB.UpdateUser(
+		ctx,
+		user,
+		"last_emailed_at",
+	); err != nil {
+		return gtserror.Newf("error updating user entry after email sent: %w", err)
+	}
+
+	return nil
+}
+
+// emailUserDigest emails the given user a digest of notifications (and
+// closed reports) since their last digest, if they've asked for digests
+// and at least one digest period has passed since the last one.
+func (s *Surface) emailUserDigest(ctx context.Context, user *gtsmodel.User, now time.Time) error {
+	period := user.EmailNotifyFrequency.Period()
+	if period == 0 ||
+		!user.EmailNotifiesAny() ||
+		!emailable(user) {
+		// No digest wanted.
+		return nil
+	}
+
+	since := user.EmailDigestSentAt
+	if since.IsZero() {
+		// User has never had a digest,
+		// so start the first period now.
+		user.EmailDigestSentAt = now
+		if err := s.State.DB.UpdateUser(ctx, user, "email_digest_sent_at"); err != nil {
+			return gtserror.Newf("error updating user: %w", err)
+		}
+		return nil
+	}
+
+	if now.Sub(since) < period {
+		// Not due yet.
+		return nil
+	}
+
+	if err := s.State.DB.PopulateUser(ctx, user); err != nil {
+		return gtserror.Newf("error populating user: %w", err)
+	}
+
+	items, err := s.notificationEmailItemsSince(ctx, user, since)
+	if err != nil {
+		return err
+	}
+
+	if len(items) > 0 {
+		instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+		if err != nil {
+			return gtserror.Newf("db error getting instance: %w", err)
+		}
+
+		unsubscribeToken, err := s.emailUnsubscribeToken(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		periodStr := "day"
+		if user.EmailNotifyFrequency == gtsmodel.EmailNotifyFrequencyWeekly {
+			periodStr = "week"
+		}
+
+		// Assemble email contents and send the email.
+		if err := s.EmailSender.SendDigestEmail(
+			user.Email,
+			email.DigestData{
+				Username:       user.Account.Username,
+				Locale:         user.Locale,
+				InstanceURL:    instance.URI,
+				InstanceName:   instance.Title,
+				Period:         periodStr,
+				Notifications:  items,
+				UnsubscribeURL: uris.GenerateURIForEmailUnsubscribe(unsubscribeToken, "all"),
+			},
+		); err != nil {
+			return err
+		}
+
+		user.LastEmailedAt = now
+	}
+
+	// Digest period done, update the
+	// user entry with the digest time.
+	user.EmailDigestSentAt = now
+	if err := s.State.DB.UpdateUser(
+		ctx,
+		user,
+		"email_digest_sent_at",
+		"last_emailed_at",
+	); err != nil {
+		return gtserror.Newf("error updating user entry after digest: %w", err)
+	}
+
+	return nil
+}
+
+// notificationEmailItemsSince returns email items for notifications
+// and closed reports since the given time that the user wants to
+// be emailed about, oldest first.
+func (s *Surface) notificationEmailItemsSince(ctx context.Context, user *gtsmodel.User, since time.Time) ([]email.NotificationItem, error) {
+	var (
+		items []email.NotificationItem
+		types []string
+	)
+
+	// Gather notification types to select.
+	if user.EmailNotifies(gtsmodel.EmailNotifyTypeMention) ||
+		user.EmailNotifies(gtsmodel.EmailNotifyTypeDirect) {
+		types = append(types, string(gtsmodel.NotificationMention))
+	}
+	if user.EmailNotifies(gtsmodel.EmailNotifyTypeFollow) {
+		types = append(types, string(gtsmodel.NotificationFollow))
+	}
+	if user.EmailNotifies(gtsmodel.EmailNotifyTypeFollowRequest) {
+		types = append(types, string(gtsmodel.NotificationFollowRequest))
+	}
+
+	if len(types) > 0 {
+		sinceID, err := id.NewULIDFromTime(since)
+		if err != nil {
+			return nil, gtserror.Newf("error generating id: %w", err)
+		}
+
+		notifs, err := s.State.DB.GetAccountNotifications(ctx,
+			user.AccountID,
+			"",
+			sinceID,
+			"",
+			maxDigestItems,
+			types,
+			nil,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting notifications: %w", err)
+		}
+
+		filters, err := s.State.DB.GetFiltersForAccountID(ctx, user.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("db error getting filters: %w", err)
+		}
+
+		mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), user.AccountID, nil)
+		if err != nil {
+			return nil, gtserror.Newf("db error getting mutes: %w", err)
+		}
+		compiledMutes := usermute.NewCompiledUserMuteList(mutes)
+
+		// Notifications are returned newest
+		// first, so iterate them in reverse.
+		for i := len(notifs) - 1; i >= 0; i-- {
+			notif := notifs[i]
+
+			emailType, ok := emailNotifyType(notif)
+			if !ok || !user.EmailNotifies(emailType) {
+				continue
+			}
+
+			// Skip anything that would be hidden from
+			// the user's notifications by filters/mutes.
+			if _, err := s.Converter.NotificationToAPINotification(ctx,
+				notif,
+				filters,
+				compiledMutes,
+			); err != nil {
+				if !errors.Is(err, status.ErrHideStatus) {
+					log.Errorf(ctx, "error converting notification %s: %v", notif.ID, err)
+				}
+				continue
+			}
+
+			items = append(items, notificationEmailItem(notif, emailType))
+		}
+	}
+
+	if user.EmailNotifies(gtsmodel.EmailNotifyTypeReport) {
+		reports, err := s.State.DB.GetReports(ctx,
+			util.Ptr(true),
+			user.AccountID,
+			"",
+			&paging.Page{Limit: maxDigestItems},
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting reports: %w", err)
+		}
+
+		// Reports are returned newest
+		// first, so iterate them in reverse.
+		for i := len(reports) - 1; i >= 0; i-- {
+			report := reports[i]
+
+			if !report.ActionTakenAt.After(since) {
+				// Closed before
+				// last digest.
+				continue
+			}
+
+			if err := s.State.DB.PopulateReport(ctx, report); err != nil {
+				log.Errorf(ctx, "error populating report %s: %v", report.ID, err)
+				continue
+			}
+
+			items = append(items, email.NotificationItem{
+				Type:    string(gtsmodel.EmailNotifyTypeReport),
+				Account: "@" + report.TargetAccount.Username + domainSuffix(report.TargetAccount),
+				Text:    report.ActionTaken,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// emailUnsubscribeToken returns the email unsubscribe
+// token for the given user, generating one if necessary.
+func (s *Surface) emailUnsubscribeToken(ctx context.Context, user *gtsmodel.User) (string, error) {
+	if user.EmailUnsubscribeToken != "" {
+		return user.EmailUnsubscribeToken, nil
+	}
+
+	// We'll use a uuid as our token
+	// since it's secure enough for this.
+	user.EmailUnsubscribeToken = uuid.NewString()
+	if err := s.State.DB.UpdateUser(ctx, user, "email_unsubscribe_token"); err != nil {
+		return "", gtserror.Newf("error updating user unsubscribe token: %w", err)
+	}
+
+	return user.EmailUnsubscribeToken, nil
+}
+
+// emailUserDormant emails the given user (with populated
+// account) that their account has been inactive since
+// lastActive, and will be disabled at disableAt, if set.
+func (s *Surface) emailUserDormant(
+	ctx context.Context,
+	user *gtsmodel.User,
+	lastActive time.Time,
+	disableAt time.Time,
+) error {
+	if user.Email == "" {
+		// Nowhere to send to.
+		return nil
+	}
+
+	instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	data := email.DormantData{
+		Username:     user.Account.Username,
+		Locale:       user.Locale,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		LastActive:   lastActive.UTC().Format(time.DateOnly),
+	}
+
+	if !disableAt.IsZero() {
+		data.DisableAt = disableAt.UTC().Format(time.DateOnly)
+	}
+
+	if err := s.EmailSender.SendDormantEmail(user.Email, data); err != nil {
+		return gtserror.Newf("error emailing user: %w", err)
+	}
+
+	return nil
+}
+
+// maxDigestItems is the maximum number of each of
+// notifications and closed reports in one digest.
+const maxDigestItems = 100
+
+// maxEmailTextLength is the maximum length of
+// status text to include in notification emails.
+const maxEmailTextLength = 500
+
+// emailable returns whether the given user can be emailed
+// about events on their account, ie., whether they:
+//   - are confirmed
+//   - are approved
+//   - are not disabled
+//   - have an email address
+func emailable(user *gtsmodel.User) bool {
+	return !user.ConfirmedAt.IsZero() &&
+		*user.Approved &&
+		!*user.Disabled &&
+		user.Email != ""
+}
+
+// emailNotifyType returns the type of email notification
+// for the given (populated) notification, if any.
+func emailNotifyType(notif *gtsmodel.Notification) (gtsmodel.EmailNotifyType, bool) {
+	switch notif.NotificationType {
+	case gtsmodel.NotificationMention:
+		if notif.Status != nil &&
+			notif.Status.Visibility == gtsmodel.VisibilityDirect {
+			return gtsmodel.EmailNotifyTypeDirect, true
+		}
+		return gtsmodel.EmailNotifyTypeMention, true
+	case gtsmodel.NotificationFollow:
+		return gtsmodel.EmailNotifyTypeFollow, true
+	case gtsmodel.NotificationFollowRequest:
+		return gtsmodel.EmailNotifyTypeFollowRequest, true
+	default:
+		return "", false
+	}
+}
+
+// notificationEmailItem converts the given (populated)
+// notification into an item for inclusion in an email.
+func notificationEmailItem(notif *gtsmodel.Notification, emailType gtsmodel.EmailNotifyType) email.NotificationItem {
+	item := email.NotificationItem{
+		Type:    string(emailType),
+		Account: "@" + notif.OriginAccount.Username + domainSuffix(notif.OriginAccount),
+		URL:     notif.OriginAccount.URL,
+	}
+
+	if s := notif.Status; s != nil {
+		item.URL = s.URL
+		if item.URL == "" {
+			item.URL = s.URI
+		}
+
+		if s.ContentWarning != "" {
+			// Respect content warnings by
+			// only including the warning.
+			item.Text = "Content warning: " + s.ContentWarning
+		} else {
+			item.Text = text.SanitizeToPlaintext(s.Content)
+		}
+
+		if r := []rune(item.Text); len(r) > maxEmailTextLength {
+			item.Text = string(r[:maxEmailTextLength]) + "…"
+		}
+	}
+
+	return item
+}
+
+// domainSuffix returns "@domain" for remote
+// accounts, or empty string for local ones.
+func domainSuffix(account *gtsmodel.Account) string {
+	if account.IsLocal() {
+		return ""
+	}
+	return "@" + account.Domain
+}
+
 // emailUserPleaseConfirm emails the given user
 // to ask them to confirm their email address.
 //
@@ -99,15 +528,27 @@ func (s *Surface) emailUserPleaseConfirm(ctx context.Context, user *gtsmodel.Use
 		confirmLink  = uris.GenerateURIForEmailConfirm(confirmToken)
 	)
 
+	// Mention who invited
+	// them, if anyone.
+	var invitedBy string
+	if newSignup {
+		invitedBy, err = s.invitedBy(ctx, user)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Assemble email contents and send the email.
 	if err := s.EmailSender.SendConfirmEmail(
 		user.UnconfirmedEmail,
 		email.ConfirmData{
 			Username:     user.Account.Username,
+			Locale:       user.Locale,
 			InstanceURL:  instance.URI,
 			InstanceName: instance.Title,
 			ConfirmLink:  confirmLink,
 			NewSignup:    newSignup,
+			InvitedBy:    invitedBy,
 		},
 	); err != nil {
 		return err
@@ -154,6 +595,7 @@ func (s *Surface) emailUserSignupApproved(ctx context.Context, user *gtsmodel.Us
 		emailAddr,
 		email.SignupApprovedData{
 			Username:     user.Account.Username,
+			Locale:       user.Locale,
 			InstanceURL:  instance.URI,
 			InstanceName: instance.Title,
 		},
@@ -190,6 +632,7 @@ func (s *Surface) emailUserSignupRejected(ctx context.Context, deniedUser *gtsmo
 		deniedUser.Email,
 		email.SignupRejectedData{
 			Message:      deniedUser.Message,
+			Locale:       deniedUser.Locale,
 			InstanceURL:  instance.URI,
 			InstanceName: instance.Title,
 		},
@@ -254,6 +697,11 @@ func (s *Surface) emailAdminNewSignup(ctx context.Context, newUser *gtsmodel.Use
 		return gtserror.Newf("error populating user: %w", err)
 	}
 
+	invitedBy, err := s.invitedBy(ctx, newUser)
+	if err != nil {
+		return err
+	}
+
 	newSignupData := email.NewSignupData{
 		InstanceURL:    instance.URI,
 		InstanceName:   instance.Title,
@@ -261,6 +709,7 @@ func (s *Surface) emailAdminNewSignup(ctx context.Context, newUser *gtsmodel.Use
 		SignupUsername: newUser.Account.Username,
 		SignupReason:   newUser.Reason,
 		SignupURL:      instance.URI + "/settings/admin/accounts/" + newUser.AccountID,
+		InvitedBy:      invitedBy,
 	}
 
 	if err := s.EmailSender.SendNewSignupEmail(toAddresses, newSignupData); err != nil {
@@ -269,3 +718,25 @@ func (s *Surface) emailAdminNewSignup(ctx context.Context, newUser *gtsmodel.Use
 
 	return nil
 }
+
+// invitedBy returns the handle of the account who created
+// the invite that the given user signed up with, eg.,
+// "@someone", or an empty string if they used no invite.
+func (s *Surface) invitedBy(ctx context.Context, user *gtsmodel.User) (string, error) {
+	if user.InviteID == "" {
+		return "", nil
+	}
+
+	invite, err := s.State.DB.GetInviteByID(ctx, user.InviteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("db error getting invite: %w", err)
+	}
+
+	if invite == nil || invite.Account == nil {
+		// Invite or its
+		// creator is gone.
+		return "", nil
+	}
+
+	return "@" + invite.Account.Username, nil
+}
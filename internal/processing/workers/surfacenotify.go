@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -228,6 +229,59 @@ func (s *Surface) notifyFave(
 	return nil
 }
 
+// notifyEmojiReaction notifies the target of the given
+// reaction that their status has been reacted to.
+func (s *Surface) notifyEmojiReaction(
+	ctx context.Context,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	if reaction.TargetAccountID == reaction.AccountID {
+		// Self-reaction, nothing to do.
+		return nil
+	}
+
+	// Beforehand, ensure the passed reaction is fully populated.
+	if err := s.State.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction %s: %w", reaction.ID, err)
+	}
+
+	if reaction.TargetAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// Ensure reactee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		reaction.Status.ThreadID,
+		reaction.TargetAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", reaction.StatusID, err)
+	}
+
+	if muted {
+		// Reactee doesn't want
+		// notifs for this thread.
+		return nil
+	}
+
+	// notify status author
+	// of reaction by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationEmojiReaction,
+		reaction.TargetAccount,
+		reaction.Account,
+		reaction.StatusID,
+	); err != nil {
+		return gtserror.Newf("error notifying status author %s: %w", reaction.TargetAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyAnnounce notifies the status boost target
 // account that their status has been boosted.
 func (s *Surface) notifyAnnounce(
@@ -416,6 +470,25 @@ func (s *Surface) Notify(
 		return nil
 	}
 
+	// Silenced accounts can only notify their followers,
+	// except for notifications that the target needs to
+	// see in order to act on them (or that they caused).
+	if originAccount.IsSilenced() &&
+		originAccount.ID != targetAccount.ID &&
+		notificationType != gtsmodel.NotificationFollowRequest &&
+		notificationType != gtsmodel.NotificationPoll &&
+		notificationType != gtsmodel.NotificationSignup {
+		follows, err := s.State.DB.IsFollowing(ctx, targetAccount.ID, originAccount.ID)
+		if err != nil {
+			return gtserror.Newf("error checking follow: %w", err)
+		}
+
+		if !follows {
+			// nothing to do.
+			return nil
+		}
+	}
+
 	// We're doing state-y stuff so get a
 	// lock on this combo of notif params.
 	lockURI := getNotifyLockURI(
@@ -489,5 +562,10 @@ func (s *Surface) Notify(
 	}
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Email notification to the user, if they want it.
+	if err := s.emailUserNotification(ctx, notif); err != nil {
+		log.Errorf(ctx, "error emailing notification %s: %v", notif.ID, err)
+	}
+
 	return nil
 }
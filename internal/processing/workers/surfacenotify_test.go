@@ -28,6 +28,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type SurfaceNotifyTestSuite struct {
@@ -110,6 +112,110 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestNotifyEmail() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	sentEmails := make(map[string]string)
+	surface := &workers.Surface{
+		State:       testStructs.State,
+		Converter:   testStructs.TypeConverter,
+		Stream:      testStructs.Processor.Stream(),
+		Filter:      visibility.NewFilter(testStructs.State),
+		EmailSender: testrig.NewEmailSender("../../../web/template/", sentEmails),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+	)
+
+	// Have the target user opt in
+	// to emails about new followers.
+	user, err := testStructs.State.DB.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	user.EmailNotifyFollow = util.Ptr(true)
+	if err := testStructs.State.DB.UpdateUser(ctx, user, "email_notify_follow"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFollow,
+		targetAccount,
+		originAccount,
+		"",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	message, ok := sentEmails[user.Email]
+	if !ok {
+		suite.FailNow("expected notification email to be sent")
+	}
+	suite.Contains(message, "Subject: GoToSocial: New follower @1happyturtle")
+	suite.Contains(message, "List-Unsubscribe-Post: List-Unsubscribe=One-Click")
+
+	// Target user should now have an unsubscribe token.
+	user, err = testStructs.State.DB.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(user.EmailUnsubscribeToken)
+}
+
+func (suite *SurfaceNotifyTestSuite) TestNotifyEmailDigest() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	sentEmails := make(map[string]string)
+	surface := &workers.Surface{
+		State:       testStructs.State,
+		Converter:   testStructs.TypeConverter,
+		Stream:      testStructs.Processor.Stream(),
+		Filter:      visibility.NewFilter(testStructs.State),
+		EmailSender: testrig.NewEmailSender("../../../web/template/", sentEmails),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+	)
+
+	// Have the target user opt in to
+	// a daily digest of new followers.
+	user, err := testStructs.State.DB.GetUserByAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	user.EmailNotifyFollow = util.Ptr(true)
+	user.EmailNotifyFrequency = gtsmodel.EmailNotifyFrequencyDaily
+	user.EmailDigestSentAt = time.Now().Add(-time.Minute)
+	if err := testStructs.State.DB.UpdateUser(ctx, user,
+		"email_notify_follow",
+		"email_notify_frequency",
+		"email_digest_sent_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFollow,
+		targetAccount,
+		originAccount,
+		"",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Nothing should be sent immediately,
+	// it'll go in the next digest instead.
+	suite.Empty(sentEmails)
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// webhookUser sends the given account event to subscribed webhooks,
+// with the admin view of the user's account as object.
+func (s *Surface) webhookUser(ctx context.Context, event gtsmodel.WebhookEvent, user *gtsmodel.User) error {
+	return s.webhook(ctx, event, func() (any, error) {
+		account := user.Account
+		if account == nil {
+			var err error
+			account, err = s.State.DB.GetAccountByID(ctx, user.AccountID)
+			if err != nil {
+				return nil, gtserror.Newf("db error getting account: %w", err)
+			}
+		}
+
+		return s.Converter.AccountToAdminAPIAccount(ctx, account)
+	})
+}
+
+// webhookReport sends the given report event to subscribed
+// webhooks, with the admin view of the report as object.
+func (s *Surface) webhookReport(ctx context.Context, event gtsmodel.WebhookEvent, report *gtsmodel.Report) error {
+	return s.webhook(ctx, event, func() (any, error) {
+		return s.Converter.ReportToAdminAPIReport(ctx, report, nil)
+	})
+}
+
+// webhookStatus sends the given status event to subscribed
+// webhooks, with the (unfiltered) status as object. Only
+// statuses created by local accounts trigger webhooks.
+func (s *Surface) webhookStatus(ctx context.Context, event gtsmodel.WebhookEvent, st *gtsmodel.Status) error {
+	if !*st.Local {
+		return nil
+	}
+
+	return s.webhook(ctx, event, func() (any, error) {
+		return s.Converter.StatusToAPIStatus(ctx, st, nil, status.FilterContextNone, nil, nil)
+	})
+}
+
+// webhook queues delivery of the given event to every enabled webhook that
+// subscribes to it. The object is only converted if there are any such webhooks.
+func (s *Surface) webhook(ctx context.Context, event gtsmodel.WebhookEvent, object func() (any, error)) error {
+	webhooks, err := s.State.DB.GetWebhooks(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting webhooks: %w", err)
+	}
+
+	var subscribed []*gtsmodel.Webhook
+	for _, webhook := range webhooks {
+		if webhook.SubscribedTo(event) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+
+	if len(subscribed) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	obj, err := object()
+	if err != nil {
+		return gtserror.Newf("error converting %s object: %w", event, err)
+	}
+
+	body, err := json.Marshal(&apimodel.WebhookPayload{
+		Event:     string(event),
+		CreatedAt: util.FormatISO8601(time.Now()),
+		Object:    obj,
+	})
+	if err != nil {
+		return gtserror.Newf("error marshaling %s payload: %w", event, err)
+	}
+
+	dlvs := make([]*delivery.Delivery, 0, len(subscribed))
+	for _, webhook := range subscribed {
+		dlv, err := prepareWebhook(ctx, webhook, body)
+		if err != nil {
+			// Don't let one broken
+			// webhook stop the others.
+			log.Errorf(ctx, "error preparing webhook %s: %v", webhook.ID, err)
+			continue
+		}
+		dlvs = append(dlvs, dlv)
+	}
+
+	// Queue for delivery (with retries) by the delivery workers.
+	s.State.Workers.Delivery.Queue.Push(dlvs...)
+
+	return nil
+}
+
+// prepareWebhook prepares a POST of body to the given webhook,
+// signed with its secret, wrapped for queueing to delivery workers.
+func prepareWebhook(ctx context.Context, webhook *gtsmodel.Webhook, body []byte) (*delivery.Delivery, error) {
+	// Use *bytes.Reader for request body,
+	// as NewRequest() automatically will
+	// set .GetBody and content-length.
+	// (this handles necessary rewinding).
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, gtserror.Newf("error preparing request: %w", err)
+	}
+
+	r.Header.Set("User-Agent", fmt.Sprintf("gotosocial/%s (+%s://%s)",
+		config.GetSoftwareVersion(), config.GetProtocol(), config.GetHost()))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Hub-Signature", "sha256="+webhookSignature(webhook.Secret, body))
+
+	// Validate the request before queueing for delivery.
+	if err := httpclient.ValidateRequest(r); err != nil {
+		return nil, err
+	}
+
+	return &delivery.Delivery{
+		Request: httpclient.WrapRequest(r),
+	}, nil
+}
+
+// webhookSignature returns the hex-encoded HMAC-SHA256
+// of the given body, using the webhook secret as key.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type SurfaceWebhookTestSuite struct {
+	WorkersTestSuite
+}
+
+func (suite *SurfaceWebhookTestSuite) TestReportUpdatedWebhook() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	for _, webhook := range []*gtsmodel.Webhook{
+		{
+			// Subscribed, should be sent.
+			ID:                 "01J3Q4YQ0JQZ3W4N3F8R8C5XKA",
+			URL:                "https://moderation-bot.example.org/hooks/gts",
+			Events:             []string{"report.created", "report.updated"},
+			Secret:             "some-very-secret-secret",
+			Disabled:           util.Ptr(false),
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			// Subscribed but disabled.
+			ID:                 "01J3Q4YQ0JQZ3W4N3F8R8C5XKB",
+			URL:                "https://disabled.example.org/hooks/gts",
+			Events:             []string{"report.updated"},
+			Secret:             "some-very-secret-secret",
+			Disabled:           util.Ptr(true),
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			// Not subscribed.
+			ID:                 "01J3Q4YQ0JQZ3W4N3F8R8C5XKC",
+			URL:                "https://statuses.example.org/hooks/gts",
+			Events:             []string{"status.created"},
+			Secret:             "some-very-secret-secret",
+			Disabled:           util.Ptr(false),
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+	} {
+		if err := testStructs.State.DB.PutWebhook(ctx, webhook); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	report, err := testStructs.State.DB.GetReportByID(ctx, "01GP3AWY4CRDVRNZKW0TEAMB5R")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActivityFlag,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       report,
+			Origin:         suite.testAccounts["admin_account"],
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the one subscribed webhook should be queued.
+	queue := &testStructs.State.Workers.Delivery.Queue
+	suite.Equal(1, queue.Len())
+
+	dlv, _ := queue.Pop()
+	suite.Equal("POST", dlv.Request.Method)
+	suite.Equal("https://moderation-bot.example.org/hooks/gts", dlv.Request.URL.String())
+	suite.Equal("application/json", dlv.Request.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(dlv.Request.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Signature should be HMAC of body with secret.
+	mac := hmac.New(sha256.New, []byte("some-very-secret-secret"))
+	mac.Write(body)
+	suite.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), dlv.Request.Header.Get("X-Hub-Signature"))
+
+	var payload struct {
+		Event  string `json:"event"`
+		Object struct {
+			ID string `json:"id"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("report.updated", payload.Event)
+	suite.Equal(report.ID, payload.Object.ID)
+}
+
+func TestSurfaceWebhookTestSuite(t *testing.T) {
+	suite.Run(t, new(SurfaceWebhookTestSuite))
+}
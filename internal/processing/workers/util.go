@@ -23,6 +23,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -35,10 +36,11 @@ import (
 // util provides util functions used by both
 // the fromClientAPI and fromFediAPI functions.
 type utils struct {
-	state   *state.State
-	media   *media.Processor
-	account *account.Processor
-	surface *Surface
+	state     *state.State
+	federator *federation.Federator
+	media     *media.Processor
+	account   *account.Processor
+	surface   *Surface
 }
 
 // wipeStatus encapsulates common logic
@@ -97,6 +99,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status faves: %w", err)
 	}
 
+	// delete all emoji reactions to this status
+	if err := u.state.DB.DeleteStatusReactionsForStatus(ctx, statusToDelete.ID); err != nil {
+		errs.Appendf("error deleting status reactions: %w", err)
+	}
+
 	if pollID := statusToDelete.PollID; pollID != "" {
 		// Delete this poll by ID from the database.
 		if err := u.state.DB.DeletePollByID(ctx, pollID); err != nil {
@@ -493,3 +500,84 @@ func (u *utils) decrementFollowRequestsCount(
 
 	return nil
 }
+
+// fetchPreviewCard queues a background fetch of the preview
+// card for the first link in the given status, un-preparing
+// the status from timelines if its card changed as a result.
+//
+// Only public and unlisted statuses get preview cards, as
+// fetching the link would otherwise leak a private status'
+// content (or at least the fact it exists) to the linked site.
+func (u *utils) fetchPreviewCard(status *gtsmodel.Status) {
+	if status.BoostOfID != "" {
+		// Boosts show the
+		// boosted status' card.
+		return
+	}
+
+	if status.Visibility != gtsmodel.VisibilityPublic &&
+		status.Visibility != gtsmodel.VisibilityUnlocked {
+		// Don't leak
+		// private links.
+		return
+	}
+
+	statusID := status.ID
+	u.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		// Get a fresh copy of the status, as
+		// the one we were passed may well be
+		// in use elsewhere by the time we run.
+		status, err := u.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting status %s: %v", statusID, err)
+			}
+
+			// Status may have been
+			// deleted in the meantime.
+			return
+		}
+
+		oldCardID := status.PreviewCardID
+
+		// Fetch card as the instance account, rather than
+		// as any particular user, since this isn't a fetch
+		// on behalf of any one of our users.
+		if err := u.federator.UpdateStatusPreviewCard(ctx,
+			"",
+			status,
+		); err != nil {
+			log.Errorf(ctx, "error updating preview card for status %s: %v", status.ID, err)
+			return
+		}
+
+		if status.PreviewCardID != oldCardID {
+			// Card changed, status representation needs updating.
+			u.surface.invalidateStatusFromTimelines(ctx, status.ID)
+		}
+	})
+}
+
+// verifyAccountFields queues a background check of the
+// rel="me" links in given local account's profile fields,
+// checking all URL fields if recheck, else only those
+// fields that aren't already verified.
+func (u *utils) verifyAccountFields(account *gtsmodel.Account, recheck bool) {
+	if !account.IsLocal() || len(account.FieldsRaw) == 0 {
+		// Nothing
+		// to verify.
+		return
+	}
+
+	u.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := u.federator.VerifyAccountFields(ctx,
+			account,
+			recheck,
+		); err != nil {
+			log.Errorf(ctx, "error verifying fields for account %s: %v", account.ID, err)
+		}
+	})
+}
@@ -32,6 +32,8 @@ import (
 type Processor struct {
 	clientAPI clientAPI
 	fediAPI   fediAPI
+	surface   *Surface
+	utils     *utils
 	workers   *workers.Workers
 }
 
@@ -65,13 +67,16 @@ func New(
 
 	// Init shared util funcs.
 	utils := &utils{
-		state:   state,
-		media:   media,
-		account: account,
-		surface: surface,
+		state:     state,
+		federator: federator,
+		media:     media,
+		account:   account,
+		surface:   surface,
 	}
 
 	return Processor{
+		surface: surface,
+		utils:   utils,
 		workers: &state.Workers,
 		clientAPI: clientAPI{
 			state:     state,
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -34,6 +35,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
@@ -47,6 +49,10 @@ import (
 // to the template funcMap for use in any template. Use these "include"
 // functions when you need to pass a template through a pipeline.
 // Otherwise, prefer the built-in "template" function.
+//
+// The special function "t" translates the given string (and format
+// args) into the language of the page; see the i18n package. Use
+// "tHTML" instead when the string contains markup.
 func LoadTemplates(engine *gin.Engine) error {
 	templateBaseDir := config.GetWebTemplateBaseDir()
 	if templateBaseDir == "" {
@@ -72,12 +78,56 @@ func LoadTemplates(engine *gin.Engine) error {
 		)
 	}
 
+	// Parse one set of templates per available
+	// language, so that pages can be rendered
+	// in the language preferred by the reader.
+	templates := make(map[string]*template.Template)
+	for _, lang := range i18n.Languages() {
+		tmpl, err := parseTemplates(templateDirAbs, lang)
+		if err != nil {
+			return err
+		}
+		templates[lang] = tmpl
+	}
+
+	// Almost done; teach the
+	// engine how to render.
+	engine.SetFuncMap(funcMap)
+	engine.HTMLRender = localizedHTMLRender{templates: templates}
+
+	return nil
+}
+
+// parseTemplates parses all templates in the given
+// directory into one set, with the "t" function of
+// the set translating strings into the given language.
+func parseTemplates(templateDirAbs string, lang string) (*template.Template, error) {
 	// Bring base template into scope.
 	tmpl := template.New("base")
 
+	funcs := maps.Clone(funcMap)
+	funcs["t"] = i18n.Translator(lang)
+
+	// Set "tHTML" function for translating strings
+	// which contain markup. The translated string is
+	// trusted like the templates themselves, but any
+	// format args are escaped as usual (unless they
+	// were already escaped, eg., by "include").
+	funcs["tHTML"] = func(msg string, args ...any) template.HTML {
+		escaped := make([]any, len(args))
+		for i, arg := range args {
+			if html, ok := arg.(template.HTML); ok {
+				escaped[i] = string(html)
+				continue
+			}
+			escaped[i] = template.HTMLEscapeString(fmt.Sprint(arg))
+		}
+		return noescape(i18n.Translate(lang, msg, escaped...))
+	}
+
 	// Set additional "include" functions to render
 	// provided template name using the base template.
-	funcMap["include"] = func(name string, data any) (template.HTML, error) {
+	funcs["include"] = func(name string, data any) (template.HTML, error) {
 		var buf strings.Builder
 		err := tmpl.ExecuteTemplate(&buf, name, data)
 
@@ -86,7 +136,7 @@ func LoadTemplates(engine *gin.Engine) error {
 		return noescape(buf.String()), err
 	}
 
-	funcMap["includeAttr"] = func(name string, data any) (template.HTMLAttr, error) {
+	funcs["includeAttr"] = func(name string, data any) (template.HTMLAttr, error) {
 		var buf strings.Builder
 		err := tmpl.ExecuteTemplate(&buf, name, data)
 
@@ -98,17 +148,37 @@ func LoadTemplates(engine *gin.Engine) error {
 	// Load functions into the base template, and
 	// associate other templates with base template.
 	templateGlob := filepath.Join(templateDirAbs, "*")
-	tmpl, err = tmpl.Funcs(funcMap).ParseGlob(templateGlob)
+	tmpl, err := tmpl.Funcs(funcs).ParseGlob(templateGlob)
 	if err != nil {
-		return gtserror.Newf("error loading templates: %w", err)
+		return nil, gtserror.Newf("error loading templates: %w", err)
 	}
 
-	// Almost done; teach the
-	// engine how to render.
-	engine.SetFuncMap(funcMap)
-	engine.HTMLRender = render.HTMLProduction{Template: tmpl}
+	return tmpl, nil
+}
 
-	return nil
+// localizedHTMLRender implements render.HTMLRender,
+// rendering with the set of templates for the language
+// given as "locale" in the template data, if any, or
+// else with the set for the default language.
+type localizedHTMLRender struct {
+	templates map[string]*template.Template
+}
+
+func (r localizedHTMLRender) Instance(name string, data any) render.Render {
+	tmpl := r.templates[i18n.DefaultLanguage]
+
+	if obj, ok := data.(map[string]any); ok {
+		lang, _ := obj["locale"].(string)
+		if t, ok := r.templates[lang]; ok {
+			tmpl = t
+		}
+	}
+
+	return render.HTML{
+		Template: tmpl,
+		Name:     name,
+		Data:     data,
+	}
 }
 
 var funcMap = template.FuncMap{
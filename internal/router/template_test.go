@@ -19,7 +19,13 @@ package router
 
 import (
 	"html/template"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 )
 
 func TestOutdentPre(t *testing.T) {
@@ -202,3 +208,74 @@ func TestOutdentPre(t *testing.T) {
 		t.Fatalf("unexpected output:\n`%s`\n", out)
 	}
 }
+
+func TestLoadTemplatesLocalized(t *testing.T) {
+	config.SetWebTemplateBaseDir("../../web/template/")
+	if err := i18n.Init("../../web/locale/"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.Init("") })
+
+	engine := gin.New()
+	if err := LoadTemplates(engine); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range []struct {
+		template string
+		data     map[string]any
+		expected []string
+	}{
+		{
+			template: "unsubscribed.tmpl",
+			data:     map[string]any{"locale": "de", "type": "mention"},
+			expected: []string{"Du erhältst keine E-Mail-Benachrichtigungen über Erwähnungen mehr."},
+		},
+		{
+			template: "unsubscribed.tmpl",
+			data:     map[string]any{"type": "mention"},
+			expected: []string{"You will no longer receive email notifications about mentions."},
+		},
+		{
+			template: "unsubscribed.tmpl",
+			data:     map[string]any{"locale": "xx", "type": "all"},
+			expected: []string{"You will no longer receive all email notifications."},
+		},
+		{
+			template: "confirm_email.tmpl",
+			data:     map[string]any{"locale": "de", "username": "<script>", "email": "a@example.org"},
+			expected: []string{
+				"Hallo <b>&lt;script&gt;</b>!",
+				"deine E-Mail-Adresse <b>a@example.org</b> zu bestätigen.",
+			},
+		},
+		{
+			template: "index_register.tmpl",
+			data: map[string]any{
+				"locale":   "de",
+				"instance": map[string]any{"Title": "<i>example</i>", "Registrations": true},
+			},
+			expected: []string{
+				"Ein Konto auf &lt;i&gt;example&lt;/i&gt; registrieren",
+				"Die Registrierung neuer Konten ist derzeit <b>geöffnet</b>.",
+				`Verwende dann die <a href="/signup">Registrierungsseite</a>`,
+			},
+		},
+		{
+			template: "statuses_page.tmpl",
+			data:     map[string]any{"locale": "de"},
+			expected: []string{"Hier gibt es nichts!"},
+		},
+	} {
+		rec := httptest.NewRecorder()
+		if err := engine.HTMLRender.Instance(test.template, test.data).Render(rec); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+
+		for _, expected := range test.expected {
+			if !strings.Contains(rec.Body.String(), expected) {
+				t.Errorf("test %d: expected %q in:\n%s", i, expected, rec.Body.String())
+			}
+		}
+	}
+}
@@ -19,10 +19,16 @@ package stream
 
 import (
 	"context"
+	"crypto/rand"
+	"io"
 	"maps"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"github.com/oklog/ulid"
 )
 
 const (
@@ -88,13 +94,74 @@ var AllStatusTimelines = []string{
 	TimelineList,
 }
 
+const (
+	// historyLen is the maximum number of recently
+	// posted messages kept per account (and globally
+	// for PostAll), for replay to reconnecting clients.
+	historyLen = 100
+
+	// historyTTL is the maximum age of
+	// a recently posted message for replay.
+	historyTTL = 5 * time.Minute
+)
+
 type Streams struct {
 	streams map[string][]*Stream
+	history map[string][]historyEntry
+	replay  map[string]*replayState
+	global  []historyEntry
+	sweeper *time.Timer
+	entropy io.Reader
 	mutex   sync.Mutex
 }
 
+// replayState tracks whether an account's
+// posted messages should be kept for replay,
+// i.e. it has open replayable streams, or had
+// one closed no longer than historyTTL ago.
+type replayState struct {
+	open  int
+	until time.Time
+}
+
+// keep returns whether history should be kept at time.
+func (r *replayState) keep(now time.Time) bool {
+	return r.open > 0 || now.Before(r.until)
+}
+
+// nextID returns a new replay ID for a posted message,
+// strictly increasing even within the same millisecond
+// so that History() can reliably return messages since
+// an ID. Must be called with the mutex held.
+func (s *Streams) nextID() string {
+	if s.entropy == nil {
+		// Monotonic entropy source needs allocating.
+		s.entropy = ulid.Monotonic(rand.Reader, 0)
+	}
+	return ulid.MustNew(ulid.Now(), s.entropy).String()
+}
+
+// historyEntry wraps a posted
+// message with its posting time.
+type historyEntry struct {
+	msg  Message
+	time time.Time
+}
+
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
 func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+	return s.open(accountID, false, streamTypes...)
+}
+
+// OpenReplay is like Open, but additionally starts keeping a short
+// history of messages posted to the account, for replay via History()
+// when the client reconnects. History is kept for historyTTL after
+// the last replayable stream for the account is closed.
+func (s *Streams) OpenReplay(accountID string, streamTypes ...string) *Stream {
+	return s.open(accountID, true, streamTypes...)
+}
+
+func (s *Streams) open(accountID string, replay bool, streamTypes ...string) *Stream {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
@@ -123,6 +190,26 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	strs = append(strs, str)
 	s.streams[accountID] = strs
 
+	if replay {
+		if s.replay == nil {
+			// Replay map needs allocating.
+			s.replay = make(map[string]*replayState)
+		}
+
+		// Mark account history as kept
+		// while this stream is open.
+		state := s.replay[accountID]
+		if state == nil {
+			state = new(replayState)
+			s.replay[accountID] = state
+		}
+		state.open++
+
+		// Ensure expired
+		// history is swept.
+		s.startSweeper()
+	}
+
 	// Register close callback
 	// to remove stream from our
 	// internal map for this account.
@@ -133,6 +220,13 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 			return s == str // remove 'str' ptr
 		})
 		s.streams[accountID] = strs
+		if replay {
+			// Keep history for a while after
+			// close, so client can reconnect.
+			state := s.replay[accountID]
+			state.open--
+			state.until = time.Now().Add(historyTTL)
+		}
 		s.mutex.Unlock()
 	}
 
@@ -149,6 +243,21 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Give message a replay ID.
+	msg.ID = s.nextID()
+
+	// Store message in account history if it has (or
+	// recently had) replayable streams open, so it can
+	// be replayed when the client reconnects.
+	if state := s.replay[accountID]; state != nil &&
+		state.keep(time.Now()) {
+		if s.history == nil {
+			// History map needs allocating.
+			s.history = make(map[string][]historyEntry)
+		}
+		s.history[accountID] = appendHistory(s.history[accountID], msg)
+	}
+
 	// Iterate all streams stored for account.
 	for _, str := range s.streams[accountID] {
 
@@ -163,6 +272,7 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 			// Use a message copy to *only*
 			// include the supported stream.
 			msgCopy := Message{
+				ID:      msg.ID,
 				Stream:  []string{stype},
 				Event:   msg.Event,
 				Payload: msg.Payload,
@@ -199,6 +309,16 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Give message a replay ID.
+	msg.ID = s.nextID()
+
+	// Store message in global history so it
+	// can be replayed on reconnect, only if
+	// there are any replayable streams.
+	if len(s.replay) > 0 {
+		s.global = appendHistory(s.global, msg)
+	}
+
 	// Iterate ALL stored streams.
 	for _, strs := range s.streams {
 		for _, str := range strs {
@@ -214,6 +334,7 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 				// Use a message copy to *only*
 				// include the supported stream.
 				msgCopy := Message{
+					ID:      msg.ID,
 					Stream:  []string{stype},
 					Event:   msg.Event,
 					Payload: msg.Payload,
@@ -244,6 +365,149 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	return ok
 }
 
+// History returns messages posted for given account ID (or to all
+// accounts) since the message with given ID, that match any of the
+// given stream types, oldest first. Only a short, recent history of
+// messages is kept, so this may not return all messages since ID.
+func (s *Streams) History(accountID string, sinceID string, streamTypes ...string) []Message {
+	var msgs []Message
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Gather all messages newer than ID and still within TTL,
+	// from both the account's and the global history.
+	for _, hist := range [][]historyEntry{
+		s.history[accountID],
+		s.global,
+	} {
+		for _, entry := range hist {
+			if entry.msg.ID <= sinceID ||
+				time.Since(entry.time) > historyTTL {
+				continue
+			}
+
+			// Check whether message targets any given stream types.
+			stype := firstMatch(entry.msg.Stream, streamTypes)
+			if stype == "" {
+				continue
+			}
+
+			// Use a message copy to *only*
+			// include the supported stream.
+			msgs = append(msgs, Message{
+				ID:      entry.msg.ID,
+				Stream:  []string{stype},
+				Event:   entry.msg.Event,
+				Payload: entry.msg.Payload,
+			})
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	// Sort messages by ID, i.e. oldest first.
+	slices.SortFunc(msgs, func(a, b Message) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return msgs
+}
+
+// startSweeper starts the timer to periodically
+// sweep expired history, if not already running.
+// Must be called with the mutex held.
+func (s *Streams) startSweeper() {
+	if s.sweeper == nil {
+		s.sweeper = time.AfterFunc(historyTTL, s.sweep)
+	}
+}
+
+// sweep drops expired history entries, and the history and
+// replay state of accounts no longer kept for replay. The
+// sweeper timer is rescheduled until nothing is left.
+func (s *Streams) sweep() {
+	now := time.Now()
+
+	// Acquire lock.
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for accountID, state := range s.replay {
+		if !state.keep(now) {
+			// Drop account replay state and history.
+			delete(s.replay, accountID)
+			delete(s.history, accountID)
+		}
+	}
+
+	for accountID, hist := range s.history {
+		hist = dropExpired(hist, now)
+		if len(hist) == 0 {
+			delete(s.history, accountID)
+			continue
+		}
+		s.history[accountID] = hist
+	}
+
+	s.global = dropExpired(s.global, now)
+
+	if len(s.replay) == 0 &&
+		len(s.history) == 0 &&
+		len(s.global) == 0 {
+		// Nothing left to sweep,
+		// restarted on next open.
+		s.sweeper = nil
+		return
+	}
+
+	// Schedule next sweep.
+	s.sweeper.Reset(historyTTL)
+}
+
+// dropExpired drops entries from the start of
+// history that are older than TTL at given time.
+func dropExpired(hist []historyEntry, now time.Time) []historyEntry {
+	for len(hist) > 0 && now.Sub(hist[0].time) > historyTTL {
+		hist = hist[1:]
+	}
+	if len(hist) == 0 {
+		// Release underlying array.
+		return nil
+	}
+	return hist
+}
+
+// appendHistory appends given message to history, dropping
+// entries from the start that are expired or over length.
+func appendHistory(hist []historyEntry, msg Message) []historyEntry {
+	now := time.Now()
+
+	// Drop any expired entries from the start.
+	hist = dropExpired(hist, now)
+
+	// Drop oldest entry if at max length.
+	if len(hist) >= historyLen {
+		hist = hist[1:]
+	}
+
+	return append(hist, historyEntry{
+		msg:  msg,
+		time: now,
+	})
+}
+
+// firstMatch returns the first of given stream types found in supported.
+func firstMatch(streamTypes []string, supported []string) string {
+	for _, streamType := range streamTypes {
+		if slices.Contains(supported, streamType) {
+			return streamType
+		}
+	}
+	return ""
+}
+
 // Stream represents one
 // open stream for a client.
 type Stream struct {
@@ -371,6 +635,11 @@ func (s *Stream) cas(fn func(map[string]struct{}) bool) {
 // one streamed message.
 type Message struct {
 
+	// Unique, sortable ID of this message,
+	// used for replay to reconnecting clients.
+	// Not included in websocket messages.
+	ID string `json:"-"`
+
 	// All the stream types this
 	// message should be delivered to.
 	Stream []string `json:"stream"`
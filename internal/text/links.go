@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ExtractLinks returns the deduplicated hrefs of all http(s)
+// links in the given html content, in order of appearance.
+// Links to mentions and hashtags are skipped, as are links
+// that can't be parsed as absolute URLs. Fragments are
+// stripped from returned links so they normalize better.
+func ExtractLinks(content string) []string {
+	var links []string
+
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// Reached end of content
+			// (or unparseable garbage).
+			return links
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data != "a" {
+				continue
+			}
+
+			link := linkHref(token)
+			if link == "" || slices.Contains(links, link) {
+				continue
+			}
+
+			links = append(links, link)
+		}
+	}
+}
+
+// linkHref returns the normalized href of given <a> token,
+// or empty string if the link is a mention or hashtag link,
+// or otherwise isn't an absolute http(s) URL.
+func linkHref(token html.Token) string {
+	var href string
+
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "href":
+			href = attr.Val
+
+		case "class":
+			// Mention and hashtag links are
+			// given these classes, by us and
+			// by most other implementations.
+			for _, class := range strings.Fields(attr.Val) {
+				if class == "mention" || class == "hashtag" {
+					return ""
+				}
+			}
+
+		case "rel":
+			// Hashtag links may
+			// also be rel="tag".
+			for _, rel := range strings.Fields(attr.Val) {
+				if rel == "tag" {
+					return ""
+				}
+			}
+		}
+	}
+
+	u, err := url.Parse(href)
+	if err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+
+	// Drop fragment, and
+	// lowercase the host.
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.Host = strings.ToLower(u.Host)
+
+	return u.String()
+}
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+type LinksTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LinksTestSuite) TestExtractLinks() {
+	for _, test := range []struct {
+		content string
+		links   []string
+	}{
+		{
+			content: simpleExpected,
+			links:   nil,
+		},
+		{
+			// Hashtag + mention links are skipped.
+			content: moreComplexExpected,
+			links:   nil,
+		},
+		{
+			content: withUTF8LinkExpected,
+			links:   []string{"https://example.org/s%C3%B6me_url"},
+		},
+		{
+			// Fragments are dropped, hosts lowercased, and duplicates removed.
+			content: `<p><a href="https://Example.org/a?b=c#d">one</a> <a href="https://example.org/a?b=c">two</a> <a href="https://example.org/e">three</a></p>`,
+			links:   []string{"https://example.org/a?b=c", "https://example.org/e"},
+		},
+		{
+			// Non-http(s) and relative links are skipped.
+			content: `<p><a href="mailto:someone@example.org">mail</a> <a href="/relative">relative</a> <a href="gopher://example.org/">gopher</a></p>`,
+			links:   nil,
+		},
+	} {
+		suite.Equal(test.links, text.ExtractLinks(test.content), test.content)
+	}
+}
+
+func TestLinksTestSuite(t *testing.T) {
+	suite.Run(t, new(LinksTestSuite))
+}
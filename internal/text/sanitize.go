@@ -153,6 +153,24 @@ var regular *bluemonday.Policy = func() *bluemonday.Policy {
 	return p
 }()
 
+// Embed HTML policy permits *only* an https iframe,
+// which is what well-behaved oEmbed providers give for
+// video and rich embeds. Anything else gets stripped.
+var embed *bluemonday.Policy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+
+	// "iframe" is permitted, with its dimensions and the
+	// "allowfullscreen" attribute, which is blank or itself.
+	p.AllowAttrs("src", "width", "height", "title").OnElements("iframe")
+	p.AllowAttrs("allowfullscreen").Matching(regexp.MustCompile(`(?i)^(|allowfullscreen)$`)).OnElements("iframe")
+
+	// Iframes must point to https URLs.
+	p.RequireParseableURLs(true)
+	p.AllowURLSchemes("https")
+
+	return p
+}()
+
 // '[C]an be thought of as equivalent to stripping all HTML
 // elements and their attributes as it has nothing on its allowlist.
 // An example usage scenario would be blog post titles where HTML
@@ -189,3 +207,16 @@ func SanitizeToPlaintext(in string) string {
 	content = html.UnescapeString(content)
 	return strings.TrimSpace(content)
 }
+
+// SanitizeEmbedHTML sanitizes oEmbed html from a remote
+// provider, returning only a single https iframe from it,
+// or an empty string if there was no such iframe.
+func SanitizeEmbedHTML(in string) string {
+	out := strings.TrimSpace(embed.Sanitize(in))
+	if !strings.HasPrefix(out, "<iframe ") ||
+		!strings.Contains(out, ` src="`) ||
+		strings.Count(out, "<iframe") != 1 {
+		return ""
+	}
+	return out
+}
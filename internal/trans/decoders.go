@@ -18,6 +18,7 @@
 package trans
 
 import (
+	"crypto/ed25519"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
@@ -66,6 +67,40 @@ func (i *importer) accountDecode(e transmodel.Entry) (*transmodel.Account, error
 		a.PrivateKey = privateKey
 	}
 
+	if a.Ed25519PublicKeyString != "" {
+		// extract ed25519 public key
+		block, _ := pem.Decode([]byte(a.Ed25519PublicKeyString))
+		if block == nil {
+			return nil, errors.New("accountDecode: error decoding account ed25519 public key")
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("accountDecode: error parsing account ed25519 public key: %s", err)
+		}
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("accountDecode: account ed25519 public key was %T", key)
+		}
+		a.Ed25519PublicKey = edKey
+	}
+
+	if a.Ed25519PrivateKeyString != "" {
+		// extract ed25519 private key (local account)
+		block, _ := pem.Decode([]byte(a.Ed25519PrivateKeyString))
+		if block == nil {
+			return nil, errors.New("accountDecode: error decoding account ed25519 private key")
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("accountDecode: error parsing account ed25519 private key: %s", err)
+		}
+		edKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("accountDecode: account ed25519 private key was %T", key)
+		}
+		a.Ed25519PrivateKey = edKey
+	}
+
 	return a, nil
 }
 
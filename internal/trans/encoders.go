@@ -57,6 +57,30 @@ func (e *exporter) accountEncode(ctx context.Context, f *os.File, a *transmodel.
 		a.PrivateKeyString = string(privateKeyBytes)
 	}
 
+	if a.Ed25519PublicKey != nil {
+		// marshal ed25519 public key
+		encodedEd25519PublicKey, err := x509.MarshalPKIXPublicKey(a.Ed25519PublicKey)
+		if err != nil {
+			return fmt.Errorf("could not MarshalPKIXPublicKey: %w", err)
+		}
+		a.Ed25519PublicKeyString = string(pem.EncodeToMemory(&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: encodedEd25519PublicKey,
+		}))
+	}
+
+	if a.Ed25519PrivateKey != nil {
+		// marshal ed25519 private key for local account
+		encodedEd25519PrivateKey, err := x509.MarshalPKCS8PrivateKey(a.Ed25519PrivateKey)
+		if err != nil {
+			return fmt.Errorf("could not MarshalPKCS8PrivateKey: %w", err)
+		}
+		a.Ed25519PrivateKeyString = string(pem.EncodeToMemory(&pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: encodedEd25519PrivateKey,
+		}))
+	}
+
 	return e.simpleEncode(ctx, f, a, a.ID)
 }
 
@@ -18,43 +18,49 @@
 package trans
 
 import (
+	"crypto/ed25519"
 	"crypto/rsa"
 	"time"
 )
 
 // Account represents the minimum viable representation of an account for export/import.
 type Account struct {
-	Type                  Type            `json:"type" bun:"-"`
-	ID                    string          `json:"id" bun:",nullzero"`
-	CreatedAt             *time.Time      `json:"createdAt" bun:",nullzero"`
-	Username              string          `json:"username" bun:",nullzero"`
-	Domain                string          `json:"domain,omitempty" bun:",nullzero"`
-	HeaderRemoteURL       string          `json:"headerRemoteURL,omitempty" bun:",nullzero"`
-	AvatarRemoteURL       string          `json:"avatarRemoteURL,omitempty" bun:",nullzero"`
-	DisplayName           string          `json:"displayName,omitempty" bun:",nullzero"`
-	Note                  string          `json:"note,omitempty" bun:",nullzero"`
-	NoteRaw               string          `json:"noteRaw,omitempty" bun:",nullzero"`
-	Memorial              *bool           `json:"memorial"`
-	Bot                   *bool           `json:"bot"`
-	Locked                *bool           `json:"locked"`
-	Discoverable          *bool           `json:"discoverable"`
-	URI                   string          `json:"uri" bun:",nullzero"`
-	URL                   string          `json:"url" bun:",nullzero"`
-	InboxURI              string          `json:"inboxURI" bun:",nullzero"`
-	OutboxURI             string          `json:"outboxURI" bun:",nullzero"`
-	FollowingURI          string          `json:"followingUri" bun:",nullzero"`
-	FollowersURI          string          `json:"followersUri" bun:",nullzero"`
-	FeaturedCollectionURI string          `json:"featuredCollectionUri" bun:",nullzero"`
-	ActorType             string          `json:"actorType" bun:",nullzero"`
-	PrivateKey            *rsa.PrivateKey `json:"-" mapstructure:"-"`
-	PrivateKeyString      string          `json:"privateKey,omitempty" mapstructure:"privateKey" bun:"-"`
-	PublicKey             *rsa.PublicKey  `json:"-" mapstructure:"-"`
-	PublicKeyString       string          `json:"publicKey,omitempty" mapstructure:"publicKey" bun:"-"`
-	PublicKeyURI          string          `json:"publicKeyUri" bun:",nullzero"`
-	SensitizedAt          *time.Time      `json:"sensitizedAt,omitempty" bun:",nullzero"`
-	SilencedAt            *time.Time      `json:"silencedAt,omitempty" bun:",nullzero"`
-	SuspendedAt           *time.Time      `json:"suspendedAt,omitempty" bun:",nullzero"`
-	SuspensionOrigin      string          `json:"suspensionOrigin,omitempty" bun:",nullzero"`
+	Type                    Type               `json:"type" bun:"-"`
+	ID                      string             `json:"id" bun:",nullzero"`
+	CreatedAt               *time.Time         `json:"createdAt" bun:",nullzero"`
+	Username                string             `json:"username" bun:",nullzero"`
+	Domain                  string             `json:"domain,omitempty" bun:",nullzero"`
+	HeaderRemoteURL         string             `json:"headerRemoteURL,omitempty" bun:",nullzero"`
+	AvatarRemoteURL         string             `json:"avatarRemoteURL,omitempty" bun:",nullzero"`
+	DisplayName             string             `json:"displayName,omitempty" bun:",nullzero"`
+	Note                    string             `json:"note,omitempty" bun:",nullzero"`
+	NoteRaw                 string             `json:"noteRaw,omitempty" bun:",nullzero"`
+	Memorial                *bool              `json:"memorial"`
+	Bot                     *bool              `json:"bot"`
+	Locked                  *bool              `json:"locked"`
+	Discoverable            *bool              `json:"discoverable"`
+	URI                     string             `json:"uri" bun:",nullzero"`
+	URL                     string             `json:"url" bun:",nullzero"`
+	InboxURI                string             `json:"inboxURI" bun:",nullzero"`
+	OutboxURI               string             `json:"outboxURI" bun:",nullzero"`
+	FollowingURI            string             `json:"followingUri" bun:",nullzero"`
+	FollowersURI            string             `json:"followersUri" bun:",nullzero"`
+	FeaturedCollectionURI   string             `json:"featuredCollectionUri" bun:",nullzero"`
+	ActorType               string             `json:"actorType" bun:",nullzero"`
+	PrivateKey              *rsa.PrivateKey    `json:"-" mapstructure:"-"`
+	PrivateKeyString        string             `json:"privateKey,omitempty" mapstructure:"privateKey" bun:"-"`
+	PublicKey               *rsa.PublicKey     `json:"-" mapstructure:"-"`
+	PublicKeyString         string             `json:"publicKey,omitempty" mapstructure:"publicKey" bun:"-"`
+	PublicKeyURI            string             `json:"publicKeyUri" bun:",nullzero"`
+	Ed25519PrivateKey       ed25519.PrivateKey `json:"-" mapstructure:"-"`
+	Ed25519PrivateKeyString string             `json:"ed25519PrivateKey,omitempty" mapstructure:"ed25519PrivateKey" bun:"-"`
+	Ed25519PublicKey        ed25519.PublicKey  `json:"-" mapstructure:"-"`
+	Ed25519PublicKeyString  string             `json:"ed25519PublicKey,omitempty" mapstructure:"ed25519PublicKey" bun:"-"`
+	Ed25519PublicKeyURI     string             `json:"ed25519PublicKeyUri,omitempty" bun:",nullzero"`
+	SensitizedAt            *time.Time         `json:"sensitizedAt,omitempty" bun:",nullzero"`
+	SilencedAt              *time.Time         `json:"silencedAt,omitempty" bun:",nullzero"`
+	SuspendedAt             *time.Time         `json:"suspendedAt,omitempty" bun:",nullzero"`
+	SuspensionOrigin        string             `json:"suspensionOrigin,omitempty" bun:",nullzero"`
 }
 
 type AccountSettings struct {
@@ -36,13 +36,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 // Controller generates transports for use in making federation requests to other servers.
 type Controller interface {
-	// NewTransport returns an http signature transport with the given public key ID (URL location of pubkey), and the given private key.
-	NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Transport, error)
+	// NewTransport returns an http signature transport for the given local account, signing requests
+	// using its RSA private key, and attaching object integrity proofs to delivered activities using
+	// its ed25519 private key (if set).
+	NewTransport(account *gtsmodel.Account) (Transport, error)
 
 	// NewTransportForUsername searches for account with username, and returns result of .NewTransport().
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
@@ -77,14 +80,14 @@ func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.C
 	return c
 }
 
-func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Transport, error) {
+func (c *controller) NewTransport(account *gtsmodel.Account) (Transport, error) {
 	// Generate public key string for cache key
 	//
 	// NOTE: it is safe to use the public key as the cache
 	// key here as we are generating it ourselves from the
 	// private key. If we were simply using a public key
 	// provided as argument that would absolutely NOT be safe.
-	pubStr := privkeyToPublicStr(privkey)
+	pubStr := privkeyToPublicStr(account.PrivateKey)
 
 	// First check for cached transport
 	transp, ok := c.trspCache.Get(pubStr)
@@ -95,8 +98,11 @@ func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Tra
 	// Create the transport
 	transp = &transport{
 		controller: c,
-		pubKeyID:   pubKeyID,
-		privkey:    privkey,
+		pubKeyID:   account.PublicKeyURI,
+		privkey:    account.PrivateKey,
+		actorID:    account.URI,
+		proofKeyID: account.Ed25519PublicKeyURI,
+		proofKey:   account.Ed25519PrivateKey,
 	}
 
 	// Cache this transport under pubkey
@@ -132,7 +138,7 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 		return nil, fmt.Errorf("error getting account %s from db: %s", username, err)
 	}
 
-	transport, err := c.NewTransport(ourAccount.PublicKeyURI, ourAccount.PrivateKey)
+	transport, err := c.NewTransport(ourAccount)
 	if err != nil {
 		return nil, fmt.Errorf("error creating transport for user %s: %s", username, err)
 	}
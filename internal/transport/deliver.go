@@ -23,12 +23,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/integrity"
 	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 )
 
@@ -45,6 +47,12 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 		host   = config.GetHost()
 	)
 
+	// Attach integrity proof.
+	obj, err := t.prove(obj)
+	if err != nil {
+		return err
+	}
+
 	// Marshal object as JSON.
 	b, err := json.Marshal(obj)
 	if err != nil {
@@ -92,6 +100,12 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 		return nil
 	}
 
+	// Attach integrity proof.
+	obj, err := t.prove(obj)
+	if err != nil {
+		return err
+	}
+
 	// Marshal object as JSON.
 	b, err := json.Marshal(obj)
 	if err != nil {
@@ -116,6 +130,25 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 	return nil
 }
 
+// prove returns a copy of the given 'serialized'
+// ActivityPub object with an object integrity proof
+// attached (FEP-8b32), created using the transport's
+// ed25519 key. If the transport has no such key, or
+// the object's actor is not the transport's account,
+// then the object is returned unchanged.
+func (t *transport) prove(obj map[string]interface{}) (map[string]interface{}, error) {
+	if t.proofKey == nil || getActorID(obj) != t.actorID {
+		return obj, nil
+	}
+
+	obj, err := integrity.Sign(obj, t.proofKeyID, t.proofKey, time.Now())
+	if err != nil {
+		return nil, gtserror.Newf("error creating integrity proof: %w", err)
+	}
+
+	return obj, nil
+}
+
 // prepare will prepare a POST http.Request{}
 // to recipient at 'to', wrapping in a queued
 // request object with signing function.
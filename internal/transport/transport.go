@@ -20,6 +20,7 @@ package transport
 import (
 	"context"
 	"crypto"
+	"crypto/ed25519"
 	"errors"
 	"io"
 	"net/http"
@@ -70,6 +71,12 @@ type Transport interface {
 	// DereferenceMedia fetches the given media attachment IRI, returning the reader and filesize.
 	DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error)
 
+	// DereferencePage fetches the (non-ActivityStreams) web page or
+	// document at given IRI with an unsigned GET request, accepting
+	// the given content type(s), and returns the response on 200 OK.
+	// The caller is responsible for closing the response body.
+	DereferencePage(ctx context.Context, iri *url.URL, accept string) (*http.Response, error)
+
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
@@ -84,6 +91,13 @@ type transport struct {
 	pubKeyID   string
 	privkey    crypto.PrivateKey
 
+	// details for attaching
+	// object integrity proofs
+	// to delivered activities.
+	actorID    string
+	proofKeyID string
+	proofKey   ed25519.PrivateKey
+
 	signerExp  time.Time
 	getSigner  httpsig.SignerWithOptions
 	postSigner httpsig.SignerWithOptions
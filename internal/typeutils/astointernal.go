@@ -20,7 +20,9 @@ package typeutils
 import (
 	"context"
 	"errors"
+	"html"
 	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -28,7 +30,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -130,8 +134,10 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	// Extract account note (bio / summary).
 	acct.Note = ap.ExtractSummary(accountable)
 
-	// Assume not memorial (todo)
-	acct.Memorial = util.Ptr(false)
+	// Extract whether account has
+	// been memorialized (default = false).
+	memorial := ap.GetMemorial(accountable)
+	acct.Memorial = &memorial
 
 	// Extract 'manuallyApprovesFollowers' aka locked account (default = true).
 	manuallyApprovesFollowers := ap.GetManuallyApprovesFollowers(accountable)
@@ -141,6 +147,11 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	discoverable := ap.GetDiscoverable(accountable)
 	acct.Discoverable = &discoverable
 
+	// Extract whether account has opted in
+	// to full-text search indexing (default = false).
+	indexable := ap.GetIndexable(accountable)
+	acct.Indexable = &indexable
+
 	// Extract the URL property.
 	urls := ap.GetURL(accountable)
 	if len(urls) == 0 {
@@ -223,6 +234,13 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	acct.PublicKey = pkey
 	acct.PublicKeyURI = pkeyURL.String()
 
+	// Extract optional ed25519 key used for
+	// object integrity proofs, if provided.
+	if edKey, edKeyURL := ap.ExtractAssertionMethod(accountable, uriObj); edKey != nil {
+		acct.Ed25519PublicKey = edKey
+		acct.Ed25519PublicKeyURI = edKeyURL.String()
+	}
+
 	return &acct, nil
 }
 
@@ -264,6 +282,15 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		ap.ExtractContent(statusable),
 	)
 
+	// Some implementations (eg., Misskey) don't
+	// send a language at all, and some statuses
+	// are just mislabelled, so double check it
+	// against the language detected in content.
+	status.Language = language.Resolve(
+		status.Language,
+		text.SanitizeToPlaintext(status.Content),
+	)
+
 	// status.Attachments
 	//
 	// Media attachments for later dereferencing.
@@ -314,7 +341,33 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	//
 	// Topic or content warning for this status;
 	// prefer Summary, fall back to Name.
-	if summary := ap.ExtractSummary(statusable); summary != "" {
+	//
+	// Pages (eg., Lemmy posts) use Name as the
+	// post title, so include it in the content
+	// instead of hiding the post behind a CW.
+	summary := ap.ExtractSummary(statusable)
+	if statusable.GetTypeName() == ap.ObjectPage {
+		status.ContentWarning = summary
+		if title := ap.ExtractName(statusable); title != "" {
+			status.Content = "<h2>" + html.EscapeString(title) + "</h2>" + status.Content
+		}
+
+		// Include links of link posts.
+		for _, href := range ap.ExtractLinkAttachments(statusable) {
+			if href.Scheme != "http" && href.Scheme != "https" {
+				// Only link to web pages.
+				continue
+			}
+
+			link := html.EscapeString(href.String())
+			status.Content += `<p><a href="` + link + `" rel="nofollow noreferrer noopener" target="_blank">` + link + `</a></p>`
+		}
+
+		// Content was sanitized when normalized,
+		// but we've since added to it, so sanitize
+		// again to be sure nothing nasty got in.
+		status.Content = text.SanitizeToHTML(status.Content)
+	} else if summary != "" {
 		status.ContentWarning = summary
 	} else {
 		status.ContentWarning = ap.ExtractName(statusable)
@@ -383,6 +436,30 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	//
+	// Status that this status quotes, if applicable.
+	// As above, if we don't have the quoted status in
+	// the database, we set the URI to deref it later.
+	if quoteURI := ap.ExtractQuoteURI(statusable); quoteURI != nil {
+		status.QuoteOfURI = quoteURI.String()
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, status.QuoteOfURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", status.QuoteOfURI, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			// We have it in the DB!
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -505,6 +582,81 @@ func (c *Converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+// ASLikeToStatusReaction converts a remote activity streams 'like' representation, carrying
+// an emoji in its content (ie., a Misskey / Pleroma emoji reaction), into a gts model status
+// reaction. If a custom emoji is used, the returned reaction's Emoji will be set to a minimal
+// placeholder extracted from the 'tag' property, which still needs to be dereferenced.
+func (c *Converter) ASLikeToStatusReaction(ctx context.Context, reactable ap.Reactable) (*gtsmodel.StatusReaction, error) {
+	uriObj := ap.GetJSONLDId(reactable)
+	if uriObj == nil {
+		err := gtserror.New("unusable iri property")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Stringify uri obj.
+	uri := uriObj.String()
+
+	name := ap.ExtractReaction(reactable)
+	if name == "" {
+		err := gtserror.Newf("no emoji reaction set on %s", uri)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	origin, err := c.getASActorAccount(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.getASObjectStatus(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       origin.ID,
+		Account:         origin,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		Name:            name,
+		URI:             uri,
+	}
+
+	if shortcode, ok := customEmojiShortcode(name); ok {
+		emojis, err := ap.ExtractEmojis(reactable)
+		if err != nil {
+			return nil, gtserror.SetMalformed(err)
+		}
+
+		for _, emoji := range emojis {
+			if emoji.Shortcode == shortcode {
+				reaction.Emoji = emoji
+				break
+			}
+		}
+
+		if reaction.Emoji == nil {
+			err := gtserror.Newf("custom emoji %s not in tags of %s", name, uri)
+			return nil, gtserror.SetMalformed(err)
+		}
+	}
+
+	return reaction, nil
+}
+
+// customEmojiShortcode returns the shortcode of
+// the given reaction name, if it's formatted as
+// a custom emoji, ie., :shortcode:.
+func customEmojiShortcode(name string) (string, bool) {
+	if len(name) < 3 ||
+		!strings.HasPrefix(name, ":") ||
+		!strings.HasSuffix(name, ":") {
+		return "", false
+	}
+	return name[1 : len(name)-1], true
+}
+
 // ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 func (c *Converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	uriObj := ap.GetJSONLDId(blockable)
@@ -577,15 +729,15 @@ func (c *Converter) ASAnnounceToStatus(
 	isNew = true
 
 	// Get the URI of the boosted status.
-	boostOf := ap.GetObjectIRIs(announceable)
-	if len(boostOf) == 0 {
+	boostOf := ap.ExtractBoostOfURI(announceable)
+	if boostOf == nil {
 		err := gtserror.Newf("unusable object property iri for %s", uri)
 		return nil, isNew, gtserror.SetMalformed(err)
 	}
 
 	// Set the URI of the boosted status on
 	// the boost, for later dereferencing.
-	boost.BoostOfURI = boostOf[0].String()
+	boost.BoostOfURI = boostOf.String()
 
 	// Extract published time for the boost,
 	// zero-time will fall back to db defaults.
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
@@ -80,6 +81,7 @@ func (suite *ASToInternalTestSuite) TestParsePerson() {
 	suite.Equal("hey I'm a new person, your instance hasn't seen me yet uwu", acct.Note)
 	suite.Equal("https://unknown-instance.com/@brand_new_person", acct.URL)
 	suite.True(*acct.Discoverable)
+	suite.False(*acct.Indexable)
 	suite.Equal("https://unknown-instance.com/users/brand_new_person#main-key", acct.PublicKeyURI)
 	suite.False(*acct.Locked)
 }
@@ -150,6 +152,7 @@ func (suite *ASToInternalTestSuite) TestParseGargron() {
 	suite.Equal("https://mastodon.social/inbox", *acct.SharedInboxURI)
 	suite.Equal([]string{"https://tooting.ai/users/Gargron"}, acct.AlsoKnownAsURIs)
 	suite.Equal(int64(1458086400), acct.CreatedAt.Unix())
+	suite.True(*acct.Indexable)
 }
 
 func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
@@ -515,6 +518,208 @@ func (suite *ASToInternalTestSuite) TestParseAnnounce() {
 	suite.Nil(boost.BoostOfAccount)
 }
 
+func (suite *ASToInternalTestSuite) TestParseGroupAnnounce() {
+	// Group actors like Lemmy communities announce
+	// the Create activity for a member's post.
+	boostingAccount := suite.testAccounts["remote_account_1"]
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + boostingAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/activities/announce/7e4c14b2",
+  "object": {
+    "id": "http://fossbros-anonymous.io/activities/create/5f3ea11a",
+    "type": "Create",
+    "actor": "` + targetStatus.AccountURI + `",
+    "object": "` + targetStatus.URI + `"
+  },
+  "type": "Announce",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "cc": "` + boostingAccount.FollowersURI + `"
+}`
+
+	t := suite.jsonToType(raw)
+	asAnnounce, ok := t.(ap.Announceable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	boost, isNew, err := suite.typeconverter.ASAnnounceToStatus(context.Background(), asAnnounce)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(isNew)
+	suite.Equal(boostingAccount.ID, boost.AccountID)
+	suite.Equal(targetStatus.URI, boost.BoostOfURI)
+	suite.Equal(gtsmodel.VisibilityPublic, boost.Visibility)
+}
+
+func (suite *ASToInternalTestSuite) TestParseGroupAnnounceNotBoost() {
+	// Relayed Likes etc should not be treated as boosts.
+	boostingAccount := suite.testAccounts["remote_account_1"]
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + boostingAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/activities/announce/0b7d3c27",
+  "object": {
+    "id": "http://fossbros-anonymous.io/activities/like/9d1a6f55",
+    "type": "Like",
+    "actor": "http://fossbros-anonymous.io/users/someone",
+    "object": "` + targetStatus.URI + `"
+  },
+  "type": "Announce",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`
+
+	t := suite.jsonToType(raw)
+	asAnnounce, ok := t.(ap.Announceable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	suite.Nil(ap.ExtractBoostOfURI(asAnnounce))
+
+	_, _, err := suite.typeconverter.ASAnnounceToStatus(context.Background(), asAnnounce)
+	suite.Error(err)
+}
+
+func (suite *ASToInternalTestSuite) TestParseLemmyPage() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/post/1452",
+  "type": "Page",
+  "attributedTo": "` + authorAccount.URI + `",
+  "to": [
+    "http://fossbros-anonymous.io/c/linux",
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "audience": "http://fossbros-anonymous.io/c/linux",
+  "name": "What's your favourite distro & why?",
+  "content": "<p>Mine is the one that works.</p>",
+  "attachment": [
+    {
+      "type": "Link",
+      "href": "https://example.org/distros"
+    }
+  ],
+  "sensitive": false,
+  "published": "2024-06-01T10:00:00Z"
+}`
+
+	t := suite.jsonToType(raw)
+	asPage, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), asPage)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(status.ContentWarning)
+	suite.Equal(`<h2>What&#39;s your favourite distro &amp; why?</h2><p>Mine is the one that works.</p><p><a href="https://example.org/distros" rel="nofollow noreferrer noopener" target="_blank">https://example.org/distros</a></p>`, status.Content)
+	suite.Equal(ap.ObjectPage, status.ActivityStreamsType)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+}
+
+func (suite *ASToInternalTestSuite) TestParseLemmyPageScriptLink() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/post/1453",
+  "type": "Page",
+  "attributedTo": "` + authorAccount.URI + `",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "name": "click me",
+  "content": "<p>totally safe</p>",
+  "attachment": [
+    {
+      "type": "Link",
+      "href": "javascript:alert(1)"
+    },
+    {
+      "type": "Link",
+      "href": "data:text/html,<script>alert(1)</script>"
+    },
+    {
+      "type": "Link",
+      "href": "https://example.org/\" onmouseover=\"alert(1)"
+    }
+  ],
+  "published": "2024-06-01T10:00:00Z"
+}`
+
+	t := suite.jsonToType(raw)
+	asPage, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), asPage)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Script links should be dropped, and
+	// no attributes should be injected.
+	suite.NotContains(status.Content, "javascript:")
+	suite.NotContains(status.Content, "data:")
+	suite.NotContains(status.Content, "<script")
+	suite.NotContains(status.Content, " onmouseover=")
+}
+
+func (suite *ASToInternalTestSuite) TestParseGroup() {
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/c/linux",
+  "type": "Group",
+  "preferredUsername": "linux",
+  "name": "Linux",
+  "inbox": "http://fossbros-anonymous.io/c/linux/inbox",
+  "outbox": "http://fossbros-anonymous.io/c/linux/outbox",
+  "followers": "http://fossbros-anonymous.io/c/linux/followers",
+  "following": "http://fossbros-anonymous.io/c/linux/following",
+  "manuallyApprovesFollowers": false,
+  "publicKey": {
+    "id": "http://fossbros-anonymous.io/c/linux#main-key",
+    "owner": "http://fossbros-anonymous.io/c/linux",
+    "publicKeyPem": "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAzGB3yDvMl+8p+ViutVRG\nVDl9FO7ZURYXnwB3TeTugtRBFOBqfL2KLNhPRq7dnBsfR5R3GVlwB7sxBZ3ZgWaD\n6TlHqn0C1eeOdGkg4L7NfwIMx/2uoBZ1MjaE0VkBZ9ZrITpY9n9iGdxTULUhOLvJ\nhpZD9WS6F4nRrd1wETBrzSfyXzQG4wgA4uwXBCOXGjWaTNPjeOKGFUpO1iSDR+ra\nCbZhkL5bEyWBZF7S4HpIt4uINbJV+P5qMJTv4PNUYWqWxvBIJgydv3CYZzi6xSiR\nXRsJ0G7SCqWJk4WQVrA9QOZxN8cDHnWkTM8pD48PtZHCC4fOFcPbnRBGbAO+CDjC\nOwIDAQAB\n-----END PUBLIC KEY-----\n"
+  }
+}`
+
+	t := suite.jsonToType(raw)
+	rep, ok := t.(ap.Accountable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	acct, err := suite.typeconverter.ASRepresentationToAccount(context.Background(), rep, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(ap.ActorGroup, acct.ActorType)
+	suite.False(*acct.Bot)
+	suite.False(*acct.Locked)
+
+	// Groups should be flagged as such in the API.
+	acct.ID = "01J1F4RZ9T9VX1W8R4E0V3M6QH"
+	acct.CreatedAt = time.Now()
+	apiAcct, err := suite.typeconverter.AccountToAPIAccountPublic(context.Background(), acct)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(apiAcct.Group)
+}
+
 func (suite *ASToInternalTestSuite) TestParseHonkAccount() {
 	// Hopefully comprehensive checks for
 	// https://github.com/superseriousbusiness/gotosocial/issues/2527.
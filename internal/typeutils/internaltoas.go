@@ -36,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // AccountToAS converts a gts model account into an activity streams person, suitable for federation
@@ -166,6 +167,20 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	discoverableProp.Set(*a.Discoverable)
 	person.SetTootDiscoverable(discoverableProp)
 
+	// indexable
+	// Allows public posts to be found via search.
+	indexable, err := c.accountIndexable(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	ap.SetIndexable(person, indexable)
+
+	// memorial
+	// Only set if true, as Mastodon does.
+	if util.PtrValueOr(a.Memorial, false) {
+		ap.SetMemorial(person, true)
+	}
+
 	// devices
 	// NOT IMPLEMENTED, probably won't implement
 
@@ -236,6 +251,16 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	// set the public key property on the Person
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
+	// assertionMethod
+	// Required for object integrity proofs.
+	if a.Ed25519PublicKey != nil {
+		ap.SetAssertionMethod(person,
+			a.Ed25519PublicKeyURI,
+			a.URI,
+			a.Ed25519PublicKey,
+		)
+	}
+
 	// tags
 	tagProp := streams.NewActivityStreamsTagProperty()
 
@@ -360,6 +385,32 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	return person, nil
 }
 
+// accountIndexable returns whether the given account has opted
+// in to having its public posts found via full-text search. This
+// is stored in settings for local accounts, or on the account itself
+// for remote accounts.
+func (c *Converter) accountIndexable(ctx context.Context, a *gtsmodel.Account) (bool, error) {
+	if a.IsRemote() {
+		return util.PtrValueOr(a.Indexable, false), nil
+	}
+
+	if a.IsInstance() {
+		// Instance account
+		// has no settings.
+		return false, nil
+	}
+
+	if a.Settings == nil {
+		var err error
+		a.Settings, err = c.state.DB.GetAccountSettings(ctx, a.ID)
+		if err != nil {
+			return false, gtserror.Newf("error getting settings for account %s: %w", a.ID, err)
+		}
+	}
+
+	return util.PtrValueOr(a.Settings.Indexable, false), nil
+}
+
 // AccountToASMinimal converts a gts model account into an activity streams person, suitable for federation.
 //
 // The returned account will just have the Type, Username, PublicKey, and ID properties set. This is
@@ -424,6 +475,16 @@ func (c *Converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 	// set the public key property on the Person
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
+	// assertionMethod
+	// Required for object integrity proofs.
+	if a.Ed25519PublicKey != nil {
+		ap.SetAssertionMethod(person,
+			a.Ed25519PublicKeyURI,
+			a.URI,
+			a.Ed25519PublicKey,
+		)
+	}
+
 	return person, nil
 }
 
@@ -561,6 +622,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	}
 	status.SetActivityStreamsTag(tagProp)
 
+	// tag -- quote, + quoteUrl, _misskey_quote
+	if s.QuoteOfURI != "" {
+		qURI, err := url.Parse(s.QuoteOfURI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing url %s: %w", s.QuoteOfURI, err)
+		}
+		ap.SetQuote(status, qURI)
+	}
+
 	// parse out some URIs we need here
 	authorFollowersURI, err := url.Parse(s.Account.FollowersURI)
 	if err != nil {
@@ -623,6 +693,23 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	status.SetActivityStreamsTo(toProp)
 	status.SetActivityStreamsCc(ccProp)
 
+	// audience -- group actors (eg., Guppe groups,
+	// Lemmy communities) mentioned in a non-direct
+	// status, which they'll announce to their members.
+	if s.Visibility != gtsmodel.VisibilityDirect {
+		for _, m := range mentions {
+			if m.TargetAccount.ActorType != ap.ActorGroup {
+				continue
+			}
+
+			iri, err := url.Parse(m.TargetAccount.URI)
+			if err != nil {
+				return nil, gtserror.Newf("error parsing uri %s: %w", m.TargetAccount.URI, err)
+			}
+			ap.AppendAudience(status, iri)
+		}
+	}
+
 	// conversation
 	// TODO
 
@@ -669,7 +756,7 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 
 	// sensitive
 	sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
-	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
+	sensitiveProp.AppendXMLSchemaBoolean(isSensitive(s))
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
 	return status, nil
@@ -1172,6 +1259,46 @@ func (c *Converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+// StatusReactionToAS converts a gts model status reaction into an activityStreams LIKE,
+// carrying the reaction emoji in the same way as Misskey, suitable for federation.
+func (c *Converter) StatusReactionToAS(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.ActivityStreamsLike, error) {
+	if err := c.state.DB.PopulateStatusReaction(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// An emoji reaction is a Like
+	// with some extra properties.
+	like, err := c.FaveToAS(ctx, &gtsmodel.StatusFave{
+		AccountID:       r.AccountID,
+		Account:         r.Account,
+		TargetAccountID: r.TargetAccountID,
+		TargetAccount:   r.TargetAccount,
+		StatusID:        r.StatusID,
+		Status:          r.Status,
+		URI:             r.URI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ap.SetReaction(like, r.Name)
+
+	if r.Emoji != nil {
+		// Include the custom emoji
+		// so it can be dereferenced.
+		emoji, err := c.EmojiToAS(ctx, r.Emoji)
+		if err != nil {
+			return nil, gtserror.Newf("error converting emoji to AS: %w", err)
+		}
+
+		tagProp := streams.NewActivityStreamsTagProperty()
+		tagProp.AppendTootEmoji(emoji)
+		like.SetActivityStreamsTag(tagProp)
+	}
+
+	return like, nil
+}
+
 // BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 func (c *Converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error) {
 	// the boosted status is probably pinned to the boostWrapperStatus but double check to make sure
@@ -69,6 +69,7 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -122,6 +123,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
   "following": "http://localhost:8080/users/1happyturtle/following",
   "id": "http://localhost:8080/users/1happyturtle",
   "inbox": "http://localhost:8080/users/1happyturtle/inbox",
+  "indexable": true,
   "manuallyApprovesFollowers": true,
   "name": "happy little turtle :3",
   "outbox": "http://localhost:8080/users/1happyturtle/outbox",
@@ -187,6 +189,7 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "movedTo": "http://localhost:8080/users/1happyturtle",
   "name": "original zork (he/they)",
@@ -236,6 +239,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithOneField() {
   "following": "http://localhost:8080/users/1happyturtle/following",
   "id": "http://localhost:8080/users/1happyturtle",
   "inbox": "http://localhost:8080/users/1happyturtle/inbox",
+  "indexable": true,
   "manuallyApprovesFollowers": true,
   "name": "happy little turtle :3",
   "outbox": "http://localhost:8080/users/1happyturtle/outbox",
@@ -286,6 +290,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -350,6 +355,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -409,6 +415,114 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASWithQuote() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	quoteOf := suite.testStatuses["admin_account_status_1"]
+	testStatus.QuoteOfID = quoteOf.ID
+	testStatus.QuoteOfURI = quoteOf.URI
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asStatus)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "_misskey_quote": "https://misskey-hub.net/ns#_misskey_quote",
+      "quoteUrl": "as:quoteUrl"
+    }
+  ],
+  "_misskey_quote": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "attachment": [],
+  "attributedTo": "http://localhost:8080/users/the_mighty_zork",
+  "cc": "http://localhost:8080/users/the_mighty_zork/followers",
+  "content": "hello everyone!",
+  "contentMap": {
+    "en": "hello everyone!"
+  },
+  "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "published": "2021-10-20T12:40:37+02:00",
+  "quoteUrl": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "replies": {
+    "first": {
+      "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/replies?page=true",
+      "next": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/replies?only_other_accounts=false\u0026page=true",
+      "partOf": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/replies",
+      "type": "CollectionPage"
+    },
+    "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/replies",
+    "type": "Collection"
+  },
+  "sensitive": true,
+  "summary": "introduction post",
+  "tag": {
+    "href": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+    "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+    "name": "RE: http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+    "type": "Link"
+  },
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "type": "Note",
+  "url": "http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
+}`, string(bytes))
+}
+
+func (suite *InternalToASTestSuite) TestStatusReactionToAS() {
+	status := suite.testStatuses["local_account_2_status_1"]
+	emoji := suite.testEmojis["rainbow"]
+	reaction := &gtsmodel.StatusReaction{
+		ID:              "01J15B9A4R2CG8ZF3EBN7V0RXH",
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            ":" + emoji.Shortcode + ":",
+		EmojiID:         emoji.ID,
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01J15B9A4R2CG8ZF3EBN7V0RXH",
+	}
+
+	asLike, err := suite.typeconverter.StatusReactionToAS(context.Background(), reaction)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asLike)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	// we can't be sure in what order the context entries
+	// will appear, so trim them out of the string for consistency
+	trimmed := strings.SplitAfter(string(bytes), "\n  ],")[1]
+	suite.Contains(string(bytes), `"_misskey_reaction": "https://misskey-hub.net/ns#_misskey_reaction"`)
+	suite.Equal(`
+  "_misskey_reaction": ":rainbow:",
+  "actor": "http://localhost:8080/users/the_mighty_zork",
+  "content": ":rainbow:",
+  "id": "http://localhost:8080/users/the_mighty_zork/liked/01J15B9A4R2CG8ZF3EBN7V0RXH",
+  "object": "http://localhost:8080/users/1happyturtle/statuses/01F8MHBQCBTDKN6X5VHGMMN4MA",
+  "tag": {
+    "icon": {
+      "mediaType": "image/png",
+      "type": "Image",
+      "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+    },
+    "id": "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
+    "name": ":rainbow:",
+    "type": "Emoji",
+    "updated": "2021-09-20T10:40:37Z"
+  },
+  "to": "http://localhost:8080/users/1happyturtle",
+  "type": "Like"
+}`, trimmed)
+}
+
 func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
 	// use the status with just IDs of attachments and emojis pinned on it
 	testStatus := suite.testStatuses["admin_account_status_1"]
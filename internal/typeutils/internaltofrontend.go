@@ -22,16 +22,19 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"codeberg.org/gruf/go-debug"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -102,6 +105,19 @@ func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimod
 	return user
 }
 
+// UserToAPIEmailNotifications converts the email notification
+// preferences of the given user into the API model.
+func (c *Converter) UserToAPIEmailNotifications(u *gtsmodel.User) *apimodel.EmailNotifications {
+	return &apimodel.EmailNotifications{
+		Mention:       u.EmailNotifies(gtsmodel.EmailNotifyTypeMention),
+		Direct:        u.EmailNotifies(gtsmodel.EmailNotifyTypeDirect),
+		Follow:        u.EmailNotifies(gtsmodel.EmailNotifyTypeFollow),
+		FollowRequest: u.EmailNotifies(gtsmodel.EmailNotifyTypeFollowRequest),
+		Report:        u.EmailNotifies(gtsmodel.EmailNotifyTypeReport),
+		Frequency:     u.EmailNotifyFrequency.String(),
+	}
+}
+
 // AppToAPIAppSensitive takes a db model application as a param, and returns a populated apitype application, or an error
 // if something goes wrong. The returned application should be ready to serialize on an API level, and may have sensitive fields
 // (such as client id and client secret), so serve it only to an authorized user who should have permission to see it.
@@ -236,7 +252,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	// Bits that vary between remote + local accounts:
 	//   - Account (acct) string.
 	//   - Role.
-	//   - Settings things (enableRSS, theme, customCSS, hideCollections).
+	//   - Settings things (enableRSS, theme, customCSS, hideCollections, indexable, disableEmbeds).
 
 	var (
 		acct            string
@@ -245,6 +261,8 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		theme           string
 		customCSS       string
 		hideCollections bool
+		indexable       bool
+		disableEmbeds   bool
 	)
 
 	if a.IsRemote() {
@@ -256,6 +274,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		}
 
 		acct = a.Username + "@" + d
+		indexable = util.PtrValueOr(a.Indexable, false)
 	} else {
 		// This is a local account, try to
 		// fetch more info. Skip for instance
@@ -279,6 +298,8 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 			theme = a.Settings.Theme
 			customCSS = a.Settings.CustomCSS
 			hideCollections = *a.Settings.HideCollections
+			indexable = util.PtrValueOr(a.Settings.Indexable, false)
+			disableEmbeds = util.PtrValueOr(a.Settings.DisableEmbeds, false)
 		}
 
 		acct = a.Username // omit domain
@@ -288,6 +309,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		locked       = util.PtrValueOr(a.Locked, true)
 		discoverable = util.PtrValueOr(a.Discoverable, false)
 		bot          = util.PtrValueOr(a.Bot, false)
+		group        = a.ActorType == ap.ActorGroup
 	)
 
 	// Remaining properties are simple and
@@ -301,6 +323,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		Locked:          locked,
 		Discoverable:    discoverable,
 		Bot:             bot,
+		Group:           group,
 		CreatedAt:       util.FormatISO8601(a.CreatedAt),
 		Note:            a.Note,
 		URL:             a.URL,
@@ -315,10 +338,13 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		Emojis:          apiEmojis,
 		Fields:          fields,
 		Suspended:       !a.SuspendedAt.IsZero(),
+		Memorial:        util.PtrValueOr(a.Memorial, false),
 		Theme:           theme,
 		CustomCSS:       customCSS,
 		EnableRSS:       enableRSS,
 		HideCollections: hideCollections,
+		Indexable:       indexable,
+		DisableEmbeds:   disableEmbeds,
 		Role:            role,
 	}
 
@@ -396,6 +422,7 @@ func (c *Converter) AccountToAPIAccountBlocked(ctx context.Context, a *gtsmodel.
 		Username:  a.Username,
 		Acct:      acct,
 		Bot:       *a.Bot,
+		Group:     a.ActorType == ap.ActorGroup,
 		CreatedAt: util.FormatISO8601(a.CreatedAt),
 		URL:       a.URL,
 		// Empty array (not nillable).
@@ -428,6 +455,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -476,6 +504,22 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// User signed up with an invite,
+			// get the account who created it.
+			invite, err := c.state.DB.GetInviteByID(
+				gtscontext.SetBarebones(ctx),
+				user.InviteID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s for account id %s: %w", user.InviteID, a.ID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -499,9 +543,10 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Disabled:               disabled,
 		Silenced:               !a.SilencedAt.IsZero(),
 		Suspended:              !a.SuspendedAt.IsZero(),
+		Sensitized:             a.IsSensitized(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
@@ -718,6 +763,111 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	}, nil
 }
 
+// TrendingTagToAPITag converts a gts model trending tag into its api (frontend)
+// representation for serialization on the API, including its usage history.
+func (c *Converter) TrendingTagToAPITag(ctx context.Context, t *gtsmodel.TrendingTag) (apimodel.Tag, error) {
+	apiTag, err := c.TagToAPITag(ctx, t.Tag, false)
+	if err != nil {
+		return apimodel.Tag{}, err
+	}
+
+	history := make([]any, 0, len(t.History))
+	for _, h := range trendHistoryToAPIHistory(t.History) {
+		history = append(history, h)
+	}
+	apiTag.History = &history
+
+	return apiTag, nil
+}
+
+// TrendingTagToAdminAPITag converts a gts model trending tag into its admin
+// api (frontend) representation, for serialization on the admin API.
+func (c *Converter) TrendingTagToAdminAPITag(ctx context.Context, t *gtsmodel.TrendingTag) (*apimodel.AdminTrendsTag, error) {
+	apiTag, err := c.TrendingTagToAPITag(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.AdminTrendsTag{
+		Tag:            apiTag,
+		ID:             t.Tag.ID,
+		Trendable:      trendApproved(t.Review),
+		Usable:         util.PtrValueOr(t.Tag.Useable, true),
+		RequiresReview: t.Review.Pending(),
+	}, nil
+}
+
+// TrendingLinkToAPILink converts a gts model trending link into its
+// api (frontend) representation for serialization on the API.
+func (c *Converter) TrendingLinkToAPILink(t *gtsmodel.TrendingLink) apimodel.TrendsLink {
+	if t.Card != nil && !t.Card.Empty() {
+		// Use the fetched preview card.
+		return apimodel.TrendsLink{
+			Card:    *c.PreviewCardToAPICard(t.Card),
+			History: trendHistoryToAPIHistory(t.History),
+		}
+	}
+
+	// No preview card (yet), so
+	// make do with just the URL.
+	var providerName, providerURL string
+	if u, err := url.Parse(t.URL); err == nil {
+		providerName = u.Host
+		providerURL = u.Scheme + "://" + u.Host
+	}
+
+	return apimodel.TrendsLink{
+		Card: apimodel.Card{
+			URL:          t.URL,
+			Title:        t.URL,
+			Type:         "link",
+			ProviderName: providerName,
+			ProviderURL:  providerURL,
+		},
+		History: trendHistoryToAPIHistory(t.History),
+	}
+}
+
+// TrendingLinkToAdminAPILink converts a gts model trending link into its
+// admin api (frontend) representation, for serialization on the admin API.
+func (c *Converter) TrendingLinkToAdminAPILink(t *gtsmodel.TrendingLink) *apimodel.AdminTrendsLink {
+	return &apimodel.AdminTrendsLink{
+		TrendsLink:     c.TrendingLinkToAPILink(t),
+		ID:             t.Review.ID,
+		Trendable:      trendApproved(t.Review),
+		RequiresReview: t.Review.Pending(),
+	}
+}
+
+// PreviewCardToAPICard converts a gts model preview card into
+// its api (frontend) representation for serialization on the API.
+func (c *Converter) PreviewCardToAPICard(card *gtsmodel.PreviewCard) *apimodel.Card {
+	// Embeds give their own dimensions,
+	// otherwise clients expect the
+	// dimensions of the preview image.
+	width, height := card.Width, card.Height
+	if width == 0 && height == 0 {
+		width, height = card.ImageWidth, card.ImageHeight
+	}
+
+	return &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         card.Type,
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		HTML:         card.HTML,
+		Width:        width,
+		Height:       height,
+		Image:        card.ImageURL,
+		EmbedURL:     card.EmbedURL,
+		Blurhash:     card.Blurhash,
+	}
+}
+
 // StatusToAPIStatus converts a gts model status into its api
 // (frontend) representation for serialization on the API.
 //
@@ -750,6 +900,10 @@ func (c *Converter) StatusToAPIStatus(
 		aside, apiStatus.Reblog.MediaAttachments = placeholdUnknownAttachments(apiStatus.Reblog.MediaAttachments)
 		apiStatus.Reblog.Content += aside
 	}
+	if apiStatus.Quote != nil {
+		aside, apiStatus.Quote.MediaAttachments = placeholdUnknownAttachments(apiStatus.Quote.MediaAttachments)
+		apiStatus.Quote.Content += aside
+	}
 
 	return apiStatus, nil
 }
@@ -1022,6 +1176,20 @@ func (c *Converter) StatusToWebStatus(
 
 	webStatus.Local = *s.Local
 
+	if quote := webStatus.Quote; quote != nil {
+		// Add additional information for template.
+		quote.LanguageTag = new(language.Language)
+		if lang := quote.Language; lang != nil {
+			if langTag, err := language.Parse(*lang); err == nil {
+				quote.LanguageTag = langTag
+			}
+		}
+
+		if q := s.QuoteOf; q != nil {
+			quote.Local = *q.Local
+		}
+	}
+
 	return webStatus, nil
 }
 
@@ -1088,11 +1256,84 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Muted = apiStatus.Reblog.Muted
 		apiStatus.Reblogged = apiStatus.Reblog.Reblogged
 		apiStatus.Pinned = apiStatus.Reblog.Pinned
+
+		// Set quote of the boosted status (if any).
+		if err := c.setStatusQuote(ctx,
+			status.BoostOf,
+			reblog,
+			requestingAccount,
+			filterContext,
+			filters,
+			mutes,
+		); err != nil {
+			log.Errorf(ctx, "error setting boosted status quote: %v", err)
+		}
+	} else {
+		// Set quote of the status (if any).
+		if err := c.setStatusQuote(ctx,
+			status,
+			apiStatus,
+			requestingAccount,
+			filterContext,
+			filters,
+			mutes,
+		); err != nil {
+			log.Errorf(ctx, "error setting status quote: %v", err)
+		}
 	}
 
 	return apiStatus, nil
 }
 
+// setStatusQuote converts the status quoted by the given
+// status (if any), and sets it on the given API status, as
+// long as the quote is visible to the requesting account.
+// Quotes are not nested, ie., a quote of a quote is not set.
+func (c *Converter) setStatusQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	apiStatus *apimodel.Status,
+	requestingAccount *gtsmodel.Account,
+	filterContext statusfilter.FilterContext,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) error {
+	if status.QuoteOf == nil {
+		// Not a quote, or quoted
+		// status not (yet) stored.
+		return nil
+	}
+
+	visible, err := c.filter.StatusVisible(ctx, requestingAccount, status.QuoteOf)
+	if err != nil {
+		return gtserror.Newf("error checking quoted status visibility: %w", err)
+	}
+
+	if !visible {
+		// Requester can't see
+		// quoted status, skip.
+		return nil
+	}
+
+	quote, err := c.baseStatusToFrontend(ctx,
+		status.QuoteOf,
+		requestingAccount,
+		filterContext,
+		filters,
+		mutes,
+	)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Quoted status is filtered
+		// out, leave just the quote.
+		return nil
+	} else if err != nil {
+		return gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	apiStatus.Quote = &apimodel.StatusQuoted{Status: quote}
+	return nil
+}
+
 // baseStatusToFrontend performs the main logic
 // of statusToFrontend() without handling of boost
 // logic, to prevent *possible* recursion issues.
@@ -1168,7 +1409,7 @@ func (c *Converter) baseStatusToFrontend(
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
-		Sensitive:          *s.Sensitive,
+		Sensitive:          isSensitive(s),
 		SpoilerText:        s.ContentWarning,
 		Visibility:         c.VisToAPIVis(ctx, s.Visibility),
 		Language:           nil, // Set below.
@@ -1185,7 +1426,7 @@ func (c *Converter) baseStatusToFrontend(
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               nil, // Set below.
 		Text:               s.Text,
 	}
 
@@ -1194,6 +1435,10 @@ func (c *Converter) baseStatusToFrontend(
 		apiStatus.InReplyToID = util.Ptr(s.InReplyToID)
 	}
 
+	if s.PreviewCard != nil && !s.PreviewCard.Empty() {
+		apiStatus.Card = c.PreviewCardToAPICard(s.PreviewCard)
+	}
+
 	if s.InReplyToAccountID != "" {
 		apiStatus.InReplyToAccountID = util.Ptr(s.InReplyToAccountID)
 	}
@@ -1325,8 +1570,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,  // approval always required
-		InvitesEnabled:       false, // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsInvitesEnabled(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1609,13 +1854,162 @@ func (c *Converter) NotificationToAPINotification(
 		apiStatus = apiStatus.Reblog.Status
 	}
 
-	return &apimodel.Notification{
+	apiNotif := &apimodel.Notification{
 		ID:        n.ID,
 		Type:      string(n.NotificationType),
 		CreatedAt: util.FormatISO8601(n.CreatedAt),
 		Account:   apiAccount,
 		Status:    apiStatus,
-	}, nil
+	}
+
+	if n.NotificationType == gtsmodel.NotificationEmojiReaction {
+		// Set the emoji used by the origin
+		// account to react to the status.
+		if err := c.setNotificationEmoji(ctx, n, apiNotif); err != nil {
+			log.Errorf(ctx, "error setting notification emoji: %v", err)
+		}
+	}
+
+	if n.NotificationType == gtsmodel.NotificationSignup {
+		// Set the account who invited the
+		// new account, if it used an invite.
+		if err := c.setNotificationInvitedBy(ctx, n, apiNotif); err != nil {
+			log.Errorf(ctx, "error setting notification inviter: %v", err)
+		}
+	}
+
+	return apiNotif, nil
+}
+
+// setNotificationInvitedBy sets the account who created the
+// invite that the origin account of the given sign-up
+// notification signed up with, if any, on the API notification.
+func (c *Converter) setNotificationInvitedBy(
+	ctx context.Context,
+	n *gtsmodel.Notification,
+	apiNotif *apimodel.Notification,
+) error {
+	user, err := c.state.DB.GetUserByAccountID(
+		gtscontext.SetBarebones(ctx),
+		n.OriginAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting user for account %s: %w", n.OriginAccountID, err)
+	}
+
+	if user.InviteID == "" {
+		// Didn't sign
+		// up by invite.
+		return nil
+	}
+
+	invite, err := c.state.DB.GetInviteByID(ctx, user.InviteID)
+	if err != nil {
+		return gtserror.Newf("error getting invite %s: %w", user.InviteID, err)
+	}
+
+	if invite.Account == nil {
+		// Inviter has
+		// been deleted.
+		return nil
+	}
+
+	apiNotif.InvitedBy, err = c.AccountToAPIAccountPublic(ctx, invite.Account)
+	if err != nil {
+		return gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	return nil
+}
+
+// setNotificationEmoji sets the emoji (and custom emoji URL)
+// of the most recent reaction by the notification's origin
+// account to its status on the given API notification.
+func (c *Converter) setNotificationEmoji(
+	ctx context.Context,
+	n *gtsmodel.Notification,
+	apiNotif *apimodel.Notification,
+) error {
+	reactions, err := c.state.DB.GetStatusReactions(ctx, n.StatusID)
+	if err != nil {
+		return gtserror.Newf("error getting reactions to status %s: %w", n.StatusID, err)
+	}
+
+	// Reactions are ordered oldest
+	// first, so work backwards.
+	for i := len(reactions) - 1; i >= 0; i-- {
+		reaction := reactions[i]
+		if reaction.AccountID != n.OriginAccountID {
+			continue
+		}
+
+		apiNotif.Emoji = reaction.Name
+		if reaction.Emoji != nil {
+			apiNotif.EmojiURL = reaction.Emoji.ImageURL
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// StatusReactionsToAPIEmojiReactions groups the given reactions to a status by emoji,
+// converting them to API emoji reactions in order of when each emoji was first used.
+// Reactions by accounts in a block relationship with requester will be skipped.
+func (c *Converter) StatusReactionsToAPIEmojiReactions(
+	ctx context.Context,
+	reactions []*gtsmodel.StatusReaction,
+	requester *gtsmodel.Account,
+) ([]*apimodel.EmojiReaction, error) {
+	var (
+		apiReactions = make([]*apimodel.EmojiReaction, 0, len(reactions))
+		byName       = make(map[string]*apimodel.EmojiReaction, len(reactions))
+	)
+
+	for _, reaction := range reactions {
+		if requester != nil {
+			blocked, err := c.state.DB.IsEitherBlocked(ctx, requester.ID, reaction.AccountID)
+			if err != nil {
+				return nil, gtserror.Newf("error checking blocks: %w", err)
+			}
+
+			if blocked {
+				continue
+			}
+		}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, reaction.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s to api: %w", reaction.AccountID, err)
+		}
+
+		apiReaction, ok := byName[reaction.Name]
+		if !ok {
+			apiReaction = &apimodel.EmojiReaction{
+				Name:     reaction.Name,
+				Accounts: make([]*apimodel.Account, 0, 1),
+			}
+
+			if reaction.Emoji != nil {
+				apiReaction.Name = reaction.Emoji.Shortcode
+				apiReaction.URL = reaction.Emoji.ImageURL
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			byName[reaction.Name] = apiReaction
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReaction.Count++
+		apiReaction.Accounts = append(apiReaction.Accounts, apiAccount)
+
+		if requester != nil && reaction.AccountID == requester.ID {
+			apiReaction.Me = true
+		}
+	}
+
+	return apiReactions, nil
 }
 
 // DomainPermToAPIDomainPerm converts a gts model domin block or allow into an api domain permission.
@@ -1654,6 +2048,48 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	return domainPerm, nil
 }
 
+// InviteToAPIInvite converts a gts model invite into an api model
+// invite. If withAccount is true, the creator of the invite will be
+// included, for serving to admins.
+func (c *Converter) InviteToAPIInvite(ctx context.Context, i *gtsmodel.Invite, withAccount bool) (*apimodel.Invite, error) {
+	invite := &apimodel.Invite{
+		ID:        i.ID,
+		Code:      i.Code,
+		URL:       config.GetProtocol() + "://" + config.GetHost() + "/signup?invite=" + url.QueryEscape(i.Code),
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		Uses:      i.Uses,
+		Valid:     i.Valid(),
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(i.ExpiresAt)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	if i.MaxUses > 0 {
+		maxUses := i.MaxUses
+		invite.MaxUses = &maxUses
+	}
+
+	if withAccount {
+		if i.Account == nil {
+			var err error
+			i.Account, err = c.state.DB.GetAccountByID(ctx, i.AccountID)
+			if err != nil {
+				return nil, gtserror.Newf("error getting account %s: %w", i.AccountID, err)
+			}
+		}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, i.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account: %w", err)
+		}
+		invite.Account = apiAccount
+	}
+
+	return invite, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1693,6 +2129,73 @@ func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (
 	return report, nil
 }
 
+// QueuedEmailToAdminAPIQueuedEmail converts a gts model queued email into an admin view email, for serving at /api/v1/admin/email/failed
+func (c *Converter) QueuedEmailToAdminAPIQueuedEmail(e *gtsmodel.QueuedEmail) *apimodel.AdminQueuedEmail {
+	return &apimodel.AdminQueuedEmail{
+		ID:        e.ID,
+		CreatedAt: util.FormatISO8601(e.CreatedAt),
+		To:        e.To,
+		Subject:   e.Subject,
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+		FailedAt:  util.FormatISO8601(e.FailedAt),
+	}
+}
+
+// QuarantinedStatusToAdminAPIQuarantinedStatus converts a gts model quarantined status into an admin view quarantined status, for serving at /api/v1/admin/spam/quarantine
+func (c *Converter) QuarantinedStatusToAdminAPIQuarantinedStatus(ctx context.Context, q *gtsmodel.QuarantinedStatus) (*apimodel.AdminQuarantinedStatus, error) {
+	var err error
+
+	if q.Account == nil {
+		q.Account, err = c.state.DB.GetAccountByID(ctx, q.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting account %s: %w", q.AccountID, err)
+		}
+	}
+
+	account, err := c.AccountToAdminAPIAccount(ctx, q.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account %s: %w", q.AccountID, err)
+	}
+
+	if q.ReceivingAccount == nil {
+		q.ReceivingAccount, err = c.state.DB.GetAccountByID(ctx, q.ReceivingAccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting receiving account %s: %w", q.ReceivingAccountID, err)
+		}
+	}
+
+	receivingAccount, err := c.AccountToAdminAPIAccount(ctx, q.ReceivingAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting receiving account %s: %w", q.ReceivingAccountID, err)
+	}
+
+	apiStatus := &apimodel.AdminQuarantinedStatus{
+		ID:               q.ID,
+		CreatedAt:        util.FormatISO8601(q.CreatedAt),
+		URI:              q.URI,
+		URL:              q.URL,
+		Account:          account,
+		ReceivingAccount: receivingAccount,
+		SpoilerText:      q.ContentWarning,
+		Content:          q.Content,
+		Score:            q.Score,
+		Rules:            q.Rules,
+		Fingerprint:      q.Fingerprint,
+		LinkDomains:      q.LinkDomains,
+	}
+
+	// Always serialize as arrays.
+	if apiStatus.Rules == nil {
+		apiStatus.Rules = []string{}
+	}
+	if apiStatus.LinkDomains == nil {
+		apiStatus.LinkDomains = []string{}
+	}
+
+	return apiStatus, nil
+}
+
 // ReportToAdminAPIReport converts a gts model report into an admin view report, for serving at /api/v1/admin/reports
 func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error) {
 	var (
@@ -52,6 +52,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontend() {
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -103,6 +104,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -140,6 +142,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "locked": true,
     "discoverable": false,
     "bot": false,
+    "group": false,
     "created_at": "2022-06-04T13:12:00.000Z",
     "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
     "url": "http://localhost:8080/@1happyturtle",
@@ -165,6 +168,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
       }
     ],
     "hide_collections": true,
+    "indexable": true,
     "role": {
       "name": "user"
     }
@@ -194,6 +198,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiStruct()
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -242,6 +247,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiIDs() {
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -286,6 +292,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -335,6 +342,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendPublicPunycode()
   "locked": false,
   "discoverable": false,
   "bot": false,
+  "group": false,
   "created_at": "2020-08-10T12:13:28.000Z",
   "note": "",
   "url": "https://xn--xample-ova.org/users/@%C3%BCser",
@@ -373,6 +381,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendPubl
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2020-05-17T13:10:59.000Z",
   "note": "",
   "url": "http://localhost:8080/@localhost:8080",
@@ -411,6 +420,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendBloc
   "locked": false,
   "discoverable": false,
   "bot": false,
+  "group": false,
   "created_at": "2020-05-17T13:10:59.000Z",
   "note": "",
   "url": "http://localhost:8080/@localhost:8080",
@@ -469,6 +479,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -598,6 +609,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -811,6 +823,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
     "locked": true,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
     "url": "http://example.org/@Some_User",
@@ -920,6 +933,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
     "locked": true,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
     "url": "http://example.org/@Some_User",
@@ -1048,6 +1062,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -1245,6 +1260,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -1366,6 +1382,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -1482,6 +1499,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend1() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
     "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -1525,6 +1543,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend2() {
     "locked": true,
     "discoverable": false,
     "bot": false,
+    "group": false,
     "created_at": "2022-06-04T13:12:00.000Z",
     "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
     "url": "http://localhost:8080/@1happyturtle",
@@ -1550,6 +1569,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend2() {
       }
     ],
     "hide_collections": true,
+    "indexable": true,
     "role": {
       "name": "user"
     }
@@ -1592,6 +1612,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
       "username": "foss_satan",
@@ -1600,6 +1621,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -1633,6 +1655,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
       "username": "1happyturtle",
@@ -1641,6 +1664,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
       "url": "http://localhost:8080/@1happyturtle",
@@ -1666,6 +1690,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
         }
       ],
       "hide_collections": true,
+      "indexable": true,
       "role": {
         "name": "user"
       }
@@ -1690,6 +1715,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
       "username": "admin",
@@ -1698,6 +1724,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -1736,6 +1763,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
       "username": "admin",
@@ -1744,6 +1772,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -1805,6 +1834,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
       "username": "1happyturtle",
@@ -1813,6 +1843,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
       "url": "http://localhost:8080/@1happyturtle",
@@ -1838,6 +1869,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
         }
       ],
       "hide_collections": true,
+      "indexable": true,
       "role": {
         "name": "user"
       }
@@ -1862,6 +1894,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
       "username": "foss_satan",
@@ -1870,6 +1903,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -1917,6 +1951,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2040,6 +2075,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
       "username": "foss_satan",
@@ -2048,6 +2084,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2081,6 +2118,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "disabled": false,
     "silenced": false,
     "suspended": true,
+    "sensitized": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
       "username": "1happyturtle",
@@ -2089,6 +2127,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "",
       "url": "http://localhost:8080/@1happyturtle",
@@ -2104,6 +2143,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "fields": [],
       "suspended": true,
       "hide_collections": true,
+      "indexable": true,
       "role": {
         "name": "user"
       }
@@ -2127,6 +2167,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
       "username": "admin",
@@ -2135,6 +2176,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -2173,6 +2215,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "disabled": false,
     "silenced": false,
     "suspended": false,
+    "sensitized": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
       "username": "admin",
@@ -2181,6 +2224,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
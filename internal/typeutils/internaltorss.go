@@ -20,12 +20,15 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/feeds"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
@@ -63,14 +66,22 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 		}
 		s.Account = a
 	}
-	authorName := "@" + s.Account.Username + "@" + config.GetAccountDomain()
+	authorDomain := s.Account.Domain
+	if s.Account.IsLocal() {
+		authorDomain = config.GetAccountDomain()
+	}
+	authorName := "@" + s.Account.Username + "@" + authorDomain
 	author := &feeds.Author{
 		Name: authorName,
 	}
 
 	// Source -- The RSS channel that the item came from.
-	source := &feeds.Link{
-		Href: s.Account.URL + "/feed.rss",
+	// Only local accounts have feeds that we know of.
+	var source *feeds.Link
+	if s.Account.IsLocal() {
+		source = &feeds.Link{
+			Href: s.Account.URL + "/feed.rss",
+		}
 	}
 
 	// Description -- The item synopsis.
@@ -104,21 +115,10 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 	id := s.URL
 
 	// Enclosure -- Describes a media object that is attached to the item.
+	// RSS only supports one enclosure, so use the first attachment if present.
 	enclosure := &feeds.Enclosure{}
-	// get first attachment if present
-	var attachment *gtsmodel.MediaAttachment
-	if len(s.Attachments) > 0 {
-		attachment = s.Attachments[0]
-	} else if len(s.AttachmentIDs) > 0 {
-		a, err := c.state.DB.GetAttachmentByID(ctx, s.AttachmentIDs[0])
-		if err == nil {
-			attachment = a
-		}
-	}
-	if attachment != nil {
-		enclosure.Type = attachment.File.ContentType
-		enclosure.Length = strconv.Itoa(attachment.File.FileSize)
-		enclosure.Url = attachment.URL
+	if enclosures := c.statusToFeedEnclosures(ctx, s); len(enclosures) > 0 {
+		enclosure = enclosures[0]
 	}
 
 	// Content
@@ -168,6 +168,125 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 	}, nil
 }
 
+// FeedFormat is a format that
+// feeds of statuses can be output as.
+type FeedFormat int
+
+const (
+	FeedFormatRSS  FeedFormat = iota // RSS 2.0
+	FeedFormatAtom                   // Atom
+	FeedFormatJSON                   // JSON Feed 1.1
+)
+
+// String returns the file extension
+// used for feeds in this format.
+func (f FeedFormat) String() string {
+	switch f {
+	case FeedFormatAtom:
+		return "atom"
+	case FeedFormatJSON:
+		return "json"
+	default:
+		return "rss"
+	}
+}
+
+// StatusesToFeed adds the given statuses as items to the given feed,
+// which should have no items yet, and outputs the feed in the given
+// format. Unlike RSS, Atom and JSON Feed include every attachment of
+// a status as an enclosure. feedURL is the URL that the feed itself
+// is served at, which is included in JSON Feed output.
+func (c *Converter) StatusesToFeed(
+	ctx context.Context,
+	feed *feeds.Feed,
+	statuses []*gtsmodel.Status,
+	format FeedFormat,
+	feedURL string,
+) (string, error) {
+	enclosures := make([][]*feeds.Enclosure, len(statuses))
+	for i, status := range statuses {
+		item, err := c.StatusToRSSItem(ctx, status)
+		if err != nil {
+			return "", gtserror.Newf("error converting status %s to feed item: %w", status.ID, err)
+		}
+
+		feed.Add(item)
+		enclosures[i] = c.statusToFeedEnclosures(ctx, status)
+	}
+
+	switch format {
+	case FeedFormatAtom:
+		atom := (&feeds.Atom{Feed: feed}).AtomFeed()
+		for i, entry := range atom.Entries {
+			entry.Published = feed.Items[i].Created.Format(time.RFC3339)
+
+			// Replace the single, possibly empty,
+			// enclosure link with one per attachment.
+			entry.Links = slices.DeleteFunc(entry.Links, func(link feeds.AtomLink) bool {
+				return link.Rel == "enclosure"
+			})
+			for _, enclosure := range enclosures[i] {
+				entry.Links = append(entry.Links, feeds.AtomLink{
+					Href:   enclosure.Url,
+					Rel:    "enclosure",
+					Type:   enclosure.Type,
+					Length: enclosure.Length,
+				})
+			}
+		}
+		return feeds.ToXML(atom)
+
+	case FeedFormatJSON:
+		json := (&feeds.JSON{Feed: feed}).JSONFeed()
+		json.FeedUrl = feedURL
+		if feed.Image != nil {
+			json.Icon = feed.Image.Url
+		}
+
+		for i, item := range json.Items {
+			// Source of an item is the author's RSS
+			// feed, not an external URL to link out to.
+			item.ExternalUrl = ""
+
+			for _, enclosure := range enclosures[i] {
+				size, _ := strconv.ParseInt(enclosure.Length, 10, 32)
+				item.Attachments = append(item.Attachments, feeds.JSONAttachment{
+					Url:      enclosure.Url,
+					MIMEType: enclosure.Type,
+					Size:     int32(size),
+				})
+			}
+		}
+		return json.ToJSON()
+
+	default:
+		return feed.ToRss()
+	}
+}
+
+// statusToFeedEnclosures returns a feed enclosure
+// for each media attachment of the given status.
+func (c *Converter) statusToFeedEnclosures(ctx context.Context, s *gtsmodel.Status) []*feeds.Enclosure {
+	if len(s.Attachments) != len(s.AttachmentIDs) {
+		attachments, err := c.state.DB.GetAttachmentsByIDs(ctx, s.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error getting attachments for status %s: %v", s.ID, err)
+		}
+		s.Attachments = attachments
+	}
+
+	enclosures := make([]*feeds.Enclosure, 0, len(s.Attachments))
+	for _, attachment := range s.Attachments {
+		enclosures = append(enclosures, &feeds.Enclosure{
+			Url:    attachment.URL,
+			Length: strconv.Itoa(attachment.File.FileSize),
+			Type:   attachment.File.ContentType,
+		})
+	}
+
+	return enclosures
+}
+
 // trimTo trims the given `in` string to
 // the length `to`, measured in runes.
 //
@@ -264,3 +264,39 @@ func ContentToContentLanguage(
 
 	return contentStr, langTagStr
 }
+
+// isSensitive returns whether the given status should
+// be marked as sensitive, accounting for its author
+// being marked as sensitive by a moderator (in which
+// case all statuses with attachments are sensitive).
+func isSensitive(s *gtsmodel.Status) bool {
+	if *s.Sensitive {
+		return true
+	}
+
+	return s.Account != nil &&
+		s.Account.IsSensitized() &&
+		len(s.AttachmentIDs) != 0
+}
+
+// trendHistoryToAPIHistory converts the given daily
+// usage history of a trending item to its api model.
+func trendHistoryToAPIHistory(history []gtsmodel.TrendHistory) []apimodel.History {
+	apiHistory := make([]apimodel.History, 0, len(history))
+	for _, h := range history {
+		apiHistory = append(apiHistory, apimodel.History{
+			Day:      strconv.FormatInt(h.Day.Unix(), 10),
+			Uses:     strconv.Itoa(h.Uses),
+			Accounts: strconv.Itoa(h.Accounts),
+		})
+	}
+	return apiHistory
+}
+
+// trendApproved returns whether the
+// given trend review is an approval.
+func trendApproved(review *gtsmodel.TrendReview) bool {
+	return review != nil &&
+		review.Approved != nil &&
+		*review.Approved
+}
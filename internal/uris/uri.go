@@ -43,9 +43,12 @@ const (
 	MovesPath        = "moves"         // MovesPath is used to generate the URI for a move
 	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
 	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	UnsubscribePath  = "unsubscribe"   // UnsubscribePath is used to generate the URI for an email unsubscribe link
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
 	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
+
+	Ed25519KeyFragment = "ed25519-key" // Ed25519KeyFragment is the fragment identifying an account's ed25519 assertion key on its actor document
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -75,6 +78,8 @@ type UserURIs struct {
 	FeaturedCollectionURI string
 	// The URI for this user's public key, eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
+	// The URI for this user's ed25519 assertion key, eg., https://example.org/users/example_user#ed25519-key
+	Ed25519PublicKeyURI string
 }
 
 // GenerateURIForFollow returns the AP URI for a new follow -- something like:
@@ -136,6 +141,14 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForEmailUnsubscribe returns a link to unsubscribe from emails of the given type -- something like:
+// https://example.org/unsubscribe?token=490e337c-0162-454f-ac48-4b22bb92a205&type=mention
+func GenerateURIForEmailUnsubscribe(token string, unsubscribeType string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?token=%s&type=%s", protocol, host, UnsubscribePath, token, unsubscribeType)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
@@ -156,6 +169,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 	likedURI := fmt.Sprintf("%s/%s", userURI, LikedPath)
 	collectionURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, FeaturedPath)
 	publicKeyURI := fmt.Sprintf("%s/%s", userURI, PublicKeyPath)
+	ed25519PublicKeyURI := fmt.Sprintf("%s#%s", userURI, Ed25519KeyFragment)
 
 	return &UserURIs{
 		HostURL:     hostURL,
@@ -171,6 +185,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 		LikedURI:              likedURI,
 		FeaturedCollectionURI: collectionURI,
 		PublicKeyURI:          publicKeyURI,
+		Ed25519PublicKeyURI:   ed25519PublicKeyURI,
 	}
 }
 
@@ -21,6 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -46,6 +51,16 @@ const (
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
 	maximumFilterTitleLength      = 200
+	maximumUnicodeEmojiLength     = 32
+	maximumPolicyKeywordLength    = 100
+	maximumPolicyKeywords         = 100
+	maximumPolicyDomains          = 1000
+	maximumPolicyTextLength       = 500
+	maximumWebhookURLLength       = 2048
+	minimumWebhookSecretLength    = 12
+	maximumWebhookSecretLength    = 128
+	maximumInviteUses             = 1000
+	maximumInviteExpiresIn        = 365 * 24 * 60 * 60 // 1 year in seconds
 )
 
 // Password returns a helpful error if the given password
@@ -199,6 +214,49 @@ func EmojiShortcode(shortcode string) error {
 	return nil
 }
 
+// UnicodeEmoji checks whether the given string looks like a single unicode
+// emoji (including any modifiers, variation selectors, and ZWJ sequences),
+// ie., something that can be used as an emoji reaction to a status.
+//
+// This is a loose check based on unicode categories, so it may permit some
+// non-emoji symbols, but it won't permit letters, numbers, or whitespace.
+func UnicodeEmoji(emoji string) error {
+	if emoji == "" {
+		return errors.New("emoji empty")
+	}
+
+	if length := len(emoji); length > maximumUnicodeEmojiLength {
+		return fmt.Errorf("emoji %s did not pass validation, must be at most %d bytes, but provided value was %d bytes", emoji, maximumUnicodeEmojiLength, length)
+	}
+
+	var symbol bool
+	for _, r := range emoji {
+		switch {
+		case unicode.Is(unicode.So, r):
+			// Emoji / other symbol.
+			symbol = true
+
+		case r == '\u200d', // zero width joiner
+			r == '\u20e3',                            // combining keycap
+			r >= '\ufe00' && r <= '\ufe0f',           // variation selectors
+			r >= '\U0001f3fb' && r <= '\U0001f3ff',   // skin tone modifiers
+			r >= '\U000e0020' && r <= '\U000e007f',   // tag sequences (eg., flags)
+			r == '#', r == '*', r >= '0' && r <= '9': // keycap bases
+			// Modifier / joiner / keycap, only
+			// permitted in combination with others.
+
+		default:
+			return fmt.Errorf("emoji %s did not pass validation, must be a single unicode emoji", emoji)
+		}
+	}
+
+	if !symbol && !strings.ContainsRune(emoji, '\u20e3') {
+		return fmt.Errorf("emoji %s did not pass validation, must be a single unicode emoji", emoji)
+	}
+
+	return nil
+}
+
 // EmojiCategory validates the length of the given category string.
 func EmojiCategory(category string) error {
 	if length := len(category); length > maximumEmojiCategoryLength {
@@ -370,6 +428,139 @@ func FilterContexts(contexts []apimodel.FilterContext) error {
 	return nil
 }
 
+// FederationPolicy validates a new or updated federation policy,
+// checking that it has the settings its type requires. Domains
+// should already be normalized to punycode by the caller.
+func FederationPolicy(policy *gtsmodel.FederationPolicy) error {
+	if !slices.Contains(gtsmodel.FederationPolicyTypes, policy.Type) {
+		return fmt.Errorf("federation policy type '%s' was not recognized, valid options are %v", policy.Type, gtsmodel.FederationPolicyTypes)
+	}
+
+	if l := len(policy.Domains); l > maximumPolicyDomains {
+		return fmt.Errorf("federation policy may have no more than %d domains, provided %d", maximumPolicyDomains, l)
+	}
+
+	for _, domain := range policy.Domains {
+		if domain == "" || strings.ContainsAny(domain, "/@:?# \t") {
+			return fmt.Errorf("federation policy domain '%s' is not a valid domain", domain)
+		}
+	}
+
+	if l := len(policy.Keywords); l > maximumPolicyKeywords {
+		return fmt.Errorf("federation policy may have no more than %d keywords, provided %d", maximumPolicyKeywords, l)
+	}
+
+	for _, keyword := range policy.Keywords {
+		if keyword == "" || len([]rune(keyword)) > maximumPolicyKeywordLength {
+			return fmt.Errorf("federation policy keywords must be provided, and must be no more than %d chars", maximumPolicyKeywordLength)
+		}
+	}
+
+	if len([]rune(policy.ContentWarning)) > maximumPolicyTextLength {
+		return fmt.Errorf("federation policy content warning must be no more than %d chars", maximumPolicyTextLength)
+	}
+
+	if len([]rune(policy.Comment)) > maximumPolicyTextLength {
+		return fmt.Errorf("federation policy comment must be no more than %d chars", maximumPolicyTextLength)
+	}
+
+	// Check settings required by type.
+	switch policy.Type {
+	case gtsmodel.FederationPolicyKeywordReject,
+		gtsmodel.FederationPolicyKeywordCW:
+		if len(policy.Keywords) == 0 {
+			return fmt.Errorf("federation policy type '%s' requires at least one keyword", policy.Type)
+		}
+
+	case gtsmodel.FederationPolicyStripMedia,
+		gtsmodel.FederationPolicyForceUnlisted,
+		gtsmodel.FederationPolicyRejectAnnounces:
+		if len(policy.Domains) == 0 {
+			return fmt.Errorf("federation policy type '%s' requires at least one domain", policy.Type)
+		}
+
+	case gtsmodel.FederationPolicyMaxMentions:
+		if policy.MaxMentions < 1 {
+			return fmt.Errorf("federation policy type '%s' requires max mentions of at least 1", policy.Type)
+		}
+
+	case gtsmodel.FederationPolicyRejectNewAccounts:
+		if policy.MinAccountAge < time.Second {
+			return fmt.Errorf("federation policy type '%s' requires a min account age of at least 1 second", policy.Type)
+		}
+	}
+
+	return nil
+}
+
+// SpamSignal validates a new or updated spam signal. Link
+// domains should already be normalized to punycode by the caller.
+func SpamSignal(signal *gtsmodel.SpamSignal) error {
+	if !slices.Contains(gtsmodel.SpamSignalTypes, signal.Type) {
+		return fmt.Errorf("spam signal type '%s' was not recognized, valid options are %v", signal.Type, gtsmodel.SpamSignalTypes)
+	}
+
+	if signal.Value == "" || len([]rune(signal.Value)) > maximumPolicyKeywordLength {
+		return fmt.Errorf("spam signal value must be provided, and must be no more than %d chars", maximumPolicyKeywordLength)
+	}
+
+	if signal.Type == gtsmodel.SpamSignalLinkDomain &&
+		strings.ContainsAny(signal.Value, "/@:?# \t") {
+		return fmt.Errorf("spam signal value '%s' is not a valid domain", signal.Value)
+	}
+
+	if signal.Score == 0 {
+		return errors.New("spam signal score must not be 0")
+	}
+
+	if len([]rune(signal.Comment)) > maximumPolicyTextLength {
+		return fmt.Errorf("spam signal comment must be no more than %d chars", maximumPolicyTextLength)
+	}
+
+	return nil
+}
+
+// Webhook validates the URL, events and secret of a webhook.
+func Webhook(webhook *gtsmodel.Webhook) error {
+	if webhook.URL == "" || len(webhook.URL) > maximumWebhookURLLength {
+		return fmt.Errorf("webhook url must be provided, and must be no more than %d chars", maximumWebhookURLLength)
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook url '%s' is not a valid http(s) url", webhook.URL)
+	}
+
+	if len(webhook.Events) == 0 {
+		return errors.New("webhook must be subscribed to at least one event")
+	}
+
+	for _, event := range webhook.Events {
+		if !slices.Contains(gtsmodel.WebhookEvents, gtsmodel.WebhookEvent(event)) {
+			return fmt.Errorf("webhook event '%s' was not recognized, valid options are %v", event, gtsmodel.WebhookEvents)
+		}
+	}
+
+	if l := len(webhook.Secret); l < minimumWebhookSecretLength || l > maximumWebhookSecretLength {
+		return fmt.Errorf("webhook secret must be between %d and %d chars", minimumWebhookSecretLength, maximumWebhookSecretLength)
+	}
+
+	return nil
+}
+
+// InviteCreate validates the max uses and expiry of a new invite.
+func InviteCreate(form *apimodel.InviteCreateRequest) error {
+	if form.MaxUses < 0 || form.MaxUses > maximumInviteUses {
+		return fmt.Errorf("max_uses must be between 0 (unlimited) and %d", maximumInviteUses)
+	}
+
+	if form.ExpiresIn < 0 || form.ExpiresIn > maximumInviteExpiresIn {
+		return fmt.Errorf("expires_in must be between 0 (never) and %d seconds", maximumInviteExpiresIn)
+	}
+
+	return nil
+}
+
 func FilterAction(action apimodel.FilterAction) error {
 	switch action {
 	case apimodel.FilterActionWarn,
@@ -395,7 +586,15 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 	}
 
 	if !config.GetAccountsRegistrationOpen() {
-		return errors.New("registration is not open for this server")
+		// Sign-ups with an invite are
+		// allowed if invites are enabled.
+		if !config.GetAccountsInvitesEnabled() {
+			return errors.New("registration is not open for this server")
+		}
+
+		if form.Invite == "" {
+			return errors.New("registration on this server is by invite only")
+		}
 	}
 
 	if err := Username(form.Username); err != nil {
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -381,6 +382,61 @@ func (suite *ValidationTestSuite) TestValidateEmojiShortcode() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateUnicodeEmoji() {
+	for _, test := range []struct {
+		emoji string
+		ok    bool
+	}{
+		{emoji: "👍", ok: true},
+		{emoji: "👍🏽", ok: true},
+		{emoji: "❤️", ok: true},
+		{emoji: "🇳🇿", ok: true},
+		{emoji: "🧑‍🚀", ok: true},
+		{emoji: "#️⃣", ok: true},
+		{emoji: "", ok: false},
+		{emoji: "a", ok: false},
+		{emoji: "1", ok: false},
+		{emoji: ":blobcat:", ok: false},
+		{emoji: "👍 ", ok: false},
+		{emoji: "👍👍👍👍👍👍👍👍👍", ok: false},
+	} {
+		err := validate.UnicodeEmoji(test.emoji)
+		ok := err == nil
+		if !suite.Equal(test.ok, ok) {
+			suite.T().Logf("fail on %q", test.emoji)
+		}
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateCreateAccountInviteOnly() {
+	config.SetAccountsRegistrationOpen(false)
+	config.SetAccountsReasonRequired(false)
+	defer config.SetAccountsInvitesEnabled(false)
+
+	form := &apimodel.AccountCreateRequest{
+		Username:  "invited_person",
+		Email:     "invited@example.org",
+		Password:  "very-good-password-123",
+		Agreement: true,
+		Locale:    "en",
+	}
+
+	// Invites off: closed for everyone.
+	config.SetAccountsInvitesEnabled(false)
+	err := validate.CreateAccount(form)
+	suite.EqualError(err, "registration is not open for this server")
+
+	// Invites on: closed without an invite...
+	config.SetAccountsInvitesEnabled(true)
+	err = validate.CreateAccount(form)
+	suite.EqualError(err, "registration on this server is by invite only")
+
+	// ...but open with one.
+	form.Invite = "zorksinvite"
+	err = validate.CreateAccount(form)
+	suite.NoError(err)
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+const (
+	imagePNG            = "image/png"
+	cacheControlNoStore = "no-store"
+)
+
+// captchaGETHandler serves the image
+// for a pending captcha challenge.
+func (m *Module) captchaGETHandler(c *gin.Context) {
+	// No content negotiation here; this is only
+	// ever requested by <img> tags, and browsers
+	// send all kinds of Accept headers for those.
+	img, errWithCode := m.processor.Challenges().CaptchaImage(
+		c.Request.Context(),
+		c.Param(apiutil.IDKey),
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Each captcha is only good once,
+	// so don't let anything cache it.
+	c.Header(cacheControlHeader, cacheControlNoStore)
+	c.Data(http.StatusOK, imagePNG, img)
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// embedGETHandler serves a single status on a minimal
+// page, without header or footer, for embedding in a
+// frame on another website.
+func (m *Module) embedGETHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// We'll need the instance later, and we can also use it
+	// before then to make it easier to return a web error.
+	instance, errWithCode := m.processor.InstanceGetV1(ctx)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	// Parse account targetUsername and status ID from the URL.
+	targetUsername, errWithCode := apiutil.ParseUsername(c.Param(apiutil.UsernameKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	targetStatusID, errWithCode := apiutil.ParseWebStatusID(c.Param(apiutil.WebStatusIDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Normalize requested username + status ID,
+	// see threadGETHandler for more info on this.
+	targetUsername = strings.ToLower(targetUsername)
+	targetStatusID = strings.ToUpper(targetStatusID)
+
+	// Get the status itself from the processor, if it can be embedded.
+	status, errWithCode := m.processor.Status().EmbedGet(ctx, targetStatusID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Ensure status actually belongs to target account.
+	if status.Account.Username != targetUsername {
+		err := fmt.Errorf("target account %s does not own status %s", targetUsername, targetStatusID)
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotFound(err), instanceGet)
+		return
+	}
+
+	// Basic status stylesheets, then user-selected
+	// theme if set, and custom CSS for this user
+	// last in cascade, as on the thread page.
+	stylesheets := []string{cssFA, cssStatus, cssThread, cssEmbed}
+	if theme := status.Account.Theme; theme != "" {
+		stylesheets = append(stylesheets, themesPathPrefix+"/"+theme)
+	}
+	stylesheets = append(stylesheets, "/@"+status.Account.Username+"/custom.css")
+
+	page := apiutil.WebPage{
+		Template:    "status_embed.tmpl",
+		Instance:    instance,
+		OGMeta:      apiutil.OGBase(instance).WithStatus(status),
+		Stylesheets: stylesheets,
+		Javascript:  []string{jsFrontend},
+		Extra: map[string]any{
+			"status": status,
+		},
+	}
+
+	apiutil.TemplateEmbedPage(c, page)
+}
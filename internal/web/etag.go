@@ -18,12 +18,18 @@
 package web
 
 import (
+	"bytes"
 	// nolint:gosec
 	"crypto/sha1"
 	"encoding/hex"
+	"encoding/json"
 	"io"
+	"net/http"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 
 	"codeberg.org/gruf/go-cache/v3"
@@ -58,3 +64,51 @@ func generateEtag(r io.Reader) (string, error) {
 
 	return `"` + hex.EncodeToString(b) + `"`, nil
 }
+
+// pageNotModified sets 'ETag' and 'Cache-Control'
+// headers for the given web page, and returns true
+// if the caller has already seen this version of
+// the page, in which case it responds with 304 and
+// the page should not be rendered.
+//
+// Since a page is rendered entirely from its
+// WebPage model and the reader's locale, the
+// ETag is generated from those. Private pages,
+// ie., those that may differ per requester,
+// may not be stored by shared caches.
+func pageNotModified(c *gin.Context, page apiutil.WebPage, private bool) (bool, error) {
+	b, err := json.Marshal(page)
+	if err != nil {
+		return false, err
+	}
+
+	// Pages are rendered in the reader's preferred
+	// language, so include that in the ETag.
+	locale := i18n.MatchAcceptLanguage(c.GetHeader("Accept-Language"))
+	b = append(b, locale...)
+
+	eTag, err := generateEtag(bytes.NewReader(b))
+	if err != nil {
+		return false, err
+	}
+
+	cacheControl := cacheControlNoCache
+	if private {
+		cacheControl += ", private"
+	}
+
+	// Require callers to validate the
+	// page with us before each reuse.
+	c.Header(eTagHeader, eTag)
+	c.Header(cacheControlHeader, cacheControl)
+
+	if c.Request.Header.Get(ifNoneMatchHeader) == eTag {
+		// Set the same Vary header
+		// as a fully rendered page.
+		c.Header("Vary", "Accept-Language")
+		c.AbortWithStatus(http.StatusNotModified)
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,302 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// feedContentTypes maps each feed format to the
+// content-types it may be negotiated and served as.
+// The first content-type is the one served with.
+var feedContentTypes = map[typeutils.FeedFormat][]string{
+	typeutils.FeedFormatRSS:  {apiutil.AppRSSXML},
+	typeutils.FeedFormatAtom: {apiutil.AppAtomXML},
+	typeutils.FeedFormatJSON: {apiutil.AppFeedJSON, apiutil.AppJSON},
+}
+
+// accountFeedGETHandler returns a handler
+// serving a local account's public posts
+// as a feed in the given format.
+func (m *Module) accountFeedGETHandler(format typeutils.FeedFormat) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := apiutil.NegotiateAccept(c, feedContentTypes[format]...); err != nil {
+			apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		// Fetch + normalize username from URL.
+		username, errWithCode := apiutil.ParseUsername(c.Param(apiutil.UsernameKey))
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Usernames on our instance will always be lowercase.
+		//
+		// todo: https://github.com/superseriousbusiness/gotosocial/issues/1813
+		username = strings.ToLower(username)
+
+		// Retrieve the getFeed function from the processor.
+		// We'll only call the function if we need to, to save db calls.
+		// lastPostAt may be a zero time if account has never posted.
+		getFeed, lastPostAt, errWithCode := m.processor.Account().GetRSSFeedForUsername(c.Request.Context(), username, format)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		m.serveFeed(c, format, getFeed, lastPostAt, false)
+	}
+}
+
+// tagFeedGETHandler returns a handler
+// serving public posts with a tag as
+// a feed in the given format.
+func (m *Module) tagFeedGETHandler(format typeutils.FeedFormat) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := apiutil.NegotiateAccept(c, feedContentTypes[format]...); err != nil {
+			apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		tagName, errWithCode := apiutil.ParseTagName(c.Param(apiutil.TagNameKey))
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		getFeed, lastPostAt, errWithCode := m.processor.Timeline().TagFeedGet(c.Request.Context(), tagName, format)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		m.serveFeed(c, format, getFeed, lastPostAt, false)
+	}
+}
+
+// listFeedGETHandler returns a handler serving
+// the posts in a local user's list as a feed in
+// the given format. The user's private feed token
+// must be given in the 'token' query parameter.
+func (m *Module) listFeedGETHandler(format typeutils.FeedFormat) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := apiutil.NegotiateAccept(c, feedContentTypes[format]...); err != nil {
+			apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		username, errWithCode := apiutil.ParseUsername(c.Param(apiutil.UsernameKey))
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+		username = strings.ToLower(username)
+
+		listID, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		token := c.Query(apiutil.FeedTokenKey)
+
+		getFeed, lastPostAt, errWithCode := m.processor.Timeline().ListFeedGet(c.Request.Context(), username, listID, token, format)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// List feeds may contain non-public posts.
+		m.serveFeed(c, format, getFeed, lastPostAt, true)
+	}
+}
+
+// serveFeed serves the feed returned by getFeed in
+// the given format, using ETag and Last-Modified
+// headers to let callers avoid refetching a feed
+// they've already seen. lastPostAt is the time of
+// the newest post in the feed, and may be zero.
+//
+// Private feeds are accessed with a token in the
+// query, and may not be stored by shared caches.
+func (m *Module) serveFeed(
+	c *gin.Context,
+	format typeutils.FeedFormat,
+	getFeed func() (string, gtserror.WithCode),
+	lastPostAt time.Time,
+	private bool,
+) {
+	var (
+		feed         string // Stringified feed.
+		errWithCode  gtserror.WithCode
+		cacheKey     = c.Request.URL.Path
+		cacheControl = cacheControlNoCache
+	)
+
+	if private {
+		cacheKey = c.Request.URL.RequestURI()
+		cacheControl += ", private"
+	}
+
+	cacheEntry, wasCached := m.eTagCache.Get(cacheKey)
+
+	if !wasCached || unixAfter(lastPostAt, cacheEntry.lastModified) {
+		// We either have no ETag cache entry for this feed,
+		// or we have an expired cache entry (something has
+		// been posted since the entry was last generated).
+		//
+		// As such, we need to generate a new ETag, and for that we need
+		// the string representation of the feed.
+		feed, errWithCode = getFeed()
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		eTag, err := generateEtag(bytes.NewBufferString(feed))
+		if err != nil {
+			apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+			return
+		}
+
+		// We never want lastModified to be zero, so if
+		// nothing has been posted to the feed yet, just
+		// use Now as the lastModified time instead for
+		// cache control.
+		var lastModified time.Time
+		if lastPostAt.IsZero() {
+			lastModified = time.Now()
+		} else {
+			lastModified = lastPostAt
+		}
+
+		// Store the new cache entry.
+		cacheEntry = eTagCacheEntry{
+			eTag:         eTag,
+			lastModified: lastModified,
+		}
+		m.eTagCache.Set(cacheKey, cacheEntry)
+	}
+
+	// Set 'ETag' and 'Last-Modified' headers no matter what;
+	// even if we return 304 in the next checks, caller may
+	// want to cache these header values.
+	c.Header(eTagHeader, cacheEntry.eTag)
+	c.Header(lastModifiedHeader, cacheEntry.lastModified.Format(http.TimeFormat))
+
+	// Instruct caller to validate the response with us before
+	// each reuse, so that the 'ETag' and 'Last-Modified' headers
+	// actually take effect.
+	//
+	// "The no-cache response directive indicates that the response
+	// can be stored in caches, but the response must be validated
+	// with the origin server before each reuse, even when the cache
+	// is disconnected from the origin server."
+	//
+	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control
+	c.Header(cacheControlHeader, cacheControl)
+
+	// Check if caller submitted an ETag via 'If-None-Match'.
+	// If they did + it matches what we have, that means they've
+	// already seen the latest version of this feed, so just bail.
+	ifNoneMatch := c.Request.Header.Get(ifNoneMatchHeader)
+	if ifNoneMatch == cacheEntry.eTag {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	// Check if the caller submitted a time via 'If-Modified-Since'.
+	// If they did, and our cached ETag entry is not newer than the
+	// given time, this means the caller has already seen the latest
+	// version of this feed, so just bail.
+	ifModifiedSince := extractIfModifiedSince(c.Request)
+	if !ifModifiedSince.IsZero() &&
+		!unixAfter(cacheEntry.lastModified, ifModifiedSince) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	// At this point we know that the client wants the newest
+	// representation of the feed, either because they didn't
+	// submit any 'If-None-Match' / 'If-Modified-Since' cache headers,
+	// or because they did but something has been posted more recently
+	// than the values of the submitted headers would suggest.
+	//
+	// If we had a cache hit earlier, we may not have called the
+	// getFeed function yet; if that's the case then do call it
+	// now because we definitely need it.
+	if feed == "" {
+		feed, errWithCode = getFeed()
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+	}
+
+	contentType := feedContentTypes[format][0] + "; charset=utf-8"
+	c.Data(http.StatusOK, contentType, []byte(feed))
+}
+
+// unixAfter returns true if the unix value of t1
+// is greater than (ie., after) the unix value of t2.
+func unixAfter(t1 time.Time, t2 time.Time) bool {
+	if t1.IsZero() {
+		// if t1 is zero then it cannot
+		// possibly be greater than t2.
+		return false
+	}
+
+	if t2.IsZero() {
+		// t1 is not zero but t2 is,
+		// so t1 is necessarily greater.
+		return true
+	}
+
+	return t1.Unix() > t2.Unix()
+}
+
+// extractIfModifiedSince parses a time.Time from the
+// 'If-Modified-Since' header of the given request.
+//
+// If no time was provided, or the provided time was
+// not parseable, it will return a zero time.
+func extractIfModifiedSince(r *http.Request) time.Time {
+	imsStr := r.Header.Get(ifModifiedSinceHeader)
+	if imsStr == "" {
+		return time.Time{} // Nothing set.
+	}
+
+	ifModifiedSince, err := http.ParseTime(imsStr)
+	if err != nil {
+		log.Errorf(r.Context(), "couldn't parse %s value '%s' as time: %q", ifModifiedSinceHeader, imsStr, err)
+		return time.Time{}
+	}
+
+	return ifModifiedSince
+}
@@ -60,7 +60,10 @@ func (m *Module) indexHandler(c *gin.Context) {
 		Instance:    instance,
 		OGMeta:      apiutil.OGBase(instance),
 		Stylesheets: []string{cssAbout, cssIndex},
-		Extra:       map[string]any{"showStrap": true},
+		Extra: map[string]any{
+			"showStrap":      true,
+			"publicTimeline": config.GetInstanceExposePublicTimeline(),
+		},
 	}
 
 	apiutil.TemplateWebPage(c, page)
@@ -31,7 +31,26 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-func (m *Module) profileGETHandler(c *gin.Context) {
+// profileTab is a tab of
+// statuses on a web profile.
+type profileTab string
+
+const (
+	profileTabPosts       profileTab = ""             // Top-level posts.
+	profileTabWithReplies profileTab = "with_replies" // Posts and replies.
+	profileTabMedia       profileTab = "media"        // Posts with media, as a gallery.
+)
+
+// profileGETHandler returns a handler serving
+// the web view of a local account's profile,
+// showing the statuses of the given tab.
+func (m *Module) profileGETHandler(tab profileTab) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m.profileGET(c, tab)
+	}
+}
+
+func (m *Module) profileGET(c *gin.Context, tab profileTab) {
 	ctx := c.Request.Context()
 
 	// We'll need the instance later, and we can also use it
@@ -65,7 +84,13 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 
 	// Check what type of content is being requested. If we're getting an AP
 	// request on this endpoint we should render the AP representation instead.
-	accept, err := apiutil.NegotiateAccept(c, apiutil.HTMLOrActivityPubHeaders...)
+	// Only the bare profile has an AP representation, not its other tabs.
+	offers := apiutil.HTMLOrActivityPubHeaders
+	if tab != profileTabPosts {
+		offers = []string{apiutil.TextHTML}
+	}
+
+	accept, err := apiutil.NegotiateAccept(c, offers...)
 	if err != nil {
 		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
 		return
@@ -102,10 +127,13 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Only generate RSS link if account has RSS enabled.
-	var rssFeed string
+	// Only generate feed links if account has RSS enabled.
+	// This is the path without extension; the feed is
+	// served as RSS, Atom, and JSON Feed at .rss, .atom
+	// and .json respectively.
+	var feed string
 	if targetAccount.EnableRSS {
-		rssFeed = "/@" + targetAccount.Username + "/feed.rss"
+		feed = "/@" + targetAccount.Username + "/feed"
 	}
 
 	// Only allow search engines / robots to
@@ -123,7 +151,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		pinnedStatuses []*apimodel.Status
 	)
 
-	if !paging {
+	if !paging && tab == profileTabPosts {
 		// Client opened bare profile (from the top)
 		// so load + display pinned statuses.
 		pinnedStatuses, errWithCode = m.processor.Account().WebStatusesGetPinned(ctx, targetAccount.ID)
@@ -134,12 +162,23 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 	}
 
 	// Get statuses from maxStatusID onwards (or from top if empty string).
-	statusResp, errWithCode := m.processor.Account().WebStatusesGet(ctx, targetAccount.ID, maxStatusID)
+	statusResp, errWithCode := m.processor.Account().WebStatusesGet(ctx,
+		targetAccount.ID,
+		tab != profileTabPosts, // with replies
+		tab == profileTabMedia, // media only
+		maxStatusID,
+	)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 		return
 	}
 
+	// Path of the first page of this tab.
+	top := "/@" + targetAccount.Username
+	if tab != profileTabPosts {
+		top += "/" + string(tab)
+	}
+
 	// Prepare stylesheets for profile.
 	stylesheets := make([]string, 0, 6)
 
@@ -176,15 +215,27 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		Javascript:  []string{jsFrontend},
 		Extra: map[string]any{
 			"account":          targetAccount,
-			"rssFeed":          rssFeed,
+			"feed":             feed,
 			"robotsMeta":       robotsMeta,
+			"tab":              string(tab),
 			"statuses":         statusResp.Items,
 			"statuses_next":    statusResp.NextLink,
 			"pinned_statuses":  pinnedStatuses,
 			"show_back_to_top": paging,
+			"top":              top,
 		},
 	}
 
+	// Profiles may differ depending on
+	// whether the requester is authed.
+	notModified, err := pageNotModified(c, page, authed.Account != nil)
+	if err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return
+	} else if notModified {
+		return
+	}
+
 	apiutil.TemplateWebPage(c, page)
 }
 
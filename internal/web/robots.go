@@ -87,6 +87,7 @@ Disallow: /check_your_email
 Disallow: /wait_for_approval
 Disallow: /account_disabled
 Disallow: /signup
+Disallow: /challenge/
 
 # Well-known endpoints.
 Disallow: /.well-known/
@@ -27,9 +27,22 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/challenges"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
+const (
+	// inviteKey is the query key, and the
+	// form key, of an invite to sign up with.
+	inviteKey = "invite"
+
+	// challengeIDKey and challengeAnswerKey are the form
+	// keys of the challenge to solve before signing up.
+	challengeIDKey     = "challenge_id"
+	challengeAnswerKey = "challenge_answer"
+)
+
 func (m *Module) signupGETHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -53,13 +66,41 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If an invite code was given, check it
+	// can be used before showing the form.
+	var invite *apimodel.Invite
+	if code := c.Query(inviteKey); code != "" {
+		invite, errWithCode = m.processor.User().SignupInviteGet(ctx, code)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+	}
+
+	// Get a challenge to solve,
+	// if sign-ups need one.
+	challenge, errWithCode := m.processor.Challenges().Issue(ctx, challenges.FormSignUp, c.ClientIP())
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	var javascript []string
+	if challenge != nil {
+		javascript = []string{jsChallenge}
+	}
+
 	page := apiutil.WebPage{
-		Template: "sign-up.tmpl",
-		Instance: instance,
-		OGMeta:   apiutil.OGBase(instance),
+		Template:   "sign-up.tmpl",
+		Instance:   instance,
+		OGMeta:     apiutil.OGBase(instance),
+		Javascript: javascript,
 		Extra: map[string]any{
 			"reasonRequired":   config.GetAccountsReasonRequired(),
 			"registrationOpen": config.GetAccountsRegistrationOpen(),
+			"invitesEnabled":   config.GetAccountsInvitesEnabled(),
+			"invite":           invite,
+			"challenge":        challenge,
 		},
 	}
 
@@ -89,6 +130,20 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Check the challenge first, no point
+	// doing anything else if it's not solved.
+	clientIP := c.ClientIP()
+	if errWithCode := m.processor.Challenges().Check(
+		ctx,
+		challenges.FormSignUp,
+		clientIP,
+		c.PostForm(challengeIDKey),
+		c.PostForm(challengeAnswerKey),
+	); errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
 	form := &apimodel.AccountCreateRequest{}
 	if err := c.ShouldBind(form); err != nil {
 		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), instanceGet)
@@ -100,7 +155,6 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	clientIP := c.ClientIP()
 	signUpIP := net.ParseIP(clientIP)
 	if signUpIP == nil {
 		err := errors.New("ip address could not be parsed from request")
@@ -132,6 +186,7 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		Extra: map[string]any{
 			"email":    user.UnconfirmedEmail,
 			"username": user.Account.Username,
+			"approved": util.PtrValueOr(user.Approved, false),
 		},
 	}
 
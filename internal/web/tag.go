@@ -23,6 +23,7 @@ import (
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
 
@@ -60,7 +61,45 @@ func (m *Module) tagGETHandler(c *gin.Context) {
 		Instance:    instance,
 		OGMeta:      apiutil.OGBase(instance),
 		Stylesheets: []string{cssFA, cssThread, cssTag},
-		Extra:       map[string]any{"tagName": tagName},
+		Extra: map[string]any{
+			"tagName": tagName,
+		},
+	}
+
+	// Tag timelines and feeds are only
+	// served if the public timeline is exposed.
+	if !config.GetInstanceExposePublicTimeline() {
+		apiutil.TemplateWebPage(c, page)
+		return
+	}
+
+	var (
+		maxStatusID = apiutil.ParseMaxID(c.Query(apiutil.MaxIDKey), "")
+		paging      = maxStatusID != ""
+		top         = "/tags/" + tagName
+	)
+
+	statusResp, errWithCode := m.processor.Timeline().WebTagTimelineGet(ctx, tagName, maxStatusID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	page.Stylesheets = []string{cssFA, cssStatus, cssThread, cssTag, cssTimeline}
+	page.Javascript = []string{jsFrontend}
+	page.Extra["feed"] = top + "/feed"
+	page.Extra["exposed"] = true
+	page.Extra["statuses"] = statusResp.Items
+	page.Extra["statuses_next"] = statusResp.NextLink
+	page.Extra["show_back_to_top"] = paging
+	page.Extra["top"] = top
+
+	notModified, err := pageNotModified(c, page, false)
+	if err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return
+	} else if notModified {
+		return
 	}
 
 	apiutil.TemplateWebPage(c, page)
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -165,15 +166,25 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		"/@"+targetAccount.Username+"/custom.css",
 	)
 
+	// Offer a snippet for embedding the status on
+	// other websites, and advertise oEmbed for it,
+	// but only if the status can be embedded.
+	ogMeta := apiutil.OGBase(instance).WithStatus(status)
+	embed := m.processor.Status().EmbedHTML(ctx, targetStatusID)
+	if embed != "" {
+		ogMeta.OEmbed = instance.URI + "/api/oembed?url=" + url.QueryEscape(status.URL)
+	}
+
 	page := apiutil.WebPage{
 		Template:    "thread.tmpl",
 		Instance:    instance,
-		OGMeta:      apiutil.OGBase(instance).WithStatus(status),
+		OGMeta:      ogMeta,
 		Stylesheets: stylesheets,
 		Javascript:  []string{jsFrontend},
 		Extra: map[string]any{
 			"status":  status,
 			"context": context,
+			"embed":   embed,
 		},
 	}
 
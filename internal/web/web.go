@@ -32,16 +32,23 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 const (
 	confirmEmailPath   = "/" + uris.ConfirmEmailPath
+	unsubscribePath    = "/" + uris.UnsubscribePath
 	profileGroupPath   = "/@:username"
+	withRepliesPath    = "/" + string(profileTabWithReplies)    // served within the profile group, like statusPath
+	mediaPath          = "/" + string(profileTabMedia)          // served within the profile group, like statusPath
 	statusPath         = "/statuses/:" + apiutil.WebStatusIDKey // leave out the '/@:username' prefix as this will be served within the profile group
+	statusEmbedPath    = statusPath + "/embed"
 	tagsPath           = "/tags/:" + apiutil.TagNameKey
 	customCSSPath      = profileGroupPath + "/custom.css"
-	rssFeedPath        = profileGroupPath + "/feed.rss"
+	feedPath           = profileGroupPath + "/feed"
+	tagFeedPath        = tagsPath + "/feed"
+	listFeedPath       = profileGroupPath + "/lists/:" + apiutil.IDKey + "/feed"
 	assetsPathPrefix   = "/assets"
 	distPathPrefix     = assetsPathPrefix + "/dist"
 	themesPathPrefix   = assetsPathPrefix + "/themes"
@@ -50,6 +57,7 @@ const (
 	userPanelPath      = settingsPathPrefix + "/user"
 	adminPanelPath     = settingsPathPrefix + "/admin"
 	signupPath         = "/signup"
+	captchaPath        = "/challenge/captcha/:" + apiutil.IDKey
 
 	cacheControlHeader    = "Cache-Control"     // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control
 	cacheControlNoCache   = "no-cache"          // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control#response_directives
@@ -66,9 +74,12 @@ const (
 	cssProfile  = distPathPrefix + "/profile.css"
 	cssSettings = distPathPrefix + "/settings-style.css"
 	cssTag      = distPathPrefix + "/tag.css"
+	cssEmbed    = distPathPrefix + "/embed.css"
+	cssTimeline = distPathPrefix + "/timeline.css"
 
-	jsFrontend = distPathPrefix + "/frontend.js" // Progressive enhancement frontend JS.
-	jsSettings = distPathPrefix + "/settings.js" // Settings panel React application.
+	jsFrontend  = distPathPrefix + "/frontend.js"  // Progressive enhancement frontend JS.
+	jsSettings  = distPathPrefix + "/settings.js"  // Settings panel React application.
+	jsChallenge = distPathPrefix + "/challenge.js" // Proof-of-work challenge solver.
 )
 
 type Module struct {
@@ -106,23 +117,39 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	profileGroup.Use(middleware.SignatureCheck(m.isURIBlocked), middleware.CacheControl(middleware.CacheControlConfig{
 		Directives: []string{"no-store"},
 	}))
-	profileGroup.Handle(http.MethodGet, "", m.profileGETHandler) // use empty path here since it's the base of the group
+	profileGroup.Handle(http.MethodGet, "", m.profileGETHandler(profileTabPosts)) // use empty path here since it's the base of the group
+	profileGroup.Handle(http.MethodGet, withRepliesPath, m.profileGETHandler(profileTabWithReplies))
+	profileGroup.Handle(http.MethodGet, mediaPath, m.profileGETHandler(profileTabMedia))
 	profileGroup.Handle(http.MethodGet, statusPath, m.threadGETHandler)
+	profileGroup.Handle(http.MethodGet, statusEmbedPath, m.embedGETHandler)
 
 	// Attach individual web handlers which require no specific middlewares
 	r.AttachHandler(http.MethodGet, "/", m.indexHandler) // front-page
 	r.AttachHandler(http.MethodGet, settingsPathPrefix, m.SettingsPanelHandler)
 	r.AttachHandler(http.MethodGet, settingsPanelGlob, m.SettingsPanelHandler)
 	r.AttachHandler(http.MethodGet, customCSSPath, m.customCSSGETHandler)
-	r.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
+	for _, format := range []typeutils.FeedFormat{
+		typeutils.FeedFormatRSS,
+		typeutils.FeedFormatAtom,
+		typeutils.FeedFormatJSON,
+	} {
+		ext := "." + format.String()
+		r.AttachHandler(http.MethodGet, feedPath+ext, m.accountFeedGETHandler(format))
+		r.AttachHandler(http.MethodGet, tagFeedPath+ext, m.tagFeedGETHandler(format))
+		r.AttachHandler(http.MethodGet, listFeedPath+ext, m.listFeedGETHandler(format))
+	}
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 	r.AttachHandler(http.MethodPost, confirmEmailPath, m.confirmEmailPOSTHandler)
+	r.AttachHandler(http.MethodGet, unsubscribePath, m.unsubscribeGETHandler)
+	r.AttachHandler(http.MethodPost, unsubscribePath, m.unsubscribePOSTHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
 	r.AttachHandler(http.MethodGet, aboutPath, m.aboutGETHandler)
 	r.AttachHandler(http.MethodGet, domainBlockListPath, m.domainBlockListGETHandler)
 	r.AttachHandler(http.MethodGet, tagsPath, m.tagGETHandler)
+	r.AttachHandler(http.MethodGet, publicPath, m.publicGETHandler)
 	r.AttachHandler(http.MethodGet, signupPath, m.signupGETHandler)
 	r.AttachHandler(http.MethodPost, signupPath, m.signupPOSTHandler)
+	r.AttachHandler(http.MethodGet, captchaPath, m.captchaGETHandler)
 
 	// Attach redirects from old endpoints to current ones for backwards compatibility
 	r.AttachHandler(http.MethodGet, "/auth/edit", func(c *gin.Context) { c.Redirect(http.StatusMovedPermanently, userPanelPath) })
@@ -6,7 +6,18 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-challenge-cooldown": 900000000000,
+    "accounts-challenge-cooldown-attempts": 5,
+    "accounts-challenge-pow-difficulty": 17,
+    "accounts-challenge-sign-in": "",
+    "accounts-challenge-sign-up": "pow",
     "accounts-custom-css-length": 5000,
+    "accounts-dormant-disable": true,
+    "accounts-dormant-grace-period": 2592000000000000,
+    "accounts-dormant-months": 12,
+    "accounts-invites-approved": false,
+    "accounts-invites-enabled": true,
+    "accounts-invites-mod-only": true,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
@@ -54,12 +65,15 @@ EXPECT=$(cat << "EOF"
         "poll-mem-ratio": 1,
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
+        "preview-card-mem-ratio": 1,
         "report-mem-ratio": 1,
         "status-bookmark-ids-mem-ratio": 2,
         "status-bookmark-mem-ratio": 0.5,
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
+        "status-reaction-ids-mem-ratio": 2,
+        "status-reaction-mem-ratio": 1,
         "tag-mem-ratio": 2,
         "thread-mute-mem-ratio": 0.2,
         "token-mem-ratio": 0.75,
@@ -98,13 +112,18 @@ EXPECT=$(cat << "EOF"
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
+    "instance-federation-backfill": 40,
     "instance-federation-mode": "allowlist",
     "instance-federation-spam-filter": true,
+    "instance-federation-spam-quarantine-score": 50,
+    "instance-federation-spam-reject-score": 100,
     "instance-inject-mastodon-version": true,
     "instance-languages": [
         "nl",
         "en-GB"
     ],
+    "instance-trends-enabled": false,
+    "instance-trends-review-required": false,
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -152,8 +171,12 @@ EXPECT=$(cat << "EOF"
     "remote-only": false,
     "request-id-header": "X-Trace-Id",
     "smtp-disclose-recipients": true,
+    "smtp-dkim-domain": "terfisland.org",
+    "smtp-dkim-private-key-path": "/root/dkim.pem",
+    "smtp-dkim-selector": "gts",
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
+    "smtp-maildir": "/root/maildir",
     "smtp-password": "hunter2",
     "smtp-port": 4269,
     "smtp-username": "sex-haver",
@@ -185,6 +208,7 @@ EXPECT=$(cat << "EOF"
     ],
     "username": "",
     "web-asset-base-dir": "/root",
+    "web-locale-base-dir": "/root",
     "web-template-base-dir": "/root"
 }
 EOF
@@ -219,19 +243,27 @@ GTS_TLS_MODE='' \
 GTS_DB_TLS_CA_CERT='' \
 GTS_WEB_TEMPLATE_BASE_DIR='/root' \
 GTS_WEB_ASSET_BASE_DIR='/root' \
+GTS_WEB_LOCALE_BASE_DIR='/root' \
 GTS_INSTANCE_EXPOSE_PEERS=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
+GTS_INSTANCE_FEDERATION_BACKFILL=40 \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
+GTS_INSTANCE_TRENDS_ENABLED=false \
+GTS_INSTANCE_TRENDS_REVIEW_REQUIRED=false \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
+GTS_ACCOUNTS_INVITES_ENABLED=true \
+GTS_ACCOUNTS_CHALLENGE_SIGN_UP=pow \
+GTS_ACCOUNTS_DORMANT_MONTHS=12 \
+GTS_ACCOUNTS_DORMANT_DISABLE=true \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
@@ -274,6 +306,10 @@ GTS_SMTP_USERNAME='sex-haver' \
 GTS_SMTP_PASSWORD='hunter2' \
 GTS_SMTP_FROM='queen.rip.in.piss@terfisland.org' \
 GTS_SMTP_DISCLOSE_RECIPIENTS=true \
+GTS_SMTP_DKIM_DOMAIN='terfisland.org' \
+GTS_SMTP_DKIM_SELECTOR='gts' \
+GTS_SMTP_DKIM_PRIVATE_KEY_PATH='/root/dkim.pem' \
+GTS_SMTP_MAILDIR='/root/maildir' \
 GTS_SYSLOG_ENABLED=true \
 GTS_SYSLOG_PROTOCOL='udp' \
 GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
@@ -65,13 +65,17 @@ func testDefaults() config.Configuration {
 
 		WebTemplateBaseDir: "./web/template/",
 		WebAssetBaseDir:    "./web/assets/",
-
-		InstanceFederationMode:         config.InstanceFederationModeDefault,
-		InstanceFederationSpamFilter:   true,
-		InstanceExposePeers:            true,
-		InstanceExposeSuspended:        true,
-		InstanceExposeSuspendedWeb:     true,
-		InstanceDeliverToSharedInboxes: true,
+		WebLocaleBaseDir:   "./web/locale/",
+
+		InstanceFederationMode:                config.InstanceFederationModeDefault,
+		InstanceFederationSpamFilter:          true,
+		InstanceFederationSpamQuarantineScore: 50,
+		InstanceFederationSpamRejectScore:     100,
+		InstanceFederationBackfill:            0,
+		InstanceExposePeers:                   true,
+		InstanceExposeSuspended:               true,
+		InstanceExposeSuspendedWeb:            true,
+		InstanceDeliverToSharedInboxes:        true,
 		InstanceLanguages: language.Languages{
 			{
 				TagStr: "nl",
@@ -80,12 +84,24 @@ func testDefaults() config.Configuration {
 				TagStr: "en-gb",
 			},
 		},
+		InstanceTrendsEnabled:        true,
+		InstanceTrendsReviewRequired: true,
 
 		AccountsRegistrationOpen: true,
 		AccountsReasonRequired:   true,
 		AccountsAllowCustomCSS:   true,
 		AccountsCustomCSSLength:  10000,
 
+		AccountsChallengeSignUp:           "",
+		AccountsChallengeSignIn:           "",
+		AccountsChallengePoWDifficulty:    8,
+		AccountsChallengeCooldownAttempts: 5,
+		AccountsChallengeCooldown:         15 * time.Minute,
+
+		AccountsDormantMonths:      0,
+		AccountsDormantDisable:     false,
+		AccountsDormantGracePeriod: 30 * 24 * time.Hour,
+
 		MediaImageMaxSize:        10485760, // 10MiB
 		MediaVideoMaxSize:        41943040, // 40MiB
 		MediaDescriptionMinChars: 0,
@@ -129,6 +145,10 @@ func testDefaults() config.Configuration {
 		SMTPPassword:           "",
 		SMTPFrom:               "GoToSocial",
 		SMTPDiscloseRecipients: false,
+		SMTPDKIMDomain:         "",
+		SMTPDKIMSelector:       "",
+		SMTPDKIMPrivateKeyPath: "",
+		SMTPMaildir:            "",
 
 		TracingEnabled:           false,
 		TracingEndpoint:          "localhost:4317",
@@ -46,15 +46,24 @@ var testModels = []interface{}{
 	&gtsmodel.Mention{},
 	&gtsmodel.Poll{},
 	&gtsmodel.PollVote{},
+	&gtsmodel.QueuedEmail{},
 	&gtsmodel.Status{},
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
 	&gtsmodel.ThreadToStatus{},
+	&gtsmodel.TrendReview{},
+	&gtsmodel.PreviewCard{},
+	&gtsmodel.FederationPolicy{},
+	&gtsmodel.QuarantinedStatus{},
+	&gtsmodel.SpamSignal{},
+	&gtsmodel.Webhook{},
+	&gtsmodel.Invite{},
 	&gtsmodel.User{},
 	&gtsmodel.UserMute{},
 	&gtsmodel.Emoji{},
@@ -157,6 +166,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestInvites() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestDomainBlocks() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
@@ -20,7 +20,6 @@ package testrig
 import (
 	"context"
 	"os"
-	"path/filepath"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -59,5 +58,8 @@ func NewTestRouter(db db.DB) *router.Router {
 
 // ConfigureTemplatesWithGin will panic on any errors related to template loading during tests
 func ConfigureTemplatesWithGin(engine *gin.Engine, templatePath string) {
-	engine.LoadHTMLGlob(filepath.Join(templatePath, "*"))
+	config.SetWebTemplateBaseDir(templatePath)
+	if err := router.LoadTemplates(engine); err != nil {
+		panic(err)
+	}
 }
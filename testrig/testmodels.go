@@ -175,98 +175,122 @@ func NewTestApplications() map[string]*gtsmodel.Application {
 func NewTestUsers() map[string]*gtsmodel.User {
 	users := map[string]*gtsmodel.User{
 		"unconfirmed_account": {
-			ID:                     "01F8MGYG9E893WRHW0TAEXR8GJ",
-			Email:                  "",
-			AccountID:              "01F8MH0BBE4FHXPH513MBVFHB0",
-			EncryptedPassword:      "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
-			CreatedAt:              TimeMustParse("2022-06-04T13:12:00Z"),
-			SignUpIP:               net.ParseIP("199.222.111.89"),
-			UpdatedAt:              time.Time{},
-			InviteID:               "",
-			Reason:                 "hi, please let me in! I'm looking for somewhere neato bombeato to hang out.",
-			Locale:                 "en",
-			CreatedByApplicationID: "01F8MGY43H3N2C8EWPR2FPYEXG",
-			LastEmailedAt:          time.Time{},
-			ConfirmationToken:      "a5a280bd-34be-44a3-8330-a57eaf61b8dd",
-			ConfirmedAt:            time.Time{},
-			ConfirmationSentAt:     TimeMustParse("2022-06-04T13:12:00Z"),
-			UnconfirmedEmail:       "weed_lord420@example.org",
-			Moderator:              util.Ptr(false),
-			Admin:                  util.Ptr(false),
-			Disabled:               util.Ptr(false),
-			Approved:               util.Ptr(false),
-			ResetPasswordToken:     "",
-			ResetPasswordSentAt:    time.Time{},
+			ID:                       "01F8MGYG9E893WRHW0TAEXR8GJ",
+			Email:                    "",
+			AccountID:                "01F8MH0BBE4FHXPH513MBVFHB0",
+			EncryptedPassword:        "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
+			CreatedAt:                TimeMustParse("2022-06-04T13:12:00Z"),
+			SignUpIP:                 net.ParseIP("199.222.111.89"),
+			UpdatedAt:                time.Time{},
+			InviteID:                 "",
+			Reason:                   "hi, please let me in! I'm looking for somewhere neato bombeato to hang out.",
+			Locale:                   "en",
+			CreatedByApplicationID:   "01F8MGY43H3N2C8EWPR2FPYEXG",
+			LastEmailedAt:            time.Time{},
+			ConfirmationToken:        "a5a280bd-34be-44a3-8330-a57eaf61b8dd",
+			ConfirmedAt:              time.Time{},
+			ConfirmationSentAt:       TimeMustParse("2022-06-04T13:12:00Z"),
+			UnconfirmedEmail:         "weed_lord420@example.org",
+			Moderator:                util.Ptr(false),
+			Admin:                    util.Ptr(false),
+			Disabled:                 util.Ptr(false),
+			Approved:                 util.Ptr(false),
+			ResetPasswordToken:       "",
+			ResetPasswordSentAt:      time.Time{},
+			EmailNotifyMention:       util.Ptr(false),
+			EmailNotifyDirect:        util.Ptr(false),
+			EmailNotifyFollow:        util.Ptr(false),
+			EmailNotifyFollowRequest: util.Ptr(false),
+			EmailNotifyReport:        util.Ptr(true),
+			EmailNotifyFrequency:     gtsmodel.EmailNotifyFrequencyImmediate,
 		},
 		"admin_account": {
-			ID:                     "01F8MGWYWKVKS3VS8DV1AMYPGE",
-			Email:                  "admin@example.org",
-			AccountID:              "01F8MH17FWEB39HZJ76B6VXSKF",
-			EncryptedPassword:      "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
-			CreatedAt:              TimeMustParse("2022-06-01T13:12:00Z"),
-			SignUpIP:               nil,
-			UpdatedAt:              TimeMustParse("2022-06-01T13:12:00Z"),
-			InviteID:               "",
-			Locale:                 "en",
-			CreatedByApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
-			LastEmailedAt:          TimeMustParse("2022-06-03T13:12:00Z"),
-			ConfirmationToken:      "",
-			ConfirmedAt:            TimeMustParse("2022-06-02T13:12:00Z"),
-			ConfirmationSentAt:     time.Time{},
-			UnconfirmedEmail:       "",
-			Moderator:              util.Ptr(true),
-			Admin:                  util.Ptr(true),
-			Disabled:               util.Ptr(false),
-			Approved:               util.Ptr(true),
-			ResetPasswordToken:     "",
-			ResetPasswordSentAt:    time.Time{},
+			ID:                       "01F8MGWYWKVKS3VS8DV1AMYPGE",
+			Email:                    "admin@example.org",
+			AccountID:                "01F8MH17FWEB39HZJ76B6VXSKF",
+			EncryptedPassword:        "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
+			CreatedAt:                TimeMustParse("2022-06-01T13:12:00Z"),
+			SignUpIP:                 nil,
+			UpdatedAt:                TimeMustParse("2022-06-01T13:12:00Z"),
+			InviteID:                 "",
+			Locale:                   "en",
+			CreatedByApplicationID:   "01F8MGXQRHYF5QPMTMXP78QC2F",
+			LastEmailedAt:            TimeMustParse("2022-06-03T13:12:00Z"),
+			ConfirmationToken:        "",
+			ConfirmedAt:              TimeMustParse("2022-06-02T13:12:00Z"),
+			ConfirmationSentAt:       time.Time{},
+			UnconfirmedEmail:         "",
+			Moderator:                util.Ptr(true),
+			Admin:                    util.Ptr(true),
+			Disabled:                 util.Ptr(false),
+			Approved:                 util.Ptr(true),
+			ResetPasswordToken:       "",
+			ResetPasswordSentAt:      time.Time{},
+			EmailNotifyMention:       util.Ptr(false),
+			EmailNotifyDirect:        util.Ptr(false),
+			EmailNotifyFollow:        util.Ptr(false),
+			EmailNotifyFollowRequest: util.Ptr(false),
+			EmailNotifyReport:        util.Ptr(true),
+			EmailNotifyFrequency:     gtsmodel.EmailNotifyFrequencyImmediate,
 		},
 		"local_account_1": {
-			ID:                     "01F8MGVGPHQ2D3P3X0454H54Z5",
-			Email:                  "zork@example.org",
-			AccountID:              "01F8MH1H7YV1Z7D2C8K2730QBF",
-			EncryptedPassword:      "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
-			CreatedAt:              TimeMustParse("2022-06-01T13:12:00Z"),
-			SignUpIP:               nil,
-			UpdatedAt:              TimeMustParse("2022-06-01T13:12:00Z"),
-			InviteID:               "",
-			Reason:                 "I wanna be on this damned webbed site so bad! Please! Wow",
-			Locale:                 "en",
-			CreatedByApplicationID: "01F8MGY43H3N2C8EWPR2FPYEXG",
-			LastEmailedAt:          TimeMustParse("2022-06-02T13:12:00Z"),
-			ConfirmationToken:      "",
-			ConfirmedAt:            TimeMustParse("2022-06-02T13:12:00Z"),
-			ConfirmationSentAt:     TimeMustParse("2022-06-02T13:12:00Z"),
-			UnconfirmedEmail:       "",
-			Moderator:              util.Ptr(false),
-			Admin:                  util.Ptr(false),
-			Disabled:               util.Ptr(false),
-			Approved:               util.Ptr(true),
-			ResetPasswordToken:     "",
-			ResetPasswordSentAt:    time.Time{},
+			ID:                       "01F8MGVGPHQ2D3P3X0454H54Z5",
+			Email:                    "zork@example.org",
+			AccountID:                "01F8MH1H7YV1Z7D2C8K2730QBF",
+			EncryptedPassword:        "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
+			CreatedAt:                TimeMustParse("2022-06-01T13:12:00Z"),
+			SignUpIP:                 nil,
+			UpdatedAt:                TimeMustParse("2022-06-01T13:12:00Z"),
+			InviteID:                 "",
+			Reason:                   "I wanna be on this damned webbed site so bad! Please! Wow",
+			Locale:                   "en",
+			CreatedByApplicationID:   "01F8MGY43H3N2C8EWPR2FPYEXG",
+			LastEmailedAt:            TimeMustParse("2022-06-02T13:12:00Z"),
+			ConfirmationToken:        "",
+			ConfirmedAt:              TimeMustParse("2022-06-02T13:12:00Z"),
+			ConfirmationSentAt:       TimeMustParse("2022-06-02T13:12:00Z"),
+			UnconfirmedEmail:         "",
+			Moderator:                util.Ptr(false),
+			Admin:                    util.Ptr(false),
+			Disabled:                 util.Ptr(false),
+			Approved:                 util.Ptr(true),
+			ResetPasswordToken:       "",
+			ResetPasswordSentAt:      time.Time{},
+			EmailNotifyMention:       util.Ptr(false),
+			EmailNotifyDirect:        util.Ptr(false),
+			EmailNotifyFollow:        util.Ptr(false),
+			EmailNotifyFollowRequest: util.Ptr(false),
+			EmailNotifyReport:        util.Ptr(true),
+			EmailNotifyFrequency:     gtsmodel.EmailNotifyFrequencyImmediate,
 		},
 		"local_account_2": {
-			ID:                     "01F8MH1VYJAE00TVVGMM5JNJ8X",
-			Email:                  "tortle.dude@example.org",
-			AccountID:              "01F8MH5NBDF2MV7CTC4Q5128HF",
-			EncryptedPassword:      "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
-			CreatedAt:              TimeMustParse("2022-05-23T13:12:00Z"),
-			SignUpIP:               nil,
-			UpdatedAt:              TimeMustParse("2022-05-23T13:12:00Z"),
-			InviteID:               "",
-			Locale:                 "en",
-			CreatedByApplicationID: "01F8MGY43H3N2C8EWPR2FPYEXG",
-			LastEmailedAt:          TimeMustParse("2022-06-06T13:12:00Z"),
-			ConfirmationToken:      "",
-			ConfirmedAt:            TimeMustParse("2022-05-24T13:12:00Z"),
-			ConfirmationSentAt:     TimeMustParse("2022-05-23T13:12:00Z"),
-			UnconfirmedEmail:       "",
-			Moderator:              util.Ptr(false),
-			Admin:                  util.Ptr(false),
-			Disabled:               util.Ptr(false),
-			Approved:               util.Ptr(true),
-			ResetPasswordToken:     "",
-			ResetPasswordSentAt:    time.Time{},
+			ID:                       "01F8MH1VYJAE00TVVGMM5JNJ8X",
+			Email:                    "tortle.dude@example.org",
+			AccountID:                "01F8MH5NBDF2MV7CTC4Q5128HF",
+			EncryptedPassword:        "$2y$10$ggWz5QWwnx6kzb9g0tnIJurFtE0dhr5Zfeaqs9iFuUIXzafQlJVZS", // 'password'
+			CreatedAt:                TimeMustParse("2022-05-23T13:12:00Z"),
+			SignUpIP:                 nil,
+			UpdatedAt:                TimeMustParse("2022-05-23T13:12:00Z"),
+			InviteID:                 "",
+			Locale:                   "en",
+			CreatedByApplicationID:   "01F8MGY43H3N2C8EWPR2FPYEXG",
+			LastEmailedAt:            TimeMustParse("2022-06-06T13:12:00Z"),
+			ConfirmationToken:        "",
+			ConfirmedAt:              TimeMustParse("2022-05-24T13:12:00Z"),
+			ConfirmationSentAt:       TimeMustParse("2022-05-23T13:12:00Z"),
+			UnconfirmedEmail:         "",
+			Moderator:                util.Ptr(false),
+			Admin:                    util.Ptr(false),
+			Disabled:                 util.Ptr(false),
+			Approved:                 util.Ptr(true),
+			ResetPasswordToken:       "",
+			ResetPasswordSentAt:      time.Time{},
+			EmailNotifyMention:       util.Ptr(false),
+			EmailNotifyDirect:        util.Ptr(false),
+			EmailNotifyFollow:        util.Ptr(false),
+			EmailNotifyFollowRequest: util.Ptr(false),
+			EmailNotifyReport:        util.Ptr(true),
+			EmailNotifyFrequency:     gtsmodel.EmailNotifyFrequencyImmediate,
 		},
 	}
 
@@ -658,6 +682,8 @@ func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 			Language:        "en",
 			EnableRSS:       util.Ptr(false),
 			HideCollections: util.Ptr(false),
+			Indexable:       util.Ptr(false),
+			DisableEmbeds:   util.Ptr(false),
 		},
 		"admin_account": {
 			AccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -668,6 +694,8 @@ func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 			Language:        "en",
 			EnableRSS:       util.Ptr(true),
 			HideCollections: util.Ptr(false),
+			Indexable:       util.Ptr(false),
+			DisableEmbeds:   util.Ptr(false),
 		},
 		"local_account_1": {
 			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
@@ -678,6 +706,8 @@ func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 			Language:        "en",
 			EnableRSS:       util.Ptr(true),
 			HideCollections: util.Ptr(false),
+			Indexable:       util.Ptr(false),
+			DisableEmbeds:   util.Ptr(false),
 		},
 		"local_account_2": {
 			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -688,6 +718,8 @@ func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 			Language:        "fr",
 			EnableRSS:       util.Ptr(false),
 			HideCollections: util.Ptr(true),
+			Indexable:       util.Ptr(true),
+			DisableEmbeds:   util.Ptr(false),
 		},
 	}
 }
@@ -2572,6 +2604,28 @@ func NewTestRules() map[string]*gtsmodel.Rule {
 	}
 }
 
+func NewTestInvites() map[string]*gtsmodel.Invite {
+	return map[string]*gtsmodel.Invite{
+		"local_account_1_invite": {
+			ID:        "01J3Y7MZ1D7V5BQ1B6Q0W0TYXN",
+			CreatedAt: TimeMustParse("2024-07-30T10:00:00+02:00"),
+			UpdatedAt: TimeMustParse("2024-07-30T10:00:00+02:00"),
+			Code:      "zorksinvite",
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			MaxUses:   5,
+			Uses:      1,
+		},
+		"admin_account_invite_expired": {
+			ID:        "01J3Y7NB0KJ3G8N3XQS7ZC5M2E",
+			CreatedAt: TimeMustParse("2024-07-30T11:00:00+02:00"),
+			UpdatedAt: TimeMustParse("2024-07-30T11:00:00+02:00"),
+			Code:      "expiredinvite",
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			ExpiresAt: TimeMustParse("2024-07-31T11:00:00+02:00"),
+		},
+	}
+}
+
 // ActivityWithSignature wraps a pub.Activity along with its signature headers, for testing.
 type ActivityWithSignature struct {
 	Activity        pub.Activity
@@ -0,0 +1,80 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+	Solves proof-of-work challenges on the sign-up and sign-in forms.
+
+	The server gives a salt and a difficulty, and we must find a number
+	which, appended to the salt, gives a sha256 hash that starts with
+	at least difficulty zero bits. Submit stays disabled until we do.
+*/
+
+const batchSize = 1000;
+
+function leadingZeroBits(bytes) {
+	let n = 0;
+	for (const b of bytes) {
+		if (b != 0) {
+			return n + Math.clz32(b) - 24;
+		}
+		n += 8;
+	}
+	return n;
+}
+
+async function solve(salt, difficulty) {
+	const encoder = new TextEncoder();
+
+	for (let nonce = 0; ; nonce++) {
+		const digest = await crypto.subtle.digest(
+			"SHA-256",
+			encoder.encode(salt + nonce),
+		);
+
+		if (leadingZeroBits(new Uint8Array(digest)) >= difficulty) {
+			return nonce;
+		}
+
+		// Give the browser a breather now and
+		// then, so the page stays responsive.
+		if (nonce % batchSize == 0) {
+			await new Promise((resolve) => setTimeout(resolve, 0));
+		}
+	}
+}
+
+document.querySelectorAll(".challenge-pow").forEach(async (el) => {
+	const form = el.closest("form");
+	const answer = el.querySelector("input[name='challenge_answer']");
+	const status = el.querySelector(".challenge-status");
+	const submit = form.querySelector("button[type='submit']");
+
+	submit.disabled = true;
+	status.textContent = el.dataset.working;
+
+	try {
+		const nonce = await solve(el.dataset.salt, parseInt(el.dataset.difficulty));
+		answer.value = nonce.toString();
+		status.textContent = el.dataset.done;
+		submit.disabled = false;
+	} catch (e) {
+		status.textContent = el.dataset.failed;
+		console.error(e);
+	}
+});
@@ -64,6 +64,15 @@ skulk({
 				}]
 			],
 		},
+		challenge: {
+			entryFile: "challenge",
+			outputFile: "challenge.js",
+			preset: ["js"],
+			prodCfg: prodCfg,
+			transform: [
+				["babelify", { global: true }]
+			],
+		},
 		settings: {
 			entryFile: "settings",
 			outputFile: "settings.js",
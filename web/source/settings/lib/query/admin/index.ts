@@ -94,6 +94,10 @@ const extended = gtsApi.injectEndpoints({
 						if (action === "suspend") {
 							draft.suspended = true;
 							draft.account.suspended = true;
+						} else if (action === "memorialize") {
+							draft.account.memorial = true;
+						} else if (action === "unmemorialize") {
+							draft.account.memorial = false;
 						}
 					})
 				);
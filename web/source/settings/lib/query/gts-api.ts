@@ -141,6 +141,7 @@ export const gtsApi = createApi({
 		"InstanceRules",
 		"HTTPHeaderAllows",
 		"HTTPHeaderBlocks",
+		"Invite",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
@@ -25,6 +25,7 @@ import type {
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
 import { User } from "../../types/user";
+import type { CreateInviteParams, Invite } from "../../types/invite";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -84,7 +85,35 @@ const extended = gtsApi.injectEndpoints({
 			query: () => ({
 				url: `/api/v1/accounts/themes`
 			})
-		})
+		}),
+		invites: build.query<Invite[], void>({
+			query: () => ({
+				url: `/api/v1/invites?limit=100`
+			}),
+			providesTags: (res) =>
+				res
+					? [
+						...res.map(({ id }) => ({ type: "Invite" as const, id })),
+						{ type: "Invite", id: "LIST" },
+					]
+					: [{ type: "Invite", id: "LIST" }],
+		}),
+		createInvite: build.mutation<Invite, CreateInviteParams>({
+			query: (formData) => ({
+				method: "POST",
+				url: `/api/v1/invites`,
+				asForm: true,
+				body: formData,
+			}),
+			invalidatesTags: [{ type: "Invite", id: "LIST" }],
+		}),
+		expireInvite: build.mutation<Invite, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/invites/${id}`
+			}),
+			invalidatesTags: (_res, _error, id) => [{ type: "Invite", id }],
+		}),
 	})
 });
 
@@ -96,4 +125,7 @@ export const {
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
+	useInvitesQuery,
+	useCreateInviteMutation,
+	useExpireInviteMutation,
 } = extended;
@@ -37,6 +37,7 @@ export interface AdminAccount {
 	silenced: boolean,
 	suspended: boolean,
 	created_by_application_id: string,
+	invited_by_account_id?: string,
 	account: Account,
 }
 
@@ -64,6 +65,7 @@ export interface Account {
 	enable_rss: boolean,
 	role: any,
 	suspended?: boolean,
+	memorial?: boolean,
 }
 
 export interface SearchAccountParams {
@@ -75,6 +77,7 @@ export interface SearchAccountParams {
 	by_domain?: string,
 	email?: string,
 	ip?: string,
+	invited_by?: string,
 	max_id?: string,
 	since_id?: string,
 	min_id?: string,
@@ -96,6 +99,6 @@ export interface HandleSignupParams {
 
 export interface ActionAccountParams {
 	id: string;
-	action: "suspend";
+	action: "suspend" | "memorialize" | "unmemorialize";
 	reason: string;
 }
@@ -58,7 +58,12 @@ export function AccountActions({ account, backLocation }: AccountActionsProps) {
 		default:
 			// Normal local or remote account, show
 			// full range of moderation options.
-			return <ModerateAccount account={account} />;
+			return (
+				<>
+					<ModerateAccount account={account} />
+					{ local && <MemorializeAccount account={account} /> }
+				</>
+			);
 	}
 }
 
@@ -103,6 +108,41 @@ function ModerateAccount({ account }: { account: AdminAccount }) {
 	);
 }
 
+function MemorializeAccount({ account }: { account: AdminAccount }) {
+	const form = {
+		id: useValue("id", account.id),
+		reason: useTextInput("text")
+	};
+
+	const memorial = account.account.memorial ?? false;
+	const [accountAction, result] = useFormSubmit(form, useActionAccountMutation());
+
+	return (
+		<form
+			onSubmit={accountAction}
+			aria-labelledby="account-memorial-actions"
+		>
+			<h3 id="account-memorial-actions">Memorial</h3>
+			<div>
+				If the person who used this account has passed away, you can turn the account into a memorial.<br/>
+				Memorial accounts can't be logged in to, can't post or follow, and can't gain new followers.
+				Their existing posts are kept, and their profile shows an "in memoriam" banner.<br/>
+				Other servers are told about the change, and it can be undone at any time.
+			</div>
+			<TextInput
+				field={form.reason}
+				placeholder="Reason for this action"
+			/>
+			<MutationButton
+				disabled={false}
+				label={memorial ? "Unmemorialize" : "Memorialize"}
+				name={memorial ? "unmemorialize" : "memorialize"}
+				result={result}
+			/>
+		</form>
+	);
+}
+
 function HandleSignup({ account, backLocation }: { account: AdminAccount, backLocation: string }) {
 	const form = {
 		id: useValue("id", account.id),
@@ -17,17 +17,20 @@
 	along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 
-import React from "react";
+import React, { ReactNode } from "react";
 
-import { useGetAccountQuery } from "../../../../lib/query/admin";
+import { useGetAccountQuery, useSearchAccountsQuery } from "../../../../lib/query/admin";
 import FormWithData from "../../../../lib/form/form-with-data";
 import FakeProfile from "../../../../components/profile";
 import { AdminAccount } from "../../../../lib/types/account";
 import { AccountActions } from "./actions";
-import { useParams } from "wouter";
+import { useLocation, useParams } from "wouter";
 import { useBaseUrl } from "../../../../lib/navigation/util";
 import BackButton from "../../../../components/back-button";
 import { UseOurInstanceAccount, yesOrNo } from "../../../../lib/util";
+import Username from "../../../../components/username";
+import Loading from "../../../../components/loading";
+import { PageableList } from "../../../../components/pageable-list";
 
 export default function AccountDetail() {
 	const params: { accountID: string } = useParams();
@@ -141,6 +144,10 @@ function GeneralAccountDetails({ adminAcct } : { adminAcct: AdminAccount }) {
 					<dt>Silenced</dt>
 					<dd>{yesOrNo(adminAcct.silenced)}</dd>
 				</div>
+				<div className="info-list-entry">
+					<dt>Memorial</dt>
+					<dd>{yesOrNo(adminAcct.account.memorial ?? false)}</dd>
+				</div>
 				<div className="info-list-entry">
 					<dt>Statuses</dt>
 					<dd>{adminAcct.account.statuses_count}</dd>
@@ -201,7 +208,76 @@ function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {
 						<dt>Locale</dt>
 						<dd>{adminAcct.locale}</dd>
 					</div> }
+				{ adminAcct.invited_by_account_id &&
+					<div className="info-list-entry">
+						<dt>Invited By</dt>
+						<dd><InvitedBy accountID={adminAcct.invited_by_account_id} /></dd>
+					</div> }
 			</dl>
+			<InvitedAccounts accountID={adminAcct.id} />
 		</> 
 	);
 }
+
+// InvitedBy shows a link to the account
+// that invited an account, falling back
+// to the inviter's ID if it can't be loaded.
+function InvitedBy({ accountID }: { accountID: string }) {
+	const [ location ] = useLocation();
+	const { data: account, isLoading, isFetching, isError } = useGetAccountQuery(accountID);
+
+	if (isLoading || isFetching) {
+		return <Loading />;
+	} else if (isError || account === undefined) {
+		return <>{accountID}</>;
+	}
+
+	return (
+		<Username
+			account={account}
+			linkTo={`~/settings/moderation/accounts/${account.id}`}
+			backLocation={`~/settings/moderation${location}`}
+		/>
+	);
+}
+
+// InvitedAccounts lists accounts that signed up
+// with invites created by the given account.
+function InvitedAccounts({ accountID }: { accountID: string }) {
+	const [ location ] = useLocation();
+	const searchRes = useSearchAccountsQuery({ invited_by: accountID });
+
+	// Don't show anything if
+	// nobody was invited.
+	if (searchRes.isSuccess && !searchRes.data?.accounts.length) {
+		return null;
+	}
+
+	function itemToEntry(account: AdminAccount): ReactNode {
+		return (
+			<Username
+				key={account.account.acct}
+				account={account}
+				linkTo={`~/settings/moderation/accounts/${account.id}`}
+				backLocation={`~/settings/moderation${location}`}
+				classNames={["entry"]}
+			/>
+		);
+	}
+
+	return (
+		<>
+			<h3>Invited Accounts</h3>
+			<PageableList
+				isLoading={searchRes.isLoading}
+				isFetching={searchRes.isFetching}
+				isSuccess={searchRes.isSuccess}
+				items={searchRes.data?.accounts}
+				itemToEntry={itemToEntry}
+				isError={searchRes.isError}
+				error={searchRes.error}
+				emptyMessage={<b>No accounts signed up with this account's invites.</b>}
+			/>
+		</>
+	);
+}
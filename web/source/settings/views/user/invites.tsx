@@ -0,0 +1,178 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import { useTextInput } from "../../lib/form";
+import useFormSubmit from "../../lib/form/submit";
+import { Select } from "../../components/form/inputs";
+import MutationButton from "../../components/form/mutation-button";
+import Loading from "../../components/loading";
+import { Error } from "../../components/error";
+import { useCreateInviteMutation, useExpireInviteMutation, useInvitesQuery } from "../../lib/query/user";
+import { useInstanceV1Query } from "../../lib/query/gts-api";
+import type { Invite } from "../../lib/types/invite";
+
+export default function UserInvites() {
+	const { data: instance, isLoading: isLoadingInstance } = useInstanceV1Query();
+
+	if (isLoadingInstance) {
+		return <Loading />;
+	}
+
+	return (
+		<>
+			<h1>Invites</h1>
+			<p>
+				Invite links let people sign up to this instance, even when
+				registration is otherwise closed. You can limit how many times
+				an invite link can be used, and for how long it stays valid.
+			</p>
+			<p>
+				Admins can see which invite (and whose) each new account signed up with.
+				Only share invite links with people you trust to behave themselves!
+			</p>
+			{ instance?.invites_enabled
+				? <>
+					<CreateInvite />
+					<InvitesList />
+				</>
+				: <b>Invites are not enabled on this instance.</b>
+			}
+		</>
+	);
+}
+
+function CreateInvite() {
+	const form = {
+		maxUses: useTextInput("max_uses", { defaultValue: "1" }),
+		expiresIn: useTextInput("expires_in", { defaultValue: "604800" }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useCreateInviteMutation());
+
+	return (
+		<form className="user-invite-create" onSubmit={submitForm}>
+			<div className="form-section-docs">
+				<h3>Create Invite</h3>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#invites"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about invites (opens in a new tab)
+				</a>
+			</div>
+			<Select field={form.maxUses} label="Max uses" options={
+				<>
+					<option value="1">1 use</option>
+					<option value="5">5 uses</option>
+					<option value="10">10 uses</option>
+					<option value="25">25 uses</option>
+					<option value="100">100 uses</option>
+					<option value="0">No limit</option>
+				</>
+			}>
+			</Select>
+			<Select field={form.expiresIn} label="Expires after" options={
+				<>
+					<option value="1800">30 minutes</option>
+					<option value="86400">1 day</option>
+					<option value="604800">1 week</option>
+					<option value="2592000">30 days</option>
+					<option value="0">Never</option>
+				</>
+			}>
+			</Select>
+			<MutationButton
+				disabled={false}
+				label="Create invite"
+				result={result}
+			/>
+		</form>
+	);
+}
+
+function InvitesList() {
+	const {
+		data: invites,
+		isLoading,
+		isError,
+		error,
+	} = useInvitesQuery();
+
+	if (isLoading) {
+		return <Loading />;
+	} else if (isError) {
+		return <Error error={error} />;
+	}
+
+	return (
+		<div className="user-invites-list">
+			<h3>Your Invites</h3>
+			{ invites && invites.length > 0
+				? <ul className="list">
+					{invites.map((invite) => <InviteEntry key={invite.id} invite={invite} />)}
+				</ul>
+				: <b>You haven't created any invites yet.</b>
+			}
+		</div>
+	);
+}
+
+function InviteEntry({ invite }: { invite: Invite }) {
+	const [ expireInvite, result ] = useExpireInviteMutation();
+
+	const uses = invite.max_uses
+		? `${invite.uses} / ${invite.max_uses} uses`
+		: `${invite.uses} uses`;
+
+	let expiry = "never expires";
+	if (invite.expires_at) {
+		const expiresAt = new Date(invite.expires_at).toLocaleString();
+		expiry = `${invite.valid ? "expires" : "expired"} ${expiresAt}`;
+	}
+
+	return (
+		<li className="entry">
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Link:</dt>
+					<dd className="monospace">{invite.url}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Status:</dt>
+					<dd>
+						{invite.valid ? "valid" : <b>no longer valid</b>}, {uses}, {expiry}
+					</dd>
+				</div>
+			</dl>
+			{ invite.valid &&
+				<MutationButton
+					type="button"
+					label="Expire now"
+					onClick={() => expireInvite(invite.id)}
+					result={result}
+					disabled={false}
+					showError={false}
+				/>
+			}
+		</li>
+	);
+}
@@ -24,6 +24,7 @@ import React from "react";
  * - /settings/user/profile
  * - /settings/user/settings
  * - /settings/user/migration
+ * - /settings/user/invites
  */
 export default function UserMenu() {	
 	return (
@@ -47,6 +48,11 @@ export default function UserMenu() {
 				itemUrl="migration"
 				icon="fa-exchange"
 			/>
+			<MenuItem
+				name="Invites"
+				itemUrl="invites"
+				icon="fa-envelope-open"
+			/>
 		</MenuItem>
 	);
 }
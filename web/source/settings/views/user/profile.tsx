@@ -67,6 +67,8 @@ function UserProfileForm({ data: profile }) {
 		- file header
 		- bool enable_rss
 		- bool hide_collections
+		- bool indexable
+		- bool disable_embeds
 		- string custom_css (if enabled)
 		- string theme
 	*/
@@ -101,6 +103,8 @@ function UserProfileForm({ data: profile }) {
 		discoverable: useBoolInput("discoverable", { source: profile}),
 		enableRSS: useBoolInput("enable_rss", { source: profile }),
 		hideCollections: useBoolInput("hide_collections", { source: profile }),
+		indexable: useBoolInput("indexable", { source: profile }),
+		disableEmbeds: useBoolInput("disable_embeds", { source: profile }),
 		fields: useFieldArrayInput("fields_attributes", {
 			defaultValue: profile?.source?.fields,
 			length: instanceConfig.maxPinnedFields
@@ -215,6 +219,14 @@ function UserProfileForm({ data: profile }) {
 				field={form.hideCollections}
 				label="Hide who you follow / are followed by"
 			/>
+			<Checkbox
+				field={form.indexable}
+				label="Allow anyone to find your Public posts via search"
+			/>
+			<Checkbox
+				field={form.disableEmbeds}
+				label="Prevent your posts from being embedded on other websites"
+			/>
 
 			<div className="form-section-docs">
 				<h3>Advanced</h3>
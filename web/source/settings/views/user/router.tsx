@@ -24,11 +24,13 @@ import { ErrorBoundary } from "../../lib/navigation/error";
 import UserProfile from "./profile";
 import UserMigration from "./migration";
 import UserSettings from "./settings";
+import UserInvites from "./invites";
 
 /**
  * - /settings/user/profile
  * - /settings/user/settings
  * - /settings/user/migration
+ * - /settings/user/invites
  */
 export default function UserRouter() {
 	const baseUrl = useBaseUrl();
@@ -43,6 +45,7 @@ export default function UserRouter() {
 						<Route path="/profile" component={UserProfile} />
 						<Route path="/settings" component={UserSettings} />
 						<Route path="/migration" component={UserMigration} />
+						<Route path="/invites" component={UserInvites} />
 						<Route><Redirect to="/profile" /></Route>
 					</Switch>
 				</ErrorBoundary>